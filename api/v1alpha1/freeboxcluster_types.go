@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
@@ -25,6 +26,8 @@ import (
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
 // FreeboxClusterSpec defines the desired state of FreeboxCluster
+// +kubebuilder:validation:XValidation:rule="!(has(self.identityRef) && has(self.credentialsSecretRef))",message="identityRef and credentialsSecretRef are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.endpoint) || has(self.identityRef) || has(self.credentialsSecretRef)",message="the credentials of endpoint must be set with identityRef or credentialsSecretRef"
 type FreeboxClusterSpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
@@ -34,6 +37,170 @@ type FreeboxClusterSpec struct {
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
 	// This is required and must be set by the user to the actual control plane endpoint.
 	ControlPlaneEndpoint clusterv1.APIEndpoint `json:"controlPlaneEndpoint"`
+
+	// Endpoint is the URL of the Freebox hosting the VMs of the cluster, e.g.
+	// "https://box.example.com:8443", when it is not the Freebox of the manager.
+	// Its credentials are then set with identityRef or credentialsSecretRef.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// APIVersion is the version of the Freebox API used with endpoint, e.g. "v10".
+	// Defaults to the API version of the manager.
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// IdentityRef references a FreeboxClusterIdentity of the namespace of the
+	// cluster whose credentials are used to manage the VMs of the cluster. The
+	// credentials of the manager are used when neither identityRef nor
+	// credentialsSecretRef is set.
+	// +optional
+	IdentityRef *corev1.LocalObjectReference `json:"identityRef,omitempty"`
+
+	// CredentialsSecretRef references a Secret of the namespace of the cluster
+	// holding the ID of the application used to manage the VMs of the cluster in its
+	// appID key, and its app token in its token key.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+
+	// Quota limits the resources the machines of the cluster may consume on the Freebox,
+	// so that one cluster cannot starve the others sharing the box.
+	// +optional
+	Quota *FreeboxResourceQuota `json:"quota,omitempty"`
+
+	// Priority of the machines of the cluster when the Freebox runs out of vCPUs or memory.
+	// Starting a VM of the cluster then stops running VMs of clusters with a lower priority
+	// until there is enough room. VMs of clusters without a priority are never stopped, and
+	// never stop other VMs.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
+	// PrefetchImages lists images downloaded to the Freebox ahead of machine creation.
+	// Machines of the cluster using one of these images copy it from the cache instead
+	// of downloading it. Cached images are removed from the Freebox when removed from
+	// the list.
+	// +optional
+	// +listType=map
+	// +listMapKey=url
+	PrefetchImages []ImageRef `json:"prefetchImages,omitempty"`
+
+	// MachineDefaults are applied to the machines of the cluster that leave the
+	// corresponding fields unset when they are created, so that templates can stay
+	// minimal and the sizing of the machines of a Freebox lives in one place.
+	// +optional
+	MachineDefaults *FreeboxMachineDefaults `json:"machineDefaults,omitempty"`
+
+	// StorageFailureDomains spread the disks of the machines of the cluster across the
+	// storage devices of the Freebox, e.g. its internal disk and a USB or SATA drive.
+	// They are published as Cluster API failure domains, across which the control plane
+	// machines are spread, so that one failing drive does not take the whole control
+	// plane down. Machines outside of a failure domain keep their disk in VM storage.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	StorageFailureDomains []FreeboxStorageFailureDomain `json:"storageFailureDomains,omitempty"`
+}
+
+// FreeboxStorageFailureDomain is a storage device of the Freebox the disks of
+// machines can be placed on.
+type FreeboxStorageFailureDomain struct {
+	// Name of the failure domain, as set in the failureDomain of the Machines.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name"`
+
+	// Path of the directory of the storage device the disks are stored in, e.g.
+	// "/Disque 2/VMs". It is created when missing.
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+
+	// ControlPlane tells whether the control plane machines are spread across the
+	// failure domain. Defaults to true.
+	// +optional
+	ControlPlane *bool `json:"controlPlane,omitempty"`
+}
+
+// FreeboxMachineDefaults are default values of FreeboxMachineSpec fields.
+type FreeboxMachineDefaults struct {
+	// ImageURL is the default FreeboxMachineSpec.ImageURL. Placeholders are supported.
+	// +optional
+	ImageURL string `json:"imageURL,omitempty"`
+
+	// DiskSizeBytes is the default FreeboxMachineSpec.DiskSizeBytes.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DiskSizeBytes int64 `json:"diskSizeBytes,omitempty"`
+
+	// VCPUs is the default FreeboxMachineSpec.VCPUs.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	VCPUs int64 `json:"vcpus,omitempty"`
+
+	// MemoryMB is the default FreeboxMachineSpec.MemoryMB.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+}
+
+// ImageRef references a disk image.
+type ImageRef struct {
+	// URL of the image, as set in FreeboxMachineSpec.ImageURL. Placeholders are not supported,
+	// and file:// images already on the Freebox are used in place.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[^{}]*$`
+	URL string `json:"url"`
+}
+
+// PrefetchedImage reports the state of an image of FreeboxClusterSpec.PrefetchImages.
+type PrefetchedImage struct {
+	// URL of the image.
+	URL string `json:"url"`
+
+	// Path of the image on the Freebox.
+	Path string `json:"path"`
+
+	// TaskID is the ID of the Freebox download task fetching the image.
+	// +optional
+	TaskID int64 `json:"taskID,omitempty"`
+
+	// Ready is true once the image is downloaded.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+}
+
+// FreeboxAPIStatus reports the version of the Freebox API used by the controller.
+type FreeboxAPIStatus struct {
+	// Version is the API version the controller uses, e.g. "v10".
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// LatestVersion is the latest API version advertised by the Freebox, e.g. "10.2".
+	// It changes with firmware updates.
+	// +optional
+	LatestVersion string `json:"latestVersion,omitempty"`
+
+	// BoxModel is the model of the Freebox, e.g. "fbxgw9-r1/full".
+	// +optional
+	BoxModel string `json:"boxModel,omitempty"`
+}
+
+// FreeboxResourceQuota limits the total resources of the machines of a cluster.
+// A zero or unset limit means no limit.
+type FreeboxResourceQuota struct {
+	// VCPUs is the maximum total number of vCPUs.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	VCPUs int64 `json:"vcpus,omitempty"`
+
+	// MemoryMB is the maximum total size of RAM in MB.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+
+	// DiskSizeBytes is the maximum total size of disks in bytes.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DiskSizeBytes int64 `json:"diskSizeBytes,omitempty"`
 }
 
 // FreeboxClusterStatus defines the observed state of FreeboxCluster.
@@ -49,6 +216,35 @@ type FreeboxClusterStatus struct {
 	// +optional
 	Initialization FreeboxClusterInitializationStatus `json:"initialization,omitempty,omitzero"`
 
+	// Ready mirrors initialization.provisioned for tooling that still reads the
+	// status.ready field of the Cluster API v1beta1 contract.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+
+	// PrefetchedImages reports the images of spec.prefetchImages cached on the Freebox.
+	// +optional
+	// +listType=map
+	// +listMapKey=url
+	PrefetchedImages []PrefetchedImage `json:"prefetchedImages,omitempty"`
+
+	// FailureDomains are the storage failure domains of the cluster, as read by
+	// Cluster API to place the machines.
+	// NOTE: This field is part of the Cluster API contract.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	FailureDomains []clusterv1.FailureDomain `json:"failureDomains,omitempty"`
+
+	// FreeboxAPI reports the version of the Freebox API used by the controller.
+	// +optional
+	FreeboxAPI *FreeboxAPIStatus `json:"freeboxAPI,omitempty"`
+
+	// ObservedGeneration is the metadata.generation of the FreeboxCluster the status
+	// was last computed from. The status may not reflect the latest spec yet while
+	// it is lower than metadata.generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// conditions represent the current state of the FreeboxCluster resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
@@ -106,6 +302,17 @@ type FreeboxClusterList struct {
 	Items           []FreeboxCluster `json:"items"`
 }
 
+// GetConditions returns the conditions of the FreeboxCluster, as Cluster API
+// condition utilities expect.
+func (c *FreeboxCluster) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets the conditions of the FreeboxCluster.
+func (c *FreeboxCluster) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
 func init() {
 	objectTypes = append(objectTypes, &FreeboxCluster{}, &FreeboxClusterList{})
 }
@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FreeboxClusterIdentitySpec defines the credentials of an application authorized
+// on the Freebox
+type FreeboxClusterIdentitySpec struct {
+	// SecretRef references a Secret of the namespace of the identity holding the ID
+	// of the application in its appID key, and its app token in its token key.
+	// +required
+	SecretRef corev1.LocalObjectReference `json:"secretRef"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=freeboxclusteridentities,scope=Namespaced,categories=cluster-api
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretRef.name",description="Secret holding the credentials"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of FreeboxClusterIdentity"
+
+// FreeboxClusterIdentity is the Schema for the freeboxclusteridentities API. The
+// FreeboxClusters of its namespace referencing it talk to the Freebox with its
+// credentials instead of those of the manager.
+type FreeboxClusterIdentity struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// metadata is a standard object metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty,omitzero"`
+
+	// spec defines the credentials of the identity
+	// +required
+	Spec FreeboxClusterIdentitySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// FreeboxClusterIdentityList contains a list of FreeboxClusterIdentity
+type FreeboxClusterIdentityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FreeboxClusterIdentity `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &FreeboxClusterIdentity{}, &FreeboxClusterIdentityList{})
+}
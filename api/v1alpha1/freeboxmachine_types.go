@@ -34,20 +34,244 @@ type FreeboxMachineSpec struct {
 	// +kubebuilder:validation:MaxLength=512
 	ProviderID string `json:"providerID,omitempty"`
 
-	// Name of the VM in the Freebox
-	Name string `json:"name"`
+	// Name of the VM in the Freebox, rendered from nameTemplate.
+	// It is set when the FreeboxMachine is created; any other value is rejected.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// NameTemplate is a Go template rendering both the name of the VM in the Freebox
+	// and the file name of its disk, e.g. "{{ .ClusterName }}-{{ .MachineName }}".
+	// The fields .ClusterName, .MachineName and .Namespace are available.
+	// The result must be a lowercase RFC 1123 label of at most 63 characters.
+	// Defaults to "{{ .MachineName }}".
+	// +optional
+	NameTemplate string `json:"nameTemplate,omitempty"`
 	// Number of vCPUs
+	// Defaults to the machineDefaults of the FreeboxCluster.
+	// +optional
 	// +kubebuilder:validation:Minimum=1
-	VCPUs int64 `json:"vcpus"` // e.g. 2
+	VCPUs int64 `json:"vcpus,omitempty"` // e.g. 2
 	// Size of the RAM in MB
+	// Defaults to the machineDefaults of the FreeboxCluster.
+	// +optional
 	// +kubebuilder:validation:Minimum=1
-	MemoryMB int64 `json:"memoryMB"` // e.g. 2048 for 2GB
+	MemoryMB int64 `json:"memoryMB,omitempty"` // e.g. 2048 for 2GB
 	// Size of the disk in MB
-	DiskSizeBytes int64 `json:"diskSizeBytes"`
+	// Defaults to the machineDefaults of the FreeboxCluster.
+	// +optional
+	DiskSizeBytes int64 `json:"diskSizeBytes,omitempty"`
 	// Image to use (ex: "debian-bullseye")
-	ImageURL string `json:"imageURL"`
+	// The placeholders {arch}, {k8sVersion} and {channel} are replaced with the Freebox
+	// architecture, the version of the owner Machine (e.g. v1.34.1) and its minor
+	// release (e.g. v1.34), so that one template can serve several Kubernetes versions.
+	// Images already stored on the Freebox are referenced with a file:// URL of their
+	// path, e.g. "file:///Freebox/VMs/images/debian-13-generic-arm64.qcow2".
+	// Defaults to the machineDefaults of the FreeboxCluster.
+	// +optional
+	ImageURL string `json:"imageURL,omitempty"`
+
+	// ImageArchiveMember is the path of the disk image inside an ImageURL archive
+	// such as .tar.gz or .zip, e.g. "disk.raw" or "images/nocloud.qcow2".
+	// When empty, the archive name without its extensions, with or without a .raw,
+	// .qcow2 or .img extension, and disk.raw, disk.qcow2 and disk.img are looked for.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[^/].*$`
+	ImageArchiveMember string `json:"imageArchiveMember,omitempty"`
+
+	// DiskImageSHA256 is the SHA-256 of the disk image once copied or extracted to VM
+	// storage, i.e. of the uncompressed image for compressed images and archives.
+	// When set, the Freebox hashes the disk before it is resized, and a disk that does
+	// not match is reported instead of being booted. Hashing a large image takes a
+	// few minutes, so it is off by default.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]{64}$`
+	DiskImageSHA256 string `json:"diskImageSHA256,omitempty"`
+
+	// BootstrapFormat is the format of the bootstrap data provided by the bootstrap provider.
+	// When empty, the format is detected from the bootstrap data itself.
+	// +optional
+	BootstrapFormat BootstrapFormat `json:"bootstrapFormat,omitempty"`
+
+	// AutoStart controls whether the VM is started right after it is created.
+	// Set it to false to attach USB devices or inspect the disk before the first
+	// boot; the machine then waits until the VM is started from the Freebox.
+	// +optional
+	// +kubebuilder:default=true
+	AutoStart *bool `json:"autoStart,omitempty"`
+
+	// CloudInit controls whether the bootstrap data is injected through the NoCloud
+	// config drive of the VM. Use Skip when creating the VM on a disk that was already
+	// provisioned, e.g. when adopting or rebuilding a VM, so that configuration steps are
+	// not run again. The Freebox generates the NoCloud meta-data itself, so the
+	// instance-id cannot be set from here.
+	// +optional
+	// +kubebuilder:default=Inject
+	CloudInit CloudInitPolicy `json:"cloudInit,omitempty"`
+
+	// Files are written by cloud-init in addition to the write_files of the bootstrap
+	// data, e.g. to configure sysctls or a containerd proxy on every node without
+	// repeating them in each KubeadmConfig. Only cloud-config bootstrap data can
+	// carry them.
+	// +optional
+	// +listType=map
+	// +listMapKey=path
+	Files []CloudInitFile `json:"files,omitempty"`
+
+	// Commands are run by cloud-init before the runcmd of the bootstrap data, so
+	// that the host is configured before kubeadm runs. Only cloud-config bootstrap
+	// data can carry them.
+	// +optional
+	Commands []string `json:"commands,omitempty"`
+
+	// SharedFolders are folders of the Freebox storage mounted on the VM, e.g. to
+	// back simple ReadWriteMany volumes. The Freebox VM API cannot share folders with
+	// VMs, so cloud-init mounts them from the Windows file sharing (SMB) of the
+	// Freebox, which must be enabled, before the runcmd of the bootstrap data. The
+	// image must provide mount.cifs, e.g. from the cifs-utils package. Only
+	// cloud-config bootstrap data can carry them.
+	// +optional
+	// +listType=map
+	// +listMapKey=mountPath
+	SharedFolders []SharedFolder `json:"sharedFolders,omitempty"`
+
+	// SecureWipe truncates the VM disk before it is removed when the machine is
+	// deleted, so that etcd data or certificates do not linger on the Freebox disk
+	// until the space is reused. The Freebox API cannot overwrite the disk in place,
+	// so the released blocks are not zeroed on the underlying storage.
+	// +optional
+	SecureWipe bool `json:"secureWipe,omitempty"`
+
+	// DeletionProtection rejects the deletion of the FreeboxMachine unless its owner
+	// Machine is being deleted, so that a stray kubectl delete cannot remove a control
+	// plane VM behind the back of Cluster API. Clear it to delete the machine anyway.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// ImageManagement controls whether the controller prepares the VM disk from
+	// ImageURL. Use Unmanaged when disks are prepared with other tooling: the disk is
+	// then expected at DiskPath, only the VM is created and deleted, and the disk is
+	// kept when the machine is deleted. Use ImageOnly to only prepare the disk, e.g. to
+	// pre-warm the image cache of the Freebox before scaling out: no VM is created, and
+	// the machine becomes Ready once its disk is prepared.
+	// +optional
+	// +kubebuilder:default=Managed
+	ImageManagement ImageManagementPolicy `json:"imageManagement,omitempty"`
+
+	// DiskPath is the path of the existing VM disk on the Freebox when ImageManagement
+	// is Unmanaged, e.g. "/Freebox/VMs/worker-0.qcow2". When empty, the disk is
+	// expected where the controller would have prepared it: in the VM storage
+	// directory, named after the VM.
+	// +optional
+	DiskPath string `json:"diskPath,omitempty"`
+
+	// ExistingDiskPolicy controls what happens when a file already exists at the disk
+	// path of the VM before its image is prepared, e.g. left over by a failed machine
+	// of the same name. Fail waits for the file to be removed, Overwrite removes it
+	// and prepares the disk from ImageURL, and Adopt creates the VM on it, resized to
+	// DiskSizeBytes.
+	// +optional
+	// +kubebuilder:default=Fail
+	ExistingDiskPolicy ExistingDiskPolicy `json:"existingDiskPolicy,omitempty"`
+}
+
+// ExistingDiskPolicy controls the handling of a disk found where the VM disk is prepared.
+// +kubebuilder:validation:Enum=Fail;Overwrite;Adopt
+type ExistingDiskPolicy string
+
+const (
+	// ExistingDiskFail reports the existing disk and waits for it to be removed.
+	ExistingDiskFail ExistingDiskPolicy = "Fail"
+
+	// ExistingDiskOverwrite removes the existing disk and prepares a new one.
+	ExistingDiskOverwrite ExistingDiskPolicy = "Overwrite"
+
+	// ExistingDiskAdopt creates the VM on the existing disk.
+	ExistingDiskAdopt ExistingDiskPolicy = "Adopt"
+)
+
+// ImageManagementPolicy controls the preparation of the VM disk.
+// +kubebuilder:validation:Enum=Managed;Unmanaged;ImageOnly
+type ImageManagementPolicy string
+
+const (
+	// ImageManagementManaged downloads ImageURL and prepares the VM disk from it.
+	ImageManagementManaged ImageManagementPolicy = "Managed"
+
+	// ImageManagementUnmanaged creates the VM on a disk prepared outside of the controller.
+	ImageManagementUnmanaged ImageManagementPolicy = "Unmanaged"
+
+	// ImageManagementImageOnly prepares the VM disk like Managed, but creates no VM.
+	// Switching to Managed creates the VM on the prepared disk.
+	ImageManagementImageOnly ImageManagementPolicy = "ImageOnly"
+)
+
+// CloudInitPolicy controls the injection of the bootstrap data into the VM.
+// +kubebuilder:validation:Enum=Inject;Skip
+type CloudInitPolicy string
+
+const (
+	// CloudInitInject hands the bootstrap data to the VM through its NoCloud config drive.
+	CloudInitInject CloudInitPolicy = "Inject"
+
+	// CloudInitSkip creates the VM without a NoCloud config drive.
+	CloudInitSkip CloudInitPolicy = "Skip"
+)
+
+// CloudInitFile is a file written by cloud-init when the VM first boots.
+type CloudInitFile struct {
+	// Path is the absolute path of the file on the VM.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+
+	// Content is the content of the file.
+	// +optional
+	Content string `json:"content,omitempty"`
+
+	// Owner is the user and group owning the file, e.g. "root:root".
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// Permissions are the octal permissions of the file, e.g. "0644".
+	// +optional
+	// +kubebuilder:validation:Pattern=`^0?[0-7]{3}$`
+	Permissions string `json:"permissions,omitempty"`
+}
+
+// SharedFolder is a folder of the Freebox storage mounted on the VM.
+type SharedFolder struct {
+	// Path is the path of the folder on the Freebox, e.g. "/Freebox/data". Its
+	// first element is the disk, which the Freebox shares under the same name.
+	// +kubebuilder:validation:Pattern=`^/[^/]+`
+	Path string `json:"path"`
+
+	// MountPath is the absolute path the folder is mounted at on the VM.
+	// +kubebuilder:validation:Pattern=`^/.`
+	MountPath string `json:"mountPath"`
+
+	// ReadOnly mounts the folder read-only.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// CredentialsSecretName is the name of a Secret of the namespace of the machine
+	// holding the username and password of a Freebox file sharing account. The folder
+	// is mounted as a guest when empty.
+	// +optional
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
 }
 
+// BootstrapFormat is the format of the bootstrap data handed to the VM.
+// +kubebuilder:validation:Enum=cloud-config;talos
+type BootstrapFormat string
+
+const (
+	// BootstrapFormatCloudConfig is cloud-init user data, e.g. as produced by the kubeadm bootstrap provider.
+	BootstrapFormatCloudConfig BootstrapFormat = "cloud-config"
+
+	// BootstrapFormatTalos is a Talos machine configuration, e.g. as produced by the Talos bootstrap provider.
+	BootstrapFormatTalos BootstrapFormat = "talos"
+)
+
 // FreeboxMachineStatus defines the observed state of FreeboxMachine.
 type FreeboxMachineStatus struct {
 	// initialization provides observations of the FreeboxMachine initialization process.
@@ -55,6 +279,17 @@ type FreeboxMachineStatus struct {
 	// +optional
 	Initialization FreeboxMachineInitializationStatus `json:"initialization,omitempty,omitzero"`
 
+	// Ready mirrors initialization.provisioned for tooling that still reads the
+	// status.ready field of the Cluster API v1beta1 contract.
+	// +optional
+	Ready bool `json:"ready,omitempty"`
+
+	// ObservedGeneration is the metadata.generation of the FreeboxMachine the status
+	// was last computed from. The status may not reflect the latest spec yet while
+	// it is lower than metadata.generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
 	// conditions represent the current state of the FreeboxMachine resource.
 	// Each condition has a unique type and reflects the status of a specific aspect of the resource.
 	//
@@ -74,24 +309,64 @@ type FreeboxMachineStatus struct {
 	// Using a pointer allows us to distinguish between "not set" (nil) and "set to 0" (valid first VM).
 	VMID *int64 `json:"vmID,omitempty"`
 
+	// FreeboxSerial is the serial number of the Freebox the machine is provisioned
+	// on. The machine is not changed while the controller manages another Freebox,
+	// until the change is confirmed with the
+	// infrastructure.cluster.x-k8s.io/confirm-freebox-target annotation.
+	// +optional
+	FreeboxSerial string `json:"freeboxSerial,omitempty"`
+
+	// VMState mirrors the status of the Freebox virtual machine, e.g. "running" or
+	// "stopped". It is refreshed periodically once the machine is provisioned.
+	// +optional
+	VMState string `json:"vmState,omitempty"`
+
 	// DiskPath stores the path to the VM disk file
 	// so it can be deleted when the FreeboxMachine is deleted.
+	// It is recorded as soon as the path is decided, and reused afterwards.
 	DiskPath string `json:"diskPath,omitempty"`
 
+	// ManagedFiles lists the files created on the Freebox for the VM, such as its
+	// disk and EFI variables, which are removed when the machine is deleted.
+	// +optional
+	// +listType=set
+	ManagedFiles []string `json:"managedFiles,omitempty"`
+
 	// Addresses contains the associated addresses for the machine.
 	// +optional
 	Addresses []clusterv1.MachineAddress `json:"addresses,omitempty"`
 
 	// Phase tracks the current provisioning stage:
-	// "download", "extract", "copy", "rename", "resize", "vmcreated", or "done".
+	// "download", "extract", "copy", "rename", "verify", "resize", "vmcreated", or "done".
 	// +optional
 	Phase string `json:"phase,omitempty"`
 
+	// PhaseTransitionTime is when the machine entered its current phase. It times
+	// the phases of the image pipeline.
+	// +optional
+	PhaseTransitionTime *metav1.Time `json:"phaseTransitionTime,omitempty"`
+
+	// ImageETA is when the image is estimated to be ready, while it is prepared.
+	// It is based on the progress of the current phase and on how long the
+	// following ones took for previous machines.
+	// +optional
+	ImageETA *metav1.Time `json:"imageETA,omitempty"`
+
 	// TaskID holds the Freebox async task ID for the current phase.
 	// Zero means no task has been started yet for the current phase.
 	// +optional
 	TaskID int64 `json:"taskID,omitempty"`
 
+	// DownloadRetries counts how many times the image download was resumed after an error.
+	// +optional
+	DownloadRetries int32 `json:"downloadRetries,omitempty"`
+
+	// ImageCachePath is the path of the image already on the Freebox the disk is prepared
+	// from instead of a download, either prefetched by the FreeboxCluster or given as a
+	// file:// imageURL. The image is left in place.
+	// +optional
+	ImageCachePath string `json:"imageCachePath,omitempty"`
+
 	// RenameSrc is the source path for the rename step.
 	// +optional
 	RenameSrc string `json:"renameSrc,omitempty"`
@@ -99,6 +374,101 @@ type FreeboxMachineStatus struct {
 	// RenameDst is the destination path for the rename step.
 	// +optional
 	RenameDst string `json:"renameDst,omitempty"`
+
+	// ImageFileName is the name of the file the image is downloaded to, when it
+	// differs from the last element of the ImageURL path, e.g. when the server
+	// names it through a Content-Disposition header.
+	// +optional
+	ImageFileName string `json:"imageFileName,omitempty"`
+
+	// ImageSizeBytes is the size of the image announced by its server when the
+	// ImageURL was validated, in bytes.
+	// +optional
+	ImageSizeBytes int64 `json:"imageSizeBytes,omitempty"`
+
+	// Resources reports what the VM was actually created with on the Freebox.
+	// +optional
+	Resources *FreeboxMachineResources `json:"resources,omitempty"`
+
+	// Template identifies the revision of the template the VM was created from, so
+	// that the machines of an outdated template can be told apart during rollouts.
+	// +optional
+	Template *FreeboxMachineTemplateRevision `json:"template,omitempty"`
+
+	// TaskHistory lists the last Freebox tasks started for the machine, oldest
+	// first, to tell which step of the image pipeline keeps failing.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	TaskHistory []FreeboxTaskRecord `json:"taskHistory,omitempty"`
+}
+
+// FreeboxTaskRecord describes a Freebox task started for a machine.
+type FreeboxTaskRecord struct {
+	// ID of the task on the Freebox.
+	ID int64 `json:"id"`
+
+	// Type is the step of the image pipeline the task ran: download, extract,
+	// copy, rename or resize.
+	Type string `json:"type"`
+
+	// Result of the task.
+	Result FreeboxTaskResult `json:"result"`
+
+	// Message explains why the task failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is when the task was started.
+	StartTime metav1.Time `json:"startTime"`
+
+	// Duration is how long the task took, once it is over.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+}
+
+// FreeboxTaskResult is the result of a Freebox task.
+// +kubebuilder:validation:Enum=Running;Succeeded;Failed
+type FreeboxTaskResult string
+
+const (
+	// TaskRunning is the result of a task that is not over yet.
+	TaskRunning FreeboxTaskResult = "Running"
+	// TaskSucceeded is the result of a task whose output the machine moved on with.
+	TaskSucceeded FreeboxTaskResult = "Succeeded"
+	// TaskFailed is the result of a task that failed.
+	TaskFailed FreeboxTaskResult = "Failed"
+)
+
+// FreeboxMachineTemplateRevision identifies the revision of the template a VM was created from.
+type FreeboxMachineTemplateRevision struct {
+	// Name of the FreeboxMachineTemplate the machine was cloned from.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Hash is the machine-template-hash of the MachineSet that created the machine.
+	// For machines not created by a MachineDeployment, such as control plane machines,
+	// it is a hash of the spec the machine was created with.
+	// +optional
+	Hash string `json:"hash,omitempty"`
+}
+
+// FreeboxMachineResources describes the resources of a Freebox VM.
+type FreeboxMachineResources struct {
+	// VCPUs is the number of vCPUs of the VM.
+	// +optional
+	VCPUs int64 `json:"vcpus,omitempty"`
+
+	// MemoryMB is the size of the RAM of the VM in MB.
+	// +optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+
+	// DiskSizeBytes is the virtual size of the VM disk after resize, in bytes.
+	// +optional
+	DiskSizeBytes int64 `json:"diskSizeBytes,omitempty"`
+
+	// DiskType is the format of the VM disk, either raw or qcow2.
+	// +optional
+	DiskType string `json:"diskType,omitempty"`
 }
 
 // FreeboxMachineInitializationStatus provides observations of the FreeboxMachine initialization process.
@@ -117,7 +487,11 @@ type FreeboxMachineInitializationStatus struct {
 // +kubebuilder:printcolumn:name="Machine",type="string",JSONPath=".metadata.ownerReferences[?(@.kind==\"Machine\")].name",description="Machine object which owns with this FreeboxMachine"
 // +kubebuilder:printcolumn:name="ProviderID",type="string",JSONPath=".spec.providerID",description="Provider ID"
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.initialization.provisioned",description="FreeboxMachine ready status"
+// +kubebuilder:printcolumn:name="Template Hash",type="string",JSONPath=".status.template.hash",description="Revision of the template the VM was created from",priority=1
+// +kubebuilder:printcolumn:name="VM State",type="string",JSONPath=".status.vmState",description="Status of the Freebox virtual machine",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of FreeboxMachine"
+// +kubebuilder:selectablefield:JSONPath=".spec.providerID"
+// +kubebuilder:selectablefield:JSONPath=".status.vmID"
 
 // FreeboxMachine is the Schema for the freeboxmachines API
 type FreeboxMachine struct {
@@ -145,6 +519,17 @@ type FreeboxMachineList struct {
 	Items           []FreeboxMachine `json:"items"`
 }
 
+// GetConditions returns the conditions of the FreeboxMachine, as Cluster API
+// condition utilities expect.
+func (m *FreeboxMachine) GetConditions() []metav1.Condition {
+	return m.Status.Conditions
+}
+
+// SetConditions sets the conditions of the FreeboxMachine.
+func (m *FreeboxMachine) SetConditions(conditions []metav1.Condition) {
+	m.Status.Conditions = conditions
+}
+
 func init() {
 	objectTypes = append(objectTypes, &FreeboxMachine{}, &FreeboxMachineList{})
 }
@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/version"
+)
+
+// Placeholders supported in FreeboxMachineSpec.ImageURL.
+const (
+	// ImageURLPlaceholderArch is replaced with the architecture of the Freebox, e.g. "arm64".
+	ImageURLPlaceholderArch = "{arch}"
+	// ImageURLPlaceholderK8sVersion is replaced with the Kubernetes version of the owner Machine, e.g. "v1.34.1".
+	ImageURLPlaceholderK8sVersion = "{k8sVersion}"
+	// ImageURLPlaceholderChannel is replaced with the minor release of the owner Machine, e.g. "v1.34".
+	ImageURLPlaceholderChannel = "{channel}"
+)
+
+var imageURLPlaceholderRegexp = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ImageURLHasPlaceholders reports whether imageURL contains placeholders.
+func ImageURLHasPlaceholders(imageURL string) bool {
+	return imageURLPlaceholderRegexp.MatchString(imageURL)
+}
+
+// ExpandImageURL replaces the placeholders of imageURL with the given architecture
+// and Kubernetes version. It returns an error for unknown placeholders, and when
+// the Kubernetes version is needed but is not a semantic version.
+func ExpandImageURL(imageURL, arch, k8sVersion string) (string, error) {
+	var expandErr error
+	expanded := imageURLPlaceholderRegexp.ReplaceAllStringFunc(imageURL, func(placeholder string) string {
+		switch placeholder {
+		case ImageURLPlaceholderArch:
+			return arch
+		case ImageURLPlaceholderK8sVersion, ImageURLPlaceholderChannel:
+			v, err := version.ParseSemantic(k8sVersion)
+			if err != nil {
+				expandErr = fmt.Errorf("cannot expand %s: invalid Kubernetes version %q: %w", placeholder, k8sVersion, err)
+				return placeholder
+			}
+			if placeholder == ImageURLPlaceholderChannel {
+				return fmt.Sprintf("v%d.%d", v.Major(), v.Minor())
+			}
+			return "v" + strings.TrimPrefix(k8sVersion, "v")
+		default:
+			expandErr = fmt.Errorf("unknown placeholder %s, supported placeholders are %s, %s and %s",
+				placeholder, ImageURLPlaceholderArch, ImageURLPlaceholderK8sVersion, ImageURLPlaceholderChannel)
+			return placeholder
+		}
+	})
+	if expandErr != nil {
+		return "", expandErr
+	}
+	return expanded, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// DefaultNameTemplate is the template used when FreeboxMachineSpec.NameTemplate is empty.
+const DefaultNameTemplate = "{{ .MachineName }}"
+
+// NameTemplateData holds the fields available to FreeboxMachineSpec.NameTemplate.
+// +kubebuilder:object:generate=false
+type NameTemplateData struct {
+	// ClusterName is the name of the Cluster the machine belongs to.
+	ClusterName string
+	// MachineName is the name of the FreeboxMachine.
+	MachineName string
+	// Namespace is the namespace of the FreeboxMachine.
+	Namespace string
+}
+
+// RenderName renders nameTemplate, or DefaultNameTemplate when empty, with data.
+// The result is used both as the Freebox VM name, which is also its hostname, and
+// as the disk file name, so it must be a lowercase RFC 1123 label of at most 63
+// characters.
+func RenderName(nameTemplate string, data NameTemplateData) (string, error) {
+	if nameTemplate == "" {
+		nameTemplate = DefaultNameTemplate
+	}
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("parsing name template: %w", err)
+	}
+	var name strings.Builder
+	if err := tmpl.Execute(&name, data); err != nil {
+		return "", fmt.Errorf("rendering name template: %w", err)
+	}
+	if errs := validation.IsDNS1123Label(name.String()); len(errs) > 0 {
+		return "", fmt.Errorf("name %q rendered from template %q is not valid: %s", name.String(), nameTemplate, strings.Join(errs, ", "))
+	}
+	return name.String(), nil
+}
+
+// VMName returns the name of the Freebox VM of the machine, rendered from its name template.
+func (m *FreeboxMachine) VMName() (string, error) {
+	return RenderName(m.Spec.NameTemplate, NameTemplateData{
+		ClusterName: m.Labels[clusterv1.ClusterNameLabel],
+		MachineName: m.Name,
+		Namespace:   m.Namespace,
+	})
+}
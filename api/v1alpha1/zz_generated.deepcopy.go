@@ -21,17 +21,48 @@ limitations under the License.
 package v1alpha1
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudInitFile) DeepCopyInto(out *CloudInitFile) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudInitFile.
+func (in *CloudInitFile) DeepCopy() *CloudInitFile {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudInitFile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeboxAPIStatus) DeepCopyInto(out *FreeboxAPIStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxAPIStatus.
+func (in *FreeboxAPIStatus) DeepCopy() *FreeboxAPIStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeboxAPIStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FreeboxCluster) DeepCopyInto(out *FreeboxCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -53,6 +84,80 @@ func (in *FreeboxCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeboxClusterIdentity) DeepCopyInto(out *FreeboxClusterIdentity) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxClusterIdentity.
+func (in *FreeboxClusterIdentity) DeepCopy() *FreeboxClusterIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeboxClusterIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreeboxClusterIdentity) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeboxClusterIdentityList) DeepCopyInto(out *FreeboxClusterIdentityList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FreeboxClusterIdentity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxClusterIdentityList.
+func (in *FreeboxClusterIdentityList) DeepCopy() *FreeboxClusterIdentityList {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeboxClusterIdentityList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FreeboxClusterIdentityList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeboxClusterIdentitySpec) DeepCopyInto(out *FreeboxClusterIdentitySpec) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxClusterIdentitySpec.
+func (in *FreeboxClusterIdentitySpec) DeepCopy() *FreeboxClusterIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeboxClusterIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FreeboxClusterInitializationStatus) DeepCopyInto(out *FreeboxClusterInitializationStatus) {
 	*out = *in
@@ -109,6 +214,43 @@ func (in *FreeboxClusterList) DeepCopyObject() runtime.Object {
 func (in *FreeboxClusterSpec) DeepCopyInto(out *FreeboxClusterSpec) {
 	*out = *in
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(FreeboxResourceQuota)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PrefetchImages != nil {
+		in, out := &in.PrefetchImages, &out.PrefetchImages
+		*out = make([]ImageRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachineDefaults != nil {
+		in, out := &in.MachineDefaults, &out.MachineDefaults
+		*out = new(FreeboxMachineDefaults)
+		**out = **in
+	}
+	if in.StorageFailureDomains != nil {
+		in, out := &in.StorageFailureDomains, &out.StorageFailureDomains
+		*out = make([]FreeboxStorageFailureDomain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxClusterSpec.
@@ -125,9 +267,26 @@ func (in *FreeboxClusterSpec) DeepCopy() *FreeboxClusterSpec {
 func (in *FreeboxClusterStatus) DeepCopyInto(out *FreeboxClusterStatus) {
 	*out = *in
 	in.Initialization.DeepCopyInto(&out.Initialization)
+	if in.PrefetchedImages != nil {
+		in, out := &in.PrefetchedImages, &out.PrefetchedImages
+		*out = make([]PrefetchedImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]v1beta2.FailureDomain, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FreeboxAPI != nil {
+		in, out := &in.FreeboxAPI, &out.FreeboxAPI
+		*out = new(FreeboxAPIStatus)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -149,7 +308,7 @@ func (in *FreeboxMachine) DeepCopyInto(out *FreeboxMachine) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -171,6 +330,21 @@ func (in *FreeboxMachine) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeboxMachineDefaults) DeepCopyInto(out *FreeboxMachineDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxMachineDefaults.
+func (in *FreeboxMachineDefaults) DeepCopy() *FreeboxMachineDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeboxMachineDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FreeboxMachineInitializationStatus) DeepCopyInto(out *FreeboxMachineInitializationStatus) {
 	*out = *in
@@ -223,9 +397,44 @@ func (in *FreeboxMachineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeboxMachineResources) DeepCopyInto(out *FreeboxMachineResources) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxMachineResources.
+func (in *FreeboxMachineResources) DeepCopy() *FreeboxMachineResources {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeboxMachineResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FreeboxMachineSpec) DeepCopyInto(out *FreeboxMachineSpec) {
 	*out = *in
+	if in.AutoStart != nil {
+		in, out := &in.AutoStart, &out.AutoStart
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]CloudInitFile, len(*in))
+		copy(*out, *in)
+	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SharedFolders != nil {
+		in, out := &in.SharedFolders, &out.SharedFolders
+		*out = make([]SharedFolder, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxMachineSpec.
@@ -244,7 +453,7 @@ func (in *FreeboxMachineStatus) DeepCopyInto(out *FreeboxMachineStatus) {
 	in.Initialization.DeepCopyInto(&out.Initialization)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -254,11 +463,41 @@ func (in *FreeboxMachineStatus) DeepCopyInto(out *FreeboxMachineStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.ManagedFiles != nil {
+		in, out := &in.ManagedFiles, &out.ManagedFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Addresses != nil {
 		in, out := &in.Addresses, &out.Addresses
 		*out = make([]v1beta2.MachineAddress, len(*in))
 		copy(*out, *in)
 	}
+	if in.PhaseTransitionTime != nil {
+		in, out := &in.PhaseTransitionTime, &out.PhaseTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ImageETA != nil {
+		in, out := &in.ImageETA, &out.ImageETA
+		*out = (*in).DeepCopy()
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(FreeboxMachineResources)
+		**out = **in
+	}
+	if in.Template != nil {
+		in, out := &in.Template, &out.Template
+		*out = new(FreeboxMachineTemplateRevision)
+		**out = **in
+	}
+	if in.TaskHistory != nil {
+		in, out := &in.TaskHistory, &out.TaskHistory
+		*out = make([]FreeboxTaskRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxMachineStatus.
@@ -276,7 +515,7 @@ func (in *FreeboxMachineTemplate) DeepCopyInto(out *FreeboxMachineTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxMachineTemplate.
@@ -332,7 +571,7 @@ func (in *FreeboxMachineTemplateList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FreeboxMachineTemplateResource) DeepCopyInto(out *FreeboxMachineTemplateResource) {
 	*out = *in
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxMachineTemplateResource.
@@ -345,10 +584,25 @@ func (in *FreeboxMachineTemplateResource) DeepCopy() *FreeboxMachineTemplateReso
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeboxMachineTemplateRevision) DeepCopyInto(out *FreeboxMachineTemplateRevision) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxMachineTemplateRevision.
+func (in *FreeboxMachineTemplateRevision) DeepCopy() *FreeboxMachineTemplateRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeboxMachineTemplateRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FreeboxMachineTemplateSpec) DeepCopyInto(out *FreeboxMachineTemplateSpec) {
 	*out = *in
-	out.Template = in.Template
+	in.Template.DeepCopyInto(&out.Template)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxMachineTemplateSpec.
@@ -360,3 +614,104 @@ func (in *FreeboxMachineTemplateSpec) DeepCopy() *FreeboxMachineTemplateSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeboxResourceQuota) DeepCopyInto(out *FreeboxResourceQuota) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxResourceQuota.
+func (in *FreeboxResourceQuota) DeepCopy() *FreeboxResourceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeboxResourceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeboxStorageFailureDomain) DeepCopyInto(out *FreeboxStorageFailureDomain) {
+	*out = *in
+	if in.ControlPlane != nil {
+		in, out := &in.ControlPlane, &out.ControlPlane
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxStorageFailureDomain.
+func (in *FreeboxStorageFailureDomain) DeepCopy() *FreeboxStorageFailureDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeboxStorageFailureDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FreeboxTaskRecord) DeepCopyInto(out *FreeboxTaskRecord) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FreeboxTaskRecord.
+func (in *FreeboxTaskRecord) DeepCopy() *FreeboxTaskRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(FreeboxTaskRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRef) DeepCopyInto(out *ImageRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRef.
+func (in *ImageRef) DeepCopy() *ImageRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrefetchedImage) DeepCopyInto(out *PrefetchedImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrefetchedImage.
+func (in *PrefetchedImage) DeepCopy() *PrefetchedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(PrefetchedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharedFolder) DeepCopyInto(out *SharedFolder) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharedFolder.
+func (in *SharedFolder) DeepCopy() *SharedFolder {
+	if in == nil {
+		return nil
+	}
+	out := new(SharedFolder)
+	in.DeepCopyInto(out)
+	return out
+}
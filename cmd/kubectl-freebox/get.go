@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// runGet lists FreeboxMachines with the state of their Freebox VM.
+func runGet(ctx context.Context, args []string) error {
+	var (
+		kube          kubeFlags
+		allNamespaces bool
+	)
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	kube.bind(fs)
+	fs.BoolVar(&allNamespaces, "all-namespaces", false, "List FreeboxMachines across all namespaces.")
+	fs.BoolVar(&allNamespaces, "A", false, "List FreeboxMachines across all namespaces (shorthand).")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	c, namespace, err := kube.client()
+	if err != nil {
+		return err
+	}
+
+	var listOpts []client.ListOption
+	if !allNamespaces {
+		listOpts = append(listOpts, client.InNamespace(namespace))
+	}
+	var machines infrastructurev1alpha1.FreeboxMachineList
+	if err := c.List(ctx, &machines, listOpts...); err != nil {
+		return fmt.Errorf("listing FreeboxMachines: %w", err)
+	}
+
+	fb, err := newFreeboxConnection(ctx)
+	if err != nil {
+		return err
+	}
+	vms, err := fb.client.ListVirtualMachines(ctx)
+	if err != nil {
+		return fmt.Errorf("listing Freebox VMs: %w", err)
+	}
+	vmsByID := make(map[int64]freeboxTypes.VirtualMachine, len(vms))
+	for _, vm := range vms {
+		vmsByID[vm.ID] = vm
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAMESPACE\tNAME\tCLUSTER\tPHASE\tVMID\tSTATE\tADDRESSES\tDISK")
+	for _, machine := range machines.Items {
+		vmID, state := "<none>", "<none>"
+		if machine.Status.VMID != nil {
+			vmID = strconv.FormatInt(*machine.Status.VMID, 10)
+			state = "<missing>"
+			if vm, ok := vmsByID[*machine.Status.VMID]; ok {
+				state = string(vm.Status)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			machine.Namespace,
+			machine.Name,
+			valueOrNone(machine.Labels[clusterv1.ClusterNameLabel]),
+			valueOrNone(machine.Status.Phase),
+			vmID,
+			state,
+			valueOrNone(formatAddresses(machine.Status.Addresses)),
+			valueOrNone(machine.Status.DiskPath),
+		)
+	}
+	return w.Flush()
+}
+
+func formatAddresses(addresses []clusterv1.MachineAddress) string {
+	values := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		values = append(values, address.Address)
+	}
+	return strings.Join(values, ",")
+}
+
+func valueOrNone(s string) string {
+	if s == "" {
+		return "<none>"
+	}
+	return s
+}
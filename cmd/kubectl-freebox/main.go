@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-freebox is a kubectl plugin to inspect FreeboxMachines together with
+// the Freebox virtual machines backing them.
+//
+// Usage:
+//
+//	kubectl freebox get [-n NAMESPACE | -A]
+//	kubectl freebox vm start|stop|console NAME [-n NAMESPACE]
+//
+// The Freebox is reached with the same FREEBOX_* environment variables as the manager.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+const usage = `Inspect FreeboxMachines and the Freebox VMs backing them.
+
+Usage:
+  kubectl freebox get [-n NAMESPACE | -A]
+  kubectl freebox vm start NAME [-n NAMESPACE]
+  kubectl freebox vm stop NAME [-n NAMESPACE] [--force]
+  kubectl freebox vm console NAME [-n NAMESPACE]
+
+Environment:
+  FREEBOX_ENDPOINT  Freebox API endpoint (default http://mafreebox.freebox.fr)
+  FREEBOX_VERSION   Freebox API version (default latest)
+  FREEBOX_APP_ID    Freebox application ID
+  FREEBOX_TOKEN     Freebox application token
+  KUBECONFIG        Kubeconfig of the management cluster
+`
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = infrastructurev1alpha1.AddToScheme(scheme)
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("a command is required")
+	}
+
+	switch args[0] {
+	case "get":
+		return runGet(ctx, args[1:])
+	case "vm":
+		if len(args) < 2 {
+			fmt.Fprint(os.Stderr, usage)
+			return fmt.Errorf("a vm action is required")
+		}
+		return runVM(ctx, args[1], args[2:])
+	case "help", "-h", "--help":
+		fmt.Fprint(os.Stdout, usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+// kubeFlags holds the flags shared by all commands to reach the management cluster.
+type kubeFlags struct {
+	kubeconfig string
+	context    string
+	namespace  string
+}
+
+func (f *kubeFlags) bind(fs *flag.FlagSet) {
+	fs.StringVar(&f.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file to use.")
+	fs.StringVar(&f.context, "context", "", "The name of the kubeconfig context to use.")
+	fs.StringVar(&f.namespace, "namespace", "", "The namespace of the FreeboxMachines.")
+	fs.StringVar(&f.namespace, "n", "", "The namespace of the FreeboxMachines (shorthand).")
+}
+
+// client returns a Kubernetes client and the namespace to use, falling back to
+// the namespace of the current kubeconfig context.
+func (f *kubeFlags) client() (client.Client, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = f.kubeconfig
+	config := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{
+		CurrentContext: f.context,
+	})
+
+	restConfig, err := config.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("loading kubeconfig: %w", err)
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, "", fmt.Errorf("creating Kubernetes client: %w", err)
+	}
+
+	namespace := f.namespace
+	if namespace == "" {
+		if namespace, _, err = config.Namespace(); err != nil {
+			return nil, "", fmt.Errorf("resolving namespace: %w", err)
+		}
+	}
+	return c, namespace, nil
+}
+
+// sessionRecorder remembers the session token free-go sends to the Freebox so
+// that the console websocket, which free-go does not expose, can reuse it.
+type sessionRecorder struct {
+	mu    sync.Mutex
+	token string
+}
+
+func (s *sessionRecorder) Do(req *http.Request) (*http.Response, error) {
+	if token := req.Header.Get(freeboxclient.AuthHeader); token != "" {
+		s.mu.Lock()
+		s.token = token
+		s.mu.Unlock()
+	}
+	return http.DefaultClient.Do(req)
+}
+
+func (s *sessionRecorder) sessionToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.token
+}
+
+// freeboxConnection is a logged in Freebox client along with what is needed
+// to open raw API connections.
+type freeboxConnection struct {
+	client   freeboxclient.Client
+	endpoint string
+	version  string
+	session  *sessionRecorder
+}
+
+// newFreeboxConnection logs in to the Freebox using the same environment
+// variables as the manager.
+func newFreeboxConnection(ctx context.Context) (*freeboxConnection, error) {
+	endpoint := os.Getenv("FREEBOX_ENDPOINT")
+	if endpoint == "" {
+		endpoint = "http://mafreebox.freebox.fr"
+	}
+	version := os.Getenv("FREEBOX_VERSION")
+	if version == "" {
+		version = "latest"
+	}
+	appID := os.Getenv("FREEBOX_APP_ID")
+	if appID == "" {
+		return nil, fmt.Errorf("FREEBOX_APP_ID undefined")
+	}
+	token := os.Getenv("FREEBOX_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("FREEBOX_TOKEN undefined")
+	}
+
+	fbClient, err := freeboxclient.New(endpoint, version)
+	if err != nil {
+		return nil, fmt.Errorf("creating Freebox client: %w", err)
+	}
+	session := &sessionRecorder{}
+	fbClient.WithAppID(appID).WithPrivateToken(token).WithHTTPClient(session)
+	if _, err := fbClient.Login(ctx); err != nil {
+		return nil, fmt.Errorf("logging in to Freebox: %w", err)
+	}
+
+	return &freeboxConnection{
+		client:   fbClient,
+		endpoint: endpoint,
+		version:  version,
+		session:  session,
+	}, nil
+}
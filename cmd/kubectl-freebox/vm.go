@@ -0,0 +1,220 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	"golang.org/x/term"
+	"k8s.io/apimachinery/pkg/types"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// consoleEscape is the byte (Ctrl-]) that detaches from the VM console.
+const consoleEscape = 0x1d
+
+// runVM runs an action against the Freebox VM backing a FreeboxMachine.
+func runVM(ctx context.Context, action string, args []string) error {
+	var (
+		kube  kubeFlags
+		force bool
+	)
+	fs := flag.NewFlagSet("vm "+action, flag.ContinueOnError)
+	kube.bind(fs)
+	if action == "stop" {
+		fs.BoolVar(&force, "force", false, "Kill the VM instead of sending an ACPI shutdown.")
+	}
+	names, err := parseInterspersed(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		return fmt.Errorf("exactly one FreeboxMachine name is required")
+	}
+
+	switch action {
+	case "start", "stop", "console":
+	default:
+		return fmt.Errorf("unknown vm action %q", action)
+	}
+
+	c, namespace, err := kube.client()
+	if err != nil {
+		return err
+	}
+	var machine infrastructurev1alpha1.FreeboxMachine
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: names[0]}, &machine); err != nil {
+		return fmt.Errorf("getting FreeboxMachine: %w", err)
+	}
+	if machine.Status.VMID == nil {
+		return fmt.Errorf("FreeboxMachine %s/%s has no VM yet", machine.Namespace, machine.Name)
+	}
+	vmID := *machine.Status.VMID
+
+	fb, err := newFreeboxConnection(ctx)
+	if err != nil {
+		return err
+	}
+	// VM IDs are only unique within a Freebox: the VM with the same ID on another
+	// Freebox is unrelated to the machine.
+	if recorded := machine.Status.FreeboxSerial; recorded != "" {
+		system, err := fb.client.GetSystemInfo(ctx)
+		if err != nil {
+			return fmt.Errorf("getting Freebox system info: %w", err)
+		}
+		if system.Serial != recorded {
+			return fmt.Errorf("FreeboxMachine %s/%s is on Freebox %s, but FREEBOX_ENDPOINT is Freebox %s",
+				machine.Namespace, machine.Name, recorded, system.Serial)
+		}
+	}
+
+	switch action {
+	case "start":
+		if err := fb.client.StartVirtualMachine(ctx, vmID); err != nil {
+			return fmt.Errorf("starting VM %d: %w", vmID, err)
+		}
+		fmt.Printf("VM %d of FreeboxMachine %s/%s started\n", vmID, machine.Namespace, machine.Name)
+	case "stop":
+		stopFn := fb.client.StopVirtualMachine
+		if force {
+			stopFn = fb.client.KillVirtualMachine
+		}
+		if err := stopFn(ctx, vmID); err != nil {
+			return fmt.Errorf("stopping VM %d: %w", vmID, err)
+		}
+		fmt.Printf("VM %d of FreeboxMachine %s/%s stopped\n", vmID, machine.Namespace, machine.Name)
+	case "console":
+		return attachConsole(ctx, fb, vmID)
+	}
+	return nil
+}
+
+// attachConsole connects the terminal to the serial console of a Freebox VM
+// until the user presses Ctrl-] or the connection is closed.
+func attachConsole(ctx context.Context, fb *freeboxConnection, vmID int64) error {
+	consoleURL, err := consoleURL(fb.endpoint, fb.version, vmID)
+	if err != nil {
+		return err
+	}
+	header := http.Header{}
+	header.Set(freeboxclient.AuthHeader, fb.session.sessionToken())
+	ws, resp, err := websocket.DefaultDialer.DialContext(ctx, consoleURL, header)
+	if err != nil {
+		if resp != nil {
+			return fmt.Errorf("opening console of VM %d returned status %s: %w", vmID, resp.Status, err)
+		}
+		return fmt.Errorf("opening console of VM %d: %w", vmID, err)
+	}
+	defer func() { _ = ws.Close() }()
+
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		state, err := term.MakeRaw(int(os.Stdin.Fd()))
+		if err != nil {
+			return fmt.Errorf("setting terminal raw mode: %w", err)
+		}
+		defer func() { _ = term.Restore(int(os.Stdin.Fd()), state) }()
+	}
+	fmt.Fprintf(os.Stderr, "Connected to the console of VM %d, press Ctrl-] to detach.\r\n", vmID)
+
+	done := make(chan error, 2)
+	go func() {
+		for {
+			_, data, err := ws.ReadMessage()
+			if err != nil {
+				done <- err
+				return
+			}
+			if _, err := os.Stdout.Write(data); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				done <- err
+				return
+			}
+			data := buf[:n]
+			if i := bytes.IndexByte(data, consoleEscape); i >= 0 {
+				if i > 0 {
+					_ = ws.WriteMessage(websocket.BinaryMessage, data[:i])
+				}
+				done <- nil
+				return
+			}
+			if err := ws.WriteMessage(websocket.BinaryMessage, data); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return nil
+	case err := <-done:
+		if err == nil || websocket.IsCloseError(err, websocket.CloseNormalClosure) || errors.Is(err, context.Canceled) {
+			return nil
+		}
+		return fmt.Errorf("console of VM %d: %w", vmID, err)
+	}
+}
+
+// consoleURL builds the websocket URL of a VM console the same way free-go
+// builds its API base URL.
+func consoleURL(endpoint, version string, vmID int64) (string, error) {
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "http://" + endpoint
+	}
+	u, err := url.Parse(fmt.Sprintf("%s/api/%s/vm/%d/console", endpoint, version, vmID))
+	if err != nil {
+		return "", fmt.Errorf("building console URL: %w", err)
+	}
+	u.Scheme = strings.Replace(u.Scheme, "http", "ws", 1)
+	return u.String(), nil
+}
+
+// parseInterspersed parses flags that may appear before or after positional
+// arguments, as kubectl does, and returns the positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
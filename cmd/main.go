@@ -20,7 +20,13 @@ import (
 	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -30,11 +36,15 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	ctrlcontroller "sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -45,6 +55,10 @@ import (
 
 	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
 	"github.com/mcanevet/cluster-api-provider-freebox/internal/controller"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/freebox"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/imagepolicy"
+	webhookv1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/internal/webhook/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/webhookcert"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -57,6 +71,8 @@ func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
 	utilruntime.Must(clusterv1.AddToScheme(scheme))
+	utilruntime.Must(bootstrapv1.AddToScheme(scheme))
+	utilruntime.Must(controlplanev1.AddToScheme(scheme))
 	utilruntime.Must(infrastructurev1alpha1.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
@@ -66,10 +82,24 @@ func main() {
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
-	var enableLeaderElection bool
+	var webhookSelfSignedCerts bool
+	var webhookNamePrefix string
+	var enableLeaderElection, leaderElectionReleaseOnCancel bool
+	var leaderElectionLeaseDuration, leaderElectionRenewDeadline time.Duration
+	var syncPeriod, vmStateResyncPeriod, addressDiscoveryInterval time.Duration
+	var addressRequeueInterval, nodeRequeueInterval time.Duration
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var freeboxEndpoint, freeboxVersion, freeboxAppID, freeboxAppIDFile, freeboxTokenFile string
+	var freeboxHTTP freebox.HTTPOptions
+	var freeboxQPS float64
+	var freeboxBurst int
+	var instanceID string
+	var maxConcurrentDownloads int
+	var imagePolicy imagepolicy.Policy
+	var probeImageURLs bool
+	var probeControlPlaneEndpoint bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -77,17 +107,92 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaderElectionLeaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"The duration that non-leader candidates will wait before attempting to acquire leadership.")
+	flag.DurationVar(&leaderElectionRenewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"The duration that the acting leader will retry refreshing leadership before giving up.")
+	flag.BoolVar(&leaderElectionReleaseOnCancel, "leader-elect-release-on-cancel", false,
+		"If set, the leader releases its lease when the manager stops, so that another replica "+
+			"takes over without waiting for the lease duration.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
 	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
 	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	flag.BoolVar(&webhookSelfSignedCerts, "webhook-self-signed-certs", false,
+		"Generate the webhook certificate and inject its CA into the webhook configurations, "+
+			"so that webhooks can be enabled without cert-manager. Mutually exclusive with --webhook-cert-path.")
+	flag.StringVar(&webhookNamePrefix, "webhook-name-prefix", "cluster-api-provider-freebox-",
+		"The prefix of the names of the webhook Service, certificate Secret and webhook configurations "+
+			"managed by --webhook-self-signed-certs, as set by kustomize.")
 	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&freeboxEndpoint, "freebox-endpoint", envOrDefault("FREEBOX_ENDPOINT", "http://mafreebox.freebox.fr"),
+		"The Freebox API endpoint. Defaults to FREEBOX_ENDPOINT.")
+	flag.StringVar(&freeboxVersion, "freebox-api-version", envOrDefault("FREEBOX_VERSION", "latest"),
+		"The Freebox API version. Defaults to FREEBOX_VERSION.")
+	flag.StringVar(&freeboxAppID, "freebox-app-id", os.Getenv("FREEBOX_APP_ID"),
+		"The Freebox application ID. Defaults to FREEBOX_APP_ID.")
+	flag.StringVar(&freeboxAppIDFile, "freebox-app-id-file", os.Getenv("FREEBOX_APP_ID_FILE"),
+		"The file containing the Freebox application ID, preferred over --freebox-app-id. "+
+			"Defaults to FREEBOX_APP_ID_FILE.")
+	flag.StringVar(&freeboxTokenFile, "freebox-token-file", os.Getenv("FREEBOX_TOKEN_FILE"),
+		"The file containing the Freebox application token, reloaded when it changes. "+
+			"Defaults to FREEBOX_TOKEN_FILE, or to the token in FREEBOX_TOKEN when unset.")
+	flag.DurationVar(&freeboxHTTP.Timeout, "freebox-request-timeout", time.Minute,
+		"How long a request to the Freebox may take, including the wait for a connection. Zero means no limit.")
+	flag.DurationVar(&freeboxHTTP.IdleConnTimeout, "freebox-idle-conn-timeout", 30*time.Second,
+		"How long an idle connection to the Freebox is kept open for reuse.")
+	flag.IntVar(&freeboxHTTP.MaxIdleConns, "freebox-max-idle-conns", 4,
+		"How many idle connections to the Freebox are kept open for reuse.")
+	flag.IntVar(&freeboxHTTP.MaxConns, "freebox-max-conns", 4,
+		"How many connections to the Freebox may be open at once. Zero means no limit.")
+	flag.Float64Var(&freeboxQPS, "freebox-qps", 5,
+		"How many requests per second are sent to the Freebox on average, by all the controllers together. Zero means no limit.")
+	flag.IntVar(&freeboxBurst, "freebox-burst", 10,
+		"How many requests may be sent to the Freebox at once above --freebox-qps.")
+	flag.StringVar(&instanceID, "instance-id", os.Getenv("FREEBOX_INSTANCE_ID"),
+		"Identifies this provider instance when several management clusters manage the same Freebox. "+
+			"Each instance then downloads images and stores VM disks in its own subdirectory named after it, "+
+			"and never reuses the VMs or downloads of the others. Defaults to FREEBOX_INSTANCE_ID.")
+	flag.IntVar(&maxConcurrentDownloads, "max-concurrent-downloads", 2,
+		"The maximum number of image downloads running at the same time on the Freebox, 0 for no limit. "+
+			"Machines beyond it wait for a download slot.")
+	flag.BoolVar(&imagePolicy.AirGapped, "air-gapped", false,
+		"Only allow images from Freebox-local file:// paths, private IP addresses and --image-hosts, "+
+			"so that the Freebox never fetches images from the Internet.")
+	flag.Func("image-hosts", "Comma-separated internal hosts images may be downloaded from in air-gapped mode.",
+		func(value string) error {
+			for _, host := range strings.Split(value, ",") {
+				if host = strings.TrimSpace(host); host != "" {
+					imagePolicy.AllowedHosts = append(imagePolicy.AllowedHosts, host)
+				}
+			}
+			return nil
+		})
+	flag.BoolVar(&probeImageURLs, "probe-image-urls", true,
+		"Send a HEAD request to image URLs before downloading them, so that unreachable URLs are reported "+
+			"immediately. Disable it when the controller cannot reach the image servers the Freebox uses.")
+	flag.BoolVar(&probeControlPlaneEndpoint, "probe-control-plane-endpoint", true,
+		"Open a TCP connection to the control plane endpoint of clusters with machines and report it in the "+
+			"ControlPlaneEndpointReachable condition. Disable it when the controller runs outside the Freebox LAN.")
+	flag.DurationVar(&syncPeriod, "sync-period", 10*time.Hour,
+		"The minimum interval at which all watched resources are reconciled again.")
+	flag.DurationVar(&vmStateResyncPeriod, "vm-state-resync-period", 5*time.Minute,
+		"The interval at which the status of the VMs of provisioned machines is mirrored in status.vmState, "+
+			"0 to disable it. Each refresh is one Freebox API call per machine.")
+	flag.DurationVar(&addressDiscoveryInterval, "address-discovery-interval", 10*time.Second,
+		"The interval at which the Freebox LAN browser is queried for the addresses of the VMs of the machines "+
+			"missing them. The LAN browser is queried once for all the machines, and not at all when none waits.")
+	flag.DurationVar(&addressRequeueInterval, "address-requeue-interval", 30*time.Second,
+		"The interval at which a machine whose VM is created is reconciled again while it waits for its addresses.")
+	flag.DurationVar(&nodeRequeueInterval, "node-requeue-interval", 10*time.Second,
+		"The interval at which a provisioned machine is reconciled again until its Node registers in the "+
+			"workload cluster and gets its providerID.")
 	opts := zap.Options{}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
@@ -124,16 +229,31 @@ func main() {
 		webhookServerOptions.KeyName = webhookCertKey
 	}
 
+	if webhookSelfSignedCerts {
+		if len(webhookCertPath) > 0 {
+			setupLog.Error(nil, "--webhook-self-signed-certs and --webhook-cert-path are mutually exclusive")
+			os.Exit(1)
+		}
+		webhookServerOptions.CertDir = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+	}
+
 	webhookServer := webhook.NewServer(webhookServerOptions)
 
 	// Metrics endpoint is enabled in 'config/default/kustomization.yaml'. The Metrics options configure the server.
 	// More info:
 	// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/metrics/server
 	// - https://book.kubebuilder.io/reference/metrics.html
+	// Every call to the Freebox goes through freeboxDiagnostics, which reports the Freebox
+	// connectivity as JSON on the metrics server under /freebox, and is rate limited.
+	freeboxHTTPClient := freebox.NewRateLimiter(freebox.NewHTTPClient(freeboxHTTP), freeboxQPS, freeboxBurst)
+	freeboxDiagnostics := freebox.NewDiagnostics(freeboxEndpoint, freeboxHTTPClient)
 	metricsServerOptions := metricsserver.Options{
 		BindAddress:   metricsAddr,
 		SecureServing: secureMetrics,
 		TLSOpts:       tlsOpts,
+		ExtraHandlers: map[string]http.Handler{
+			freebox.DiagnosticsPath: freeboxDiagnostics,
+		},
 	}
 
 	if secureMetrics {
@@ -161,57 +281,108 @@ func main() {
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
+		Cache:                  cache.Options{SyncPeriod: &syncPeriod},
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "9ecca9fd.cluster.x-k8s.io",
-		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
-		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		LeaseDuration:          &leaderElectionLeaseDuration,
+		RenewDeadline:          &leaderElectionRenewDeadline,
+		// LeaderElectionReleaseOnCancel requires the binary to end immediately when
+		// the Manager is stopped, which is the case here: nothing runs after mgr.Start.
+		LeaderElectionReleaseOnCancel: leaderElectionReleaseOnCancel,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
-	freeboxEndpoint := os.Getenv("FREEBOX_ENDPOINT")
-	if freeboxEndpoint == "" {
-		freeboxEndpoint = "http://mafreebox.freebox.fr"
+	if webhookSelfSignedCerts {
+		namespace, err := podNamespace()
+		if err != nil {
+			setupLog.Error(err, "unable to determine the namespace of the webhook certificate")
+			os.Exit(1)
+		}
+		certClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create client for the webhook certificate")
+			os.Exit(1)
+		}
+		opts := webhookcert.Options{
+			Namespace:   namespace,
+			ServiceName: webhookNamePrefix + "webhook-service",
+			SecretName:  webhookNamePrefix + "webhook-server-cert",
+			CertDir:     webhookServerOptions.CertDir,
+		}
+		if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+			opts.MutatingWebhookConfiguration = webhookNamePrefix + "mutating-webhook-configuration"
+			opts.ValidatingWebhookConfiguration = webhookNamePrefix + "validating-webhook-configuration"
+		}
+		if err := webhookcert.Provision(context.Background(), certClient, opts); err != nil {
+			setupLog.Error(err, "unable to provision the webhook certificate")
+			os.Exit(1)
+		}
+		setupLog.Info("Provisioned self-signed webhook certificate", "secret", opts.SecretName, "namespace", namespace)
 	}
 
-	freeboxVersion := os.Getenv("FREEBOX_VERSION")
-	if freeboxVersion == "" {
-		freeboxVersion = "latest"
+	// Use the API version the firmware of the Freebox provides rather than whatever
+	// "latest" becomes after a firmware update.
+	probe, err := freeboxclient.New(freeboxEndpoint, freeboxVersion)
+	if err != nil {
+		setupLog.Error(err, "unable to create freebox client")
+		os.Exit(1)
 	}
+	probe.WithHTTPClient(freeboxDiagnostics)
+	negotiatedVersion, err := freebox.NegotiateAPIVersion(context.Background(), probe, freeboxVersion)
+	if err != nil {
+		setupLog.Error(err, "unable to negotiate the Freebox API version", "apiVersion", freeboxVersion)
+		os.Exit(1)
+	}
+	setupLog.Info("Using the Freebox API", "apiVersion", negotiatedVersion, "configured", freeboxVersion)
 
-	fbClient, err := freeboxclient.New(freeboxEndpoint, freeboxVersion)
+	unlockedClient, err := freeboxclient.New(freeboxEndpoint, negotiatedVersion)
 	if err != nil {
 		setupLog.Error(err, "unable to create freebox client")
 		os.Exit(1)
 	}
-
-	var freeboxDownloadDir string
-	var vmStoragePath string
-
-	freeboxAppID := os.Getenv("FREEBOX_APP_ID")
+	// Log in again as soon as the Freebox rejects the session, e.g. after a reboot.
+	unlockedClient.WithHTTPClient(&freebox.SessionRenewer{Next: freeboxDiagnostics, Client: unlockedClient})
+	// The reconcilers, the webhooks and the token watcher share the client.
+	fbClient := freebox.NewLockedClient(unlockedClient)
+
+	if freeboxAppIDFile != "" {
+		if freeboxAppID, err = freebox.ReadAppIDFile(freeboxAppIDFile); err != nil {
+			setupLog.Error(err, "unable to read Freebox app ID file", "path", freeboxAppIDFile)
+			os.Exit(1)
+		}
+	}
 	if freeboxAppID == "" {
-		setupLog.Error(err, "FREEBOX_APP_ID undefined")
+		setupLog.Error(err, "Freebox app ID undefined, set --freebox-app-id-file, FREEBOX_APP_ID_FILE, --freebox-app-id or FREEBOX_APP_ID")
 		os.Exit(1)
 	}
 	fbClient.WithAppID(freeboxAppID)
 
-	freeboxToken := os.Getenv("FREEBOX_TOKEN")
-	if freeboxToken == "" {
-		setupLog.Error(err, "FREEBOX_TOKEN undefined")
+	if errs := validation.IsDNS1123Label(instanceID); instanceID != "" && len(errs) > 0 {
+		setupLog.Error(fmt.Errorf("%s", strings.Join(errs, ", ")), "invalid --instance-id", "instanceID", instanceID)
 		os.Exit(1)
 	}
-	fbClient.WithPrivateToken(freeboxToken)
+
+	if freeboxTokenFile != "" {
+		tokenWatcher, err := freebox.NewTokenWatcher(freeboxTokenFile, fbClient)
+		if err != nil {
+			setupLog.Error(err, "unable to read Freebox token file", "path", freeboxTokenFile)
+			os.Exit(1)
+		}
+		if err := mgr.Add(tokenWatcher); err != nil {
+			setupLog.Error(err, "unable to add Freebox token watcher to manager")
+			os.Exit(1)
+		}
+	} else {
+		freeboxToken := os.Getenv("FREEBOX_TOKEN")
+		if freeboxToken == "" {
+			setupLog.Error(err, "Freebox token undefined, set --freebox-token-file, FREEBOX_TOKEN_FILE or FREEBOX_TOKEN")
+			os.Exit(1)
+		}
+		fbClient.WithPrivateToken(freeboxToken)
+	}
 
 	setupLog.Info("Freebox client created successfully")
 
@@ -224,40 +395,23 @@ func main() {
 	}
 	setupLog.Info("Logged in to Freebox successfully", "permissions", permissions)
 
-	// Fetch Freebox download directory from Freebox using free-go
-	downloadConfig, err := fbClient.GetDownloadConfiguration(ctx)
+	// Fetch the download directory and VM storage path from the Freebox, in the
+	// subdirectories of the provider instance when it shares the Freebox
+	settings, err := freebox.Discover(ctx, fbClient, instanceID)
 	if err != nil {
-		setupLog.Error(err, "unable to fetch download configuration from Freebox")
+		setupLog.Error(err, "unable to discover the Freebox")
 		os.Exit(1)
 	}
-	freeboxDownloadDir = string(downloadConfig.DownloadDir)
-	setupLog.Info("Using Freebox download directory from /downloads/config", "path", freeboxDownloadDir)
-
-	// Fetch VM storage path from Freebox system config using free-go
-	systemConfig, err := fbClient.GetSystemInfo(ctx)
-	if err != nil {
-		setupLog.Error(err, "unable to fetch system info from Freebox")
-		os.Exit(1)
-	}
-	vmStoragePath = systemConfig.UserMainStorage
-	setupLog.Info("Using VM storage path from /system/ user_main_storage", "path", vmStoragePath)
+	setupLog.Info("Using the directories of the Freebox", "instanceID", instanceID,
+		"downloadDir", settings.DownloadDir, "vmStoragePath", settings.VMStoragePath)
 
 	// Set up ClusterCache for accessing workload cluster APIs.
 	// This is required by the FreeboxMachine controller to patch Kubernetes Nodes
 	// with providerID (acting as a cloud controller manager, following the CAPD pattern).
-	secretCachingClient, err := client.New(mgr.GetConfig(), client.Options{
-		HTTPClient: mgr.GetHTTPClient(),
-		Cache: &client.CacheOptions{
-			Reader: mgr.GetCache(),
-		},
-	})
-	if err != nil {
-		setupLog.Error(err, "unable to create secret caching client for ClusterCache")
-		os.Exit(1)
-	}
-
+	// Kubeconfig secrets are read from the API server rather than from a cache, so
+	// that the provider only needs to get secrets, not to list and watch all of them.
 	clusterCache, err := clustercache.SetupWithManager(ctx, mgr, clustercache.Options{
-		SecretClient: secretCachingClient,
+		SecretClient: mgr.GetAPIReader(),
 		Cache:        clustercache.CacheOptions{},
 		Client: clustercache.ClientOptions{
 			UserAgent: remote.DefaultClusterAPIUserAgent("cluster-api-provider-freebox"),
@@ -274,25 +428,87 @@ func main() {
 		os.Exit(1)
 	}
 
+	var controlPlaneDialer *net.Dialer
+	if probeControlPlaneEndpoint {
+		controlPlaneDialer = &net.Dialer{Timeout: 5 * time.Second}
+	}
+
+	// Clusters with their own credentials or Freebox talk to it through clients kept
+	// logged in across reconciles.
+	freeboxClients := freebox.NewClients(freeboxEndpoint, negotiatedVersion, freeboxDiagnostics, instanceID, settings)
+	freeboxClients.OtherHTTPClient = freeboxHTTPClient
 	if err := (&controller.FreeboxClusterReconciler{
-		Client:        mgr.GetClient(),
-		Scheme:        mgr.GetScheme(),
-		FreeboxClient: fbClient,
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		FreeboxClient:        fbClient,
+		FreeboxDownloadDir:   settings.DownloadDir,
+		ImagePolicy:          imagePolicy,
+		ControlPlaneDialer:   controlPlaneDialer,
+		FreeboxAPIVersion:    freeboxVersion,
+		NegotiatedAPIVersion: negotiatedVersion,
+		Clients:              freeboxClients,
+		SecretReader:         mgr.GetAPIReader(),
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "FreeboxCluster")
 		os.Exit(1)
 	}
+	var imageProbeClient *http.Client
+	if probeImageURLs {
+		imageProbeClient = &http.Client{Timeout: 30 * time.Second}
+	}
 	if err := (&controller.FreeboxMachineReconciler{
-		Client:             mgr.GetClient(),
-		Scheme:             mgr.GetScheme(),
-		FreeboxClient:      fbClient,
-		ClusterCache:       clusterCache,
-		FreeboxDownloadDir: freeboxDownloadDir,
-		VMStoragePath:      vmStoragePath,
+		Client:                 mgr.GetClient(),
+		Scheme:                 mgr.GetScheme(),
+		FreeboxClient:          fbClient,
+		ClusterCache:           clusterCache,
+		FreeboxDownloadDir:     settings.DownloadDir,
+		VMStoragePath:          settings.VMStoragePath,
+		InstanceID:             instanceID,
+		FreeboxSerial:          settings.Serial,
+		FreeboxClock:           freeboxDiagnostics,
+		Clients:                freeboxClients,
+		MaxConcurrentDownloads: maxConcurrentDownloads,
+		ImagePolicy:            imagePolicy,
+		ImageProbeClient:       imageProbeClient,
+		VMStateResyncPeriod:    vmStateResyncPeriod,
+		AddressRequeueInterval: addressRequeueInterval,
+		NodeRequeueInterval:    nodeRequeueInterval,
+		SecretReader:           mgr.GetAPIReader(),
+		APIReader:              mgr.GetAPIReader(),
+		Recorder:               mgr.GetEventRecorder("freeboxmachine-controller"),
 	}).SetupWithManager(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "FreeboxMachine")
 		os.Exit(1)
 	}
+	if err := mgr.Add(&controller.AddressDiscovery{
+		Client:        mgr.GetClient(),
+		FreeboxClient: fbClient,
+		Clients:       freeboxClients,
+		SecretReader:  mgr.GetAPIReader(),
+		Interval:      addressDiscoveryInterval,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up address discovery")
+		os.Exit(1)
+	}
+	// nolint:goconst
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err := webhookv1alpha1.SetupFreeboxMachineWebhookWithManager(mgr, imagePolicy, fbClient, freeboxEndpoint); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FreeboxMachine")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupFreeboxClusterWebhookWithManager(mgr, freeboxEndpoint); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FreeboxCluster")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupFreeboxMachineTemplateWebhookWithManager(mgr, fbClient); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "FreeboxMachineTemplate")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupKubeadmControlPlaneWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "KubeadmControlPlane")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -310,3 +526,27 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// envOrDefault returns the value of the environment variable key, or def when it is unset or empty.
+func envOrDefault(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
+
+// serviceAccountNamespaceFile holds the namespace of the pod the controller runs in.
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// podNamespace returns the namespace the controller runs in, from POD_NAMESPACE or
+// from its service account.
+func podNamespace() (string, error) {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace, nil
+	}
+	namespace, err := os.ReadFile(serviceAccountNamespaceFile)
+	if err != nil {
+		return "", fmt.Errorf("set POD_NAMESPACE when not running in a pod: %w", err)
+	}
+	return strings.TrimSpace(string(namespace)), nil
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// generate-locked-client writes the methods of the LockedClient of internal/freebox,
+// which implement the free-go client interface by calling the wrapped client with
+// the lock held.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"os"
+	"slices"
+	"strings"
+)
+
+const clientPackage = "github.com/nikolalohinski/free-go/client"
+
+// aliases are the names the freebox package imports the free-go packages with.
+var aliases = map[string]string{
+	clientPackage: "freeboxclient",
+	"github.com/nikolalohinski/free-go/types": "freeboxTypes",
+}
+
+func main() {
+	var header, output string
+	flag.StringVar(&header, "header", "hack/boilerplate.go.txt", "File holding the license header of the generated file.")
+	flag.StringVar(&output, "output", "internal/freebox/zz_generated.locked_client.go", "File the methods are written to.")
+	flag.Parse()
+
+	if err := run(header, output); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(header, output string) error {
+	boilerplate, err := os.ReadFile(header)
+	if err != nil {
+		return err
+	}
+	pkg, err := importer.ForCompiler(token.NewFileSet(), "source", nil).Import(clientPackage)
+	if err != nil {
+		return fmt.Errorf("loading %s: %w", clientPackage, err)
+	}
+	object := pkg.Scope().Lookup("Client")
+	if object == nil {
+		return fmt.Errorf("%s has no Client type", clientPackage)
+	}
+	iface, ok := object.Type().Underlying().(*types.Interface)
+	if !ok {
+		return fmt.Errorf("%s.Client is not an interface", clientPackage)
+	}
+
+	imports := map[string]string{}
+	qualifier := func(pkg *types.Package) string {
+		name, ok := aliases[pkg.Path()]
+		if !ok {
+			name = pkg.Name()
+		}
+		imports[pkg.Path()] = name
+		return name
+	}
+	var methods bytes.Buffer
+	for method := range iface.Methods() {
+		if err := writeMethod(&methods, method, object.Type(), qualifier); err != nil {
+			return err
+		}
+	}
+
+	var file bytes.Buffer
+	file.Write(boilerplate)
+	file.WriteString("\n// Code generated by hack/generate-locked-client. DO NOT EDIT.\n\npackage freebox\n\n")
+	writeImports(&file, imports)
+	file.Write(methods.Bytes())
+	source, err := format.Source(file.Bytes())
+	if err != nil {
+		return fmt.Errorf("formatting the generated code: %w", err)
+	}
+	return os.WriteFile(output, source, 0o644)
+}
+
+// writeMethod writes the LockedClient method implementing method of the client
+// interface. Methods returning the client return the LockedClient instead, and
+// methods returning a stream document that the stream is used without the lock.
+func writeMethod(w *bytes.Buffer, method *types.Func, client types.Type, qualifier types.Qualifier) error {
+	signature := method.Signature()
+	var params, args []string
+	for i := range signature.Params().Len() {
+		param := signature.Params().At(i)
+		name := param.Name()
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
+			if types.TypeString(param.Type(), nil) == "context.Context" {
+				name = "ctx"
+			}
+		}
+		if name == "c" {
+			return fmt.Errorf("parameter %s of %s shadows the receiver", name, method.Name())
+		}
+		typ, arg := types.TypeString(param.Type(), qualifier), name
+		if signature.Variadic() && i == signature.Params().Len()-1 {
+			typ, arg = "..."+strings.TrimPrefix(typ, "[]"), name+"..."
+		}
+		params = append(params, name+" "+typ)
+		args = append(args, arg)
+	}
+	var results, streams []string
+	returnsClient := false
+	for result := range signature.Results().Variables() {
+		typ := types.TypeString(result.Type(), qualifier)
+		results = append(results, typ)
+		switch {
+		case types.Identical(result.Type(), client):
+			returnsClient = true
+		case isStream(result.Type()):
+			streams = append(streams, typ)
+		}
+	}
+
+	fmt.Fprintf(w, "// %s implements freeboxclient.Client.", method.Name())
+	if len(streams) > 0 {
+		fmt.Fprintf(w, "\n//\n// The lock is only held while free-go opens the stream with the session of the\n"+
+			"// client. The returned %s is then used without it, as\n"+
+			"// free-go no longer reads the client, see TestLockedClientStreams.",
+			strings.Join(streams, " and "))
+	}
+	fmt.Fprintf(w, "\nfunc (c *LockedClient) %s(%s)", method.Name(), strings.Join(params, ", "))
+	switch len(results) {
+	case 0:
+	case 1:
+		fmt.Fprintf(w, " %s", results[0])
+	default:
+		fmt.Fprintf(w, " (%s)", strings.Join(results, ", "))
+	}
+	call := fmt.Sprintf("c.client.%s(%s)", method.Name(), strings.Join(args, ", "))
+	fmt.Fprint(w, " {\n\tc.mu.Lock()\n\tdefer c.mu.Unlock()\n")
+	switch {
+	case returnsClient && len(results) == 1:
+		fmt.Fprintf(w, "\t%s\n\treturn c\n", call)
+	case returnsClient:
+		return fmt.Errorf("%s returns the client along with other results", method.Name())
+	case len(results) == 0:
+		fmt.Fprintf(w, "\t%s\n", call)
+	default:
+		fmt.Fprintf(w, "\treturn %s\n", call)
+	}
+	fmt.Fprint(w, "}\n\n")
+	return nil
+}
+
+// isStream reports whether a result of type typ outlives the call it is returned
+// by: a channel, or an interface other than error.
+func isStream(typ types.Type) bool {
+	switch typ.Underlying().(type) {
+	case *types.Chan:
+		return true
+	case *types.Interface:
+		return !types.Identical(typ, types.Universe.Lookup("error").Type())
+	}
+	return false
+}
+
+// writeImports writes the import declaration of the generated file, the standard
+// library first.
+func writeImports(w *bytes.Buffer, imports map[string]string) {
+	var std, others []string
+	for path, name := range imports {
+		spec := fmt.Sprintf("%q", path)
+		if name != path[strings.LastIndex(path, "/")+1:] {
+			spec = name + " " + spec
+		}
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			others = append(others, spec)
+		} else {
+			std = append(std, spec)
+		}
+	}
+	slices.Sort(std)
+	slices.Sort(others)
+	fmt.Fprintln(w, "import (")
+	for _, spec := range std {
+		fmt.Fprintf(w, "\t%s\n", spec)
+	}
+	if len(std) > 0 && len(others) > 0 {
+		fmt.Fprintln(w)
+	}
+	for _, spec := range others {
+		fmt.Fprintf(w, "\t%s\n", spec)
+	}
+	fmt.Fprint(w, ")\n\n")
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// lanInterface is the LAN browser interface the VMs are connected to.
+const lanInterface = "pub"
+
+// AddressDiscovery periodically fetches the hosts of the Freebox LAN and the VMs
+// once, and records the addresses of the VMs of every FreeboxMachine missing them.
+// Machines whose VM boots wait for their addresses to be recorded rather than each
+// querying the LAN browser, so that each Freebox is queried once per interval
+// whatever the number of machines.
+type AddressDiscovery struct {
+	Client        client.Client
+	FreeboxClient freeboxclient.Client
+
+	// Clients and SecretReader reach the Freebox of the machines of clusters with
+	// their own credentials, as FreeboxMachineReconciler does.
+	Clients      FreeboxClients
+	SecretReader client.Reader
+
+	// Interval is how often addresses are looked for.
+	Interval time.Duration
+}
+
+// Start implements manager.Runnable.
+func (d *AddressDiscovery) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("address-discovery")
+	ctx = logf.IntoContext(ctx, log)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := d.discover(ctx); err != nil {
+			log.Error(err, "Failed to discover the addresses of the VMs")
+		}
+	}, d.Interval)
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that only the
+// leader writes the addresses.
+func (d *AddressDiscovery) NeedLeaderElection() bool {
+	return true
+}
+
+// discover records the addresses the LAN browser knows for the VMs of the
+// FreeboxMachines that have a VM but no address. A Freebox is not queried when
+// none of its machines is waiting.
+func (d *AddressDiscovery) discover(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+
+	var machines infrastructurev1alpha1.FreeboxMachineList
+	if err := d.Client.List(ctx, &machines); err != nil {
+		return fmt.Errorf("listing FreeboxMachines: %w", err)
+	}
+	secrets := d.SecretReader
+	if secrets == nil {
+		secrets = d.Client
+	}
+	// Machines are grouped by the client of their Freebox, connections being cached.
+	waiting := map[freeboxclient.Client][]*infrastructurev1alpha1.FreeboxMachine{}
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		if machine.Status.VMID == nil || len(machine.Status.Addresses) > 0 || !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		connection, err := machineConnection(ctx, d.Client, secrets, d.Clients, machine)
+		if err != nil {
+			log.Error(err, "Failed to reach the Freebox of the machine", "machine", client.ObjectKeyFromObject(machine))
+			continue
+		}
+		fbClient := d.FreeboxClient
+		if connection != nil {
+			fbClient = connection.Client
+		}
+		waiting[fbClient] = append(waiting[fbClient], machine)
+	}
+
+	var errs []error
+	for fbClient, machines := range waiting {
+		if err := d.discoverOn(ctx, fbClient, machines); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// discoverOn records the addresses the LAN browser of the Freebox of fbClient
+// knows for the VMs of machines.
+func (d *AddressDiscovery) discoverOn(ctx context.Context, fbClient freeboxclient.Client, waiting []*infrastructurev1alpha1.FreeboxMachine) error {
+	log := logf.FromContext(ctx)
+
+	vms, err := fbClient.ListVirtualMachines(ctx)
+	if err != nil {
+		return fmt.Errorf("listing VMs: %w", err)
+	}
+	macs := make(map[int64]string, len(vms))
+	for _, vm := range vms {
+		macs[vm.ID] = strings.ToLower(vm.Mac)
+	}
+	hosts, err := fbClient.GetLanInterface(ctx, lanInterface)
+	if err != nil {
+		return fmt.Errorf("querying the LAN browser: %w", err)
+	}
+	hostsByMac := make(map[string]freeboxTypes.LanInterfaceHost, len(hosts))
+	for _, host := range hosts {
+		hostsByMac[strings.ToLower(host.L2Ident.ID)] = host
+	}
+
+	for _, machine := range waiting {
+		vmID := *machine.Status.VMID
+		mac, ok := macs[vmID]
+		if !ok {
+			log.Info("VM of the machine not found on the Freebox", "machine", client.ObjectKeyFromObject(machine), "vmID", vmID)
+			continue
+		}
+		host, ok := hostsByMac[mac]
+		if !ok {
+			log.Info("VM not yet visible in LAN browser", "machine", client.ObjectKeyFromObject(machine), "vmID", vmID, "mac", mac)
+			continue
+		}
+		addresses := machineAddressesFromLanHost(host)
+		if len(addresses) == 0 {
+			log.Info("VM found in LAN browser but no IP address yet", "machine", client.ObjectKeyFromObject(machine), "vmID", vmID, "mac", mac)
+			continue
+		}
+
+		patch := client.MergeFrom(machine.DeepCopy())
+		machine.Status.Addresses = addresses
+		if err := d.Client.Status().Patch(ctx, machine, patch); err != nil {
+			log.Error(err, "Failed to record the addresses of the VM", "machine", client.ObjectKeyFromObject(machine))
+			continue
+		}
+		log.Info("Found IP address for VM", "machine", client.ObjectKeyFromObject(machine), "vmID", vmID, "mac", mac, "addresses", addresses)
+	}
+	return nil
+}
+
+// machineAddressesFromLanHost returns the InternalIP addresses the LAN browser knows for a host.
+// IPv4 addresses come first so that consumers picking the first address keep using IPv4 on
+// dual-stack networks. Link-local IPv6 addresses are skipped as they are not routable.
+func machineAddressesFromLanHost(host freeboxTypes.LanInterfaceHost) []clusterv1.MachineAddress {
+	var ipv4, ipv6 []clusterv1.MachineAddress
+	for _, l3 := range host.L3Connectivities {
+		if l3.Address == "" {
+			continue
+		}
+		address := clusterv1.MachineAddress{
+			Type:    clusterv1.MachineInternalIP,
+			Address: l3.Address,
+		}
+		switch l3.Type {
+		case freeboxTypes.IPV4:
+			ipv4 = append(ipv4, address)
+		case freeboxTypes.IPV6:
+			if ip := net.ParseIP(l3.Address); ip == nil || ip.IsLinkLocalUnicast() {
+				continue
+			}
+			ipv6 = append(ipv6, address)
+		}
+	}
+	return append(ipv4, ipv6...)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/freebox/mock"
+)
+
+var _ = Describe("AddressDiscovery", func() {
+	It("queries the LAN browser once for all the machines waiting for their address", func() {
+		var hosts []freeboxTypes.LanInterfaceHost
+		var vms []freeboxTypes.VirtualMachine
+		for i, name := range []string{"discovery-a", "discovery-b"} {
+			machine := newMachineForPhaseTest(name, infrastructurev1alpha1.FreeboxMachineSpec{
+				Name: name, VCPUs: 1, MemoryMB: 512, DiskSizeBytes: 1 << 30, ImageURL: "https://example.com/image.raw",
+			})
+			Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, machine)).To(Succeed()) })
+			machine.Status.Phase = phaseVMCreated
+			machine.Status.VMID = ptr.To(int64(100 + i))
+			Expect(k8sClient.Status().Update(ctx, machine)).To(Succeed())
+
+			mac := fmt.Sprintf("02:00:00:00:00:0%d", i)
+			vms = append(vms, freeboxTypes.VirtualMachine{ID: int64(100 + i), Mac: mac})
+			hosts = append(hosts, freeboxTypes.LanInterfaceHost{
+				L2Ident:          freeboxTypes.L2Ident{ID: mac},
+				L3Connectivities: []freeboxTypes.LanHostL3Connectivity{{Type: "ipv4", Address: fmt.Sprintf("192.168.1.%d", 50+i)}},
+			})
+		}
+		fc := &mock.Client{}
+		fc.ListVirtualMachinesReturns(vms, nil)
+		fc.GetLanInterfaceReturns(hosts, nil)
+		d := &AddressDiscovery{Client: k8sClient, FreeboxClient: fc}
+
+		Expect(d.discover(ctx)).To(Succeed())
+		Expect(fc.GetLanInterfaceCallCount()).To(Equal(1))
+		Expect(fc.ListVirtualMachinesCallCount()).To(Equal(1))
+		machine := &infrastructurev1alpha1.FreeboxMachine{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "discovery-b", Namespace: "default"}, machine)).To(Succeed())
+		Expect(machine.Status.Addresses).To(Equal([]clusterv1.MachineAddress{{Type: clusterv1.MachineInternalIP, Address: "192.168.1.51"}}))
+
+		By("not querying the Freebox once no machine waits")
+		Expect(d.discover(ctx)).To(Succeed())
+		Expect(fc.GetLanInterfaceCallCount()).To(Equal(1))
+	})
+})
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=bootstrap.cluster.x-k8s.io,resources=kubeadmconfigs,verbs=get;list;watch;patch
+
+const (
+	// bootstrapTokenMargin is how long the join token must remain valid when the VM
+	// is created, to leave it time to boot and run kubeadm join.
+	bootstrapTokenMargin = 5 * time.Minute
+
+	// BootstrapTokenRefreshAnnotation is set on a KubeadmConfig whose join token is
+	// about to expire. Changing it makes the kubeadm bootstrap provider reconcile the
+	// KubeadmConfig, which extends the lifetime of the token.
+	BootstrapTokenRefreshAnnotation = "infrastructure.cluster.x-k8s.io/bootstrap-token-refresh-requested"
+)
+
+// reconcileBootstrapToken checks that the kubeadm join token embedded in the
+// bootstrap data of ownerMachine outlives the boot of the VM. When it does not,
+// it sets the BootstrapDataReady condition to False, asks the kubeadm bootstrap
+// provider to refresh the token, and returns false so that the VM is not created.
+// Machines not bootstrapped by a joining KubeadmConfig, and clusters whose API
+// server cannot be reached, are not checked.
+func (r *FreeboxMachineReconciler) reconcileBootstrapToken(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, ownerMachine *clusterv1.Machine) (bool, error) {
+	logger := logf.FromContext(ctx)
+
+	ref := ownerMachine.Spec.Bootstrap.ConfigRef
+	if r.ClusterCache == nil || ref.Kind != "KubeadmConfig" || ref.APIGroup != bootstrapv1.GroupVersion.Group {
+		return true, nil
+	}
+	var config bootstrapv1.KubeadmConfig
+	if err := r.Get(ctx, client.ObjectKey{Namespace: ownerMachine.Namespace, Name: ref.Name}, &config); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("getting KubeadmConfig %s: %w", ref.Name, err)
+	}
+	tokenID, _, ok := strings.Cut(config.Spec.JoinConfiguration.Discovery.BootstrapToken.Token, ".")
+	if !ok {
+		return true, nil
+	}
+
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machine.ObjectMeta)
+	if err != nil || cluster == nil {
+		return true, nil
+	}
+	remoteClient, err := r.ClusterCache.GetClient(ctx, client.ObjectKeyFromObject(cluster))
+	if err != nil {
+		logger.Info("Cannot connect to workload cluster, skipping bootstrap token check", "error", err)
+		return true, nil
+	}
+	var secret corev1.Secret
+	err = remoteClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceSystem, Name: "bootstrap-token-" + tokenID}, &secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		logger.Info("Cannot get bootstrap token, skipping bootstrap token check", "error", err)
+		return true, nil
+	}
+
+	if apierrors.IsNotFound(err) {
+		// The kubeadm bootstrap provider only recreates tokens of MachinePools, so
+		// this Machine can never join: it has to be replaced.
+		meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+			Type:    ConditionBootstrapDataReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  "BootstrapTokenExpired",
+			Message: fmt.Sprintf("The join token of KubeadmConfig %s no longer exists in the workload cluster; delete Machine %s to get a new one", config.Name, ownerMachine.Name),
+		})
+		return false, nil
+	}
+
+	expiration, err := time.Parse(time.RFC3339, string(secret.Data["expiration"]))
+	if err != nil || expiration.After(time.Now().Add(bootstrapTokenMargin)) {
+		// Tokens without a valid expiration never expire.
+		return true, nil
+	}
+
+	logger.Info("Bootstrap token expires before the VM can join, requesting a refresh", "kubeadmConfig", config.Name, "expiration", expiration)
+	patch := client.MergeFrom(config.DeepCopy())
+	if config.Annotations == nil {
+		config.Annotations = map[string]string{}
+	}
+	config.Annotations[BootstrapTokenRefreshAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Patch(ctx, &config, patch); err != nil {
+		return false, fmt.Errorf("requesting bootstrap token refresh on KubeadmConfig %s: %w", config.Name, err)
+	}
+	meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+		Type:    ConditionBootstrapDataReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  "BootstrapTokenExpiring",
+		Message: fmt.Sprintf("The join token of KubeadmConfig %s expires at %s, waiting for the bootstrap provider to refresh it", config.Name, expiration.Format(time.RFC3339)),
+	})
+	return false, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// maxClockSkew is how far the Freebox clock may be off before machines report it.
+// VMs boot with the time of the Freebox, so a Freebox late by more than the slack
+// of the certificates generated by Cluster API makes kubeadm reject them as not
+// valid yet until the VM synchronizes its clock.
+const maxClockSkew = time.Minute
+
+// ClockSkewReporter reports how far the Freebox clock is ahead of the controller
+// one, negative when it is behind, and false while it is not known.
+type ClockSkewReporter interface {
+	ClockSkew() (time.Duration, bool)
+}
+
+// reconcileClockSkew records in the FreeboxClockSkewed condition of machine whether
+// the Freebox clock is off, and warns when it becomes so.
+func (r *FreeboxMachineReconciler) reconcileClockSkew(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) {
+	if r.FreeboxClock == nil {
+		return
+	}
+	skew, ok := r.FreeboxClock.ClockSkew()
+	if !ok {
+		return
+	}
+	if skew.Abs() <= maxClockSkew {
+		meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+			Type:    ConditionFreeboxClockSkewed,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ClockSynchronized",
+			Message: fmt.Sprintf("The Freebox clock is within %s of the controller clock", maxClockSkew),
+		})
+		return
+	}
+
+	direction := "ahead of"
+	if skew < 0 {
+		direction = "behind"
+	}
+	// Minutes are precise enough, and do not rewrite the status on each reconcile.
+	message := fmt.Sprintf("The Freebox clock is about %s %s the controller clock; freshly booted VMs start with it, "+
+		"which can make kubeadm reject certificates as not valid yet until they synchronize their clock",
+		strings.TrimSuffix(skew.Abs().Round(time.Minute).String(), "0s"), direction)
+	wasSkewed := meta.IsStatusConditionTrue(machine.Status.Conditions, ConditionFreeboxClockSkewed)
+	meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+		Type:    ConditionFreeboxClockSkewed,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ClockSkewed",
+		Message: message,
+	})
+	if !wasSkewed {
+		r.ownerEvent(ctx, machine, corev1.EventTypeWarning, "FreeboxClockSkewed", "Provision", "%s", message)
+	}
+}
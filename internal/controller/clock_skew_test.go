@@ -0,0 +1,48 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+type fixedClockSkew time.Duration
+
+func (s fixedClockSkew) ClockSkew() (time.Duration, bool) { return time.Duration(s), true }
+
+var _ = Describe("reconcileClockSkew", func() {
+	It("reports a Freebox clock off by more than a minute", func() {
+		machine := &infrastructurev1alpha1.FreeboxMachine{}
+		r := &FreeboxMachineReconciler{FreeboxClock: fixedClockSkew(-5*time.Minute - 12*time.Second)}
+		r.reconcileClockSkew(ctx, machine)
+		skewed := meta.FindStatusCondition(machine.Status.Conditions, ConditionFreeboxClockSkewed)
+		Expect(skewed).NotTo(BeNil())
+		Expect(skewed.Status).To(Equal(metav1.ConditionTrue))
+		Expect(skewed.Message).To(HavePrefix("The Freebox clock is about 5m behind the controller clock"))
+
+		r.FreeboxClock = fixedClockSkew(2 * time.Second)
+		r.reconcileClockSkew(ctx, machine)
+		Expect(meta.IsStatusConditionFalse(machine.Status.Conditions, ConditionFreeboxClockSkewed)).To(BeTrue())
+	})
+})
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"sigs.k8s.io/yaml"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// mergeCloudConfig adds files to the write_files of the cloud-config user data,
+// and commands in front of its runcmd. The comments heading data, such as the
+// #cloud-config and jinja template markers, are kept as is.
+func mergeCloudConfig(data []byte, files []infrastructurev1alpha1.CloudInitFile, commands []string) ([]byte, error) {
+	if len(files) == 0 && len(commands) == 0 {
+		return data, nil
+	}
+
+	var header bytes.Buffer
+	body := data
+	for {
+		line, rest, _ := bytes.Cut(body, []byte("\n"))
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) > 0 && trimmed[0] != '#' || len(body) == 0 {
+			break
+		}
+		header.Write(line)
+		header.WriteByte('\n')
+		body = rest
+	}
+	if !bytes.Contains(header.Bytes(), []byte("#cloud-config")) {
+		return nil, fmt.Errorf("files and commands can only be added to #cloud-config user data")
+	}
+
+	// Numbers are kept as written rather than turned into floats.
+	document := map[string]any{}
+	if err := yaml.Unmarshal(body, &document, func(d *json.Decoder) *json.Decoder { d.UseNumber(); return d }); err != nil {
+		return nil, fmt.Errorf("parsing cloud-config user data: %w", err)
+	}
+	if document == nil {
+		document = map[string]any{}
+	}
+
+	if len(files) > 0 {
+		writeFiles, err := listOf(document, "write_files")
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			entry := map[string]any{}
+			for _, field := range [][2]string{{"path", file.Path}, {"content", file.Content}, {"owner", file.Owner}, {"permissions", file.Permissions}} {
+				if field[1] != "" {
+					entry[field[0]] = field[1]
+				}
+			}
+			writeFiles = append(writeFiles, entry)
+		}
+		document["write_files"] = writeFiles
+	}
+
+	if len(commands) > 0 {
+		runcmd, err := listOf(document, "runcmd")
+		if err != nil {
+			return nil, err
+		}
+		merged := make([]any, 0, len(commands)+len(runcmd))
+		for _, command := range commands {
+			merged = append(merged, command)
+		}
+		document["runcmd"] = append(merged, runcmd...)
+	}
+
+	merged, err := yaml.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("writing cloud-config user data: %w", err)
+	}
+	return append(header.Bytes(), merged...), nil
+}
+
+// listOf returns the list at key in document, empty when missing.
+func listOf(document map[string]any, key string) ([]any, error) {
+	value, ok := document[key]
+	if !ok || value == nil {
+		return nil, nil
+	}
+	list, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%s of the cloud-config user data is not a list", key)
+	}
+	return list, nil
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+var _ = Describe("mergeCloudConfig", func() {
+	files := []infrastructurev1alpha1.CloudInitFile{{Path: "/etc/containerd/proxy.conf", Content: "HTTP_PROXY=http://proxy:3128\n", Permissions: "0600"}}
+
+	It("keeps the bootstrap data when there is nothing to add", func() {
+		data := []byte("#!/bin/sh\necho hello\n")
+		Expect(mergeCloudConfig(data, nil, nil)).To(Equal(data))
+	})
+
+	It("appends files to the existing write_files", func() {
+		merged, err := mergeCloudConfig([]byte("#cloud-config\nwrite_files:\n- path: /etc/kubeadm.yaml\n  content: x\n"), files, nil)
+		Expect(err).NotTo(HaveOccurred())
+		var config struct {
+			WriteFiles []map[string]string `json:"write_files"`
+		}
+		Expect(yaml.Unmarshal(merged, &config)).To(Succeed())
+		Expect(config.WriteFiles).To(HaveLen(2))
+		Expect(config.WriteFiles[1]).To(Equal(map[string]string{
+			"path": "/etc/containerd/proxy.conf", "content": "HTTP_PROXY=http://proxy:3128\n", "permissions": "0600",
+		}))
+	})
+
+	It("puts the commands in front of runcmd, keeping the other keys as written", func() {
+		merged, err := mergeCloudConfig([]byte("#cloud-config\nruncmd:\n- kubeadm join\nswap:\n  size: 0\n"), nil, []string{"sysctl --system"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(merged)).To(Equal("#cloud-config\nruncmd:\n- sysctl --system\n- kubeadm join\nswap:\n  size: 0\n"))
+	})
+
+	It("rejects user data that is not a cloud-config", func() {
+		_, err := mergeCloudConfig([]byte("#!/bin/sh\necho hello\n"), files, nil)
+		Expect(err).To(MatchError(ContainSubstring("#cloud-config")))
+	})
+})
@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// Cluster API looks the objects a Cluster references up in the namespace of the
+// Cluster only, so a FreeboxCluster or FreeboxMachineTemplate created in another
+// namespace, e.g. when reusing the manifests of another cluster, is never found and
+// its machines never reconcile, without any error. The checks below report them.
+
+// templateReference is a FreeboxMachineTemplate referenced by a Cluster API object.
+type templateReference struct {
+	name string
+	// user describes the object referencing the template, e.g. "MachineDeployment md-0".
+	user string
+}
+
+// reconcileClusterReferences checks that the FreeboxMachineTemplates used by the
+// control plane and MachineDeployments of cluster are found in its namespace, and
+// that freeboxCluster and the templates are not labeled for another cluster. The
+// outcome is recorded in the ClusterReferencesValid condition.
+func (r *FreeboxClusterReconciler) reconcileClusterReferences(ctx context.Context, cluster *clusterv1.Cluster, freeboxCluster *infrastructurev1alpha1.FreeboxCluster) error {
+	var problems []string
+	if name, ok := freeboxCluster.Labels[clusterv1.ClusterNameLabel]; ok && name != cluster.Name {
+		problems = append(problems, fmt.Sprintf("FreeboxCluster %s is labeled for cluster %s", freeboxCluster.Name, name))
+	}
+
+	refs, err := r.clusterTemplateReferences(ctx, cluster)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		var template infrastructurev1alpha1.FreeboxMachineTemplate
+		err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: ref.name}, &template)
+		switch {
+		case apierrors.IsNotFound(err):
+			elsewhere, err := r.templateNamespaces(ctx, ref.name, cluster.Namespace)
+			if err != nil {
+				return err
+			}
+			if len(elsewhere) > 0 {
+				problems = append(problems, fmt.Sprintf("FreeboxMachineTemplate %s of %s is in namespace %s instead of %s",
+					ref.name, ref.user, strings.Join(elsewhere, ", "), cluster.Namespace))
+			} else {
+				problems = append(problems, fmt.Sprintf("FreeboxMachineTemplate %s of %s does not exist", ref.name, ref.user))
+			}
+		case err != nil:
+			return fmt.Errorf("getting FreeboxMachineTemplate %s: %w", ref.name, err)
+		default:
+			if name, ok := template.Labels[clusterv1.ClusterNameLabel]; ok && name != cluster.Name {
+				problems = append(problems, fmt.Sprintf("FreeboxMachineTemplate %s of %s is labeled for cluster %s", ref.name, ref.user, name))
+			}
+		}
+	}
+
+	condition := metav1.Condition{
+		Type:    ConditionClusterReferencesValid,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ReferencesValid",
+		Message: fmt.Sprintf("The FreeboxCluster and FreeboxMachineTemplates of Cluster %s are in namespace %s", cluster.Name, cluster.Namespace),
+	}
+	if len(problems) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "InvalidReferences"
+		condition.Message = strings.Join(problems, "; ")
+	}
+	meta.SetStatusCondition(&freeboxCluster.Status.Conditions, condition)
+	return nil
+}
+
+// clusterTemplateReferences returns the FreeboxMachineTemplates used by the
+// KubeadmControlPlane and the MachineDeployments of cluster.
+func (r *FreeboxClusterReconciler) clusterTemplateReferences(ctx context.Context, cluster *clusterv1.Cluster) ([]templateReference, error) {
+	var refs []templateReference
+	add := func(ref clusterv1.ContractVersionedObjectReference, user string) {
+		if ref.Kind == "FreeboxMachineTemplate" && ref.APIGroup == infrastructurev1alpha1.GroupVersion.Group && ref.Name != "" {
+			refs = append(refs, templateReference{name: ref.Name, user: user})
+		}
+	}
+
+	if ref := cluster.Spec.ControlPlaneRef; ref.Kind == "KubeadmControlPlane" && ref.APIGroup == controlplanev1.GroupVersion.Group {
+		var kcp controlplanev1.KubeadmControlPlane
+		err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}, &kcp)
+		switch {
+		case err == nil:
+			add(kcp.Spec.MachineTemplate.Spec.InfrastructureRef, "KubeadmControlPlane "+kcp.Name)
+		case !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err):
+			return nil, fmt.Errorf("getting KubeadmControlPlane %s: %w", ref.Name, err)
+		}
+	}
+
+	var machineDeployments clusterv1.MachineDeploymentList
+	if err := r.List(ctx, &machineDeployments, client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return nil, fmt.Errorf("listing MachineDeployments: %w", err)
+	}
+	for _, md := range machineDeployments.Items {
+		add(md.Spec.Template.Spec.InfrastructureRef, "MachineDeployment "+md.Name)
+	}
+	return refs, nil
+}
+
+// templateNamespaces returns the namespaces other than namespace holding a
+// FreeboxMachineTemplate named name.
+func (r *FreeboxClusterReconciler) templateNamespaces(ctx context.Context, name, namespace string) ([]string, error) {
+	var templates infrastructurev1alpha1.FreeboxMachineTemplateList
+	if err := r.List(ctx, &templates); err != nil {
+		return nil, fmt.Errorf("listing FreeboxMachineTemplates: %w", err)
+	}
+	var namespaces []string
+	for _, template := range templates.Items {
+		if template.Name == name && template.Namespace != namespace {
+			namespaces = append(namespaces, template.Namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// reportForeignClusters records in the ClusterReferencesValid condition of
+// freeboxCluster, which has no owner Cluster yet, the Clusters of other namespaces
+// referencing a FreeboxCluster of its name: Cluster API looks for it in their
+// namespace, so it never becomes their infrastructure.
+func (r *FreeboxClusterReconciler) reportForeignClusters(ctx context.Context, freeboxCluster *infrastructurev1alpha1.FreeboxCluster) error {
+	var clusters clusterv1.ClusterList
+	if err := r.List(ctx, &clusters); err != nil {
+		return fmt.Errorf("listing Clusters: %w", err)
+	}
+	var foreign []string
+	for _, cluster := range clusters.Items {
+		ref := cluster.Spec.InfrastructureRef
+		if cluster.Namespace != freeboxCluster.Namespace && ref.Kind == "FreeboxCluster" && ref.Name == freeboxCluster.Name {
+			foreign = append(foreign, cluster.Namespace+"/"+cluster.Name)
+		}
+	}
+	if len(foreign) == 0 {
+		meta.RemoveStatusCondition(&freeboxCluster.Status.Conditions, ConditionClusterReferencesValid)
+		return nil
+	}
+	meta.SetStatusCondition(&freeboxCluster.Status.Conditions, metav1.Condition{
+		Type:   ConditionClusterReferencesValid,
+		Status: metav1.ConditionFalse,
+		Reason: "ClusterInAnotherNamespace",
+		Message: fmt.Sprintf("Cluster %s references a FreeboxCluster named %s, but Cluster API only looks for it in the namespace of the Cluster, not in %s",
+			strings.Join(foreign, ", "), freeboxCluster.Name, freeboxCluster.Namespace),
+	})
+	return nil
+}
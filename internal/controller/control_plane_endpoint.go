@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/ipcommand"
+)
+
+// reconcileControlPlaneEndpointConsistency compares the control plane endpoint of
+// freeboxCluster with the KubeadmControlPlane of cluster, and records the outcome
+// in the ControlPlaneEndpointConsistent condition. Clusters without an endpoint or
+// managed by another control plane provider are not checked.
+func (r *FreeboxClusterReconciler) reconcileControlPlaneEndpointConsistency(ctx context.Context, cluster *clusterv1.Cluster, freeboxCluster *infrastructurev1alpha1.FreeboxCluster) error {
+	endpoint := freeboxCluster.Spec.ControlPlaneEndpoint
+	ref := cluster.Spec.ControlPlaneRef
+	if endpoint.Host == "" || ref.Kind != "KubeadmControlPlane" || ref.APIGroup != controlplanev1.GroupVersion.Group {
+		return nil
+	}
+
+	var kcp controlplanev1.KubeadmControlPlane
+	if err := r.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}, &kcp); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("getting KubeadmControlPlane %s: %w", ref.Name, err)
+	}
+
+	condition := metav1.Condition{
+		Type:    ConditionControlPlaneEndpointConsistent,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Consistent",
+		Message: fmt.Sprintf("KubeadmControlPlane %s uses control plane endpoint %s", kcp.Name, endpoint.Host),
+	}
+	if mismatch := controlPlaneEndpointMismatch(endpoint, kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.ControlPlaneEndpoint,
+		kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.CertSANs); mismatch != "" {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "EndpointMismatch"
+		condition.Message = fmt.Sprintf("KubeadmControlPlane %s %s", kcp.Name, mismatch)
+	} else if slices.Contains(ipcommand.AddedAddresses(kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands), endpoint.Host) {
+		// Every control plane node, including those a rolling update adds next to a
+		// single replica, would claim the address.
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "EndpointAddressOnEveryNode"
+		condition.Message = fmt.Sprintf("KubeadmControlPlane %s adds %s to the interface of every control plane node in its preKubeadmCommands, "+
+			"which makes them conflict as soon as two of them run; use a virtual IP such as kube-vip or an external load balancer instead",
+			kcp.Name, endpoint.Host)
+	}
+
+	meta.SetStatusCondition(&freeboxCluster.Status.Conditions, condition)
+	return nil
+}
+
+// controlPlaneEndpointMismatch describes how the controlPlaneEndpoint and certSANs
+// of a kubeadm cluster configuration contradict endpoint, or returns an empty
+// string when they do not. Empty fields are filled in by Cluster API and kubeadm.
+func controlPlaneEndpointMismatch(endpoint clusterv1.APIEndpoint, kubeadmEndpoint string, certSANs []string) string {
+	if kubeadmEndpoint != "" {
+		host, port, err := net.SplitHostPort(kubeadmEndpoint)
+		if err != nil {
+			host, port = kubeadmEndpoint, ""
+		}
+		if host != endpoint.Host || (port != "" && endpoint.Port != 0 && port != strconv.Itoa(int(endpoint.Port))) {
+			return fmt.Sprintf("sets controlPlaneEndpoint %s, but the FreeboxCluster endpoint is %s",
+				kubeadmEndpoint, net.JoinHostPort(endpoint.Host, strconv.Itoa(int(endpoint.Port))))
+		}
+	}
+	if len(certSANs) > 0 && !slices.Contains(certSANs, endpoint.Host) {
+		return fmt.Sprintf("lists certSANs %v, which do not include the FreeboxCluster endpoint %s", certSANs, endpoint.Host)
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// reconcileControlPlaneReachability probes the control plane endpoint of cluster
+// once it has machines, and records the outcome in the ControlPlaneEndpointReachable
+// condition of freeboxCluster. It reports false when the endpoint should be probed
+// again later.
+func (r *FreeboxClusterReconciler) reconcileControlPlaneReachability(ctx context.Context, cluster *clusterv1.Cluster, freeboxCluster *infrastructurev1alpha1.FreeboxCluster) (bool, error) {
+	endpoint := cluster.Spec.ControlPlaneEndpoint
+	if r.ControlPlaneDialer == nil || endpoint.Host == "" {
+		return true, nil
+	}
+
+	var machines infrastructurev1alpha1.FreeboxMachineList
+	if err := r.List(ctx, &machines, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return false, fmt.Errorf("listing FreeboxMachines of cluster %s: %w", cluster.Name, err)
+	}
+	if len(machines.Items) == 0 {
+		return true, nil
+	}
+
+	address := net.JoinHostPort(endpoint.Host, strconv.Itoa(int(endpoint.Port)))
+	condition := metav1.Condition{
+		Type:    ConditionControlPlaneEndpointReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Reachable",
+		Message: fmt.Sprintf("Control plane endpoint %s accepts connections", address),
+	}
+	conn, err := r.ControlPlaneDialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "Unreachable"
+		condition.Message = fmt.Sprintf("Control plane endpoint %s does not accept connections, check the port forwarding or VIP configuration if this persists once the control plane is up: %v", address, err)
+	} else {
+		_ = conn.Close()
+	}
+
+	meta.SetStatusCondition(&freeboxCluster.Status.Conditions, condition)
+	return condition.Status == metav1.ConditionTrue, nil
+}
+
+// freeboxMachineToFreeboxCluster maps a FreeboxMachine to the FreeboxCluster of its
+// Cluster, so that the control plane endpoint is probed as soon as machines exist.
+func (r *FreeboxClusterReconciler) freeboxMachineToFreeboxCluster(ctx context.Context, obj client.Object) []ctrl.Request {
+	clusterName := obj.GetLabels()[clusterv1.ClusterNameLabel]
+	if clusterName == "" {
+		return nil
+	}
+	var cluster clusterv1.Cluster
+	if err := r.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: clusterName}, &cluster); err != nil {
+		return nil
+	}
+	ref := cluster.Spec.InfrastructureRef
+	if ref.Kind != "FreeboxCluster" || ref.Name == "" {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: client.ObjectKey{Namespace: cluster.Namespace, Name: ref.Name}}}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/freebox"
+)
+
+// wipeDisk truncates the disk at diskPath by uploading an empty file over it. The
+// Freebox API offers no way to write to a file in place, so this is the closest
+// it gets to erasing the disk contents before the file is removed.
+func (r *FreeboxMachineReconciler) wipeDisk(ctx context.Context, diskPath string) error {
+	w, _, err := r.freeboxClient(ctx).FileUploadStart(ctx, freeboxTypes.FileUploadStartActionInput{
+		Size:     0,
+		Dirname:  freebox.Base64Path(path.Dir(diskPath)),
+		Filename: path.Base(diskPath),
+		Force:    freeboxTypes.FileUploadStartActionForceOverwrite,
+	})
+	if err != nil {
+		return fmt.Errorf("wiping disk %s: %w", diskPath, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("wiping disk %s: %w", diskPath, err)
+	}
+	return nil
+}
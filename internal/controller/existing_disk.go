@@ -0,0 +1,37 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+)
+
+// diskExists reports whether a file exists at diskPath on the Freebox.
+func (r *FreeboxMachineReconciler) diskExists(ctx context.Context, diskPath string) (bool, error) {
+	_, err := r.freeboxClient(ctx).GetFileInfo(ctx, diskPath)
+	switch {
+	case errors.Is(err, freeboxclient.ErrPathNotFound):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("looking for an existing disk at %s: %w", diskPath, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// fileIndexingGrace is how long after a file system task completed the files it
+// wrote may still be missing because the Freebox has not indexed them yet.
+const fileIndexingGrace = 2 * time.Minute
+
+// waitForIndexing reports whether p is not visible on the Freebox yet. Files written
+// by a file system task may only show up once the Freebox indexed them, shortly
+// after the task completed, and the tasks reading them fail with path_not_found
+// until then. The caller requeues while waiting, with the exponential backoff of
+// the work queue, as indexing usually takes seconds but is slower on a busy Freebox.
+func (r *FreeboxMachineReconciler) waitForIndexing(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, p string) (bool, error) {
+	exists, err := r.diskExists(ctx, p)
+	if err != nil || exists {
+		return false, err
+	}
+	logf.FromContext(ctx).Info("File is not visible on the Freebox yet, waiting for it to be indexed", "path", p)
+	setWaitingForIndexing(machine, p)
+	return true, nil
+}
+
+// setWaitingForIndexing records in the ImageReady condition of machine that the
+// image pipeline waits for the Freebox to index p.
+func setWaitingForIndexing(machine *infrastructurev1alpha1.FreeboxMachine, p string) {
+	meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+		Type:    ConditionImageReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  "WaitingForFileIndexing",
+		Message: fmt.Sprintf("Waiting for the Freebox to index %s", p),
+	})
+}
+
+// indexingPending reports whether the files written by a file system task that
+// completed at doneTimestamp, in seconds since the epoch, may not be indexed yet.
+func indexingPending(doneTimestamp int64) bool {
+	return time.Since(time.Unix(doneTimestamp, 0)) < fileIndexingGrace
+}
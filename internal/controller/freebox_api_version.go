@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/freebox"
+)
+
+// reconcileFreeboxAPIVersion records the Freebox API version used by the controller
+// in the status of freeboxCluster, and reports in the FreeboxAPIVersionCurrent
+// condition whether it is the latest version advertised by the Freebox. Failures
+// are only logged, as the version is informational.
+func (r *FreeboxClusterReconciler) reconcileFreeboxAPIVersion(ctx context.Context, freeboxCluster *infrastructurev1alpha1.FreeboxCluster) {
+	if r.FreeboxAPIVersion == "" {
+		return
+	}
+	logger := logf.FromContext(ctx)
+
+	advertised, err := r.freeboxClient(ctx).APIVersion(ctx)
+	if err != nil {
+		logger.Info("Could not get the API version advertised by the Freebox", "error", err)
+		return
+	}
+	latest := apiMajorVersion(advertised.APIVersion)
+	if latest == "" {
+		logger.Info("Could not parse the API version advertised by the Freebox", "apiVersion", advertised.APIVersion)
+		return
+	}
+
+	if previous := freeboxCluster.Status.FreeboxAPI; previous != nil && previous.LatestVersion != advertised.APIVersion {
+		logger.Info("Freebox API version changed, likely after a firmware update", "previous", previous.LatestVersion, "latest", advertised.APIVersion)
+	}
+	version := r.NegotiatedAPIVersion
+	if version == "" {
+		version = r.FreeboxAPIVersion
+		if version == "latest" {
+			version = latest
+		}
+	}
+	freeboxCluster.Status.FreeboxAPI = &infrastructurev1alpha1.FreeboxAPIStatus{
+		Version:       version,
+		LatestVersion: advertised.APIVersion,
+		BoxModel:      advertised.BoxModel,
+	}
+
+	condition := metav1.Condition{
+		Type:    ConditionFreeboxAPIVersionCurrent,
+		Status:  metav1.ConditionTrue,
+		Reason:  "Current",
+		Message: fmt.Sprintf("Using Freebox API %s, the latest advertised by the Freebox (%s)", version, advertised.APIVersion),
+	}
+	switch {
+	case r.FreeboxAPIVersion == "latest" && version != latest:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "VersionSkew"
+		condition.Message = fmt.Sprintf("FREEBOX_VERSION is latest, which was API %s when the manager started, but the Freebox now advertises API %s (%s); restart the manager to use it",
+			version, latest, advertised.APIVersion)
+	case r.FreeboxAPIVersion == "latest":
+		condition.Reason = "Latest"
+		condition.Message = fmt.Sprintf("Using the latest Freebox API, currently %s (%s); set FREEBOX_VERSION to %s to keep it across firmware updates",
+			version, advertised.APIVersion, version)
+	case version != latest:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "VersionSkew"
+		condition.Message = fmt.Sprintf("Using Freebox API %s (FREEBOX_VERSION is %s), but the Freebox advertises API %s (%s); endpoints deprecated since %s may stop working after a firmware update",
+			version, r.FreeboxAPIVersion, latest, advertised.APIVersion, version)
+	}
+	meta.SetStatusCondition(&freeboxCluster.Status.Conditions, condition)
+}
+
+// apiMajorVersion returns the version used in the Freebox API URLs for the
+// advertised api_version, e.g. "v10" for "10.2", or "" when it cannot be parsed.
+func apiMajorVersion(apiVersion string) string {
+	major, ok := freebox.APIMajorVersion(apiVersion)
+	if !ok {
+		return ""
+	}
+	return "v" + strconv.Itoa(major)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/freebox"
+)
+
+// A FreeboxCluster may manage its VMs with its own credentials, from a
+// FreeboxClusterIdentity or a Secret, and on its own Freebox. Its reconciles, and
+// those of its machines, then carry the connection to that Freebox in their
+// context, which the reconcilers use instead of their own client and settings.
+
+// FreeboxClients returns the connections to the Freebox of clusters with their
+// own credentials.
+type FreeboxClients interface {
+	Get(ctx context.Context, credentials freebox.Credentials) (*freebox.Connection, error)
+}
+
+// freeboxConnectionKey is the context key of the connection of the reconciled object.
+type freeboxConnectionKey struct{}
+
+// withFreeboxConnection returns ctx carrying the connection of the reconciled object.
+func withFreeboxConnection(ctx context.Context, c *freebox.Connection) context.Context {
+	return context.WithValue(ctx, freeboxConnectionKey{}, c)
+}
+
+// freeboxConnectionFrom returns the connection carried by ctx, or nil when the
+// reconciled object uses the Freebox of the manager.
+func freeboxConnectionFrom(ctx context.Context) *freebox.Connection {
+	c, _ := ctx.Value(freeboxConnectionKey{}).(*freebox.Connection)
+	return c
+}
+
+// freeboxClient returns the client talking to the Freebox of the reconciled machine.
+func (r *FreeboxMachineReconciler) freeboxClient(ctx context.Context) freeboxclient.Client {
+	if c := freeboxConnectionFrom(ctx); c != nil {
+		return c.Client
+	}
+	return r.FreeboxClient
+}
+
+// downloadDir returns the download directory of the Freebox of the reconciled machine.
+func (r *FreeboxMachineReconciler) downloadDir(ctx context.Context) string {
+	if c := freeboxConnectionFrom(ctx); c != nil {
+		return c.DownloadDir
+	}
+	return r.FreeboxDownloadDir
+}
+
+// vmStoragePath returns the VM storage directory of the Freebox of the reconciled machine.
+func (r *FreeboxMachineReconciler) vmStoragePath(ctx context.Context) string {
+	if c := freeboxConnectionFrom(ctx); c != nil {
+		return c.VMStoragePath
+	}
+	return r.VMStoragePath
+}
+
+// freeboxSerial returns the serial number of the Freebox of the reconciled machine.
+func (r *FreeboxMachineReconciler) freeboxSerial(ctx context.Context) string {
+	if c := freeboxConnectionFrom(ctx); c != nil {
+		return c.Serial
+	}
+	return r.FreeboxSerial
+}
+
+// freeboxClient returns the client talking to the Freebox of the reconciled cluster.
+func (r *FreeboxClusterReconciler) freeboxClient(ctx context.Context) freeboxclient.Client {
+	if c := freeboxConnectionFrom(ctx); c != nil {
+		return c.Client
+	}
+	return r.FreeboxClient
+}
+
+// downloadDir returns the download directory of the Freebox of the reconciled cluster.
+func (r *FreeboxClusterReconciler) downloadDir(ctx context.Context) string {
+	if c := freeboxConnectionFrom(ctx); c != nil {
+		return c.DownloadDir
+	}
+	return r.FreeboxDownloadDir
+}
+
+// clusterConnection returns the connection to the Freebox of freeboxCluster, or nil
+// when it uses the Freebox and credentials of the manager. Secrets are read from
+// secrets, so that they are not cached.
+func clusterConnection(ctx context.Context, c client.Reader, secrets client.Reader, clients FreeboxClients, freeboxCluster *infrastructurev1alpha1.FreeboxCluster) (*freebox.Connection, error) {
+	spec := freeboxCluster.Spec
+	secretRef := spec.CredentialsSecretRef
+	if spec.IdentityRef != nil {
+		var identity infrastructurev1alpha1.FreeboxClusterIdentity
+		if err := c.Get(ctx, client.ObjectKey{Namespace: freeboxCluster.Namespace, Name: spec.IdentityRef.Name}, &identity); err != nil {
+			return nil, fmt.Errorf("getting FreeboxClusterIdentity %s: %w", spec.IdentityRef.Name, err)
+		}
+		secretRef = &identity.Spec.SecretRef
+	}
+	if secretRef == nil {
+		return nil, nil
+	}
+	if clients == nil {
+		return nil, fmt.Errorf("clusters with their own credentials are not supported by this manager")
+	}
+
+	var secret corev1.Secret
+	if err := secrets.Get(ctx, client.ObjectKey{Namespace: freeboxCluster.Namespace, Name: secretRef.Name}, &secret); err != nil {
+		return nil, fmt.Errorf("getting credentials Secret %s: %w", secretRef.Name, err)
+	}
+	credentials := freebox.Credentials{
+		Endpoint:   spec.Endpoint,
+		APIVersion: spec.APIVersion,
+		AppID:      string(secret.Data["appID"]),
+		Token:      string(secret.Data["token"]),
+	}
+	if credentials.AppID == "" || credentials.Token == "" {
+		return nil, fmt.Errorf("credentials Secret %s must hold the appID and token keys", secret.Name)
+	}
+	return clients.Get(ctx, credentials)
+}
+
+// machineConnection returns the connection to the Freebox of the cluster of
+// machine, or nil when it uses the Freebox and credentials of the manager, e.g.
+// when the FreeboxCluster is already gone.
+func (r *FreeboxMachineReconciler) machineConnection(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) (*freebox.Connection, error) {
+	return machineConnection(ctx, r.Client, r.secretReader(), r.Clients, machine)
+}
+
+func machineConnection(ctx context.Context, c client.Reader, secrets client.Reader, clients FreeboxClients, machine *infrastructurev1alpha1.FreeboxMachine) (*freebox.Connection, error) {
+	freeboxCluster, err := freeboxClusterOf(ctx, c, machine)
+	if err != nil || freeboxCluster == nil {
+		return nil, err
+	}
+	return clusterConnection(ctx, c, secrets, clients, freeboxCluster)
+}
+
+// reconcileConnection returns ctx carrying the connection to the Freebox of
+// freeboxCluster, and records whether the controller could log in with its
+// credentials in the IdentityReady condition, and in the Ready condition once the
+// cluster is provisioned.
+func (r *FreeboxClusterReconciler) reconcileConnection(ctx context.Context, freeboxCluster *infrastructurev1alpha1.FreeboxCluster) (context.Context, error) {
+	secrets := r.SecretReader
+	if secrets == nil {
+		secrets = r.Client
+	}
+	connection, err := clusterConnection(ctx, r.Client, secrets, r.Clients, freeboxCluster)
+	if err != nil {
+		condition := metav1.Condition{
+			Type:    ConditionIdentityReady,
+			Status:  metav1.ConditionFalse,
+			Reason:  "IdentityUnavailable",
+			Message: err.Error(),
+		}
+		meta.SetStatusCondition(&freeboxCluster.Status.Conditions, condition)
+		if ptr.Deref(freeboxCluster.Status.Initialization.Provisioned, false) {
+			condition.Type = ReadyCondition
+			meta.SetStatusCondition(&freeboxCluster.Status.Conditions, condition)
+		}
+		return ctx, err
+	}
+	if connection == nil {
+		meta.RemoveStatusCondition(&freeboxCluster.Status.Conditions, ConditionIdentityReady)
+		return ctx, nil
+	}
+	meta.SetStatusCondition(&freeboxCluster.Status.Conditions, metav1.Condition{
+		Type:    ConditionIdentityReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "LoggedIn",
+		Message: fmt.Sprintf("Logged in to Freebox %s with the credentials of the cluster", connection.Serial),
+	})
+	return withFreeboxConnection(ctx, connection), nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// Condition reasons for errors returned by the Freebox API.
+const (
+	ReasonInsufficientFreeboxRights = "InsufficientFreeboxRights"
+	ReasonFreeboxAuthRequired       = "FreeboxAuthenticationRequired"
+	ReasonFreeboxTaskNotFound       = "FreeboxTaskNotFound"
+	ReasonFreeboxResourceExists     = "FreeboxResourceExists"
+	ReasonFreeboxResourceNotFound   = "FreeboxResourceNotFound"
+	ReasonFreeboxBusy               = "FreeboxBusy"
+	ReasonFreeboxAPIError           = "FreeboxAPIError"
+)
+
+// freeboxErrorCodeReasons maps Freebox API error codes to condition reasons.
+var freeboxErrorCodeReasons = map[string]string{
+	"insufficient_rights":  ReasonInsufficientFreeboxRights,
+	"auth_required":        ReasonFreeboxAuthRequired,
+	"invalid_token":        ReasonFreeboxAuthRequired,
+	"invalid_session":      ReasonFreeboxAuthRequired,
+	"task_not_found":       ReasonFreeboxTaskNotFound,
+	"invalid_id":           ReasonFreeboxTaskNotFound,
+	"exists":               ReasonFreeboxResourceExists,
+	"destination_conflict": ReasonFreeboxResourceExists,
+	"noent":                ReasonFreeboxResourceNotFound,
+	"no_such_vm":           ReasonFreeboxResourceNotFound,
+	"path_not_found":       ReasonFreeboxResourceNotFound,
+	"busy":                 ReasonFreeboxBusy,
+	"ratelimited":          ReasonFreeboxBusy,
+	"rate_limited":         ReasonFreeboxBusy,
+}
+
+// freeboxErrorReason returns the condition reason for an error returned by the
+// Freebox API, or an empty string when err does not come from the Freebox API.
+func freeboxErrorReason(err error) string {
+	switch {
+	case errors.Is(err, freeboxclient.ErrTaskNotFound):
+		return ReasonFreeboxTaskNotFound
+	case errors.Is(err, freeboxclient.ErrDestinationConflict):
+		return ReasonFreeboxResourceExists
+	case errors.Is(err, freeboxclient.ErrVirtualMachineNotFound), errors.Is(err, freeboxclient.ErrPathNotFound):
+		return ReasonFreeboxResourceNotFound
+	}
+
+	var apiErr *freeboxclient.APIError
+	if !errors.As(err, &apiErr) {
+		return ""
+	}
+	if reason, ok := freeboxErrorCodeReasons[apiErr.Code]; ok {
+		return reason
+	}
+	return ReasonFreeboxAPIError
+}
+
+// reportFreeboxError surfaces an error returned by the Freebox API as the
+// reason of the Ready condition, so that users see why provisioning is stuck
+// without reading the controller logs.
+func reportFreeboxError(machine *infrastructurev1alpha1.FreeboxMachine, reconcileErr error) {
+	reason := freeboxErrorReason(reconcileErr)
+	if reason == "" {
+		return
+	}
+	meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+		Type:    ReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: reconcileErr.Error(),
+	})
+}
+
+// Backoff of the retries of objects whose reconcile failed because the Freebox
+// was busy. The Freebox does not tell how long to wait.
+const (
+	freeboxBusyBaseDelay = 5 * time.Second
+	freeboxBusyMaxDelay  = 5 * time.Minute
+)
+
+// freeboxBusyRetry retries the reconciles that failed because the Freebox was
+// busy or rate limited the controller after an exponential backoff per object,
+// instead of returning their error, which would be logged and counted as a
+// reconcile error although nothing is wrong. The zero value is ready to use.
+type freeboxBusyRetry struct {
+	once    sync.Once
+	limiter workqueue.TypedRateLimiter[types.NamespacedName]
+}
+
+// handle returns the result of a reconcile of the object named req, turning a
+// busy error into a delayed requeue. The backoff of the object is reset by any
+// other outcome.
+func (b *freeboxBusyRetry) handle(ctx context.Context, req ctrl.Request, result ctrl.Result, err error) (ctrl.Result, error) {
+	b.once.Do(func() {
+		b.limiter = workqueue.NewTypedItemExponentialFailureRateLimiter[types.NamespacedName](freeboxBusyBaseDelay, freeboxBusyMaxDelay)
+	})
+	if err == nil || freeboxErrorReason(err) != ReasonFreeboxBusy {
+		b.limiter.Forget(req.NamespacedName)
+		return result, err
+	}
+	delay := b.limiter.When(req.NamespacedName)
+	logf.FromContext(ctx).Info("Freebox is busy, retrying later", "after", delay, "error", err.Error())
+	return ctrl.Result{RequeueAfter: delay}, nil
+}
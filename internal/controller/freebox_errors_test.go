@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/freebox/mock"
+)
+
+var _ = Describe("Freebox API errors", func() {
+	DescribeTable("freeboxErrorReason",
+		func(err error, want string) {
+			Expect(freeboxErrorReason(err)).To(Equal(want))
+		},
+		Entry("insufficient rights", &freeboxclient.APIError{Code: "insufficient_rights"}, ReasonInsufficientFreeboxRights),
+		Entry("wrapped insufficient rights",
+			fmt.Errorf("failed to POST downloads/add endpoint: %w", &freeboxclient.APIError{Code: "insufficient_rights"}),
+			ReasonInsufficientFreeboxRights),
+		Entry("task not found code", &freeboxclient.APIError{Code: "task_not_found"}, ReasonFreeboxTaskNotFound),
+		Entry("task not found sentinel", freeboxclient.ErrTaskNotFound, ReasonFreeboxTaskNotFound),
+		Entry("exists", &freeboxclient.APIError{Code: "exists"}, ReasonFreeboxResourceExists),
+		Entry("destination conflict sentinel", freeboxclient.ErrDestinationConflict, ReasonFreeboxResourceExists),
+		Entry("missing VM", freeboxclient.ErrVirtualMachineNotFound, ReasonFreeboxResourceNotFound),
+		Entry("busy", &freeboxclient.APIError{Code: "busy"}, ReasonFreeboxBusy),
+		Entry("rate limited", &freeboxclient.APIError{Code: "ratelimited"}, ReasonFreeboxBusy),
+		Entry("unknown code", &freeboxclient.APIError{Code: "internal_error"}, ReasonFreeboxAPIError),
+		Entry("not a Freebox error", errors.New("connection refused"), ""),
+	)
+
+	It("reports a Freebox error code as the Ready condition reason", func() {
+		ctx := context.Background()
+		nn := types.NamespacedName{Name: "freebox-error-test", Namespace: "default"}
+		machine := newMachineForPhaseTest(nn.Name, infrastructurev1alpha1.FreeboxMachineSpec{
+			Name:          "test-vm",
+			VCPUs:         1,
+			MemoryMB:      512,
+			DiskSizeBytes: 10 * 1024 * 1024 * 1024,
+			ImageURL:      "https://example.com/images/nocloud.raw",
+		})
+		Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Get(ctx, nn, machine)).To(Succeed())
+			machine.Finalizers = nil
+			Expect(k8sClient.Update(ctx, machine)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, machine)).To(Succeed())
+		})
+
+		fc := &mock.Client{}
+		fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+		fc.AddDownloadTaskReturns(0, fmt.Errorf("failed to POST downloads/add endpoint: %w",
+			&freeboxclient.APIError{Code: "insufficient_rights", Message: "Cette application n'est pas autorisée"}))
+		r := &FreeboxMachineReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), FreeboxClient: fc}
+		_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+		Expect(err).To(HaveOccurred())
+
+		Expect(k8sClient.Get(ctx, nn, machine)).To(Succeed())
+		ready := meta.FindStatusCondition(machine.Status.Conditions, ReadyCondition)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal(ReasonInsufficientFreeboxRights))
+		Expect(ready.Message).To(ContainSubstring("insufficient_rights"))
+	})
+
+	It("retries later with a growing delay instead of failing when the Freebox is busy", func() {
+		ctx := context.Background()
+		nn := types.NamespacedName{Name: "freebox-busy-test", Namespace: "default"}
+		machine := newMachineForPhaseTest(nn.Name, infrastructurev1alpha1.FreeboxMachineSpec{
+			Name:          "test-vm",
+			VCPUs:         1,
+			MemoryMB:      512,
+			DiskSizeBytes: 10 * 1024 * 1024 * 1024,
+			ImageURL:      "https://example.com/images/nocloud.raw",
+		})
+		Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+		DeferCleanup(func() {
+			Expect(k8sClient.Get(ctx, nn, machine)).To(Succeed())
+			machine.Finalizers = nil
+			Expect(k8sClient.Update(ctx, machine)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, machine)).To(Succeed())
+		})
+
+		fc := &mock.Client{}
+		fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+		fc.AddDownloadTaskReturns(0, fmt.Errorf("failed to POST downloads/add endpoint: %w", &freeboxclient.APIError{Code: "busy"}))
+		r := &FreeboxMachineReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), FreeboxClient: fc}
+		first, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first.RequeueAfter).To(BeNumerically(">", 0))
+		second, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second.RequeueAfter).To(BeNumerically(">", first.RequeueAfter))
+
+		Expect(k8sClient.Get(ctx, nn, machine)).To(Succeed())
+		Expect(meta.FindStatusCondition(machine.Status.Conditions, ReadyCondition).Reason).To(Equal(ReasonFreeboxBusy))
+	})
+})
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// ConfirmFreeboxTargetAnnotation confirms that a FreeboxMachine provisioned on
+// another Freebox is to be managed on the one the controller now talks to. Its value
+// is the serial number of that Freebox, so that a confirmation does not carry over
+// to a later change. The controller removes it once applied.
+const ConfirmFreeboxTargetAnnotation = "infrastructure.cluster.x-k8s.io/confirm-freebox-target"
+
+// checkFreeboxTarget records in status.freeboxSerial the Freebox machine is
+// provisioned on, and reports whether the controller still talks to that Freebox.
+// After a change of endpoint or credentials, the VM IDs and paths recorded in status
+// designate nothing, or other VMs, on the new Freebox, so the machine is left alone,
+// deletion included, and reported in the FreeboxTargetChanged condition until an
+// operator confirms the change with ConfirmFreeboxTargetAnnotation.
+func (r *FreeboxMachineReconciler) checkFreeboxTarget(ctx context.Context, original, machine *infrastructurev1alpha1.FreeboxMachine) (bool, error) {
+	current := r.freeboxSerial(ctx)
+	if current == "" {
+		return true, nil
+	}
+	recorded := machine.Status.FreeboxSerial
+	if recorded == "" || recorded == current {
+		machine.Status.FreeboxSerial = current
+		meta.RemoveStatusCondition(&machine.Status.Conditions, ConditionFreeboxTargetChanged)
+		return true, nil
+	}
+
+	if machine.Annotations[ConfirmFreeboxTargetAnnotation] == current {
+		logf.FromContext(ctx).Info("Managing the machine on the new Freebox as confirmed by the annotation",
+			"previousSerial", recorded, "serial", current)
+		delete(machine.Annotations, ConfirmFreeboxTargetAnnotation)
+		if err := r.updateMachine(ctx, original, machine); err != nil {
+			return false, err
+		}
+		machine.Status.FreeboxSerial = current
+		meta.RemoveStatusCondition(&machine.Status.Conditions, ConditionFreeboxTargetChanged)
+		r.ownerEvent(ctx, machine, corev1.EventTypeNormal, "FreeboxTargetConfirmed", "Reconcile",
+			"Managing the machine on Freebox %s instead of %s", current, recorded)
+		return true, nil
+	}
+
+	message := fmt.Sprintf("The machine was provisioned on Freebox %s, but the controller now manages Freebox %s; "+
+		"nothing is changed until the %s annotation is set to %s, or the controller is pointed back to Freebox %s",
+		recorded, current, ConfirmFreeboxTargetAnnotation, current, recorded)
+	if !meta.IsStatusConditionTrue(machine.Status.Conditions, ConditionFreeboxTargetChanged) {
+		r.ownerEvent(ctx, machine, corev1.EventTypeWarning, "FreeboxTargetChanged", "Reconcile", "%s", message)
+	}
+	meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+		Type:    ConditionFreeboxTargetChanged,
+		Status:  metav1.ConditionTrue,
+		Reason:  "FreeboxChanged",
+		Message: message,
+	})
+	logf.FromContext(ctx).Info("Freebox changed since the machine was provisioned, waiting for confirmation",
+		"previousSerial", recorded, "serial", current)
+	return false, nil
+}
@@ -18,10 +18,12 @@ package controller
 
 import (
 	"context"
+	"net"
+	"time"
 
-	"k8s.io/apimachinery/pkg/api/meta"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/util"
@@ -30,29 +32,95 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	freeboxclient "github.com/nikolalohinski/free-go/client"
 
 	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/imagepolicy"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/conditions"
 )
 
+// ConditionControlPlaneEndpointReachable is a supplementary condition that tracks
+// whether the control plane endpoint accepts TCP connections once machines exist
+const ConditionControlPlaneEndpointReachable = conditions.ControlPlaneEndpointReachable
+
+// ConditionControlPlaneEndpointConsistent is a supplementary condition that tracks
+// whether the KubeadmControlPlane of the cluster uses its control plane endpoint
+const ConditionControlPlaneEndpointConsistent = conditions.ControlPlaneEndpointConsistent
+
+// ConditionFreeboxAPIVersionCurrent is a supplementary condition that tracks
+// whether the controller uses the latest Freebox API version advertised by the box
+const ConditionFreeboxAPIVersionCurrent = conditions.FreeboxAPIVersionCurrent
+
+// ConditionStorageDegraded is a supplementary condition that tracks whether the
+// Freebox internal disk reports errors, which pauses image writes
+const ConditionStorageDegraded = conditions.StorageDegraded
+
+// ConditionClusterReferencesValid is a supplementary condition that tracks whether
+// the FreeboxCluster and FreeboxMachineTemplates of the Cluster are in its namespace
+const ConditionClusterReferencesValid = conditions.ClusterReferencesValid
+
+// ConditionIdentityReady is a supplementary condition that tracks whether the
+// controller logs in to the Freebox with the FreeboxClusterIdentity of the cluster
+const ConditionIdentityReady = conditions.IdentityReady
+
 // FreeboxClusterReconciler reconciles a FreeboxCluster object
 type FreeboxClusterReconciler struct {
 	client.Client
-	Scheme        *runtime.Scheme
-	FreeboxClient freeboxclient.Client
+	Scheme             *runtime.Scheme
+	FreeboxClient      freeboxclient.Client
+	FreeboxDownloadDir string // Freebox download directory path from /api/v*/downloads/config/
+
+	// ImagePolicy restricts where prefetched images may be fetched from.
+	ImagePolicy imagepolicy.Policy
+
+	// ControlPlaneDialer connects to the control plane endpoint to report whether it
+	// is reachable. When nil, the endpoint is not probed.
+	ControlPlaneDialer *net.Dialer
+
+	// FreeboxAPIVersion is the configured Freebox API version, e.g. "latest" or "v10".
+	// When empty, the version advertised by the Freebox is not checked.
+	FreeboxAPIVersion string
+
+	// NegotiatedAPIVersion is the Freebox API version the controller uses, negotiated
+	// from FreeboxAPIVersion with the Freebox at startup, e.g. "v10". Defaults to
+	// FreeboxAPIVersion, latest being the version advertised by the Freebox.
+	NegotiatedAPIVersion string
+
+	// Clients logs in to the Freebox with the FreeboxClusterIdentities of the
+	// clusters. Clusters referencing an identity fail to reconcile when nil.
+	Clients FreeboxClients
+
+	// SecretReader reads the Secrets of FreeboxClusterIdentities from the API
+	// server, so that secrets are not cached. The Client is used when nil.
+	SecretReader client.Reader
+
+	// busyRetry delays the reconciles that failed because the Freebox was busy.
+	busyRetry freeboxBusyRetry
 }
 
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=freeboxclusteridentities,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=freeboxclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=freeboxclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=freeboxclusters/finalizers,verbs=update
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=freeboxmachines,verbs=get;list;watch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kubeadmcontrolplanes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=freeboxmachinetemplates,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 func (r *FreeboxClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, err := r.reconcileCluster(ctx, req)
+	return r.busyRetry.handle(ctx, req, result, err)
+}
+
+func (r *FreeboxClusterReconciler) reconcileCluster(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	logger := logf.FromContext(ctx)
 
 	// Fetch the FreeboxCluster resource
@@ -61,6 +129,37 @@ func (r *FreeboxClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Status changes are written once, when the reconcile ends, as a patch that is
+	// not bound to the resource version, so that a stale cached FreeboxCluster does
+	// not make the write fail with a conflict.
+	original := freeboxCluster.DeepCopy()
+	paused := false
+	defer func() {
+		freeboxCluster.Status.Ready = legacyReady(freeboxCluster.Status.Initialization.Provisioned)
+		freeboxCluster.Status.ObservedGeneration = freeboxCluster.Generation
+		conditions.SetObservedGeneration(freeboxCluster.Status.Conditions, freeboxCluster.Generation)
+		if !equality.Semantic.DeepEqual(original.Status, freeboxCluster.Status) {
+			if err := r.Status().Patch(ctx, &freeboxCluster, client.MergeFrom(original)); err != nil {
+				logger.Error(err, "Failed to update FreeboxCluster status")
+				reterr = kerrors.NewAggregate([]error{reterr, client.IgnoreNotFound(err)})
+			}
+		}
+		if paused {
+			return
+		}
+		outcome, phase := reconcileSucceeded, "Provisioning"
+		if reterr != nil {
+			outcome = reconcileFailed
+		}
+		if ptr.Deref(freeboxCluster.Status.Initialization.Provisioned, false) {
+			phase = "Provisioned"
+		}
+		if err := patchLastReconcile(ctx, r.Client, &freeboxCluster, outcome, phase); err != nil {
+			logger.Error(err, "Failed to record the outcome of the reconcile")
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
 	// Get the owner Cluster
 	cluster, err := util.GetOwnerCluster(ctx, r.Client, freeboxCluster.ObjectMeta)
 	if err != nil {
@@ -68,50 +167,99 @@ func (r *FreeboxClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 	if cluster == nil {
 		logger.Info("Cluster Controller has not yet set OwnerRef")
+		// A Cluster of another namespace referencing the FreeboxCluster never owns it.
+		if err := r.reportForeignClusters(ctx, &freeboxCluster); err != nil {
+			return ctrl.Result{}, err
+		}
 		return ctrl.Result{}, nil
 	}
 
 	// Check for paused state - this is required for CAPI pivot compatibility
 	// Skip reconciliation if the Cluster is paused OR if the FreeboxCluster has the paused annotation
-	if ptr.Deref(cluster.Spec.Paused, false) || annotations.HasPaused(&freeboxCluster) {
+	paused = ptr.Deref(cluster.Spec.Paused, false) || annotations.HasPaused(&freeboxCluster)
+	setPausedCondition(&freeboxCluster.Status.Conditions, paused)
+	if paused {
 		logger.Info("Reconciliation skipped due to paused annotation or paused Cluster")
 		return ctrl.Result{}, nil
 	}
 
+	// Talk to the Freebox of the cluster with its credentials, if any. Once
+	// provisioned, the Ready condition, which Cluster API mirrors in the
+	// InfrastructureReady condition of the Cluster, reports whether that works.
+	ctx, err = r.reconcileConnection(ctx, &freeboxCluster)
+	if err != nil {
+		logger.Error(err, "Failed to log in with the credentials of the cluster")
+		return ctrl.Result{}, err
+	}
+
 	// Following YAGNI principle: Since we don't manage external cluster infrastructure,
 	// the cluster is always provisioned. We just need to report that to CAPI.
 
 	// Set the control plane endpoint on the Cluster if not already set and if provided in FreeboxCluster.Spec
 	if !freeboxCluster.Spec.ControlPlaneEndpoint.IsZero() && cluster.Spec.ControlPlaneEndpoint.IsZero() {
+		patch := client.MergeFrom(cluster.DeepCopy())
 		cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{
 			Host: freeboxCluster.Spec.ControlPlaneEndpoint.Host,
 			Port: freeboxCluster.Spec.ControlPlaneEndpoint.Port,
 		}
-		if err := r.Update(ctx, cluster); err != nil {
+		if err := r.Patch(ctx, cluster, patch); err != nil {
 			logger.Error(err, "Failed to update Cluster with ControlPlaneEndpoint")
 			return ctrl.Result{}, err
 		}
 		logger.Info("Updated Cluster with ControlPlaneEndpoint", "host", cluster.Spec.ControlPlaneEndpoint.Host, "port", cluster.Spec.ControlPlaneEndpoint.Port)
 	}
 
-	// Set initialization.provisioned to true
-	if freeboxCluster.Status.Initialization.Provisioned == nil || !*freeboxCluster.Status.Initialization.Provisioned {
-		freeboxCluster.Status.Initialization.Provisioned = ptr.To(true)
+	// Publish the storage failure domains, across which Cluster API spreads the
+	// control plane machines
+	freeboxCluster.Status.FailureDomains = storageFailureDomains(&freeboxCluster)
 
-		// Set Ready condition to True
-		meta.SetStatusCondition(&freeboxCluster.Status.Conditions, metav1.Condition{
-			Type:    "Ready",
-			Status:  metav1.ConditionTrue,
-			Reason:  "InfrastructureReady",
-			Message: "Freebox cluster infrastructure is ready",
-		})
+	// Set initialization.provisioned to true, and Ready back to True after a failure
+	if !ptr.Deref(freeboxCluster.Status.Initialization.Provisioned, false) {
+		logger.Info("FreeboxCluster marked as ready and provisioned")
+	}
+	markProvisioned(&freeboxCluster.Status.Initialization.Provisioned, &freeboxCluster.Status.Conditions,
+		"Freebox cluster infrastructure is ready")
 
-		if err := r.Status().Update(ctx, &freeboxCluster); err != nil {
-			logger.Error(err, "Failed to update FreeboxCluster status")
-			return ctrl.Result{}, err
-		}
+	// Report whether the KubeadmControlPlane uses the control plane endpoint, as a
+	// mismatch produces API server certificates that do not match the endpoint
+	if err := r.reconcileControlPlaneEndpointConsistency(ctx, cluster, &freeboxCluster); err != nil {
+		logger.Error(err, "Failed to check control plane endpoint consistency")
+		return ctrl.Result{}, err
+	}
 
-		logger.Info("FreeboxCluster marked as ready and provisioned")
+	// Report the FreeboxMachineTemplates of the cluster created in another namespace,
+	// as Cluster API never finds them and their machines silently never reconcile
+	if err := r.reconcileClusterReferences(ctx, cluster, &freeboxCluster); err != nil {
+		logger.Error(err, "Failed to check cluster references")
+		return ctrl.Result{}, err
+	}
+
+	// Report the Freebox API version in use, as "latest" changes silently with
+	// firmware updates and a pinned version may be outdated
+	r.reconcileFreeboxAPIVersion(ctx, &freeboxCluster)
+
+	// Report whether the control plane endpoint is reachable, to catch port
+	// forwarding or VIP misconfiguration before machines wait for it forever
+	reachable, err := r.reconcileControlPlaneReachability(ctx, cluster, &freeboxCluster)
+	if err != nil {
+		logger.Error(err, "Failed to probe control plane endpoint")
+		return ctrl.Result{}, err
+	}
+
+	// Report whether the Freebox disk is healthy, as images must not be written
+	// onto failing storage
+	if !r.reconcileStorageHealth(ctx, &freeboxCluster) {
+		return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+	}
+
+	// Download the images to prefetch, so machines only have to copy them
+	done, err := r.reconcilePrefetchImages(ctx, &freeboxCluster)
+	if err != nil {
+		logger.Error(err, "Failed to prefetch images")
+		return ctrl.Result{}, err
+	}
+	if !done || !reachable {
+		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
 	return ctrl.Result{}, nil
@@ -121,13 +269,25 @@ func (r *FreeboxClusterReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 func (r *FreeboxClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "freeboxcluster")
 
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&infrastructurev1alpha1.FreeboxCluster{}).
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&infrastructurev1alpha1.FreeboxCluster{}, builder.WithPredicates(ignoreLastReconcileUpdates)).
 		Named("freeboxcluster").
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(util.ClusterToInfrastructureMapFunc(ctx, infrastructurev1alpha1.GroupVersion.WithKind("FreeboxCluster"), mgr.GetClient(), &infrastructurev1alpha1.FreeboxCluster{})),
 			builder.WithPredicates(predicates.ClusterPausedTransitions(mgr.GetScheme(), predicateLog)),
-		).
-		Complete(r)
+		)
+	if r.ControlPlaneDialer != nil {
+		// Probe the control plane endpoint as soon as the first machines are created
+		b = b.Watches(
+			&infrastructurev1alpha1.FreeboxMachine{},
+			handler.EnqueueRequestsFromMapFunc(r.freeboxMachineToFreeboxCluster),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc:  func(event.UpdateEvent) bool { return false },
+				DeleteFunc:  func(event.DeleteEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			}),
+		)
+	}
+	return b.Complete(r)
 }
@@ -18,18 +18,27 @@ package controller
 
 import (
 	"context"
+	"net"
+	"testing"
+	"time"
 
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/freebox"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/freebox/mock"
 )
 
 var _ = Describe("FreeboxCluster Controller", func() {
@@ -83,6 +92,11 @@ var _ = Describe("FreeboxCluster Controller", func() {
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
+
+			By("recording the generation the status was computed from")
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(freeboxCluster.Status.ObservedGeneration).To(Equal(freeboxCluster.Generation))
 		})
 	})
 
@@ -186,6 +200,637 @@ var _ = Describe("FreeboxCluster Controller", func() {
 			Expect(err).NotTo(HaveOccurred())
 			// Since Initialization is not a pointer, check for the zero value
 			Expect(freeboxCluster.Status.Initialization.Provisioned).To(BeNil(), "Status.Initialization.Provisioned should not be set when paused")
+			paused := meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionPaused)
+			Expect(paused).NotTo(BeNil())
+			Expect(paused.Status).To(Equal(metav1.ConditionTrue))
+			Expect(paused.Reason).To(Equal(clusterv1.PausedReason))
+		})
+	})
+
+	Context("When prefetching images", func() {
+		const resourceName = "test-prefetch"
+		const imageURL = "https://example.com/images/nocloud.raw.xz"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       clusterv1.ClusterSpec{Paused: ptr.To(false)},
+			}
+			Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, cluster)).To(Succeed()) })
+
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: clusterv1.GroupVersion.String(),
+						Kind:       "Cluster",
+						Name:       cluster.Name,
+						UID:        cluster.UID,
+					}},
+				},
+				Spec: infrastructurev1alpha1.FreeboxClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "192.168.1.100", Port: 6443},
+					PrefetchImages:       []infrastructurev1alpha1.ImageRef{{URL: imageURL}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, freeboxCluster)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, freeboxCluster)).To(Succeed()) })
+		})
+
+		It("downloads the images to the cluster cache directory, and removes them once unlisted", func() {
+			fc := &mock.Client{}
+			fc.AddDownloadTaskReturns(42, nil)
+			controllerReconciler := &FreeboxClusterReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				FreeboxClient:      fc,
+				FreeboxDownloadDir: "/mnt/downloads",
+			}
+			cachePath := "/mnt/downloads/prefetch-default-" + resourceName + "/nocloud.raw.xz"
+
+			By("starting the download")
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(fc.CreateDirectoryCallCount()).To(Equal(1))
+			_, req := fc.AddDownloadTaskArgsForCall(0)
+			Expect(req.DownloadDirectory).To(Equal("/mnt/downloads/prefetch-default-" + resourceName))
+			Expect(req.Filename).To(Equal("nocloud.raw.xz"))
+
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(freeboxCluster.Status.PrefetchedImages).To(Equal([]infrastructurev1alpha1.PrefetchedImage{
+				{URL: imageURL, Path: cachePath, TaskID: 42},
+			}))
+
+			By("recording the downloaded image")
+			fc.GetDownloadTaskReturns(freeboxTypes.DownloadTask{ID: 42, Status: freeboxTypes.DownloadTaskStatusDone}, nil)
+			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(fc.AddDownloadTaskCallCount()).To(Equal(1))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(freeboxCluster.Status.PrefetchedImages).To(Equal([]infrastructurev1alpha1.PrefetchedImage{
+				{URL: imageURL, Path: cachePath, Ready: true},
+			}))
+
+			By("removing the image no longer listed")
+			freeboxCluster.Spec.PrefetchImages = nil
+			Expect(k8sClient.Update(ctx, freeboxCluster)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.RemoveFilesCallCount()).To(Equal(1))
+			_, removed := fc.RemoveFilesArgsForCall(0)
+			Expect(removed).To(ConsistOf(cachePath))
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(freeboxCluster.Status.PrefetchedImages).To(BeEmpty())
+		})
+
+		It("writes the status when the cached FreeboxCluster is stale", func() {
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			staleVersion := freeboxCluster.ResourceVersion
+			freeboxCluster.Labels = map[string]string{"touched": "true"}
+			Expect(k8sClient.Update(ctx, freeboxCluster)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.AddDownloadTaskReturns(42, nil)
+			controllerReconciler := &FreeboxClusterReconciler{
+				Client:             &staleClient{Client: k8sClient, key: typeNamespacedName, resourceVersion: staleVersion},
+				Scheme:             k8sClient.Scheme(),
+				FreeboxClient:      fc,
+				FreeboxDownloadDir: "/mnt/downloads",
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(freeboxCluster.Status.Initialization.Provisioned).To(Equal(ptr.To(true)))
+			Expect(freeboxCluster.Status.Ready).To(BeTrue())
+			Expect(freeboxCluster.Status.PrefetchedImages).To(HaveLen(1))
+		})
+	})
+
+	Context("When checking the Freebox", func() {
+		const resourceName = "test-api-version"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       clusterv1.ClusterSpec{Paused: ptr.To(false)},
+			}
+			Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, cluster)).To(Succeed()) })
+
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: clusterv1.GroupVersion.String(),
+						Kind:       "Cluster",
+						Name:       cluster.Name,
+						UID:        cluster.UID,
+					}},
+				},
+				Spec: infrastructurev1alpha1.FreeboxClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "192.168.1.100", Port: 6443},
+				},
+			}
+			Expect(k8sClient.Create(ctx, freeboxCluster)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, freeboxCluster)).To(Succeed()) })
+		})
+
+		It("pauses image writes while the Freebox disk reports errors", func() {
+			fc := &mock.Client{}
+			fc.GetSystemInfoReturns(freeboxTypes.SystemConfig{DiskStatus: freeboxTypes.DiskStatusError}, nil)
+			controllerReconciler := &FreeboxClusterReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				FreeboxClient: fc,
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(1 * time.Minute))
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(freeboxCluster.Status.Conditions, ConditionStorageDegraded)).To(BeTrue())
+
+			By("clearing the condition once the disk recovers")
+			fc.GetSystemInfoReturns(freeboxTypes.SystemConfig{DiskStatus: freeboxTypes.DiskStatusActive}, nil)
+			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(freeboxCluster.Status.Conditions, ConditionStorageDegraded)).To(BeTrue())
+		})
+
+		It("publishes the storage failure domains for Cluster API", func() {
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			freeboxCluster.Spec.StorageFailureDomains = []infrastructurev1alpha1.FreeboxStorageFailureDomain{
+				{Name: "internal", Path: "/Freebox/VMs"},
+				{Name: "usb", Path: "/USB/VMs", ControlPlane: ptr.To(false)},
+			}
+			Expect(k8sClient.Update(ctx, freeboxCluster)).To(Succeed())
+
+			controllerReconciler := &FreeboxClusterReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(freeboxCluster.Status.FailureDomains).To(Equal([]clusterv1.FailureDomain{
+				{Name: "internal", ControlPlane: ptr.To(true), Attributes: map[string]string{"path": "/Freebox/VMs"}},
+				{Name: "usb", ControlPlane: ptr.To(false), Attributes: map[string]string{"path": "/USB/VMs"}},
+			}))
+		})
+
+		It("reports FreeboxMachineTemplates of the cluster found in another namespace", func() {
+			otherNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other-cluster-templates"}}
+			Expect(k8sClient.Create(ctx, otherNamespace)).To(Succeed())
+			template := &infrastructurev1alpha1.FreeboxMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "workers", Namespace: otherNamespace.Name},
+				Spec: infrastructurev1alpha1.FreeboxMachineTemplateSpec{Template: infrastructurev1alpha1.FreeboxMachineTemplateResource{
+					Spec: infrastructurev1alpha1.FreeboxMachineSpec{VCPUs: 1, MemoryMB: 512, DiskSizeBytes: 1 << 30, ImageURL: "https://example.com/nocloud.raw"},
+				}},
+			}
+			Expect(k8sClient.Create(ctx, template)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, template)).To(Succeed()) })
+			md := &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "md-0",
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: resourceName},
+				},
+				Spec: clusterv1.MachineDeploymentSpec{
+					ClusterName: resourceName,
+					Selector:    metav1.LabelSelector{MatchLabels: map[string]string{"pool": "md-0"}},
+					Template: clusterv1.MachineTemplateSpec{
+						ObjectMeta: clusterv1.ObjectMeta{Labels: map[string]string{"pool": "md-0"}},
+						Spec: clusterv1.MachineSpec{
+							ClusterName: resourceName,
+							Bootstrap:   clusterv1.Bootstrap{DataSecretName: ptr.To("md-0-bootstrap")},
+							InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+								APIGroup: infrastructurev1alpha1.GroupVersion.Group,
+								Kind:     "FreeboxMachineTemplate",
+								Name:     template.Name,
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, md)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, md)).To(Succeed()) })
+
+			controllerReconciler := &FreeboxClusterReconciler{Client: k8sClient, Scheme: k8sClient.Scheme()}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			valid := meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionClusterReferencesValid)
+			Expect(valid).NotTo(BeNil())
+			Expect(valid.Status).To(Equal(metav1.ConditionFalse))
+			Expect(valid.Message).To(Equal("FreeboxMachineTemplate workers of MachineDeployment md-0 is in namespace other-cluster-templates instead of default"))
+
+			By("reporting a template labeled for another cluster once it is in the namespace")
+			local := template.DeepCopy()
+			local.ObjectMeta = metav1.ObjectMeta{
+				Name:      template.Name,
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "other"},
+			}
+			Expect(k8sClient.Create(ctx, local)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, local)).To(Succeed()) })
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			valid = meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionClusterReferencesValid)
+			Expect(valid.Message).To(Equal("FreeboxMachineTemplate workers of MachineDeployment md-0 is labeled for cluster other"))
+		})
+
+		It("reports a pinned version older than the one advertised by the Freebox", func() {
+			fc := &mock.Client{}
+			fc.APIVersionReturns(freeboxTypes.APIVersion{APIVersion: "10.2", BoxModel: "fbxgw9-r1/full"}, nil)
+			controllerReconciler := &FreeboxClusterReconciler{
+				Client:            k8sClient,
+				Scheme:            k8sClient.Scheme(),
+				FreeboxClient:     fc,
+				FreeboxAPIVersion: "v8",
+			}
+
+			By("reporting the skew")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(freeboxCluster.Status.FreeboxAPI).To(Equal(&infrastructurev1alpha1.FreeboxAPIStatus{
+				Version:       "v8",
+				LatestVersion: "10.2",
+				BoxModel:      "fbxgw9-r1/full",
+			}))
+			cond := meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionFreeboxAPIVersionCurrent)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("VersionSkew"))
+			Expect(cond.Message).To(HavePrefix("Using Freebox API v8 (FREEBOX_VERSION is v8)"))
+
+			By("reporting latest pinned to an older version at startup")
+			controllerReconciler.FreeboxAPIVersion = "latest"
+			controllerReconciler.NegotiatedAPIVersion = "v9"
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(freeboxCluster.Status.FreeboxAPI.Version).To(Equal("v9"))
+			cond = meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionFreeboxAPIVersionCurrent)
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("VersionSkew"))
+			Expect(cond.Message).To(ContainSubstring("FREEBOX_VERSION is latest, which was API v9 when the manager started"))
+
+			By("reporting latest pinned to the advertised version")
+			controllerReconciler.NegotiatedAPIVersion = "v10"
+			_, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(freeboxCluster.Status.FreeboxAPI.Version).To(Equal("v10"))
+			cond = meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionFreeboxAPIVersionCurrent)
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal("Latest"))
+		})
+	})
+
+	Context("When checking the KubeadmControlPlane endpoint", func() {
+		const resourceName = "test-kcp-endpoint"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		BeforeEach(func() {
+			kcp := &controlplanev1.KubeadmControlPlane{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Version: "v1.34.1",
+					MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+						Spec: controlplanev1.KubeadmControlPlaneMachineTemplateSpec{
+							InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+								APIGroup: infrastructurev1alpha1.GroupVersion.Group,
+								Kind:     "FreeboxMachineTemplate",
+								Name:     resourceName,
+							},
+						},
+					},
+				},
+			}
+			kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.CertSANs = []string{"192.168.1.200"}
+			Expect(k8sClient.Create(ctx, kcp)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, kcp)).To(Succeed()) })
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: clusterv1.ClusterSpec{
+					Paused: ptr.To(false),
+					ControlPlaneRef: clusterv1.ContractVersionedObjectReference{
+						APIGroup: controlplanev1.GroupVersion.Group,
+						Kind:     "KubeadmControlPlane",
+						Name:     kcp.Name,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, cluster)).To(Succeed()) })
+
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: clusterv1.GroupVersion.String(),
+						Kind:       "Cluster",
+						Name:       cluster.Name,
+						UID:        cluster.UID,
+					}},
+				},
+				Spec: infrastructurev1alpha1.FreeboxClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "192.168.1.100", Port: 6443},
+				},
+			}
+			Expect(k8sClient.Create(ctx, freeboxCluster)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, freeboxCluster)).To(Succeed()) })
+		})
+
+		It("reports certSANs that do not include the control plane endpoint", func() {
+			controllerReconciler := &FreeboxClusterReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				FreeboxClient: &mock.Client{},
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			consistent := meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionControlPlaneEndpointConsistent)
+			Expect(consistent).NotTo(BeNil())
+			Expect(consistent.Status).To(Equal(metav1.ConditionFalse))
+			Expect(consistent.Reason).To(Equal("EndpointMismatch"))
+			Expect(consistent.Message).To(ContainSubstring("192.168.1.200"))
+		})
+
+		It("reports control plane nodes that all add the endpoint address, even with a single replica", func() {
+			kcp := &controlplanev1.KubeadmControlPlane{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, kcp)).To(Succeed())
+			kcp.Spec.Replicas = ptr.To[int32](1)
+			kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.CertSANs = []string{"192.168.1.100"}
+			kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands = []string{"ip addr add 192.168.1.100/24 dev enp0s5 || true"}
+			Expect(k8sClient.Update(ctx, kcp)).To(Succeed())
+
+			controllerReconciler := &FreeboxClusterReconciler{
+				Client:        k8sClient,
+				Scheme:        k8sClient.Scheme(),
+				FreeboxClient: &mock.Client{},
+			}
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			consistent := meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionControlPlaneEndpointConsistent)
+			Expect(consistent).NotTo(BeNil())
+			Expect(consistent.Status).To(Equal(metav1.ConditionFalse))
+			Expect(consistent.Reason).To(Equal("EndpointAddressOnEveryNode"))
+		})
+	})
+
+	Context("When probing the control plane endpoint", func() {
+		const resourceName = "test-cp-probe"
+
+		ctx := context.Background()
+
+		typeNamespacedName := types.NamespacedName{
+			Name:      resourceName,
+			Namespace: "default",
+		}
+
+		var listener net.Listener
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() { _ = listener.Close() })
+			port := listener.Addr().(*net.TCPAddr).Port
+
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: clusterv1.ClusterSpec{
+					Paused: ptr.To(false),
+					InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+						APIGroup: infrastructurev1alpha1.GroupVersion.Group,
+						Kind:     "FreeboxCluster",
+						Name:     resourceName,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, cluster)).To(Succeed()) })
+
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: clusterv1.GroupVersion.String(),
+						Kind:       "Cluster",
+						Name:       cluster.Name,
+						UID:        cluster.UID,
+					}},
+				},
+				Spec: infrastructurev1alpha1.FreeboxClusterSpec{
+					ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "127.0.0.1", Port: int32(port)},
+				},
+			}
+			Expect(k8sClient.Create(ctx, freeboxCluster)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, freeboxCluster)).To(Succeed()) })
+		})
+
+		It("reports whether the endpoint accepts connections once machines exist", func() {
+			controllerReconciler := &FreeboxClusterReconciler{
+				Client:             k8sClient,
+				Scheme:             k8sClient.Scheme(),
+				FreeboxClient:      &mock.Client{},
+				ControlPlaneDialer: &net.Dialer{Timeout: time.Second},
+			}
+			freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{}
+
+			By("not probing a cluster without machines")
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionControlPlaneEndpointReachable)).To(BeNil())
+
+			machine := &infrastructurev1alpha1.FreeboxMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: resourceName},
+				},
+				Spec: infrastructurev1alpha1.FreeboxMachineSpec{
+					Name:          "cp-vm",
+					VCPUs:         1,
+					MemoryMB:      512,
+					DiskSizeBytes: 10 * 1024 * 1024 * 1024,
+				},
+			}
+			Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(ctx, machine)).To(Succeed()) })
+			Expect(controllerReconciler.freeboxMachineToFreeboxCluster(ctx, machine)).To(ConsistOf(reconcile.Request{NamespacedName: typeNamespacedName}))
+
+			By("reporting a reachable endpoint")
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(freeboxCluster.Status.Conditions, ConditionControlPlaneEndpointReachable)).To(BeTrue())
+
+			By("reporting an unreachable endpoint")
+			Expect(listener.Close()).To(Succeed())
+			result, err = controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: typeNamespacedName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxCluster)).To(Succeed())
+			reachable := meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionControlPlaneEndpointReachable)
+			Expect(reachable).NotTo(BeNil())
+			Expect(reachable.Status).To(Equal(metav1.ConditionFalse))
+			Expect(reachable.Reason).To(Equal("Unreachable"))
 		})
 	})
 })
+
+// stubClients returns the same connection for any credentials, recording them.
+type stubClients struct {
+	connection  *freebox.Connection
+	credentials []freebox.Credentials
+}
+
+func (s *stubClients) Get(_ context.Context, credentials freebox.Credentials) (*freebox.Connection, error) {
+	s.credentials = append(s.credentials, credentials)
+	return s.connection, nil
+}
+
+var _ = Describe("reconcileConnection", func() {
+	It("talks to the Freebox with the credentials of the FreeboxClusterIdentity", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a-credentials", Namespace: "default"},
+			Data:       map[string][]byte{"appID": []byte("team-a"), "token": []byte("secret")},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, secret)
+		identity := &infrastructurev1alpha1.FreeboxClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "default"},
+			Spec:       infrastructurev1alpha1.FreeboxClusterIdentitySpec{SecretRef: corev1.LocalObjectReference{Name: secret.Name}},
+		}
+		Expect(k8sClient.Create(ctx, identity)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, identity)
+
+		identityClient := &mock.Client{}
+		clients := &stubClients{connection: &freebox.Connection{Client: identityClient, Settings: freebox.Settings{DownloadDir: "/team-a"}}}
+		r := &FreeboxClusterReconciler{Client: k8sClient, FreeboxClient: &mock.Client{}, Clients: clients}
+		freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-a", Namespace: "default"},
+			Spec:       infrastructurev1alpha1.FreeboxClusterSpec{IdentityRef: &corev1.LocalObjectReference{Name: identity.Name}},
+		}
+		identityCtx, err := r.reconcileConnection(ctx, freeboxCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.freeboxClient(identityCtx)).To(BeIdenticalTo(identityClient))
+		Expect(r.downloadDir(identityCtx)).To(Equal("/team-a"))
+		Expect(clients.credentials).To(Equal([]freebox.Credentials{{AppID: "team-a", Token: "secret"}}))
+		Expect(meta.IsStatusConditionTrue(freeboxCluster.Status.Conditions, ConditionIdentityReady)).To(BeTrue())
+
+		By("reporting a missing identity, also in the Ready condition once provisioned")
+		markProvisioned(&freeboxCluster.Status.Initialization.Provisioned, &freeboxCluster.Status.Conditions, "ready")
+		freeboxCluster.Spec.IdentityRef.Name = "missing"
+		_, err = r.reconcileConnection(ctx, freeboxCluster)
+		Expect(err).To(HaveOccurred())
+		ready := meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionIdentityReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Message).To(ContainSubstring("missing"))
+		Expect(meta.IsStatusConditionFalse(freeboxCluster.Status.Conditions, ReadyCondition)).To(BeTrue())
+
+		By("using the client of the manager without identity")
+		freeboxCluster.Spec.IdentityRef = nil
+		managerCtx, err := r.reconcileConnection(ctx, freeboxCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(r.freeboxClient(managerCtx)).To(BeIdenticalTo(r.FreeboxClient))
+		Expect(meta.FindStatusCondition(freeboxCluster.Status.Conditions, ConditionIdentityReady)).To(BeNil())
+	})
+
+	It("talks to the Freebox of the endpoint with the credentials of the Secret", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "second-freebox", Namespace: "default"},
+			Data:       map[string][]byte{"appID": []byte("capi"), "token": []byte("secret")},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, secret)
+
+		clients := &stubClients{connection: &freebox.Connection{Client: &mock.Client{}}}
+		r := &FreeboxClusterReconciler{Client: k8sClient, FreeboxClient: &mock.Client{}, Clients: clients}
+		freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "second-freebox", Namespace: "default"},
+			Spec: infrastructurev1alpha1.FreeboxClusterSpec{
+				Endpoint:             "https://second.example.com",
+				APIVersion:           "v12",
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: secret.Name},
+			},
+		}
+		_, err := r.reconcileConnection(ctx, freeboxCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clients.credentials).To(Equal([]freebox.Credentials{{
+			Endpoint: "https://second.example.com", APIVersion: "v12", AppID: "capi", Token: "secret",
+		}}))
+
+		By("reporting a Secret without token")
+		secret.Data = map[string][]byte{"appID": []byte("capi")}
+		Expect(k8sClient.Update(ctx, secret)).To(Succeed())
+		_, err = r.reconcileConnection(ctx, freeboxCluster)
+		Expect(err).To(MatchError(ContainSubstring("appID and token")))
+	})
+})
+
+func TestControlPlaneEndpointMismatch(t *testing.T) {
+	endpoint := clusterv1.APIEndpoint{Host: "192.168.1.100", Port: 6443}
+	for _, tc := range []struct {
+		kubeadmEndpoint string
+		certSANs        []string
+		mismatch        bool
+	}{
+		{"", nil, false},
+		{"192.168.1.100:6443", []string{"192.168.1.100", "k8s.lan"}, false},
+		{"192.168.1.100", nil, false},
+		{"192.168.1.101:6443", nil, true},
+		{"192.168.1.100:8443", nil, true},
+		{"", []string{"192.168.1.200"}, true},
+	} {
+		if got := controlPlaneEndpointMismatch(endpoint, tc.kubeadmEndpoint, tc.certSANs); (got != "") != tc.mismatch {
+			t.Errorf("controlPlaneEndpointMismatch(%q, %v) = %q, want mismatch=%t", tc.kubeadmEndpoint, tc.certSANs, got, tc.mismatch)
+		}
+	}
+}
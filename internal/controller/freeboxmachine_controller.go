@@ -17,21 +17,27 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"path"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	freeboxclient "github.com/nikolalohinski/free-go/client"
 	freeboxTypes "github.com/nikolalohinski/free-go/types"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
@@ -44,18 +50,61 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/yaml"
 
 	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/freebox"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/imagepolicy"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/quota"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/conditions"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/diskimage"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/providerid"
 )
 
 const (
 	// ReadyCondition is the main condition type that CAPI watches
 	// It reflects the overall state of the FreeboxMachine infrastructure
-	ReadyCondition = "Ready"
+	ReadyCondition = conditions.Ready
+
+	// ConditionPaused is the Cluster API condition that tracks whether the
+	// reconciliation of the FreeboxMachine or FreeboxCluster is paused
+	ConditionPaused = conditions.Paused
 
 	// ConditionImageReady is a supplementary condition that tracks
 	// whether the disk image has been downloaded, extracted, and prepared
-	ConditionImageReady = "ImageReady"
+	ConditionImageReady = conditions.ImageReady
+
+	// ConditionBootstrapDataReady is a supplementary condition that tracks
+	// whether the bootstrap provider has generated the bootstrap data secret
+	ConditionBootstrapDataReady = conditions.BootstrapDataReady
+
+	// ConditionImageVersionMismatch is a supplementary condition that tracks
+	// whether the Kubernetes version embedded in the image differs from the Machine one
+	ConditionImageVersionMismatch = conditions.ImageVersionMismatch
+
+	// ConditionShutdownEscalated is a supplementary condition that tracks
+	// whether the VM had to be killed because it did not shut down in time
+	ConditionShutdownEscalated = conditions.ShutdownEscalated
+
+	// ConditionReconciliationFrozen is a supplementary condition that tracks
+	// whether the FreezeAnnotation stops the controller from changing the VM
+	ConditionReconciliationFrozen = conditions.ReconciliationFrozen
+
+	// ConditionFreeboxClockSkewed is a supplementary condition that tracks
+	// whether the Freebox clock, which freshly booted VMs start from, is off
+	ConditionFreeboxClockSkewed = conditions.FreeboxClockSkewed
+
+	// ConditionFreeboxTargetChanged is a supplementary condition that tracks whether
+	// the controller now manages another Freebox than the machine was provisioned on
+	ConditionFreeboxTargetChanged = conditions.FreeboxTargetChanged
+
+	// reasonProvisioningFailed is the reason of the Ready condition of machines whose
+	// image or VM could not be prepared
+	reasonProvisioningFailed = "ProvisioningFailed"
+
+	// reasonImagePrepared is the reason of the Ready condition of image-only machines
+	// whose disk is prepared
+	reasonImagePrepared = "ImagePrepared"
 
 	FreeboxMachineFinalizer = "freeboxmachine.infrastructure.cluster.x-k8s.io/finalizer"
 
@@ -74,11 +123,20 @@ const (
 	taskStateDone  = "done"
 	taskStateError = "error"
 
+	// freeboxArch is the value of the {arch} ImageURL placeholder. The Freebox API
+	// does not expose the CPU architecture, but every Freebox able to host VMs
+	// (Delta, Ultra) runs an arm64 SoC.
+	freeboxArch = "arm64"
+
+	// maxDownloadRetries is how many times a failed image download is resumed before giving up.
+	maxDownloadRetries = 5
+
 	// Phase tracks the image-preparation pipeline
 	phaseDownload  = "download"
 	phaseExtract   = "extract"
 	phaseCopy      = "copy"
 	phaseRename    = "rename"
+	phaseVerify    = "verify"
 	phaseResize    = "resize"
 	phaseVMCreated = "vmcreated" // VM exists, waiting for IP from LAN browser
 	phaseDone      = "done"
@@ -92,23 +150,116 @@ type FreeboxMachineReconciler struct {
 	ClusterCache       clustercache.ClusterCache
 	FreeboxDownloadDir string // Freebox download directory path from /api/v*/downloads/config/
 	VMStoragePath      string // VM storage path from user_main_storage + "/VMs"
+
+	// MaxConcurrentDownloads caps the image downloads running at the same time on
+	// the Freebox, whose downloader degrades with many parallel HTTP downloads.
+	// Zero means no limit.
+	MaxConcurrentDownloads int
+
+	// ImagePolicy restricts where images may be fetched from.
+	ImagePolicy imagepolicy.Policy
+
+	// ImageProbeClient sends the HEAD requests validating image URLs before the
+	// Freebox downloads them. URLs are not probed when nil.
+	ImageProbeClient *http.Client
+
+	// VMStateResyncPeriod is how often the status of the VM of a provisioned machine
+	// is mirrored in status.vmState. Zero disables the periodic refresh.
+	VMStateResyncPeriod time.Duration
+
+	// AddressRequeueInterval is how often a machine whose VM is created is reconciled
+	// again while it waits for its addresses. defaultAddressRequeueInterval when zero.
+	AddressRequeueInterval time.Duration
+
+	// NodeRequeueInterval is how often a provisioned machine is reconciled again until
+	// its Node registers in the workload cluster and gets its providerID.
+	// defaultNodeRequeueInterval when zero.
+	NodeRequeueInterval time.Duration
+
+	// Recorder records events on FreeboxMachines. Events are dropped when nil.
+	Recorder events.EventRecorder
+
+	// SecretReader reads bootstrap data secrets from the API server, so that secrets
+	// are not cached and the provider does not need to list and watch them. The
+	// Client is used when nil.
+	SecretReader client.Reader
+
+	// APIReader reads FreeboxMachines from the API server before a Freebox task is
+	// started, so that a stale cached machine does not start a task again. The Client
+	// is used when nil.
+	APIReader client.Reader
+
+	// InstanceID identifies the provider instance among those managing the Freebox.
+	// It is recorded in the metadata of the VMs it creates, and VMs recorded for
+	// another instance are never reused. Empty when the instance manages the Freebox
+	// alone.
+	InstanceID string
+
+	// FreeboxSerial is the serial number of the Freebox the controller manages.
+	// Machines provisioned on another Freebox are not changed until an operator
+	// confirms it. Machines are not checked when empty.
+	FreeboxSerial string
+
+	// Clients logs in to the Freebox with the FreeboxClusterIdentities of the
+	// clusters. Machines of clusters referencing an identity fail to reconcile when nil.
+	Clients FreeboxClients
+
+	// FreeboxClock reports the skew of the Freebox clock from the controller one.
+	// The skew is not checked when nil.
+	FreeboxClock ClockSkewReporter
+
+	// taskStartMu serializes the start of Freebox tasks, see lockTaskStart.
+	taskStartMu sync.Mutex
+
+	// machineWrites holds the lock of each machine, see lockMachineWrites.
+	machineWritesMu sync.Mutex
+	machineWrites   map[types.NamespacedName]*sync.Mutex
+
+	// busyRetry delays the reconciles that failed because the Freebox was busy.
+	busyRetry freeboxBusyRetry
+
+	// phaseDurations estimates when the images being prepared are ready.
+	phaseDurations phaseDurations
+}
+
+// event records an event regarding obj when a recorder is configured.
+func (r *FreeboxMachineReconciler) event(regarding, related runtime.Object, eventType, reason, action, note string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Eventf(regarding, related, eventType, reason, action, note, args...)
+}
+
+// secretReader returns the reader of bootstrap data secrets.
+func (r *FreeboxMachineReconciler) secretReader() client.Reader {
+	if r.SecretReader != nil {
+		return r.SecretReader
+	}
+	return r.Client
 }
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=freeboxmachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=freeboxmachines/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=freeboxmachines/finalizers,verbs=update
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=freeboxclusters,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+// +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
 //
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/reconcile
-//
-//nolint:gocyclo // TODO: Refactor into smaller helper functions
 func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	result, err := r.reconcileMachine(ctx, req)
+	r.updatePhaseMetrics(ctx)
+	return r.busyRetry.handle(ctx, req, result, err)
+}
+
+//nolint:gocyclo // TODO: Refactor into smaller helper functions
+func (r *FreeboxMachineReconciler) reconcileMachine(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	logger := logf.FromContext(ctx)
 
 	// Fetch the FreeboxMachine resource
@@ -117,6 +268,74 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Status changes are accumulated in memory and written once, when the
+	// reconcile ends, rather than after each of them.
+	original := machine.DeepCopy()
+	initialReady := meta.FindStatusCondition(original.Status.Conditions, ReadyCondition)
+	if initialReady != nil {
+		initialReady = initialReady.DeepCopy()
+	}
+	initialPhase := machine.Status.Phase
+	paused := false
+	defer func() {
+		if reterr != nil {
+			reportFreeboxError(&machine, reterr)
+		}
+		r.trackImagePhase(&machine, initialPhase)
+		var failure string
+		if ready := meta.FindStatusCondition(machine.Status.Conditions, ReadyCondition); ready != nil && ready.Reason == reasonProvisioningFailed &&
+			(initialReady == nil || initialReady.Reason != reasonProvisioningFailed || initialReady.Message != ready.Message) {
+			failure = ready.Message
+			r.ownerEvent(ctx, &machine, corev1.EventTypeWarning, reasonProvisioningFailed, "Provision", "%s", ready.Message)
+		}
+		trackTaskHistory(&machine, failure)
+		machine.Status.Ready = legacyReady(machine.Status.Initialization.Provisioned)
+		machine.Status.ObservedGeneration = machine.Generation
+		conditions.SetObservedGeneration(machine.Status.Conditions, machine.Generation)
+		if err := r.patchStatus(ctx, original, &machine); err != nil {
+			logger.Error(err, "Failed to update status")
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+		if paused {
+			return
+		}
+		outcome := reconcileSucceeded
+		if _, frozen := frozenUntil(&machine); frozen {
+			outcome = reconcileFrozen
+		}
+		if reterr != nil {
+			outcome = reconcileFailed
+		}
+		unlockWrites := r.lockMachineWrites(&machine)
+		err := patchLastReconcile(ctx, r.Client, &machine, outcome, machine.Status.Phase)
+		unlockWrites()
+		if err != nil {
+			logger.Error(err, "Failed to record the outcome of the reconcile")
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	// --- Honor the freeze window, which also holds deletion ---
+	if until, frozen := frozenUntil(&machine); frozen {
+		return r.reconcileFrozenMachine(ctx, &machine, until), nil
+	}
+	meta.RemoveStatusCondition(&machine.Status.Conditions, ConditionReconciliationFrozen)
+
+	// --- Talk to the Freebox of the cluster with its credentials, if any ---
+	connection, err := r.machineConnection(ctx, &machine)
+	if err != nil {
+		logger.Error(err, "Failed to log in with the credentials of the cluster")
+		return ctrl.Result{}, err
+	}
+	if connection != nil {
+		ctx = withFreeboxConnection(ctx, connection)
+	}
+
+	// --- Leave machines of another Freebox alone, which also holds deletion ---
+	if ok, err := r.checkFreeboxTarget(ctx, original, &machine); err != nil || !ok {
+		return ctrl.Result{}, err
+	}
+
 	// --- Handle deletion ---
 	if !machine.DeletionTimestamp.IsZero() {
 		if slices.Contains(machine.Finalizers, FreeboxMachineFinalizer) {
@@ -127,7 +346,7 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				logger.Info("Skipping VM deletion: clusterctl move in progress, resource being moved to target cluster")
 				// Remove finalizer to allow the Kubernetes object to be deleted
 				machine.Finalizers = slices.DeleteFunc(machine.Finalizers, func(s string) bool { return s == FreeboxMachineFinalizer })
-				if err := r.Update(ctx, &machine); err != nil {
+				if err := r.updateMachine(ctx, original, &machine); err != nil {
 					return ctrl.Result{}, err
 				}
 				return ctrl.Result{}, nil
@@ -142,58 +361,66 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				Reason:  "Deleting",
 				Message: "Deleting infrastructure resources",
 			})
-			if err := r.Status().Update(ctx, &machine); err != nil {
-				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status during deletion")
-					return ctrl.Result{}, err
-				}
+
+			// Stop preparing the image of a machine deleted before its VM was created
+			if err := r.cancelImagePipeline(ctx, &machine); err != nil {
+				logger.Error(err, "Failed to cancel image preparation")
+				return ctrl.Result{}, err
 			}
 
 			vmID := machine.Status.VMID
 			if vmID != nil {
-				// Force stop (kill) the VM before deletion - Freebox API requires VMs to be stopped before deletion
-				logger.Info("Force stopping VM before deletion", "vmID", *vmID)
-				if err := r.FreeboxClient.KillVirtualMachine(ctx, *vmID); err != nil {
-					logger.Error(err, "Failed to force stop VM (may already be stopped)")
-					// Don't return error here - the VM might already be stopped
-				}
-
-				// Wait for VM to be fully stopped before attempting deletion
-				logger.Info("Waiting for VM to stop", "vmID", *vmID)
-				for i := 0; i < 30; i++ { // Wait up to 30 seconds
-					vm, err := r.FreeboxClient.GetVirtualMachine(ctx, *vmID)
-					if err != nil {
-						logger.Error(err, "Failed to get VM status while waiting for stop")
-						break
-					}
-
-					if vm.Status == "stopped" {
-						logger.Info("VM is now stopped", "vmID", *vmID)
-						break
-					}
-
-					logger.Info("VM not yet stopped, waiting...", "vmID", *vmID, "status", vm.Status, "attempt", i+1)
-					time.Sleep(1 * time.Second)
+				// The Freebox API requires VMs to be stopped before deletion
+				if !r.stopVMForDeletion(ctx, &machine, *vmID) {
+					return ctrl.Result{RequeueAfter: vmShutdownPollInterval}, nil
 				}
 
 				// Now delete the VM
-				if err := r.FreeboxClient.DeleteVirtualMachine(ctx, *vmID); err != nil {
+				if err := r.deleteVM(ctx, *vmID); err != nil {
 					logger.Error(err, "Failed to delete VM")
 					return ctrl.Result{}, err
 				}
 				logger.Info("VM deleted", "vmID", *vmID)
+				r.ownerEvent(ctx, &machine, corev1.EventTypeNormal, "VMDeleted", "Delete", "Deleted VM %d", *vmID)
+				// Forget the VM, so that the retries of a failed cleanup below do not
+				// delete it again.
+				machine.Status.VMID = nil
 			}
 
-			// Delete associated disk files
+			// Delete associated disk files. The disk path is recorded before the image
+			// is prepared, but the disk only exists once the image reached VM storage.
 			diskPath := machine.Status.DiskPath
-			if diskPath != "" {
-				filesToDelete := []string{
-					diskPath,              // .raw file
-					diskPath + ".efivars", // .raw.efivars file
+			switch machine.Status.Phase {
+			case phaseDownload, phaseExtract, phaseCopy:
+				diskPath = ""
+			}
+			// Only the files recorded in status as created for the VM are removed.
+			// Machines provisioned before they were recorded fall back to the files
+			// usually found next to their disk.
+			filesToDelete := machine.Status.ManagedFiles
+			if len(filesToDelete) == 0 && diskPath != "" {
+				filesToDelete = []string{
+					diskPath,                 // .raw file
+					diskPath + ".efivars",    // .raw.efivars file
+					vmMetadataPath(diskPath), // .raw.meta.json file
+				}
+				// Unmanaged disks were not created by the controller, so only the files
+				// the Freebox and the controller added next to them are removed.
+				if machine.Spec.ImageManagement == infrastructurev1alpha1.ImageManagementUnmanaged {
+					filesToDelete = filesToDelete[1:]
+				}
+			}
+			if len(filesToDelete) > 0 {
+				if machine.Spec.SecureWipe && diskPath != "" && slices.Contains(filesToDelete, diskPath) {
+					if err := r.wipeDisk(ctx, diskPath); err != nil {
+						logger.Error(err, "Failed to wipe disk", "path", diskPath)
+						return ctrl.Result{}, err
+					}
+					logger.Info("Disk wiped", "path", diskPath)
 				}
 
 				// Start file deletion task
-				deleteTask, err := r.FreeboxClient.RemoveFiles(ctx, filesToDelete)
+				deleteTask, err := r.freeboxClient(ctx).RemoveFiles(ctx, filesToDelete)
 				if err != nil {
 					logger.Error(err, "Failed to start disk file deletion", "files", filesToDelete)
 					return ctrl.Result{}, err
@@ -206,7 +433,7 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 			// Remove finalizer
 			machine.Finalizers = slices.DeleteFunc(machine.Finalizers, func(s string) bool { return s == FreeboxMachineFinalizer })
-			if err := r.Update(ctx, &machine); err != nil {
+			if err := r.updateMachine(ctx, original, &machine); err != nil {
 				return ctrl.Result{}, err
 			}
 		}
@@ -216,7 +443,7 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// --- Ensure finalizer ---
 	if !slices.Contains(machine.Finalizers, FreeboxMachineFinalizer) {
 		machine.Finalizers = append(machine.Finalizers, FreeboxMachineFinalizer)
-		if err := r.Update(ctx, &machine); err != nil {
+		if err := r.updateMachine(ctx, original, &machine); err != nil {
 			return ctrl.Result{}, err
 		}
 	}
@@ -230,14 +457,16 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Check for paused state - this is required for CAPI pivot compatibility
 	// Skip reconciliation if the Cluster is paused OR if the FreeboxMachine has the paused annotation
-	if cluster != nil && ptr.Deref(cluster.Spec.Paused, false) || annotations.HasPaused(&machine) {
+	paused = cluster != nil && ptr.Deref(cluster.Spec.Paused, false) || annotations.HasPaused(&machine)
+	setPausedCondition(&machine.Status.Conditions, paused)
+	if paused {
 		logger.Info("Reconciliation skipped due to paused annotation or paused Cluster")
 
 		// Remove block-move annotation if present - the resource is now pausable
 		if _, hasBlockMove := machine.Annotations[BlockMoveAnnotation]; hasBlockMove {
 			logger.Info("Removing block-move annotation - resource is paused")
 			delete(machine.Annotations, BlockMoveAnnotation)
-			if err := r.Update(ctx, &machine); err != nil {
+			if err := r.updateMachine(ctx, original, &machine); err != nil {
 				return ctrl.Result{}, err
 			}
 		}
@@ -255,34 +484,140 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		if _, hasBlockMove := machine.Annotations[BlockMoveAnnotation]; !hasBlockMove {
 			logger.Info("Setting block-move annotation - resource cannot be instantaneously paused")
 			machine.Annotations[BlockMoveAnnotation] = ""
-			if err := r.Update(ctx, &machine); err != nil {
+			if err := r.updateMachine(ctx, original, &machine); err != nil {
 				return ctrl.Result{}, err
 			}
 		}
 	}
 
+	r.reconcileClockSkew(ctx, &machine)
+
+	// Unmanaged disks are prepared outside of the controller, which only creates the VM.
+	unmanaged := machine.Spec.ImageManagement == infrastructurev1alpha1.ImageManagementUnmanaged
+	// Image-only machines stop once their disk is prepared, without creating a VM.
+	imageOnly := machine.Spec.ImageManagement == infrastructurev1alpha1.ImageManagementImageOnly
+	if ready := meta.FindStatusCondition(machine.Status.Conditions, ReadyCondition); !imageOnly && ready != nil && ready.Reason == reasonImagePrepared {
+		// The machine was switched from ImageOnly, its VM is created on the prepared disk.
+		meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+			Type:    ReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "Provisioning",
+			Message: "Creating the VM on the prepared disk",
+		})
+	}
+
 	imageURL := machine.Spec.ImageURL
-	if imageURL == "" {
-		logger.Info("No ImageURL specified, skipping reconciliation")
+	if imageURL == "" && !unmanaged {
+		logger.Info("No ImageURL specified, waiting for one")
+		meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+			Type:    ReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "ImageURLMissing",
+			Message: "spec.imageURL is empty; set it, or set spec.imageManagement to Unmanaged to bring your own disk",
+		})
 		return ctrl.Result{}, nil
 	}
+	if infrastructurev1alpha1.ImageURLHasPlaceholders(imageURL) {
+		ownerMachine, err := util.GetOwnerMachine(ctx, r.Client, machine.ObjectMeta)
+		if err != nil {
+			logger.Error(err, "Failed to get owner Machine")
+			return ctrl.Result{}, err
+		}
+		if ownerMachine == nil || ownerMachine.Spec.Version == "" {
+			logger.Info("ImageURL has placeholders but the owner Machine or its version is not known yet, waiting")
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		imageURL, err = infrastructurev1alpha1.ExpandImageURL(imageURL, freeboxArch, ownerMachine.Spec.Version)
+		if err != nil {
+			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+				Type:    ReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  "InvalidImageURL",
+				Message: err.Error(),
+			})
+			return ctrl.Result{}, err
+		}
+		logger.Info("Expanded ImageURL placeholders", "imageURL", imageURL)
+	}
+	if !unmanaged && machine.Status.Phase == "" {
+		ok, err := r.checkImageVersion(ctx, &machine, imageURL)
+		if err != nil {
+			logger.Error(err, "Failed to check the Kubernetes version of the image")
+			return ctrl.Result{}, err
+		}
+		if !ok {
+			logger.Info("Image is built for another Kubernetes version than the Machine, not using it", "imageURL", imageURL)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// The VM and its disk share a name rendered from the name template
+	vmName, err := machine.VMName()
+	if err != nil {
+		meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+			Type:    ReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  "InvalidNameTemplate",
+			Message: err.Error(),
+		})
+		return ctrl.Result{}, err
+	}
 
-	// Images are downloaded to FreeboxDownloadDir, then extracted/copied to VMStoragePath
-	imageName := path.Base(imageURL)
-	downloadPath := path.Join(r.FreeboxDownloadDir, imageName)
+	// Images are downloaded to FreeboxDownloadDir, then extracted/copied to the VM
+	// storage directory of the machine
+	imageName := diskimage.FileName(imageURL)
+	if machine.Status.ImageFileName != "" {
+		imageName = machine.Status.ImageFileName
+	}
+	localPath, isLocal := imagepolicy.FreeboxLocalPath(imageURL)
+	if isLocal {
+		imageName = path.Base(localPath)
+	}
+	downloadPath := path.Join(r.downloadDir(ctx), imageName)
+	if machine.Status.ImageCachePath != "" {
+		downloadPath = machine.Status.ImageCachePath
+	}
 
 	// Determine the final image path in VM storage using VM name
-	// The final image will be named after the VM (machine.Spec.Name) with the underlying disk extension
+	// The final image will be named after the VM with the underlying disk extension
 	underlyingName := imageName
-	if isCompressedFile(imageName) {
-		underlyingName = stripCompressionSuffix(imageName)
+	if diskimage.IsCompressed(imageName) {
+		underlyingName = diskimage.StripCompressionSuffix(imageName)
+	}
+	if machine.Spec.ImageArchiveMember != "" {
+		underlyingName = path.Base(machine.Spec.ImageArchiveMember)
 	}
 	ext := path.Ext(underlyingName)
 	if ext == "" {
 		ext = ".raw" // Default extension if none found
 	}
-	vmImageName := machine.Spec.Name + ext
-	finalImagePath := path.Join(r.VMStoragePath, vmImageName)
+	vmImageName := vmName + ext
+	var finalImagePath string
+
+	// Once decided, the disk path is recorded in status and always reused, so that
+	// machines keep track of their disk if the naming logic above changes. It is
+	// persisted along with the next status update.
+	if unmanaged && machine.Spec.DiskPath != "" {
+		finalImagePath = machine.Spec.DiskPath
+		machine.Status.DiskPath = finalImagePath
+	} else if machine.Status.DiskPath != "" {
+		finalImagePath = machine.Status.DiskPath
+	} else {
+		storageDir, ok, err := r.machineStorageDir(ctx, &machine)
+		if err != nil {
+			logger.Error(err, "Failed to choose the storage of the disk")
+			return ctrl.Result{}, err
+		}
+		if !ok {
+			logger.Info("Waiting for the owner Machine to choose the storage failure domain of the disk")
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+		finalImagePath = path.Join(storageDir, vmImageName)
+		machine.Status.DiskPath = finalImagePath
+	}
+	// The image is prepared next to the disk, which may be on another storage
+	// device than VMStoragePath when the machine is in a storage failure domain.
+	vmStorageDir := path.Dir(finalImagePath)
 
 	// Retrieve current phase from status fields
 	phase := machine.Status.Phase
@@ -292,32 +627,212 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// 1. Start download
 	// -----------------------
 	if phase == "" {
-		logger.Info("Starting image download", "url", imageURL, "dest", r.FreeboxDownloadDir)
+		if err := r.ImagePolicy.Check(imageURL); err != nil && !unmanaged {
+			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+				Type:    ReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  "ImageNotAllowed",
+				Message: err.Error(),
+			})
+			return ctrl.Result{}, err
+		}
+
+		// Only machines that started provisioning consume resources on the Freebox.
+		if err := quota.Check(ctx, r.Client, &machine, func(m *infrastructurev1alpha1.FreeboxMachine) bool {
+			return m.Status.Phase != ""
+		}); err != nil {
+			if !quota.IsExceeded(err) {
+				logger.Error(err, "Failed to check the cluster quota")
+				return ctrl.Result{}, err
+			}
+			logger.Info("Cluster quota exceeded, waiting for resources to be released", "reason", err.Error())
+			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+				Type:    ReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  "QuotaExceeded",
+				Message: err.Error(),
+			})
+			return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+		}
+
+		// Without an image to prepare, the disk only has to exist before the VM is
+		// created, which is checked in place of the resize.
+		if unmanaged {
+			logger.Info("Using an unmanaged disk", "diskPath", finalImagePath)
+			machine.Status.Phase = phaseResize
+			machine.Status.TaskID = 0
+			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+		}
+
+		// Images are not written onto a disk in error; the FreeboxCluster reports
+		// when it recovers.
+		degraded, err := r.storageDegraded(ctx, &machine)
+		if err != nil {
+			logger.Error(err, "Failed to check the Freebox storage health")
+			return ctrl.Result{}, err
+		}
+		if degraded {
+			logger.Info("Freebox storage is degraded, waiting before writing the disk image")
+			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+				Type:    ReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  "StorageDegraded",
+				Message: "The Freebox internal disk reports errors; see the StorageDegraded condition of the FreeboxCluster",
+			})
+			return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+		}
+
+		// A file left at the disk path, e.g. by a failed machine of the same name, is
+		// handled explicitly rather than through the overwrite mode of the Freebox tasks.
+		exists, err := r.diskExists(ctx, finalImagePath)
+		if err != nil {
+			logger.Error(err, "Failed to look for an existing disk")
+			return ctrl.Result{}, err
+		}
+		if exists {
+			switch machine.Spec.ExistingDiskPolicy {
+			case infrastructurev1alpha1.ExistingDiskAdopt:
+				logger.Info("Adopting the existing disk", "diskPath", finalImagePath)
+				machine.Status.Phase = phaseResize
+				machine.Status.TaskID = 0
+				return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+			case infrastructurev1alpha1.ExistingDiskOverwrite:
+				files := []string{finalImagePath, finalImagePath + ".efivars", vmMetadataPath(finalImagePath)}
+				rmTask, err := r.freeboxClient(ctx).RemoveFiles(ctx, files)
+				if err != nil {
+					logger.Error(err, "Failed to remove the existing disk", "files", files)
+					return ctrl.Result{}, err
+				}
+				logger.Info("Scheduled removal of the existing disk", "taskID", rmTask.ID, "files", files)
+			default:
+				logger.Info("A disk already exists where the VM disk is prepared, waiting for it to be removed", "diskPath", finalImagePath)
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:   ReadyCondition,
+					Status: metav1.ConditionFalse,
+					Reason: "DiskAlreadyExists",
+					Message: fmt.Sprintf("A file already exists at %s; remove it, or set spec.existingDiskPolicy to Overwrite or Adopt",
+						finalImagePath),
+				})
+				return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+			}
+		}
+
+		// Images already on the Freebox, given as a local path or prefetched by the
+		// FreeboxCluster, are extracted or copied without being downloaded.
+		cachePath := localPath
+		if !isLocal {
+			cachePath, err = r.prefetchedImagePath(ctx, &machine, imageURL)
+			if err != nil {
+				logger.Error(err, "Failed to look up prefetched images")
+				return ctrl.Result{}, err
+			}
+		}
+		if cachePath != "" {
+			logger.Info("Preparing disk from an image already on the Freebox", "path", cachePath)
+			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+				Type:    ReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  "Provisioning",
+				Message: "Preparing disk image from " + cachePath,
+			})
+			machine.Status.ImageCachePath = cachePath
+			machine.Status.TaskID = 0
+			if diskimage.IsCompressed(imageName) {
+				machine.Status.Phase = phaseExtract
+			} else {
+				machine.Status.Phase = phaseCopy
+			}
+			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+		}
+
+		// The file name given by the server wins over the one of the URL. It is
+		// resolved before the download starts, and the paths derived from it are
+		// recomputed on the next reconcile when it changes.
+		fileName := diskimage.FileName(imageURL)
+		if r.ImageProbeClient != nil {
+			probed, err := r.probeImageURL(ctx, imageURL)
+			if err != nil {
+				logger.Info("Image URL is not available, waiting", "url", imageURL, "reason", err.Error())
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ReadyCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  "ImageURLUnavailable",
+					Message: err.Error(),
+				})
+				return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
+			}
+			if probed.size > 0 {
+				machine.Status.ImageSizeBytes = probed.size
+			}
+			if probed.fileName != "" {
+				fileName = probed.fileName
+			}
+		}
+		if fileName != imageName {
+			logger.Info("Resolved image file name", "url", imageURL, "fileName", fileName)
+			machine.Status.ImageFileName = fileName
+			if fileName == diskimage.FileName(imageURL) {
+				machine.Status.ImageFileName = ""
+			}
+			machine.Status.DiskPath = ""
+			return ctrl.Result{Requeue: true}, nil
+		}
+
+		logger.Info("Starting image download", "url", imageURL, "dest", r.downloadDir(ctx))
+
+		unlock, err := r.lockTaskStart(ctx, &machine, phase, taskID)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if unlock == nil {
+			return ctrl.Result{Requeue: true}, nil
+		}
+		defer unlock()
 
 		// Check for an existing download task to avoid duplicates (e.g. after a
 		// controller restart that occurred between AddDownloadTask and the
 		// subsequent Status().Update call).
 		var newTaskID int64
-		existingTasks, err := r.FreeboxClient.ListDownloadTasks(ctx)
+		existingTasks, err := r.freeboxClient(ctx).ListDownloadTasks(ctx)
 		if err != nil {
 			logger.Error(err, "Failed to list download tasks")
 			return ctrl.Result{}, err
 		}
 		for _, t := range existingTasks {
-			if t.Name == imageName && t.Status != freeboxTypes.DownloadTaskStatusError {
+			// Tasks downloading to another directory may belong to another provider instance.
+			if t.Name == imageName && freebox.SamePath(t.DownloadDirectory, r.downloadDir(ctx)) &&
+				t.Status != freeboxTypes.DownloadTaskStatusError {
 				logger.Info("Reusing existing download task", "taskID", t.ID, "status", t.Status)
 				newTaskID = t.ID
 				break
 			}
 		}
 
+		if newTaskID == 0 && r.MaxConcurrentDownloads > 0 {
+			downloads, err := r.activeDownloads(ctx)
+			if err != nil {
+				logger.Error(err, "Failed to count active downloads")
+				return ctrl.Result{}, err
+			}
+			if downloads >= r.MaxConcurrentDownloads {
+				logger.Info("Too many concurrent downloads, waiting for a download slot", "active", downloads, "max", r.MaxConcurrentDownloads)
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ReadyCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  "WaitingForDownloadSlot",
+					Message: fmt.Sprintf("Waiting for one of the %d image downloads in progress to complete", downloads),
+				})
+				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+		}
+
 		if newTaskID == 0 {
 			reqDownload := freeboxTypes.DownloadRequest{
 				DownloadURLs:      []string{imageURL},
-				DownloadDirectory: r.FreeboxDownloadDir,
+				DownloadDirectory: r.downloadDir(ctx),
 				Filename:          imageName,
 			}
-			newTaskID, err = r.FreeboxClient.AddDownloadTask(ctx, reqDownload)
+			newTaskID, err = r.freeboxClient(ctx).AddDownloadTask(ctx, reqDownload)
 			if err != nil {
 				logger.Error(err, "Failed to create download task")
 				return ctrl.Result{}, err
@@ -333,7 +848,7 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		})
 		machine.Status.Phase = phaseDownload
 		machine.Status.TaskID = newTaskID
-		if err := r.Status().Update(ctx, &machine); err != nil {
+		if err := r.recordTask(ctx, original, &machine); err != nil {
 			if !errors.IsConflict(err) {
 				logger.Error(err, "Failed to update status after starting download")
 				return ctrl.Result{}, err
@@ -347,7 +862,7 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// 2. Wait for download
 	// -----------------------
 	if phase == phaseDownload {
-		downloadTask, err := r.FreeboxClient.GetDownloadTask(ctx, taskID)
+		downloadTask, err := r.freeboxClient(ctx).GetDownloadTask(ctx, taskID)
 		if err != nil {
 			logger.Error(err, "Failed to get download task status")
 			return ctrl.Result{}, err
@@ -360,44 +875,61 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			// Remove the task from the Freebox downloader UI now that the file
 			// has been downloaded. The file itself will be cleaned up after the
 			// copy/extract step completes.
-			if err := r.FreeboxClient.DeleteDownloadTask(ctx, taskID); err != nil {
+			if err := r.freeboxClient(ctx).DeleteDownloadTask(ctx, taskID); err != nil {
 				logger.Error(err, "Failed to delete download task (non-fatal)", "taskID", taskID)
 			}
 
-			if isCompressedFile(imageName) {
+			switch {
+			case diskimage.IsCompressed(imageName):
 				// Extract from download dir to VM storage
 				machine.Status.Phase = phaseExtract
 				machine.Status.TaskID = 0
-			} else {
+			case sameFreeboxVolume(downloadPath, vmStorageDir):
+				// Moving within a volume is instant, unlike copying a multi-GB image,
+				// so move the download straight to its VM-named path.
+				logger.Info("Download and VM storage share a volume, moving instead of copying", "from", downloadPath, "to", finalImagePath)
+				machine.Status.Phase = phaseRename
+				machine.Status.TaskID = 0
+				machine.Status.RenameSrc = downloadPath
+				machine.Status.RenameDst = finalImagePath
+			default:
 				// Copy from download dir to VM storage
 				machine.Status.Phase = phaseCopy
 				machine.Status.TaskID = 0
 			}
-			if err := r.Status().Update(ctx, &machine); err != nil {
-				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status after download completed")
+			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+
+		case freeboxTypes.DownloadTaskStatusError:
+			// Resume the task rather than failing: the Freebox keeps the partial
+			// data, so a flaky WAN link does not restart a multi-GB fetch from zero.
+			if isRetriableDownloadError(string(downloadTask.Error)) && machine.Status.DownloadRetries < maxDownloadRetries {
+				machine.Status.DownloadRetries++
+				logger.Info("Download failed, resuming it", "taskID", taskID, "error", downloadTask.Error, "attempt", machine.Status.DownloadRetries)
+				if err := r.freeboxClient(ctx).UpdateDownloadTask(ctx, taskID, freeboxTypes.DownloadTaskUpdate{Status: freeboxTypes.DownloadTaskStatusRetry}); err != nil {
+					logger.Error(err, "Failed to resume download task", "taskID", taskID)
 					return ctrl.Result{}, err
 				}
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:   ReadyCondition,
+					Status: metav1.ConditionFalse,
+					Reason: "Provisioning",
+					Message: fmt.Sprintf("Resuming image download after error %q (attempt %d/%d)",
+						downloadTask.Error, machine.Status.DownloadRetries, maxDownloadRetries),
+				})
+				return ctrl.Result{RequeueAfter: time.Duration(machine.Status.DownloadRetries) * 30 * time.Second}, nil
 			}
-			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 
-		case freeboxTypes.DownloadTaskStatusError:
-			logger.Error(fmt.Errorf("download failed"), "Download failed")
+			logger.Error(fmt.Errorf("download failed"), "Download failed", "error", downloadTask.Error)
 			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
 				Type:    ReadyCondition,
 				Status:  metav1.ConditionFalse,
-				Reason:  "ProvisioningFailed",
-				Message: "Image download failed",
+				Reason:  reasonProvisioningFailed,
+				Message: fmt.Sprintf("Image download failed: %s", downloadTask.Error),
 			})
-			if err := r.Status().Update(ctx, &machine); err != nil {
-				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status after download failure")
-					return ctrl.Result{}, err
-				}
-			}
 			return ctrl.Result{}, fmt.Errorf("download failed")
 
 		default:
+			r.reportImageProgress(&machine, imageName, downloadRemaining(downloadTask))
 			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 		}
 	}
@@ -407,20 +939,45 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// -----------------------
 	if phase == phaseExtract {
 		if taskID == 0 {
+			unlock, err := r.lockTaskStart(ctx, &machine, phase, taskID)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if unlock == nil {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			defer unlock()
+
+			if err := r.ensureVMStorageDir(ctx, vmStorageDir); err != nil {
+				logger.Error(err, "Failed to create VM storage directory")
+				return ctrl.Result{}, err
+			}
+
+			// Archives are extracted to a directory of their own, where the disk
+			// image is looked for among the other files once the task is done.
+			extractDst := vmStorageDir
+			if diskimage.IsArchive(imageName) {
+				extractDst = archiveExtractDir(finalImagePath)
+				if err := r.createArchiveExtractDir(ctx, extractDst); err != nil {
+					logger.Error(err, "Failed to create extraction directory")
+					return ctrl.Result{}, err
+				}
+			}
 			fsPayload := freeboxTypes.ExtractFilePayload{
-				Src: freeboxTypes.Base64Path(downloadPath),
-				Dst: freeboxTypes.Base64Path(r.VMStoragePath),
+				Src: freebox.Base64Path(downloadPath),
+				Dst: freebox.Base64Path(extractDst),
 			}
 
-			fsTask, err := r.FreeboxClient.ExtractFile(ctx, fsPayload)
+			fsTaskID, err := r.startFileSystemTask(ctx, string(freeboxTypes.FileTaskTypeExtract), downloadPath,
+				func() (freeboxTypes.FileSystemTask, error) { return r.freeboxClient(ctx).ExtractFile(ctx, fsPayload) })
 			if err != nil {
 				logger.Error(err, "Failed to start extraction")
 				return ctrl.Result{}, err
 			}
 
-			logger.Info("Extraction started", "taskID", fsTask.ID)
-			machine.Status.TaskID = fsTask.ID
-			if err := r.Status().Update(ctx, &machine); err != nil {
+			logger.Info("Extraction started", "taskID", fsTaskID)
+			machine.Status.TaskID = fsTaskID
+			if err := r.recordTask(ctx, original, &machine); err != nil {
 				if !errors.IsConflict(err) {
 					logger.Error(err, "Failed to update status after starting extraction")
 					return ctrl.Result{}, err
@@ -429,7 +986,7 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 		}
 
-		fsTask, err := r.FreeboxClient.GetFileSystemTask(ctx, taskID)
+		fsTask, err := r.freeboxClient(ctx).GetFileSystemTask(ctx, taskID)
 		if err != nil {
 			logger.Error(err, "Failed to get extraction task status")
 			return ctrl.Result{}, err
@@ -439,59 +996,90 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		case taskStateDone:
 			logger.Info("Extraction completed", "taskID", taskID)
 
+			// After extraction, the disk image has the underlying name (without the
+			// compression suffix), or is looked for among the files of an archive. It
+			// is checked before going further, so that an unexpected archive layout is
+			// reported here rather than as an opaque resize failure.
+			extractDir, candidates := vmStorageDir, []string{diskimage.StripCompressionSuffix(imageName)}
+			if diskimage.IsArchive(imageName) {
+				extractDir = archiveExtractDir(finalImagePath)
+				candidates = diskimage.ArchiveDiskCandidates(machine.Spec.ImageArchiveMember, imageName)
+			}
+			extractedPath, err := r.locateExtractedDisk(ctx, extractDir, candidates)
+			if err != nil {
+				if !isExtractedDiskNotFound(err) {
+					logger.Error(err, "Failed to look for the extracted disk image")
+					return ctrl.Result{}, err
+				}
+				if indexingPending(fsTask.DoneTimestamp) {
+					logger.Info("Extracted disk image is not visible yet, waiting for it to be indexed", "dir", extractDir)
+					setWaitingForIndexing(&machine, extractDir)
+					return ctrl.Result{Requeue: true}, nil
+				}
+				logger.Error(err, "Extraction produced no usable disk image")
+				message := fmt.Sprintf("Extracting %s produced no usable disk image: %v", imageName, err)
+				if diskimage.IsArchive(imageName) && machine.Spec.ImageArchiveMember == "" {
+					message += "; set spec.imageArchiveMember to the path of the disk image inside the archive"
+				}
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ReadyCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  "ExtractedDiskNotFound",
+					Message: message,
+				})
+				return ctrl.Result{}, err
+			}
+			// Compressed images do not tell their uncompressed size, so a truncated
+			// extraction is only caught by the hash of spec.diskImageSHA256, but an
+			// extraction onto a full disk that wrote nothing is caught here.
+			if err := r.verifyExtractedDisk(ctx, extractedPath); err != nil {
+				logger.Error(err, "Extracted disk image is not usable")
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ReadyCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  reasonProvisioningFailed,
+					Message: fmt.Sprintf("Image extraction verification failed: %v", err),
+				})
+				return ctrl.Result{}, err
+			}
+
 			// Remove the compressed archive from the downloads directory now that
-			// it has been successfully extracted to VM storage.
-			if rmTask, err := r.FreeboxClient.RemoveFiles(ctx, []string{downloadPath}); err != nil {
+			// it has been successfully extracted to VM storage. Images that were
+			// already on the Freebox are kept.
+			if machine.Status.ImageCachePath != "" {
+				logger.Info("Keeping source image", "path", downloadPath)
+			} else if rmTask, err := r.freeboxClient(ctx).RemoveFiles(ctx, []string{downloadPath}); err != nil {
 				logger.Error(err, "Failed to remove downloaded archive (non-fatal)", "path", downloadPath)
 			} else {
 				logger.Info("Scheduled removal of downloaded archive", "taskID", rmTask.ID, "path", downloadPath)
 			}
 
-			// After extraction, file has the underlying name (without compression suffix)
-			// Need to rename to VM-named file
-			extractedPath := path.Join(r.VMStoragePath, stripCompressionSuffix(imageName))
+			// The extracted file is then renamed after the VM
 			if extractedPath != finalImagePath {
 				logger.Info("Starting rename after extraction", "from", extractedPath, "to", finalImagePath)
 				machine.Status.Phase = phaseRename
 				machine.Status.TaskID = 0
 				machine.Status.RenameSrc = extractedPath
 				machine.Status.RenameDst = finalImagePath
-				if err := r.Status().Update(ctx, &machine); err != nil {
-					if !errors.IsConflict(err) {
-						logger.Error(err, "Failed to update status before rename")
-						return ctrl.Result{}, err
-					}
-				}
 				return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 			}
 
-			machine.Status.Phase = phaseResize
+			machine.Status.Phase = imagePreparedPhase(&machine)
 			machine.Status.TaskID = 0
-			if err := r.Status().Update(ctx, &machine); err != nil {
-				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status before resize")
-					return ctrl.Result{}, err
-				}
-			}
 			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 		case taskStateError:
 			logger.Error(fmt.Errorf("extraction failed"), "Extraction failed")
 			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
 				Type:    ReadyCondition,
 				Status:  metav1.ConditionFalse,
-				Reason:  "ProvisioningFailed",
+				Reason:  reasonProvisioningFailed,
 				Message: "Image extraction failed",
 			})
-			if err := r.Status().Update(ctx, &machine); err != nil {
-				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status after extraction failure")
-					return ctrl.Result{}, err
-				}
-			}
 			return ctrl.Result{}, fmt.Errorf("extraction failed")
 		default:
 			// Still in progress
 			logger.Info("Extraction in progress", "taskID", taskID, "state", fsTask.State)
+			r.reportImageProgress(&machine, imageName, fileTaskRemaining(fsTask))
 		}
 
 		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
@@ -502,18 +1090,35 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// -----------------------
 	if phase == phaseCopy {
 		if taskID == 0 {
+			unlock, err := r.lockTaskStart(ctx, &machine, phase, taskID)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if unlock == nil {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			defer unlock()
+
+			if err := r.ensureVMStorageDir(ctx, vmStorageDir); err != nil {
+				logger.Error(err, "Failed to create VM storage directory")
+				return ctrl.Result{}, err
+			}
+
 			// Copy file from download dir to VM storage directory
 			// Note: CopyFiles can only specify directory destination, not filename
 			// We'll copy to VM storage dir, keeping the original in downloads
-			fsTask, err := r.FreeboxClient.CopyFiles(ctx, []string{downloadPath}, r.VMStoragePath, freeboxTypes.FileCopyModeOverwrite)
+			fsTaskID, err := r.startFileSystemTask(ctx, string(freeboxTypes.FileTaskTypeCopy), downloadPath,
+				func() (freeboxTypes.FileSystemTask, error) {
+					return r.freeboxClient(ctx).CopyFiles(ctx, []string{downloadPath}, vmStorageDir, freeboxTypes.FileCopyModeOverwrite)
+				})
 			if err != nil {
 				logger.Error(err, "Failed to start copy to VM storage")
 				return ctrl.Result{}, err
 			}
 
-			logger.Info("Copy started", "taskID", fsTask.ID, "from", downloadPath, "to", r.VMStoragePath)
-			machine.Status.TaskID = fsTask.ID
-			if err := r.Status().Update(ctx, &machine); err != nil {
+			logger.Info("Copy started", "taskID", fsTaskID, "from", downloadPath, "to", vmStorageDir)
+			machine.Status.TaskID = fsTaskID
+			if err := r.recordTask(ctx, original, &machine); err != nil {
 				if !errors.IsConflict(err) {
 					logger.Error(err, "Failed to update status after starting copy")
 					return ctrl.Result{}, err
@@ -522,7 +1127,7 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 		}
 
-		fsTask, err := r.FreeboxClient.GetFileSystemTask(ctx, taskID)
+		fsTask, err := r.freeboxClient(ctx).GetFileSystemTask(ctx, taskID)
 		if err != nil {
 			logger.Error(err, "Failed to get copy task status")
 			return ctrl.Result{}, err
@@ -532,9 +1137,37 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		case taskStateDone:
 			logger.Info("Copy completed", "taskID", taskID)
 
+			// A copy onto a nearly full disk can complete truncated, which only
+			// shows up later as an unbootable VM, so check it before going on.
+			copiedPath := path.Join(vmStorageDir, imageName)
+			if indexingPending(fsTask.DoneTimestamp) {
+				waiting, err := r.waitForIndexing(ctx, &machine, copiedPath)
+				if err != nil {
+					logger.Error(err, "Failed to look for the copied image")
+					return ctrl.Result{}, err
+				}
+				if waiting {
+					return ctrl.Result{Requeue: true}, nil
+				}
+			}
+			if err := r.verifyCopy(ctx, downloadPath, copiedPath); err != nil {
+				logger.Error(err, "Copied image does not match the download, copying again")
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ReadyCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  reasonProvisioningFailed,
+					Message: fmt.Sprintf("Image copy verification failed: %v", err),
+				})
+				machine.Status.TaskID = 0
+				return ctrl.Result{}, err
+			}
+
 			// Remove the source file from the downloads directory now that it
-			// has been successfully copied to VM storage.
-			if rmTask, err := r.FreeboxClient.RemoveFiles(ctx, []string{downloadPath}); err != nil {
+			// has been successfully copied to VM storage. Images that were
+			// already on the Freebox are kept.
+			if machine.Status.ImageCachePath != "" {
+				logger.Info("Keeping source image", "path", downloadPath)
+			} else if rmTask, err := r.freeboxClient(ctx).RemoveFiles(ctx, []string{downloadPath}); err != nil {
 				logger.Error(err, "Failed to remove downloaded file (non-fatal)", "path", downloadPath)
 			} else {
 				logger.Info("Scheduled removal of downloaded file", "taskID", rmTask.ID, "path", downloadPath)
@@ -542,31 +1175,18 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 			// After copy completes, we need to rename from source filename to VM name
 			// The copied file has the source image name, we need to rename it to VM name
-			copiedPath := path.Join(r.VMStoragePath, imageName)
 			if copiedPath != finalImagePath {
 				// Need to rename the copied file to the VM-named path
 				machine.Status.Phase = phaseRename
 				machine.Status.TaskID = 0
 				machine.Status.RenameSrc = copiedPath
 				machine.Status.RenameDst = finalImagePath
-				if err := r.Status().Update(ctx, &machine); err != nil {
-					if !errors.IsConflict(err) {
-						logger.Error(err, "Failed to update status before rename")
-						return ctrl.Result{}, err
-					}
-				}
 				return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 			}
 
 			// If names already match (shouldn't happen), proceed to resize
-			machine.Status.Phase = phaseResize
+			machine.Status.Phase = imagePreparedPhase(&machine)
 			machine.Status.TaskID = 0
-			if err := r.Status().Update(ctx, &machine); err != nil {
-				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status before resize")
-					return ctrl.Result{}, err
-				}
-			}
 			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 
 		case taskStateError:
@@ -574,104 +1194,236 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
 				Type:    ReadyCondition,
 				Status:  metav1.ConditionFalse,
-				Reason:  "ProvisioningFailed",
+				Reason:  reasonProvisioningFailed,
 				Message: "Image copy failed",
 			})
-			if err := r.Status().Update(ctx, &machine); err != nil {
+			return ctrl.Result{}, fmt.Errorf("copy failed")
+
+		default:
+			logger.Info("Copy in progress", "taskID", taskID, "state", fsTask.State)
+			r.reportImageProgress(&machine, imageName, fileTaskRemaining(fsTask))
+			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+		}
+	}
+
+	// -----------------------
+	// 5. Rename to VM name
+	// -----------------------
+	if phase == phaseRename {
+		srcPath := machine.Status.RenameSrc
+		dstPath := machine.Status.RenameDst
+
+		if taskID == 0 {
+			waiting, err := r.waitForIndexing(ctx, &machine, srcPath)
+			if err != nil {
+				logger.Error(err, "Failed to look for the image to rename")
+				return ctrl.Result{}, err
+			}
+			if waiting {
+				return ctrl.Result{Requeue: true}, nil
+			}
+
+			unlock, err := r.lockTaskStart(ctx, &machine, phase, taskID)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if unlock == nil {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			defer unlock()
+
+			if err := r.ensureVMStorageDir(ctx, vmStorageDir); err != nil {
+				logger.Error(err, "Failed to create VM storage directory")
+				return ctrl.Result{}, err
+			}
+
+			// Start the rename operation using MoveFiles
+			mvTaskID, err := r.startFileSystemTask(ctx, string(freeboxTypes.FileTaskTypeMove), srcPath,
+				func() (freeboxTypes.FileSystemTask, error) {
+					return r.freeboxClient(ctx).MoveFiles(ctx, []string{srcPath}, dstPath, freeboxTypes.FileMoveModeOverwrite)
+				})
+			if err != nil {
+				logger.Error(err, "Failed to start rename", "from", srcPath, "to", dstPath)
+				return ctrl.Result{}, err
+			}
+
+			logger.Info("Rename task started", "taskID", mvTaskID, "from", srcPath, "to", dstPath)
+			machine.Status.TaskID = mvTaskID
+			if err := r.recordTask(ctx, original, &machine); err != nil {
 				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status after copy failure")
+					logger.Error(err, "Failed to update status after starting rename")
 					return ctrl.Result{}, err
 				}
 			}
-			return ctrl.Result{}, fmt.Errorf("copy failed")
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+
+		fsTask, err := r.freeboxClient(ctx).GetFileSystemTask(ctx, taskID)
+		if err != nil {
+			logger.Error(err, "Failed to get rename task status")
+			return ctrl.Result{}, err
+		}
 
+		switch fsTask.State {
+		case taskStateDone:
+			logger.Info("Rename completed", "taskID", taskID)
+			// Remove what else the archive the disk image came from contained.
+			if extractDir := archiveExtractDir(dstPath); strings.HasPrefix(srcPath, extractDir+"/") {
+				if rmTask, err := r.freeboxClient(ctx).RemoveFiles(ctx, []string{extractDir}); err != nil {
+					logger.Error(err, "Failed to remove extraction directory (non-fatal)", "path", extractDir)
+				} else {
+					logger.Info("Scheduled removal of extraction directory", "taskID", rmTask.ID, "path", extractDir)
+				}
+			}
+			machine.Status.Phase = imagePreparedPhase(&machine)
+			machine.Status.TaskID = 0
+			machine.Status.RenameSrc = ""
+			machine.Status.RenameDst = ""
+			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
+		case taskStateError:
+			logger.Error(fmt.Errorf("rename failed"), "Rename failed", "error", fsTask.Error)
+			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+				Type:    ReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  reasonProvisioningFailed,
+				Message: fmt.Sprintf("Image rename failed: %s", fsTask.Error),
+			})
+			return ctrl.Result{}, fmt.Errorf("rename failed: %s", fsTask.Error)
 		default:
-			logger.Info("Copy in progress", "taskID", taskID, "state", fsTask.State)
-			return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
+			// Still in progress
+			logger.Info("Rename in progress", "taskID", taskID, "state", fsTask.State)
+			r.reportImageProgress(&machine, imageName, fileTaskRemaining(fsTask))
 		}
+
+		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
 	// -----------------------
-	// 5. Rename to VM name
+	// 6. Verify the disk image (opt-in)
 	// -----------------------
-	if phase == phaseRename {
-		srcPath := machine.Status.RenameSrc
-		dstPath := machine.Status.RenameDst
-
+	if phase == phaseVerify {
 		if taskID == 0 {
-			// Start the rename operation using MoveFiles
-			mvTask, err := r.FreeboxClient.MoveFiles(ctx, []string{srcPath}, dstPath, freeboxTypes.FileMoveModeOverwrite)
+			waiting, err := r.waitForIndexing(ctx, &machine, finalImagePath)
+			if err != nil {
+				logger.Error(err, "Failed to look for the disk to verify")
+				return ctrl.Result{}, err
+			}
+			if waiting {
+				return ctrl.Result{Requeue: true}, nil
+			}
+
+			unlock, err := r.lockTaskStart(ctx, &machine, phase, taskID)
 			if err != nil {
-				logger.Error(err, "Failed to start rename", "from", srcPath, "to", dstPath)
+				return ctrl.Result{}, err
+			}
+			if unlock == nil {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			defer unlock()
+
+			hashPayload := freeboxTypes.HashPayload{
+				HashType: freeboxTypes.HashTypeSHA256,
+				Path:     freebox.Base64Path(finalImagePath),
+			}
+			fsTaskID, err := r.startFileSystemTask(ctx, string(freeboxTypes.FileTaskTypeHash), finalImagePath,
+				func() (freeboxTypes.FileSystemTask, error) {
+					return r.freeboxClient(ctx).AddHashFileTask(ctx, hashPayload)
+				})
+			if err != nil {
+				logger.Error(err, "Failed to start hashing the disk image")
 				return ctrl.Result{}, err
 			}
 
-			logger.Info("Rename task started", "taskID", mvTask.ID, "from", srcPath, "to", dstPath)
-			machine.Status.TaskID = mvTask.ID
-			if err := r.Status().Update(ctx, &machine); err != nil {
+			logger.Info("Hashing of the disk image started", "taskID", fsTaskID)
+			machine.Status.TaskID = fsTaskID
+			if err := r.recordTask(ctx, original, &machine); err != nil {
 				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status after starting rename")
+					logger.Error(err, "Failed to update status after starting to hash the disk image")
 					return ctrl.Result{}, err
 				}
 			}
 			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 		}
 
-		fsTask, err := r.FreeboxClient.GetFileSystemTask(ctx, taskID)
+		fsTask, err := r.freeboxClient(ctx).GetFileSystemTask(ctx, taskID)
 		if err != nil {
-			logger.Error(err, "Failed to get rename task status")
+			logger.Error(err, "Failed to get hash task status")
 			return ctrl.Result{}, err
 		}
 
 		switch fsTask.State {
 		case taskStateDone:
-			logger.Info("Rename completed", "taskID", taskID)
+			// The result is kept with the task, so a corrected spec.diskImageSHA256 is
+			// compared again without hashing the disk again.
+			if err := r.verifyDiskHash(ctx, taskID, machine.Spec.DiskImageSHA256); err != nil {
+				logger.Error(err, "Disk image does not match its hash")
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ReadyCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  "DiskImageHashMismatch",
+					Message: fmt.Sprintf("Image verification failed: %v", err),
+				})
+				return ctrl.Result{}, err
+			}
+			logger.Info("Disk image matches its hash", "taskID", taskID)
 			machine.Status.Phase = phaseResize
 			machine.Status.TaskID = 0
-			machine.Status.RenameSrc = ""
-			machine.Status.RenameDst = ""
-			if err := r.Status().Update(ctx, &machine); err != nil {
-				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status after rename")
-					return ctrl.Result{}, err
-				}
-			}
 			return ctrl.Result{RequeueAfter: 1 * time.Second}, nil
 		case taskStateError:
-			logger.Error(fmt.Errorf("rename failed"), "Rename failed", "error", fsTask.Error)
+			logger.Error(fmt.Errorf("hashing failed"), "Hashing of the disk image failed", "error", fsTask.Error)
 			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
 				Type:    ReadyCondition,
 				Status:  metav1.ConditionFalse,
-				Reason:  "ProvisioningFailed",
-				Message: fmt.Sprintf("Image rename failed: %s", fsTask.Error),
+				Reason:  reasonProvisioningFailed,
+				Message: fmt.Sprintf("Image hashing failed: %s", fsTask.Error),
 			})
-			if err := r.Status().Update(ctx, &machine); err != nil {
-				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status after rename failure")
-					return ctrl.Result{}, err
-				}
-			}
-			return ctrl.Result{}, fmt.Errorf("rename failed: %s", fsTask.Error)
+			machine.Status.TaskID = 0
+			return ctrl.Result{}, fmt.Errorf("hashing failed: %s", fsTask.Error)
 		default:
-			// Still in progress
-			logger.Info("Rename in progress", "taskID", taskID, "state", fsTask.State)
+			logger.Info("Hashing of the disk image in progress", "taskID", taskID, "state", fsTask.State)
+			r.reportImageProgress(&machine, imageName, fileTaskRemaining(fsTask))
 		}
 
 		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
 	// -----------------------
-	// 6. Resize disk
+	// 7. Resize disk
 	// -----------------------
 	if phase == phaseResize {
-		if taskID == 0 {
+		// The disk is in VM storage from now on, whether it was prepared or adopted.
+		if !unmanaged {
+			addManagedFiles(&machine, finalImagePath)
+		}
+		if imageOnly && meta.IsStatusConditionTrue(machine.Status.Conditions, ConditionImageReady) {
+			return ctrl.Result{}, nil
+		}
+		if taskID == 0 && !unmanaged {
+			waiting, err := r.waitForIndexing(ctx, &machine, finalImagePath)
+			if err != nil {
+				logger.Error(err, "Failed to look for the disk to resize")
+				return ctrl.Result{}, err
+			}
+			if waiting {
+				return ctrl.Result{Requeue: true}, nil
+			}
+
+			unlock, err := r.lockTaskStart(ctx, &machine, phase, taskID)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if unlock == nil {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			defer unlock()
+
 			resizePayload := freeboxTypes.VirtualDisksResizePayload{
-				DiskPath:    freeboxTypes.Base64Path(finalImagePath),
+				DiskPath:    freebox.Base64Path(finalImagePath),
 				NewSize:     machine.Spec.DiskSizeBytes,
 				ShrinkAllow: false,
 			}
 
-			newTaskID, err := r.FreeboxClient.ResizeVirtualDisk(ctx, resizePayload)
+			newTaskID, err := r.freeboxClient(ctx).ResizeVirtualDisk(ctx, resizePayload)
 			if err != nil {
 				logger.Error(err, "Failed to start disk resize")
 				return ctrl.Result{}, err
@@ -679,7 +1431,7 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 			logger.Info("Resize task started", "taskID", newTaskID)
 			machine.Status.TaskID = newTaskID
-			if err := r.Status().Update(ctx, &machine); err != nil {
+			if err := r.recordTask(ctx, original, &machine); err != nil {
 				if !errors.IsConflict(err) {
 					logger.Error(err, "Failed to update status after starting resize")
 					return ctrl.Result{}, err
@@ -688,10 +1440,24 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 		}
 
-		resizeTask, err := r.FreeboxClient.GetVirtualDiskTask(ctx, taskID)
-		if err != nil {
-			logger.Error(err, "Failed to get resize task status")
-			return ctrl.Result{}, err
+		resizeTask := freeboxTypes.VirtualMachineDiskTask{Done: true}
+		if unmanaged {
+			if _, err := r.freeboxClient(ctx).GetVirtualDiskInfo(ctx, finalImagePath); err != nil {
+				logger.Info("Unmanaged disk is not available yet, waiting", "diskPath", finalImagePath, "error", err)
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ConditionImageReady,
+					Status:  metav1.ConditionFalse,
+					Reason:  "DiskNotFound",
+					Message: fmt.Sprintf("Waiting for the unmanaged disk %s: %v", finalImagePath, err),
+				})
+				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+		} else {
+			resizeTask, err = r.freeboxClient(ctx).GetVirtualDiskTask(ctx, taskID)
+			if err != nil {
+				logger.Error(err, "Failed to get resize task status")
+				return ctrl.Result{}, err
+			}
 		}
 
 		if resizeTask.Done {
@@ -700,27 +1466,44 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
 					Type:    ReadyCondition,
 					Status:  metav1.ConditionFalse,
-					Reason:  "ProvisioningFailed",
+					Reason:  reasonProvisioningFailed,
 					Message: "Disk resize failed",
 				})
-				if err := r.Status().Update(ctx, &machine); err != nil {
-					if !errors.IsConflict(err) {
-						logger.Error(err, "Failed to update status after resize failure")
-						return ctrl.Result{}, err
-					}
-				}
 				return ctrl.Result{}, fmt.Errorf("resize failed")
 			}
 
-			logger.Info("Disk resize completed", "taskID", taskID)
-
 			// Image is now ready (downloaded, extracted/copied, renamed, and resized).
-			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
-				Type:    ConditionImageReady,
-				Status:  metav1.ConditionTrue,
-				Reason:  "ImageReady",
-				Message: "Image downloaded, extracted, renamed, and resized",
-			})
+			if unmanaged {
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ConditionImageReady,
+					Status:  metav1.ConditionTrue,
+					Reason:  "UnmanagedDisk",
+					Message: "Using the unmanaged disk " + finalImagePath,
+				})
+			} else {
+				logger.Info("Disk resize completed", "taskID", taskID)
+				if !meta.IsStatusConditionTrue(machine.Status.Conditions, ConditionImageReady) {
+					r.observeImagePhase(&machine, phaseResize)
+				}
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ConditionImageReady,
+					Status:  metav1.ConditionTrue,
+					Reason:  "ImageReady",
+					Message: "Image downloaded, extracted, renamed, and resized",
+				})
+			}
+
+			if imageOnly {
+				logger.Info("Image prepared, not creating a VM for an image-only machine", "diskPath", finalImagePath)
+				machine.Status.TaskID = 0
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ReadyCondition,
+					Status:  metav1.ConditionTrue,
+					Reason:  reasonImagePrepared,
+					Message: fmt.Sprintf("Disk %s prepared; no VM is created as spec.imageManagement is ImageOnly", finalImagePath),
+				})
+				return ctrl.Result{}, nil
+			}
 
 			// If VM was already created in a previous reconcile (e.g. Status().Update
 			// failed after CreateVirtualMachine), transition to vmcreated phase to
@@ -729,23 +1512,9 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				logger.Info("VM already created, transitioning to vmcreated phase", "vmID", *machine.Status.VMID)
 				machine.Status.Phase = phaseVMCreated
 				machine.Status.TaskID = 0
-				if err := r.Status().Update(ctx, &machine); err != nil {
-					if !errors.IsConflict(err) {
-						logger.Error(err, "Failed to update status")
-						return ctrl.Result{}, err
-					}
-				}
 				return ctrl.Result{Requeue: true}, nil
 			}
 
-			if err := r.Status().Update(ctx, &machine); err != nil {
-				if !errors.IsConflict(err) {
-					logger.Error(err, "Failed to update status after resize")
-					return ctrl.Result{}, err
-				}
-				logger.Info("Status update conflict, another reconcile already updated - continuing")
-			}
-
 			// -----------------------
 			// 7. Create VM
 			// -----------------------
@@ -765,6 +1534,14 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			// Check if bootstrap data is ready
 			if ownerMachine.Spec.Bootstrap.DataSecretName == nil {
 				logger.Info("Bootstrap data secret not ready yet, waiting", "machineName", ownerMachine.Name)
+				if !meta.IsStatusConditionFalse(machine.Status.Conditions, ConditionBootstrapDataReady) {
+					meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+						Type:    ConditionBootstrapDataReady,
+						Status:  metav1.ConditionFalse,
+						Reason:  "WaitingForBootstrapData",
+						Message: fmt.Sprintf("Waiting for the bootstrap provider to set dataSecretName on Machine %s", ownerMachine.Name),
+					})
+				}
 				return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 			}
 
@@ -776,7 +1553,7 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 				Namespace: ownerMachine.Namespace,
 				Name:      *ownerMachine.Spec.Bootstrap.DataSecretName,
 			}
-			if err := r.Get(ctx, secretKey, bootstrapSecret); err != nil {
+			if err := r.secretReader().Get(ctx, secretKey, bootstrapSecret); err != nil {
 				logger.Error(err, "Failed to get bootstrap data secret", "secretName", secretKey.Name)
 				return ctrl.Result{}, err
 			}
@@ -791,28 +1568,93 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 			logger.Info("Successfully retrieved bootstrap data", "secretName", secretKey.Name, "dataSize", len(bootstrapData))
 
-			// Determine disk type based on the final image file extension
-			diskType := freeboxTypes.RawDisk // Default to raw
-			finalExt := strings.ToLower(path.Ext(finalImagePath))
-			if finalExt == ".qcow2" {
-				diskType = freeboxTypes.QCow2Disk
-				logger.Info("Using qcow2 disk type", "imagePath", finalImagePath)
-			} else {
-				logger.Info("Using raw disk type", "imagePath", finalImagePath, "extension", finalExt)
+			// Do not boot a node whose join token expires before it can join.
+			tokenValid, err := r.reconcileBootstrapToken(ctx, &machine, ownerMachine)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !tokenValid {
+				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+
+			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+				Type:    ConditionBootstrapDataReady,
+				Status:  metav1.ConditionTrue,
+				Reason:  "BootstrapDataAvailable",
+				Message: fmt.Sprintf("Bootstrap data read from secret %s", secretKey.Name),
+			})
+
+			// Both supported formats are delivered through the NoCloud config drive the
+			// Freebox attaches when cloud-init is enabled: cloud-init reads it as user data
+			// and Talos nocloud images read it as their machine configuration. The Freebox
+			// VM API offers no way to pass kernel arguments, so other formats are rejected.
+			bootstrapFormat := machine.Spec.BootstrapFormat
+			if bootstrapFormat == "" {
+				bootstrapFormat, err = detectBootstrapFormat(bootstrapData)
+				if err != nil {
+					logger.Error(err, "Unsupported bootstrap data", "secretName", secretKey.Name)
+					meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+						Type:    ReadyCondition,
+						Status:  metav1.ConditionFalse,
+						Reason:  "UnsupportedBootstrapFormat",
+						Message: err.Error(),
+					})
+					return ctrl.Result{}, err
+				}
+			}
+
+			logger.Info("Using bootstrap format", "format", bootstrapFormat)
+
+			// Host settings shared by every machine of a template are merged into the
+			// cloud-config, so that they do not have to be repeated in each KubeadmConfig.
+			// Shared folders are mounted first, so that the commands can use them.
+			folderFiles, folderCommands, err := r.sharedFolderCloudConfig(ctx, &machine)
+			if err != nil {
+				logger.Error(err, "Failed to prepare the shared folders")
+				meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+					Type:    ReadyCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  "SharedFolderCredentialsUnavailable",
+					Message: err.Error(),
+				})
+				return ctrl.Result{}, err
+			}
+			files := append(slices.Clone(machine.Spec.Files), folderFiles...)
+			commands := append(folderCommands, machine.Spec.Commands...)
+			userData := bootstrapData
+			if len(files) > 0 || len(commands) > 0 {
+				if bootstrapFormat == infrastructurev1alpha1.BootstrapFormatCloudConfig {
+					userData, err = mergeCloudConfig(bootstrapData, files, commands)
+				} else {
+					err = fmt.Errorf("spec.files, spec.commands and spec.sharedFolders cannot be added to %s bootstrap data", bootstrapFormat)
+				}
+				if err != nil {
+					logger.Error(err, "Failed to add files and commands to the bootstrap data", "secretName", secretKey.Name)
+					meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+						Type:    ReadyCondition,
+						Status:  metav1.ConditionFalse,
+						Reason:  "UnsupportedBootstrapFormat",
+						Message: err.Error(),
+					})
+					return ctrl.Result{}, err
+				}
 			}
 
+			diskType := r.detectDiskType(ctx, finalImagePath)
+			logger.Info("Using disk type", "diskType", diskType, "imagePath", finalImagePath)
+
 			// Check if VM already exists with same name AND disk path, to guard
 			// against duplicate creation if Status().Update failed after a previous
 			// CreateVirtualMachine call.
 			// If the list call fails, skip dedup and proceed to create.
 			var vm freeboxTypes.VirtualMachine
 			var foundVM *freeboxTypes.VirtualMachine
-			existingVMs, listErr := r.FreeboxClient.ListVirtualMachines(ctx)
+			existingVMs, listErr := r.freeboxClient(ctx).ListVirtualMachines(ctx)
 			if listErr != nil {
 				logger.Info("Could not list virtual machines before creation, skipping dedup check", "error", listErr)
 			} else {
 				for i := range existingVMs {
-					if existingVMs[i].Name == machine.Name && existingVMs[i].DiskPath == freeboxTypes.Base64Path(finalImagePath) {
+					if existingVMs[i].Name == vmName && freebox.SamePath(existingVMs[i].DiskPath, finalImagePath) {
 						foundVM = &existingVMs[i]
 						break
 					}
@@ -820,22 +1662,50 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 			}
 
 			if foundVM != nil {
+				// Only adopt the VM when its metadata, if any, attributes it to this machine.
+				metadata, err := r.readVMMetadata(ctx, finalImagePath)
+				if err != nil {
+					logger.Info("Could not read VM metadata, reusing the VM anyway", "vmID", foundVM.ID, "error", err)
+				} else if !metadata.ownedBy(&machine, r.InstanceID) {
+					owner := metadata.Namespace + "/" + metadata.FreeboxMachine
+					if metadata.Instance != "" {
+						owner += " of provider instance " + metadata.Instance
+					}
+					return ctrl.Result{}, fmt.Errorf("VM %d named %s belongs to FreeboxMachine %s according to its metadata",
+						foundVM.ID, foundVM.Name, owner)
+				}
+				owners, err := freeboxMachinesForVM(ctx, r.Client, foundVM.ID)
+				if err != nil {
+					return ctrl.Result{}, fmt.Errorf("looking up FreeboxMachines of VM %d: %w", foundVM.ID, err)
+				}
+				for _, owner := range owners {
+					if owner.UID != machine.UID {
+						return ctrl.Result{}, fmt.Errorf("VM %d named %s is already used by FreeboxMachine %s/%s",
+							foundVM.ID, foundVM.Name, owner.Namespace, owner.Name)
+					}
+				}
 				logger.Info("VM already exists, reusing", "vmID", foundVM.ID, "name", foundVM.Name)
 				vm = *foundVM
 			} else {
 				vmPayload := freeboxTypes.VirtualMachinePayload{
-					Name:              machine.Name,
-					DiskPath:          freeboxTypes.Base64Path(finalImagePath),
+					Name:              vmName,
+					DiskPath:          freebox.Base64Path(finalImagePath),
 					DiskType:          diskType,
 					Memory:            machine.Spec.MemoryMB, // in MB
 					VCPUs:             machine.Spec.VCPUs,
 					OS:                freeboxTypes.UnknownOS,
 					EnableCloudInit:   true,
-					CloudInitUserData: string(bootstrapData),
-					CloudHostName:     machine.Name,
+					CloudInitUserData: string(userData),
+					CloudHostName:     vmName,
+				}
+				if machine.Spec.CloudInit == infrastructurev1alpha1.CloudInitSkip {
+					logger.Info("Skipping cloud-init injection, the disk is expected to be provisioned already")
+					vmPayload.EnableCloudInit = false
+					vmPayload.CloudInitUserData = ""
+					vmPayload.CloudHostName = ""
 				}
 
-				createdVM, createErr := r.FreeboxClient.CreateVirtualMachine(ctx, vmPayload)
+				createdVM, createErr := r.freeboxClient(ctx).CreateVirtualMachine(ctx, vmPayload)
 				if createErr != nil {
 					logger.Error(createErr, "Failed to create virtual machine")
 					return ctrl.Result{}, createErr
@@ -843,114 +1713,86 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 				vm = createdVM
 				logger.Info("VM created successfully", "vmID", vm.ID, "name", vm.Name)
+				r.ownerEvent(ctx, &machine, corev1.EventTypeNormal, "VMCreated", "Create", "Created VM %d named %s", vm.ID, vm.Name)
 			}
 
 			// Store VM ID and disk path in status immediately after creation
 			// This ensures we can clean up the VM even if subsequent operations fail
 			machine.Status.VMID = &vm.ID
 			machine.Status.DiskPath = finalImagePath
+			machine.Status.Resources = r.vmResources(ctx, vm)
+			machine.Status.Template = templateRevision(&machine)
+			// The Freebox stores the EFI variables of the VM next to its disk.
+			addManagedFiles(&machine, finalImagePath+".efivars", vmMetadataPath(finalImagePath))
+			if err := r.writeVMMetadata(ctx, &machine, vm); err != nil {
+				logger.Error(err, "Failed to write VM metadata (non-fatal)", "vmID", vm.ID)
+			}
 
 			// Start the VM only if it is not already running
-			if vm.Status != "running" {
-				if err := r.FreeboxClient.StartVirtualMachine(ctx, vm.ID); err != nil {
+			switch {
+			case vm.Status == "running":
+				logger.Info("VM already running, skipping start", "vmID", vm.ID)
+			case !ptr.Deref(machine.Spec.AutoStart, true):
+				logger.Info("AutoStart disabled, waiting for the VM to be started from the Freebox", "vmID", vm.ID)
+			default:
+				if err := r.preemptForCapacity(ctx, &machine); err != nil {
+					logger.Error(err, "Failed to make room for the VM")
+					return ctrl.Result{}, err
+				}
+				if err := r.freeboxClient(ctx).StartVirtualMachine(ctx, vm.ID); err != nil {
 					logger.Error(err, "Failed to start virtual machine")
 					return ctrl.Result{}, err
 				}
 				logger.Info("VM started", "vmID", vm.ID)
-			} else {
-				logger.Info("VM already running, skipping start", "vmID", vm.ID)
 			}
 
 			// Transition to vmcreated phase for IP polling
 			machine.Status.Phase = phaseVMCreated
 			machine.Status.TaskID = 0
-			if err := r.Status().Update(ctx, &machine); err != nil {
-				logger.Error(err, "Failed to update FreeboxMachine status after VM start")
-				return ctrl.Result{}, err
-			}
 
 			return ctrl.Result{Requeue: true}, nil
 		}
 
 		// Resize still in progress
+		r.reportImageProgress(&machine, imageName, 0)
 		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
 	// -----------------------
-	// 7b. Poll LAN browser for VM IP address
+	// 7b. Wait for the VM IP address
 	// -----------------------
 	if phase == phaseVMCreated {
 		if machine.Status.VMID == nil {
 			return ctrl.Result{}, fmt.Errorf("phase is vmcreated but VMID is nil")
 		}
 
-		// Get VM details to retrieve MAC address
-		vm, err := r.FreeboxClient.GetVirtualMachine(ctx, *machine.Status.VMID)
-		if err != nil {
-			logger.Error(err, "Failed to get VM details")
-			return ctrl.Result{}, err
+		// The addresses are recorded by the address discovery, which queries the LAN
+		// browser once for all the machines. Recording them triggers a reconcile.
+		if len(machine.Status.Addresses) == 0 {
+			logger.Info("Waiting for the address of the VM to be discovered on the LAN", "vmID", *machine.Status.VMID)
+			return ctrl.Result{RequeueAfter: r.addressRequeueInterval()}, nil
 		}
 
-		// Query the LAN browser for hosts on the "pub" interface
-		lanHosts, err := r.FreeboxClient.GetLanInterface(ctx, "pub")
+		vm, err := r.freeboxClient(ctx).GetVirtualMachine(ctx, *machine.Status.VMID)
 		if err != nil {
-			logger.Error(err, "Failed to query LAN browser")
-			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
-		}
-
-		logger.Info("Searching for VM in LAN browser", "vmID", *machine.Status.VMID, "vmMac", vm.Mac, "totalHosts", len(lanHosts))
-
-		// Find the host with matching MAC address (case-insensitive comparison)
-		vmMacLower := strings.ToLower(vm.Mac)
-		idx := slices.IndexFunc(lanHosts, func(h freeboxTypes.LanInterfaceHost) bool {
-			return strings.ToLower(h.L2Ident.ID) == vmMacLower
-		})
-		if idx < 0 {
-			logger.Info("VM not yet visible in LAN browser, will retry", "vmID", *machine.Status.VMID, "mac", vm.Mac)
-			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
-		}
-
-		host := lanHosts[idx]
-		// Extract IPv4 addresses from L3Connectivities
-		var addresses []clusterv1.MachineAddress
-		for _, l3 := range host.L3Connectivities {
-			if l3.Type == "ipv4" && l3.Address != "" {
-				addresses = append(addresses, clusterv1.MachineAddress{
-					Type:    clusterv1.MachineInternalIP,
-					Address: l3.Address,
-				})
-			}
-		}
-		if len(addresses) == 0 {
-			logger.Info("VM found in LAN browser but no IP address yet, will retry", "vmID", *machine.Status.VMID, "mac", vm.Mac)
-			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+			logger.Error(err, "Failed to get VM details")
+			return ctrl.Result{}, err
 		}
 
-		logger.Info("Found IP address for VM", "vmID", *machine.Status.VMID, "mac", vm.Mac, "addresses", addresses)
-
-		providerID := fmt.Sprintf("freebox://%d", *machine.Status.VMID)
+		providerID := providerid.New(*machine.Status.VMID)
 
 		// Phase A: immediately mark infrastructure as provisioned so that CAPI
 		// propagates addresses → Machine.status.addresses and unblocks bootstrap
 		// providers (e.g. Talos) that need addresses before the workload cluster
 		// is reachable.
-		machine.Status.Addresses = addresses
+		machine.Status.VMState = vm.Status
 		machine.Status.Phase = phaseDone
-		machine.Status.Initialization.Provisioned = ptr.To(true)
-		meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
-			Type:    ReadyCondition,
-			Status:  metav1.ConditionTrue,
-			Reason:  "InfrastructureReady",
-			Message: "Freebox machine infrastructure is fully provisioned",
-		})
-		if err := r.Status().Update(ctx, &machine); err != nil {
-			logger.Error(err, "Failed to update FreeboxMachine status with addresses")
-			return ctrl.Result{}, err
-		}
+		markProvisioned(&machine.Status.Initialization.Provisioned, &machine.Status.Conditions,
+			"Freebox machine infrastructure is fully provisioned")
 
 		// Set providerID on the spec (required by CAPI contract alongside provisioned=true)
 		machine.Spec.ProviderID = providerID
-		if err := r.Update(ctx, &machine); err != nil {
+		if err := r.updateMachine(ctx, original, &machine); err != nil {
 			logger.Error(err, "Failed to update FreeboxMachine spec with providerID")
 			return ctrl.Result{}, err
 		}
@@ -966,7 +1808,19 @@ func (r *FreeboxMachineReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	// 8. Patch workload cluster node providerID (best-effort, until it succeeds)
 	// -----------------------
 	if phase == phaseDone {
-		return r.reconcileNodeProviderID(ctx, &machine)
+		if r.VMStateResyncPeriod <= 0 || machine.Status.VMID == nil {
+			return r.reconcileNodeProviderID(ctx, &machine)
+		}
+		if vm, err := r.freeboxClient(ctx).GetVirtualMachine(ctx, *machine.Status.VMID); err != nil {
+			logger.Info("Could not refresh the VM state", "vmID", *machine.Status.VMID, "error", err)
+		} else {
+			machine.Status.VMState = vm.Status
+		}
+		result, err := r.reconcileNodeProviderID(ctx, &machine)
+		if err == nil && result.IsZero() {
+			result.RequeueAfter = r.VMStateResyncPeriod
+		}
+		return result, err
 	}
 
 	return ctrl.Result{}, nil
@@ -983,7 +1837,7 @@ func (r *FreeboxMachineReconciler) reconcileNodeProviderID(ctx context.Context,
 		return ctrl.Result{}, fmt.Errorf("reconcileNodeProviderID called with nil VMID")
 	}
 
-	providerID := fmt.Sprintf("freebox://%d", *machine.Status.VMID)
+	providerID := providerid.New(*machine.Status.VMID)
 
 	// Get the owning CAPI Cluster so we can get a remote client
 	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machine.ObjectMeta)
@@ -993,42 +1847,42 @@ func (r *FreeboxMachineReconciler) reconcileNodeProviderID(ctx context.Context,
 	}
 	if cluster == nil {
 		logger.Info("FreeboxMachine has no owning Cluster yet, waiting")
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.nodeRequeueInterval()}, nil
 	}
 
 	// Get a remote client to the workload cluster
 	remoteClient, err := r.ClusterCache.GetClient(ctx, client.ObjectKeyFromObject(cluster))
 	if err != nil {
 		logger.Info("Cannot connect to workload cluster yet, will retry", "error", err)
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.nodeRequeueInterval()}, nil
 	}
 
-	// Find the Node in the workload cluster whose InternalIP matches the machine's IP.
+	// Find the Node in the workload cluster whose InternalIP matches one of the machine's IPs.
 	// We cannot rely on node name because Talos generates a random hostname (e.g.
 	// "talos-xxx-yyy") instead of using CloudHostName from cloud-init.
 	// kubeadm-based clusters would match by name, but we support Talos first-class.
-	machineIP := ""
+	// Any address family is matched, as IPv6-only nodes only report IPv6 InternalIPs.
+	machineIPs := map[string]struct{}{}
 	for _, addr := range machine.Status.Addresses {
 		if addr.Type == clusterv1.MachineInternalIP {
-			machineIP = addr.Address
-			break
+			machineIPs[addr.Address] = struct{}{}
 		}
 	}
-	if machineIP == "" {
+	if len(machineIPs) == 0 {
 		logger.Info("FreeboxMachine has no InternalIP address, will retry")
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.nodeRequeueInterval()}, nil
 	}
 
 	nodeList := &corev1.NodeList{}
 	if err := remoteClient.List(ctx, nodeList); err != nil {
 		logger.Info("Failed to list nodes in workload cluster, will retry", "error", err)
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		return ctrl.Result{RequeueAfter: r.nodeRequeueInterval()}, nil
 	}
 
 	var targetNode *corev1.Node
 	for i := range nodeList.Items {
 		for _, addr := range nodeList.Items[i].Status.Addresses {
-			if addr.Type == corev1.NodeInternalIP && addr.Address == machineIP {
+			if _, ok := machineIPs[addr.Address]; ok && addr.Type == corev1.NodeInternalIP {
 				targetNode = &nodeList.Items[i]
 				break
 			}
@@ -1038,8 +1892,8 @@ func (r *FreeboxMachineReconciler) reconcileNodeProviderID(ctx context.Context,
 		}
 	}
 	if targetNode == nil {
-		logger.Info("Node not yet registered in workload cluster, will retry", "machineIP", machineIP)
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		logger.Info("Node not yet registered in workload cluster, will retry", "addresses", machine.Status.Addresses)
+		return ctrl.Result{RequeueAfter: r.nodeRequeueInterval()}, nil
 	}
 
 	// Already patched — nothing to do
@@ -1067,45 +1921,254 @@ func (r *FreeboxMachineReconciler) reconcileNodeProviderID(ctx context.Context,
 	return ctrl.Result{}, nil
 }
 
-// Helper to check if a file is a known compressed format
-func isCompressedFile(name string) bool {
-	ext := strings.ToLower(path.Ext(name))
-	switch ext {
-	case ".gz", ".xz", ".bz2", ".zip", ".tar":
-		return true
-	default:
-		return false
+const (
+	// defaultAddressRequeueInterval is the AddressRequeueInterval when unset.
+	defaultAddressRequeueInterval = 30 * time.Second
+	// defaultNodeRequeueInterval is the NodeRequeueInterval when unset.
+	defaultNodeRequeueInterval = 10 * time.Second
+)
+
+// addressRequeueInterval returns how often a machine waiting for its addresses is reconciled.
+func (r *FreeboxMachineReconciler) addressRequeueInterval() time.Duration {
+	if r.AddressRequeueInterval > 0 {
+		return r.AddressRequeueInterval
+	}
+	return defaultAddressRequeueInterval
+}
+
+// nodeRequeueInterval returns how often a machine waiting for its Node is reconciled.
+func (r *FreeboxMachineReconciler) nodeRequeueInterval() time.Duration {
+	if r.NodeRequeueInterval > 0 {
+		return r.NodeRequeueInterval
+	}
+	return defaultNodeRequeueInterval
+}
+
+// statusUpdateTimeout bounds status updates that must outlive the reconcile context.
+const statusUpdateTimeout = 10 * time.Second
+
+// recordTask persists the status right after a Freebox task was started, rather
+// than when the reconcile ends, so that the ID of the task is not lost if the
+// controller stops in between, which would start it again on the next run. The
+// task is also added to the task history of the machine.
+func (r *FreeboxMachineReconciler) recordTask(ctx context.Context, original, machine *infrastructurev1alpha1.FreeboxMachine) error {
+	recordTaskStart(machine)
+	return r.patchStatus(ctx, original, machine)
+}
+
+// patchStatus writes the changes made to the status of machine since original
+// was read, and records them in original. The update is detached from the
+// cancellation of ctx so that a controller shutdown cannot lose them.
+func (r *FreeboxMachineReconciler) patchStatus(ctx context.Context, original, machine *infrastructurev1alpha1.FreeboxMachine) error {
+	if equality.Semantic.DeepEqual(original.Status, machine.Status) {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statusUpdateTimeout)
+	defer cancel()
+
+	// Only the status is compared, so that the patch is not bound to the
+	// resource version of the machine nor to its other fields, except when the
+	// image pipeline moves: a reconcile working on a stale copy of the machine
+	// must not move it back to a phase it already went through.
+	base := machine.DeepCopy()
+	base.Status = original.Status
+	var opts []client.MergeFromOption
+	if machine.Status.Phase != original.Status.Phase || machine.Status.TaskID != original.Status.TaskID {
+		opts = append(opts, client.MergeFromWithOptimisticLock{})
+	}
+	if err := r.Status().Patch(ctx, machine, client.MergeFromWithOptions(base, opts...)); err != nil {
+		// The machine is gone once its finalizer is removed.
+		return client.IgnoreNotFound(err)
+	}
+	original.Status = *machine.Status.DeepCopy()
+	return nil
+}
+
+// updateMachine writes the changes made to the metadata and spec of machine since
+// original was read, and records them in original, keeping the status changes not
+// written yet, which the response would otherwise overwrite. Like the status, the
+// patch is not bound to the resource version, so that a stale cached machine does
+// not make it fail with a conflict, except when the finalizers change: a merge
+// patch replaces the whole list, which must not drop a finalizer added meanwhile.
+func (r *FreeboxMachineReconciler) updateMachine(ctx context.Context, original, machine *infrastructurev1alpha1.FreeboxMachine) error {
+	base := original.DeepCopy()
+	base.Status = machine.Status
+	var opts []client.MergeFromOption
+	if !slices.Equal(original.Finalizers, machine.Finalizers) {
+		opts = append(opts, client.MergeFromWithOptimisticLock{})
+	}
+	status := machine.Status.DeepCopy()
+	if err := r.Patch(ctx, machine, client.MergeFromWithOptions(base, opts...)); err != nil {
+		return err
+	}
+	machine.Status = *status
+	original.ObjectMeta = *machine.ObjectMeta.DeepCopy()
+	original.Spec = *machine.Spec.DeepCopy()
+	return nil
+}
+
+// startFileSystemTask returns the ID of an unfinished Freebox file system task of
+// the given type reading src, e.g. one whose ID was not recorded before the
+// controller restarted, and only calls start when there is none.
+func (r *FreeboxMachineReconciler) startFileSystemTask(ctx context.Context, taskType, src string, start func() (freeboxTypes.FileSystemTask, error)) (int64, error) {
+	tasks, err := r.freeboxClient(ctx).ListFileSystemTasks(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("listing file system tasks: %w", err)
+	}
+	for _, t := range tasks {
+		if string(t.Type) != taskType {
+			continue
+		}
+		switch t.State {
+		case freeboxTypes.FileTaskStateQueued, freeboxTypes.FileTaskStateRunning, freeboxTypes.FileTaskStatePaused:
+		default:
+			continue
+		}
+		if slices.ContainsFunc(t.Sources, func(s string) bool { return freebox.DecodeTaskPath(s) == src }) {
+			logf.FromContext(ctx).Info("Resuming unfinished file system task", "taskID", t.ID, "type", taskType, "src", src)
+			return t.ID, nil
+		}
+	}
+
+	task, err := start()
+	if err != nil {
+		return 0, err
+	}
+	return task.ID, nil
+}
+
+// detectBootstrapFormat infers the format of the given bootstrap data.
+// It returns an error for formats the Freebox VM stack cannot deliver, such as Ignition.
+func detectBootstrapFormat(data []byte) (infrastructurev1alpha1.BootstrapFormat, error) {
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("#cloud-config")),
+		bytes.HasPrefix(trimmed, []byte("#!")),
+		bytes.HasPrefix(trimmed, []byte("Content-Type: multipart/")):
+		return infrastructurev1alpha1.BootstrapFormatCloudConfig, nil
+	case bytes.HasPrefix(trimmed, []byte("{")):
+		return "", fmt.Errorf("bootstrap data looks like Ignition JSON, which cannot be delivered to Freebox VMs")
+	}
+
+	// Talos machine configurations are (possibly multi-document) YAML whose
+	// first document holds the "machine" and "cluster" sections.
+	firstDocument, _, _ := bytes.Cut(trimmed, []byte("\n---"))
+	var document map[string]interface{}
+	if err := yaml.Unmarshal(firstDocument, &document); err == nil {
+		if _, ok := document["machine"]; ok {
+			return infrastructurev1alpha1.BootstrapFormatTalos, nil
+		}
+	}
+
+	return infrastructurev1alpha1.BootstrapFormatCloudConfig, nil
+}
+
+// activeDownloads returns the number of distinct download tasks of FreeboxMachines
+// in the download phase. Machines using the same image share a task.
+func (r *FreeboxMachineReconciler) activeDownloads(ctx context.Context) (int, error) {
+	var machines infrastructurev1alpha1.FreeboxMachineList
+	if err := r.List(ctx, &machines); err != nil {
+		return 0, err
+	}
+	tasks := map[int64]struct{}{}
+	for _, m := range machines.Items {
+		if m.Status.Phase == phaseDownload {
+			tasks[m.Status.TaskID] = struct{}{}
+		}
 	}
+	return len(tasks), nil
 }
 
-// stripCompressionSuffix removes the trailing compression extension
-// e.g. "nocloud.raw.xz" -> "nocloud.raw"
-func stripCompressionSuffix(name string) string {
-	lower := strings.ToLower(name)
-	compressedExts := []string{".xz", ".gz", ".bz2", ".zip", ".tar"}
-	for _, ext := range compressedExts {
-		if strings.HasSuffix(lower, ext) {
-			return name[:len(name)-len(ext)]
+// detectDiskType returns the format of the disk image at imagePath. The Freebox
+// inspects the image content, so that e.g. Ubuntu ".img" cloud images, which are
+// qcow2, are not mistaken for raw disks. When the Freebox cannot tell, the format
+// is guessed from the file extension.
+func (r *FreeboxMachineReconciler) detectDiskType(ctx context.Context, imagePath string) string {
+	info, err := r.freeboxClient(ctx).GetVirtualDiskInfo(ctx, imagePath)
+	if err == nil {
+		switch info.Type {
+		case freeboxTypes.RawDisk, freeboxTypes.QCow2Disk:
+			return info.Type
 		}
+	} else {
+		logf.FromContext(ctx).Info("Could not inspect disk image, guessing its format from its extension", "imagePath", imagePath, "error", err)
 	}
-	// fallback: use path.Ext trimming once
-	if ext := path.Ext(name); ext != "" {
-		return strings.TrimSuffix(name, ext)
+	if strings.ToLower(path.Ext(imagePath)) == ".qcow2" {
+		return freeboxTypes.QCow2Disk
 	}
-	return name
+	return freeboxTypes.RawDisk
+}
+
+// vmResources returns the resources vm was created with, as reported by the Freebox.
+func (r *FreeboxMachineReconciler) vmResources(ctx context.Context, vm freeboxTypes.VirtualMachine) *infrastructurev1alpha1.FreeboxMachineResources {
+	resources := &infrastructurev1alpha1.FreeboxMachineResources{
+		VCPUs:    vm.VCPUs,
+		MemoryMB: vm.Memory,
+		DiskType: vm.DiskType,
+	}
+	info, err := r.freeboxClient(ctx).GetVirtualDiskInfo(ctx, freebox.PlainPath(vm.DiskPath))
+	if err != nil {
+		logf.FromContext(ctx).Info("Could not get VM disk info, not reporting its size", "diskPath", vm.DiskPath, "error", err)
+		return resources
+	}
+	resources.DiskSizeBytes = info.VirtualSize
+	return resources
+}
+
+// verifyCopy checks that the file copied to dst has the size of src.
+func (r *FreeboxMachineReconciler) verifyCopy(ctx context.Context, src, dst string) error {
+	srcInfo, err := r.freeboxClient(ctx).GetFileInfo(ctx, src)
+	if err != nil {
+		return fmt.Errorf("getting info of %s: %w", src, err)
+	}
+	dstInfo, err := r.freeboxClient(ctx).GetFileInfo(ctx, dst)
+	if err != nil {
+		return fmt.Errorf("getting info of %s: %w", dst, err)
+	}
+	if dstInfo.SizeBytes != srcInfo.SizeBytes {
+		return fmt.Errorf("%s is %d bytes but %s is %d bytes", dst, dstInfo.SizeBytes, src, srcInfo.SizeBytes)
+	}
+	return nil
+}
+
+// isRetriableDownloadError reports whether a failed download task may succeed when resumed.
+// Errors caused by the URL or the content itself fail the same way on every attempt.
+func isRetriableDownloadError(code string) bool {
+	switch code {
+	case string(freeboxTypes.DownloadTaskErrorInvalidURL),
+		string(freeboxTypes.DownloadTaskErrorInvalidFile),
+		string(freeboxTypes.DownloadTaskError4XX),
+		string(freeboxTypes.DownloadTaskErrorBadHash):
+		return false
+	}
+	return true
+}
+
+// sameFreeboxVolume reports whether two Freebox paths are on the same volume.
+// Freebox paths start with the name of the volume, e.g. "/Freebox/VMs".
+func sameFreeboxVolume(a, b string) bool {
+	volume := func(p string) string {
+		v, _, _ := strings.Cut(strings.TrimPrefix(path.Clean(p), "/"), "/")
+		return v
+	}
+	return volume(a) == volume(b)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *FreeboxMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "freeboxmachine")
 
+	if err := SetupFreeboxMachineIndexes(ctx, mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+
 	clusterToFreeboxMachines, err := util.ClusterToTypedObjectsMapper(mgr.GetClient(), &infrastructurev1alpha1.FreeboxMachineList{}, mgr.GetScheme())
 	if err != nil {
 		return err
 	}
 
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&infrastructurev1alpha1.FreeboxMachine{}).
+		For(&infrastructurev1alpha1.FreeboxMachine{}, builder.WithPredicates(ignoreLastReconcileUpdates)).
 		Named("freeboxmachine").
 		Watches(
 			&clusterv1.Cluster{},
@@ -1114,3 +2177,35 @@ func (r *FreeboxMachineReconciler) SetupWithManager(ctx context.Context, mgr ctr
 		).
 		Complete(r)
 }
+
+// freeboxClusterOf returns the FreeboxCluster of the Cluster machine belongs to, or
+// nil when the machine has no Cluster or either cannot be found.
+func (r *FreeboxMachineReconciler) freeboxClusterOf(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) (*infrastructurev1alpha1.FreeboxCluster, error) {
+	return freeboxClusterOf(ctx, r.Client, machine)
+}
+
+func freeboxClusterOf(ctx context.Context, r client.Reader, machine *infrastructurev1alpha1.FreeboxMachine) (*infrastructurev1alpha1.FreeboxCluster, error) {
+	clusterName := machine.Labels[clusterv1.ClusterNameLabel]
+	if clusterName == "" {
+		return nil, nil
+	}
+	var cluster clusterv1.Cluster
+	if err := r.Get(ctx, types.NamespacedName{Namespace: machine.Namespace, Name: clusterName}, &cluster); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	ref := cluster.Spec.InfrastructureRef
+	if ref.Kind != "FreeboxCluster" || ref.Name == "" {
+		return nil, nil
+	}
+	var freeboxCluster infrastructurev1alpha1.FreeboxCluster
+	if err := r.Get(ctx, types.NamespacedName{Namespace: machine.Namespace, Name: ref.Name}, &freeboxCluster); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &freeboxCluster, nil
+}
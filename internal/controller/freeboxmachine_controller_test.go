@@ -18,18 +18,25 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"testing"
+	"time"
 
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/freebox/mock"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
@@ -85,6 +92,16 @@ var _ = Describe("FreeboxMachine Controller", func() {
 				NamespacedName: typeNamespacedName,
 			})
 			Expect(err).NotTo(HaveOccurred())
+
+			By("reporting the missing image")
+			Expect(k8sClient.Get(ctx, typeNamespacedName, freeboxmachine)).To(Succeed())
+			ready := meta.FindStatusCondition(freeboxmachine.Status.Conditions, ReadyCondition)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal("ImageURLMissing"))
+
+			By("recording the generation the status was computed from")
+			Expect(freeboxmachine.Status.ObservedGeneration).To(Equal(freeboxmachine.Generation))
+			Expect(ready.ObservedGeneration).To(Equal(freeboxmachine.Generation))
 		})
 	})
 
@@ -179,6 +196,7 @@ var _ = Describe("FreeboxMachine Controller", func() {
 			err = k8sClient.Get(ctx, typeNamespacedName, freeboxMachine)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(freeboxMachine.Status.Phase).To(BeEmpty(), "Phase should be empty when paused")
+			Expect(meta.IsStatusConditionTrue(freeboxMachine.Status.Conditions, ConditionPaused)).To(BeTrue())
 		})
 	})
 
@@ -292,25 +310,134 @@ var _ = Describe("FreeboxMachine Controller", func() {
 	})
 })
 
-func TestStripCompressionSuffix(t *testing.T) {
+func TestDetectBootstrapFormat(t *testing.T) {
 	tests := []struct {
-		input string
-		want  string
+		name    string
+		input   string
+		want    infrastructurev1alpha1.BootstrapFormat
+		wantErr bool
 	}{
-		{"nocloud.raw.xz", "nocloud.raw"},
-		{"image.img.gz", "image.img"},
-		{"archive.tar.bz2", "archive.tar"},
-		{"file.zip", "file"},
-		{"nocloud.tar", "nocloud"},
-		{"plain.raw", "plain"}, // no compression extension — falls back to path.Ext trimming
-		{"noext", "noext"},
-		{"UPPER.XZ", "UPPER"},
-		{"mixed.Gz", "mixed"},
+		{"cloud-config", "#cloud-config\nruncmd: []\n", infrastructurev1alpha1.BootstrapFormatCloudConfig, false},
+		{"shell script", "#!/bin/sh\necho hello\n", infrastructurev1alpha1.BootstrapFormatCloudConfig, false},
+		{"multipart", "Content-Type: multipart/mixed; boundary=\"x\"\n", infrastructurev1alpha1.BootstrapFormatCloudConfig, false},
+		{"talos", "version: v1alpha1\nmachine:\n  type: controlplane\ncluster:\n  clusterName: test\n", infrastructurev1alpha1.BootstrapFormatTalos, false},
+		{"talos multi-document", "version: v1alpha1\nmachine: {}\n---\napiVersion: v1alpha1\nkind: HostnameConfig\n", infrastructurev1alpha1.BootstrapFormatTalos, false},
+		{"ignition", "{\"ignition\":{\"version\":\"3.4.0\"}}", "", true},
+		{"unknown", "hello", infrastructurev1alpha1.BootstrapFormatCloudConfig, false},
 	}
 	for _, tc := range tests {
-		got := stripCompressionSuffix(tc.input)
+		got, err := detectBootstrapFormat([]byte(tc.input))
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: detectBootstrapFormat() error = %v, wantErr %v", tc.name, err, tc.wantErr)
+			continue
+		}
 		if got != tc.want {
-			t.Errorf("stripCompressionSuffix(%q) = %q, want %q", tc.input, got, tc.want)
+			t.Errorf("%s: detectBootstrapFormat() = %q, want %q", tc.name, got, tc.want)
 		}
 	}
 }
+
+func TestMachineAddressesFromLanHost(t *testing.T) {
+	l3 := func(af, addr string) freeboxTypes.LanHostL3Connectivity {
+		return freeboxTypes.LanHostL3Connectivity{Type: af, Address: addr}
+	}
+	tests := []struct {
+		name  string
+		input []freeboxTypes.LanHostL3Connectivity
+		want  []string
+	}{
+		{"ipv4 only", []freeboxTypes.LanHostL3Connectivity{l3("ipv4", "192.168.1.10")}, []string{"192.168.1.10"}},
+		{"ipv6 only", []freeboxTypes.LanHostL3Connectivity{l3("ipv6", "2a01:e0a::10")}, []string{"2a01:e0a::10"}},
+		{"dual-stack keeps ipv4 first", []freeboxTypes.LanHostL3Connectivity{
+			l3("ipv6", "2a01:e0a::10"), l3("ipv4", "192.168.1.10"),
+		}, []string{"192.168.1.10", "2a01:e0a::10"}},
+		{"link-local ipv6 skipped", []freeboxTypes.LanHostL3Connectivity{
+			l3("ipv6", "fe80::1"), l3("ipv4", "192.168.1.10"),
+		}, []string{"192.168.1.10"}},
+		{"empty address skipped", []freeboxTypes.LanHostL3Connectivity{l3("ipv4", "")}, nil},
+	}
+	for _, tc := range tests {
+		got := machineAddressesFromLanHost(freeboxTypes.LanInterfaceHost{L3Connectivities: tc.input})
+		var gotAddresses []string
+		for _, addr := range got {
+			if addr.Type != clusterv1.MachineInternalIP {
+				t.Errorf("%s: address %s has type %s, want %s", tc.name, addr.Address, addr.Type, clusterv1.MachineInternalIP)
+			}
+			gotAddresses = append(gotAddresses, addr.Address)
+		}
+		if !slices.Equal(gotAddresses, tc.want) {
+			t.Errorf("%s: machineAddressesFromLanHost() = %v, want %v", tc.name, gotAddresses, tc.want)
+		}
+	}
+}
+
+func TestSetLastReconcile(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	machine := &infrastructurev1alpha1.FreeboxMachine{}
+	tests := []struct {
+		name    string
+		at      time.Time
+		outcome string
+		phase   string
+		changed bool
+		want    string
+	}{
+		{"first reconcile", now, reconcileSucceeded, "", true, "time=2025-06-01T12:00:00Z outcome=Succeeded"},
+		{"same state right after", now.Add(10 * time.Second), reconcileSucceeded, "", false, "time=2025-06-01T12:00:00Z outcome=Succeeded"},
+		{"phase changed", now.Add(20 * time.Second), reconcileSucceeded, phaseDownload, true, "time=2025-06-01T12:00:20Z outcome=Succeeded phase=" + phaseDownload},
+		{"outcome changed", now.Add(30 * time.Second), reconcileFailed, phaseDownload, true, "time=2025-06-01T12:00:30Z outcome=Failed phase=" + phaseDownload},
+		{"same state refreshed", now.Add(2 * time.Minute), reconcileFailed, phaseDownload, true, "time=2025-06-01T12:02:00Z outcome=Failed phase=" + phaseDownload},
+	}
+	for _, tc := range tests {
+		if changed := setLastReconcile(machine, tc.at, tc.outcome, tc.phase); changed != tc.changed {
+			t.Errorf("%s: setLastReconcile() = %v, want %v", tc.name, changed, tc.changed)
+		}
+		if got := machine.Annotations[LastReconcileAnnotation]; got != tc.want {
+			t.Errorf("%s: annotation = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestIgnoreLastReconcileUpdates(t *testing.T) {
+	old := &infrastructurev1alpha1.FreeboxMachine{}
+	old.ResourceVersion = "1"
+	setLastReconcile(old, time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC), reconcileSucceeded, "")
+
+	recorded := old.DeepCopy()
+	recorded.ResourceVersion = "2"
+	setLastReconcile(recorded, time.Date(2025, 6, 1, 12, 5, 0, 0, time.UTC), reconcileSucceeded, "")
+	if ignoreLastReconcileUpdates.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: recorded}) {
+		t.Errorf("the update of the annotation alone triggers a reconcile")
+	}
+
+	changed := recorded.DeepCopy()
+	changed.Spec.VCPUs = 2
+	if !ignoreLastReconcileUpdates.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: changed}) {
+		t.Errorf("the update of the annotation and the spec does not trigger a reconcile")
+	}
+
+	frozen := old.DeepCopy()
+	frozen.Annotations[FreezeAnnotation] = ""
+	if !ignoreLastReconcileUpdates.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: frozen}) {
+		t.Errorf("the update of another annotation does not trigger a reconcile")
+	}
+}
+
+var _ = Describe("detectDiskType", func() {
+	DescribeTable("detects the disk image format",
+		func(imagePath string, info freeboxTypes.VirtualDiskInfo, infoErr error, want string) {
+			fc := &mock.Client{}
+			fc.GetVirtualDiskInfoReturns(info, infoErr)
+			r := &FreeboxMachineReconciler{FreeboxClient: fc}
+			Expect(r.detectDiskType(context.Background(), imagePath)).To(Equal(want))
+		},
+		Entry("qcow2 content behind an .img extension", "/mnt/VMs/vm.img",
+			freeboxTypes.VirtualDiskInfo{Type: freeboxTypes.QCow2Disk}, nil, freeboxTypes.QCow2Disk),
+		Entry("raw content", "/mnt/VMs/vm.raw",
+			freeboxTypes.VirtualDiskInfo{Type: freeboxTypes.RawDisk}, nil, freeboxTypes.RawDisk),
+		Entry("qcow2 extension when the image cannot be inspected", "/mnt/VMs/vm.qcow2",
+			freeboxTypes.VirtualDiskInfo{}, fmt.Errorf("boom"), freeboxTypes.QCow2Disk),
+		Entry("raw fallback when the image cannot be inspected", "/mnt/VMs/vm.img",
+			freeboxTypes.VirtualDiskInfo{}, fmt.Errorf("boom"), freeboxTypes.RawDisk),
+	)
+})
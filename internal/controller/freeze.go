@@ -0,0 +1,88 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// FreezeAnnotation stops the controller from changing anything on the Freebox for
+// a FreeboxMachine, e.g. while its VM is maintained from Freebox OS. Its value is
+// the RFC 3339 time the freeze ends at; when empty, the freeze lasts until the
+// annotation is removed.
+const FreezeAnnotation = "infrastructure.cluster.x-k8s.io/freeze-until"
+
+// frozenUntil reports whether machine is frozen, and until when. The zero time
+// means until the annotation is removed, which is also the case of values that
+// cannot be parsed, so that a typo does not end the freeze.
+func frozenUntil(machine *infrastructurev1alpha1.FreeboxMachine) (time.Time, bool) {
+	value, ok := machine.Annotations[FreezeAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	if value == "" {
+		return time.Time{}, true
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, true
+	}
+	return until, time.Now().Before(until)
+}
+
+// reconcileFrozenMachine only refreshes the observed state of the VM of a frozen
+// machine, and reports the freeze in the ReconciliationFrozen condition. It
+// requeues when the freeze ends, or at the VM state resync period.
+func (r *FreeboxMachineReconciler) reconcileFrozenMachine(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, until time.Time) ctrl.Result {
+	logger := logf.FromContext(ctx)
+	logger.Info("Reconciliation frozen, not changing anything on the Freebox", "until", until)
+
+	message := "Changes on the Freebox are frozen by the " + FreezeAnnotation + " annotation"
+	if !until.IsZero() {
+		message += " until " + until.Format(time.RFC3339)
+	}
+	meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+		Type:    ConditionReconciliationFrozen,
+		Status:  metav1.ConditionTrue,
+		Reason:  "FreezeWindow",
+		Message: message,
+	})
+
+	if machine.Status.VMID != nil {
+		vm, err := r.freeboxClient(ctx).GetVirtualMachine(ctx, *machine.Status.VMID)
+		if err != nil {
+			logger.Info("Could not refresh the VM state", "vmID", *machine.Status.VMID, "error", err)
+		} else {
+			machine.Status.VMState = vm.Status
+			machine.Status.Resources = r.vmResources(ctx, vm)
+		}
+	}
+
+	result := ctrl.Result{RequeueAfter: r.VMStateResyncPeriod}
+	if remaining := time.Until(until); !until.IsZero() && (result.RequeueAfter <= 0 || remaining < result.RequeueAfter) {
+		result.RequeueAfter = remaining
+	}
+	return result
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+var _ = Describe("frozenUntil", func() {
+	DescribeTable("reads the freeze window of a machine",
+		func(annotations map[string]string, wantFrozen bool) {
+			machine := &infrastructurev1alpha1.FreeboxMachine{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}}
+			_, frozen := frozenUntil(machine)
+			Expect(frozen).To(Equal(wantFrozen))
+		},
+		Entry("no annotation", nil, false),
+		Entry("until the annotation is removed", map[string]string{FreezeAnnotation: ""}, true),
+		Entry("window not over yet", map[string]string{FreezeAnnotation: time.Now().Add(time.Hour).Format(time.RFC3339)}, true),
+		Entry("window over", map[string]string{FreezeAnnotation: time.Now().Add(-time.Hour).Format(time.RFC3339)}, false),
+		Entry("unparseable end", map[string]string{FreezeAnnotation: "tomorrow"}, true),
+	)
+})
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+)
+
+// archiveExtractDir returns the directory an archive is extracted to before its
+// disk image is moved to diskPath.
+func archiveExtractDir(diskPath string) string {
+	return diskPath + ".extract"
+}
+
+// createArchiveExtractDir creates the directory dir an archive is extracted to.
+func (r *FreeboxMachineReconciler) createArchiveExtractDir(ctx context.Context, dir string) error {
+	if _, err := r.freeboxClient(ctx).CreateDirectory(ctx, path.Dir(dir), path.Base(dir)); err != nil && !errors.Is(err, freeboxclient.ErrDestinationConflict) {
+		return fmt.Errorf("creating extraction directory %s: %w", dir, err)
+	}
+	return nil
+}
+
+// errExtractedDiskNotFound is returned when an extraction produced none of the
+// expected disk images.
+var errExtractedDiskNotFound = errors.New("disk image not found")
+
+// isExtractedDiskNotFound reports whether err tells that an extraction produced
+// none of the expected disk images.
+func isExtractedDiskNotFound(err error) bool {
+	return errors.Is(err, errExtractedDiskNotFound)
+}
+
+// locateExtractedDisk returns the path of the first of candidates found in the
+// extraction directory dir.
+func (r *FreeboxMachineReconciler) locateExtractedDisk(ctx context.Context, dir string, candidates []string) (string, error) {
+	for _, candidate := range candidates {
+		diskPath := path.Join(dir, candidate)
+		_, err := r.freeboxClient(ctx).GetFileInfo(ctx, diskPath)
+		switch {
+		case errors.Is(err, freeboxclient.ErrPathNotFound):
+		case err != nil:
+			return "", fmt.Errorf("looking for disk image %s: %w", diskPath, err)
+		default:
+			return diskPath, nil
+		}
+	}
+	return "", fmt.Errorf("%w: none of %s in %s", errExtractedDiskNotFound, strings.Join(candidates, ", "), dir)
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/freebox"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/diskimage"
+)
+
+// cancelImagePipeline cancels the download or file system task of a FreeboxMachine
+// deleted while its image is being prepared, and removes the partial files the task
+// left behind. Tasks shared with another FreeboxMachine preparing the same image are
+// left alone.
+func (r *FreeboxMachineReconciler) cancelImagePipeline(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) error {
+	logger := logf.FromContext(ctx)
+	phase := machine.Status.Phase
+	taskID := machine.Status.TaskID
+
+	switch phase {
+	case phaseDownload, phaseExtract, phaseCopy, phaseRename, phaseVerify:
+	default:
+		return nil
+	}
+
+	shared, err := r.imageTaskShared(ctx, machine)
+	if err != nil {
+		return err
+	}
+	if shared {
+		logger.Info("Image task is shared with another FreeboxMachine, leaving it running", "phase", phase, "taskID", taskID)
+		return nil
+	}
+
+	if phase == phaseDownload {
+		if taskID == 0 {
+			return nil
+		}
+		// Erasing the download task also removes the partially downloaded file.
+		if err := r.freeboxClient(ctx).EraseDownloadTask(ctx, taskID); err != nil && !errors.Is(err, freeboxclient.ErrTaskNotFound) {
+			return fmt.Errorf("erasing download task %d: %w", taskID, err)
+		}
+		logger.Info("Cancelled image download", "taskID", taskID)
+		return nil
+	}
+
+	// The disk at status.diskPath is removed with the VM, so only other files are listed here.
+	var files []string
+	if phase == phaseRename {
+		src := machine.Status.RenameSrc
+		// A disk image taken from an archive goes with the rest of the archive.
+		if extractDir := archiveExtractDir(machine.Status.RenameDst); strings.HasPrefix(src, extractDir+"/") {
+			src = extractDir
+		}
+		for _, f := range []string{src, machine.Status.RenameDst} {
+			if f != "" && f != machine.Status.DiskPath {
+				files = append(files, f)
+			}
+		}
+	}
+
+	if taskID != 0 {
+		task, err := r.freeboxClient(ctx).GetFileSystemTask(ctx, taskID)
+		switch {
+		case errors.Is(err, freeboxclient.ErrTaskNotFound):
+		case err != nil:
+			return fmt.Errorf("getting file system task %d: %w", taskID, err)
+		default:
+			for _, f := range fileSystemTaskFiles(machine, task) {
+				// Images already on the Freebox are not owned by the machine.
+				if f != machine.Status.ImageCachePath {
+					files = append(files, f)
+				}
+			}
+		}
+		if err := r.freeboxClient(ctx).DeleteFileSystemTask(ctx, taskID); err != nil && !errors.Is(err, freeboxclient.ErrTaskNotFound) {
+			return fmt.Errorf("deleting file system task %d: %w", taskID, err)
+		}
+		logger.Info("Cancelled image file system task", "phase", phase, "taskID", taskID)
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+	rmTask, err := r.freeboxClient(ctx).RemoveFiles(ctx, files)
+	if err != nil {
+		return fmt.Errorf("removing partial image files %v: %w", files, err)
+	}
+	logger.Info("Scheduled removal of partial image files", "taskID", rmTask.ID, "files", files)
+	return nil
+}
+
+// imageTaskShared reports whether another FreeboxMachine is waiting on the same
+// image task, or renaming the same file, as machine.
+func (r *FreeboxMachineReconciler) imageTaskShared(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) (bool, error) {
+	var machines infrastructurev1alpha1.FreeboxMachineList
+	if err := r.List(ctx, &machines); err != nil {
+		return false, err
+	}
+	for _, m := range machines.Items {
+		if m.Namespace == machine.Namespace && m.Name == machine.Name {
+			continue
+		}
+		if m.Status.Phase != machine.Status.Phase {
+			continue
+		}
+		if machine.Status.TaskID != 0 && m.Status.TaskID == machine.Status.TaskID {
+			return true, nil
+		}
+		if machine.Status.Phase == phaseRename && m.Status.RenameSrc == machine.Status.RenameSrc {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fileSystemTaskFiles returns the downloaded image an extract or copy task of
+// machine reads from, and the file it writes to VM storage. Directories are never
+// taken from the task: an archive is removed with the extraction directory of the
+// disk of machine, and nothing is removed when the task reports no destination.
+func fileSystemTaskFiles(machine *infrastructurev1alpha1.FreeboxMachine, task freeboxTypes.FileSystemTask) []string {
+	if len(task.Sources) == 0 {
+		return nil
+	}
+	src := freebox.DecodeTaskPath(task.Sources[0])
+	imageName := path.Base(src)
+	// Machines in a storage failure domain prepare their image out of VM storage.
+	dstDir := freebox.DecodeTaskPath(task.Destination)
+	if dstDir == "" {
+		return nil
+	}
+
+	switch task.Type {
+	case freeboxTypes.FileTaskTypeExtract:
+		// Archives are extracted to a directory of their own.
+		if diskimage.IsArchive(imageName) {
+			if machine.Status.DiskPath == "" {
+				return []string{src}
+			}
+			return []string{src, archiveExtractDir(machine.Status.DiskPath)}
+		}
+		return []string{src, path.Join(dstDir, diskimage.StripCompressionSuffix(imageName))}
+	case freeboxTypes.FileTaskTypeCopy:
+		return []string{src, path.Join(dstDir, imageName)}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/diskimage"
+)
+
+// imagePipelinePhases are the phases preparing the disk image of a machine.
+var imagePipelinePhases = []string{phaseDownload, phaseExtract, phaseCopy, phaseRename, phaseVerify, phaseResize}
+
+// imagePhaseActions describe what each phase of the image pipeline does.
+var imagePhaseActions = map[string]string{
+	phaseDownload: "Downloading",
+	phaseExtract:  "Extracting",
+	phaseCopy:     "Copying",
+	phaseRename:   "Renaming",
+	phaseVerify:   "Verifying",
+	phaseResize:   "Resizing",
+}
+
+// phaseDurationWeight is the weight of the latest duration of a phase in its
+// average, so that the estimates follow a Freebox getting slower or faster.
+const phaseDurationWeight = 0.3
+
+// phaseDurations keeps the average duration of the phases of the image pipeline,
+// to estimate how long the phases a machine has not reached yet will take.
+type phaseDurations struct {
+	mu       sync.Mutex
+	averages map[string]time.Duration
+}
+
+// observe records that phase took d.
+func (p *phaseDurations) observe(phase string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.averages == nil {
+		p.averages = map[string]time.Duration{}
+	}
+	average, ok := p.averages[phase]
+	if !ok {
+		p.averages[phase] = d
+		return
+	}
+	p.averages[phase] = average + time.Duration(phaseDurationWeight*float64(d-average))
+}
+
+// average returns the average duration of phase, and false when it was never timed.
+func (p *phaseDurations) average(phase string) (time.Duration, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	average, ok := p.averages[phase]
+	return average, ok
+}
+
+// observeImagePhase records how long machine spent in phase, when it was timed.
+func (r *FreeboxMachineReconciler) observeImagePhase(machine *infrastructurev1alpha1.FreeboxMachine, phase string) {
+	if machine.Status.PhaseTransitionTime == nil {
+		return
+	}
+	d := time.Since(machine.Status.PhaseTransitionTime.Time)
+	imagePhaseDuration.WithLabelValues(phase).Observe(d.Seconds())
+	r.phaseDurations.observe(phase, d)
+}
+
+// trackImagePhase times the phase machine leaves when it moved on from
+// previousPhase, and drops the ETA of images that are no longer being prepared.
+// Resizes are timed when they complete, as the machine then stays in the resize
+// phase until its VM is created.
+func (r *FreeboxMachineReconciler) trackImagePhase(machine *infrastructurev1alpha1.FreeboxMachine, previousPhase string) {
+	if machine.Status.Phase != previousPhase {
+		if previousPhase != phaseResize && slices.Contains(imagePipelinePhases, previousPhase) {
+			r.observeImagePhase(machine, previousPhase)
+		}
+		machine.Status.PhaseTransitionTime = &metav1.Time{Time: time.Now()}
+		if machine.Status.Phase == "" {
+			machine.Status.PhaseTransitionTime = nil
+		}
+	}
+	if !slices.Contains(imagePipelinePhases, machine.Status.Phase) || meta.IsStatusConditionTrue(machine.Status.Conditions, ConditionImageReady) {
+		machine.Status.ImageETA = nil
+	}
+}
+
+// upcomingImagePhases returns the phases of the image pipeline that follow phase
+// for the image imageName.
+func upcomingImagePhases(phase, imageName string) []string {
+	switch phase {
+	case phaseDownload:
+		if diskimage.IsCompressed(imageName) {
+			return []string{phaseExtract, phaseRename, phaseVerify, phaseResize}
+		}
+		return []string{phaseCopy, phaseRename, phaseVerify, phaseResize}
+	case phaseExtract, phaseCopy:
+		return []string{phaseRename, phaseVerify, phaseResize}
+	case phaseRename:
+		return []string{phaseVerify, phaseResize}
+	case phaseVerify:
+		return []string{phaseResize}
+	}
+	return nil
+}
+
+// downloadRemaining returns how long task still needs according to its receive
+// rate, or zero when unknown.
+func downloadRemaining(task freeboxTypes.DownloadTask) time.Duration {
+	if task.ReceiveRate > 0 && task.SizeBytes > task.ReceivedBytes {
+		return time.Duration(task.SizeBytes-task.ReceivedBytes) * time.Second / time.Duration(task.ReceiveRate)
+	}
+	return time.Duration(task.ETASeconds) * time.Second
+}
+
+// fileTaskRemaining returns how long task still needs according to its processing
+// rate, or zero when unknown.
+func fileTaskRemaining(task freeboxTypes.FileSystemTask) time.Duration {
+	if task.ProcessingRate > 0 && task.TotalBytes > task.TotalBytesDone {
+		return time.Duration(task.TotalBytes-task.TotalBytesDone) * time.Second / time.Duration(task.ProcessingRate)
+	}
+	return time.Duration(task.EstimatedTimeRemainingSeconds) * time.Second
+}
+
+// reportImageProgress records in the ImageReady condition and in status.imageETA
+// when the image of machine is estimated to be ready. taskRemaining is how long the
+// Freebox task of the current phase still needs, or zero when unknown, in which case
+// the average duration of the phase is used. Upcoming phases that were never timed
+// are left out, so the estimate is a lower bound until each phase ran once.
+func (r *FreeboxMachineReconciler) reportImageProgress(machine *infrastructurev1alpha1.FreeboxMachine, imageName string, taskRemaining time.Duration) {
+	phase := machine.Status.Phase
+	remaining, known := taskRemaining, taskRemaining > 0
+	if !known {
+		if average, ok := r.phaseDurations.average(phase); ok && machine.Status.PhaseTransitionTime != nil {
+			remaining, known = max(average-time.Since(machine.Status.PhaseTransitionTime.Time), 0), true
+		}
+	}
+	for _, upcoming := range upcomingImagePhases(phase, imageName) {
+		if upcoming == phaseVerify && imagePreparedPhase(machine) != phaseVerify {
+			continue
+		}
+		if average, ok := r.phaseDurations.average(upcoming); ok {
+			remaining, known = remaining+average, true
+		}
+	}
+
+	message := fmt.Sprintf("%s the image", imagePhaseActions[phase])
+	machine.Status.ImageETA = nil
+	if known {
+		// Minutes are precise enough, and do not rewrite the status on each reconcile.
+		remaining = remaining.Round(time.Minute)
+		if remaining > 0 {
+			message += fmt.Sprintf(", ready in about %s", strings.TrimSuffix(remaining.String(), "0s"))
+		} else {
+			message += ", ready in less than a minute"
+		}
+		machine.Status.ImageETA = &metav1.Time{Time: time.Now().Add(remaining).Truncate(time.Minute)}
+	}
+	meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+		Type:    ConditionImageReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  "PreparingImage",
+		Message: message,
+	})
+}
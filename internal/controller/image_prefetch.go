@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/imagepolicy"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/diskimage"
+)
+
+// prefetchDirPrefix prefixes the directories of the download directory holding
+// the images prefetched for each FreeboxCluster.
+const prefetchDirPrefix = "prefetch-"
+
+// prefetchDir returns the directory holding the images prefetched for freeboxCluster.
+// Each cluster has its own directory, so that removing an image from one cluster
+// does not remove it from the others.
+func (r *FreeboxClusterReconciler) prefetchDir(ctx context.Context, freeboxCluster *infrastructurev1alpha1.FreeboxCluster) string {
+	return path.Join(r.downloadDir(ctx), prefetchDirPrefix+freeboxCluster.Namespace+"-"+freeboxCluster.Name)
+}
+
+// reconcilePrefetchImages downloads the images of spec.prefetchImages to the Freebox,
+// removes the images no longer listed, and records their state in status.prefetchedImages.
+// It reports whether all the images are downloaded.
+func (r *FreeboxClusterReconciler) reconcilePrefetchImages(ctx context.Context, freeboxCluster *infrastructurev1alpha1.FreeboxCluster) (bool, error) {
+	logger := logf.FromContext(ctx)
+
+	wanted := map[string]bool{}
+	for _, ref := range freeboxCluster.Spec.PrefetchImages {
+		wanted[ref.URL] = true
+	}
+	previous := map[string]infrastructurev1alpha1.PrefetchedImage{}
+	var stale []string
+	for _, img := range freeboxCluster.Status.PrefetchedImages {
+		if wanted[img.URL] {
+			previous[img.URL] = img
+			continue
+		}
+		if img.TaskID != 0 {
+			if err := r.freeboxClient(ctx).EraseDownloadTask(ctx, img.TaskID); err != nil && !errors.Is(err, freeboxclient.ErrTaskNotFound) {
+				return false, fmt.Errorf("erasing download task %d of prefetched image %s: %w", img.TaskID, img.URL, err)
+			}
+		}
+		// Local images were not downloaded by the cluster, so they are not removed.
+		if _, ok := imagepolicy.FreeboxLocalPath(img.URL); !ok {
+			stale = append(stale, img.Path)
+		}
+	}
+	if len(stale) > 0 {
+		rmTask, err := r.freeboxClient(ctx).RemoveFiles(ctx, stale)
+		if err != nil {
+			return false, fmt.Errorf("removing prefetched images %v: %w", stale, err)
+		}
+		logger.Info("Scheduled removal of images no longer prefetched", "taskID", rmTask.ID, "files", stale)
+	}
+
+	dir := r.prefetchDir(ctx, freeboxCluster)
+	done := true
+	images := make([]infrastructurev1alpha1.PrefetchedImage, 0, len(freeboxCluster.Spec.PrefetchImages))
+	for _, ref := range freeboxCluster.Spec.PrefetchImages {
+		img, ok := previous[ref.URL]
+		if !ok {
+			img = infrastructurev1alpha1.PrefetchedImage{URL: ref.URL, Path: path.Join(dir, diskimage.FileName(ref.URL))}
+		}
+
+		if !img.Ready && img.TaskID != 0 {
+			task, err := r.freeboxClient(ctx).GetDownloadTask(ctx, img.TaskID)
+			switch {
+			case errors.Is(err, freeboxclient.ErrTaskNotFound):
+				logger.Info("Prefetch download task disappeared, restarting it", "url", img.URL, "taskID", img.TaskID)
+				img.TaskID = 0
+			case err != nil:
+				return false, fmt.Errorf("getting download task %d of prefetched image %s: %w", img.TaskID, img.URL, err)
+			case task.Status == freeboxTypes.DownloadTaskStatusDone:
+				if err := r.freeboxClient(ctx).DeleteDownloadTask(ctx, img.TaskID); err != nil {
+					logger.Error(err, "Failed to delete download task (non-fatal)", "taskID", img.TaskID)
+				}
+				logger.Info("Image prefetched", "url", img.URL, "path", img.Path)
+				img.Ready = true
+				img.TaskID = 0
+			case task.Status == freeboxTypes.DownloadTaskStatusError:
+				logger.Info("Prefetch download failed, restarting it", "url", img.URL, "taskID", img.TaskID, "error", task.Error)
+				if err := r.freeboxClient(ctx).EraseDownloadTask(ctx, img.TaskID); err != nil && !errors.Is(err, freeboxclient.ErrTaskNotFound) {
+					return false, fmt.Errorf("erasing failed download task %d: %w", img.TaskID, err)
+				}
+				img.TaskID = 0
+			}
+		}
+
+		if localPath, ok := imagepolicy.FreeboxLocalPath(img.URL); ok {
+			img.Path, img.Ready = localPath, true
+		}
+
+		if !img.Ready && img.TaskID == 0 {
+			if err := r.ImagePolicy.Check(img.URL); err != nil {
+				return false, fmt.Errorf("prefetching image %s: %w", img.URL, err)
+			}
+			if _, err := r.freeboxClient(ctx).CreateDirectory(ctx, r.downloadDir(ctx), path.Base(dir)); err != nil && !errors.Is(err, freeboxclient.ErrDestinationConflict) {
+				return false, fmt.Errorf("creating prefetch directory %s: %w", dir, err)
+			}
+			taskID, err := r.freeboxClient(ctx).AddDownloadTask(ctx, freeboxTypes.DownloadRequest{
+				DownloadURLs:      []string{img.URL},
+				DownloadDirectory: dir,
+				Filename:          path.Base(img.Path),
+			})
+			if err != nil {
+				return false, fmt.Errorf("prefetching image %s: %w", img.URL, err)
+			}
+			logger.Info("Prefetching image", "url", img.URL, "taskID", taskID, "dest", dir)
+			img.TaskID = taskID
+		}
+
+		done = done && img.Ready
+		images = append(images, img)
+	}
+	freeboxCluster.Status.PrefetchedImages = images
+	return done, nil
+}
+
+// prefetchedImagePath returns the path of imageURL when it has been prefetched by
+// the FreeboxCluster of machine, or an empty string otherwise.
+func (r *FreeboxMachineReconciler) prefetchedImagePath(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, imageURL string) (string, error) {
+	freeboxCluster, err := r.freeboxClusterOf(ctx, machine)
+	if err != nil || freeboxCluster == nil {
+		return "", err
+	}
+	for _, img := range freeboxCluster.Status.PrefetchedImages {
+		if img.URL == imageURL && img.Ready {
+			return img.Path, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/diskimage"
+)
+
+// contentTypeExtensions maps the media types of compressed images to the extension
+// telling the controller to extract them.
+var contentTypeExtensions = map[string]string{
+	"application/gzip":    ".gz",
+	"application/x-gzip":  ".gz",
+	"application/x-xz":    ".xz",
+	"application/x-bzip2": ".bz2",
+	"application/zip":     ".zip",
+	"application/x-tar":   ".tar",
+}
+
+// probedImage is what the server of an image URL tells about the image.
+type probedImage struct {
+	// size is the size of the image in bytes, or -1 when unknown.
+	size int64
+	// fileName is the file name of the image, or an empty string when unknown.
+	fileName string
+}
+
+// probeImageURL sends a HEAD request to imageURL. It fails when the URL does not
+// resolve or the server reports an error, so that typos are caught before the
+// Freebox spends minutes on a download task bound to fail.
+func (r *FreeboxMachineReconciler) probeImageURL(ctx context.Context, imageURL string) (probedImage, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, imageURL, nil)
+	if err != nil {
+		return probedImage{}, fmt.Errorf("invalid image URL: %w", err)
+	}
+	resp, err := r.ImageProbeClient.Do(req)
+	if err != nil {
+		return probedImage{}, fmt.Errorf("probing image URL: %w", err)
+	}
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusNotImplemented:
+		// Some servers only answer GET requests; leave it to the download task.
+		return probedImage{size: -1}, nil
+	case resp.StatusCode >= http.StatusBadRequest:
+		return probedImage{}, fmt.Errorf("image URL %s returned %s", imageURL, resp.Status)
+	}
+
+	probed := probedImage{size: resp.ContentLength}
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		probed.fileName = diskimage.SanitizeFileName(params["filename"])
+	}
+	// Mirrors serving images without an extension may still tell they are compressed.
+	if name := diskimage.FileName(imageURL); probed.fileName == "" && path.Ext(name) == "" {
+		if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil && contentTypeExtensions[mediaType] != "" {
+			probed.fileName = name + contentTypeExtensions[mediaType]
+		}
+	}
+	return probed, nil
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// imagePreparedPhase returns the phase following the copy or extraction of the
+// disk image of machine, and its rename: the disk is hashed first when machine
+// sets spec.diskImageSHA256.
+func imagePreparedPhase(machine *infrastructurev1alpha1.FreeboxMachine) string {
+	if machine.Spec.DiskImageSHA256 != "" {
+		return phaseVerify
+	}
+	return phaseResize
+}
+
+// verifyExtractedDisk checks that the disk image extracted to diskPath is not empty.
+func (r *FreeboxMachineReconciler) verifyExtractedDisk(ctx context.Context, diskPath string) error {
+	info, err := r.freeboxClient(ctx).GetFileInfo(ctx, diskPath)
+	if err != nil {
+		return fmt.Errorf("getting info of %s: %w", diskPath, err)
+	}
+	if info.SizeBytes == 0 {
+		return fmt.Errorf("%s is empty", diskPath)
+	}
+	return nil
+}
+
+// verifyDiskHash checks that the hash computed by the Freebox hash task taskID is want.
+func (r *FreeboxMachineReconciler) verifyDiskHash(ctx context.Context, taskID int64, want string) error {
+	got, err := r.freeboxClient(ctx).GetHashResult(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("getting the result of hash task %d: %w", taskID, err)
+	}
+	if !strings.EqualFold(got, want) {
+		return fmt.Errorf("the SHA-256 of the disk is %s, but spec.diskImageSHA256 is %s", got, want)
+	}
+	return nil
+}
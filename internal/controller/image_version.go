@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/cluster-api/util"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// imageKubernetesVersionRegexp matches the Kubernetes version embedded in the URL of
+// images built for one release, e.g. "ubuntu-2404-kube-v1.34.1.qcow2" as named by the
+// Cluster API image-builder, or "kubernetes-1.34/nocloud-arm64.raw.xz". Versions not
+// following a kube, kubernetes or k8s marker, such as the Talos version of Talos
+// images, are not Kubernetes versions.
+var imageKubernetesVersionRegexp = regexp.MustCompile(`(?i)(?:kube|kubernetes|k8s)[-_]?v?(\d+\.\d+(?:\.\d+)?)`)
+
+// imageKubernetesVersion returns the Kubernetes version embedded in imageURL, or nil
+// when none is detected.
+func imageKubernetesVersion(imageURL string) *version.Version {
+	match := imageKubernetesVersionRegexp.FindStringSubmatch(imageURL)
+	if match == nil {
+		return nil
+	}
+	v, err := version.ParseGeneric(match[1])
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// checkImageVersion compares the Kubernetes version embedded in imageURL, when
+// detected, with the version of the owner Machine, and reports the result in the
+// ImageVersionMismatch condition. It returns false when they differ, in which case
+// the image must not be used: the node would join with a skewed version.
+func (r *FreeboxMachineReconciler) checkImageVersion(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, imageURL string) (bool, error) {
+	imageVersion := imageKubernetesVersion(imageURL)
+	if imageVersion == nil {
+		meta.RemoveStatusCondition(&machine.Status.Conditions, ConditionImageVersionMismatch)
+		return true, nil
+	}
+	ownerMachine, err := util.GetOwnerMachine(ctx, r.Client, machine.ObjectMeta)
+	if err != nil {
+		return false, fmt.Errorf("getting owner Machine: %w", err)
+	}
+	if ownerMachine == nil || ownerMachine.Spec.Version == "" {
+		return true, nil
+	}
+	machineVersion, err := version.ParseGeneric(ownerMachine.Spec.Version)
+	if err != nil {
+		return true, nil
+	}
+
+	// Images named after a minor release serve all its patch releases.
+	matches := imageVersion.Major() == machineVersion.Major() && imageVersion.Minor() == machineVersion.Minor() &&
+		(len(imageVersion.Components()) < 3 || imageVersion.Patch() == machineVersion.Patch())
+	if matches {
+		meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+			Type:    ConditionImageVersionMismatch,
+			Status:  metav1.ConditionFalse,
+			Reason:  "KubernetesVersionMatches",
+			Message: fmt.Sprintf("Image is built for Kubernetes %s", imageVersion),
+		})
+		return true, nil
+	}
+
+	message := fmt.Sprintf("Image %s is built for Kubernetes %s but the Machine runs %s", imageURL, imageVersion, ownerMachine.Spec.Version)
+	meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+		Type:    ConditionImageVersionMismatch,
+		Status:  metav1.ConditionTrue,
+		Reason:  "KubernetesVersionMismatch",
+		Message: message,
+	})
+	meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+		Type:    ReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  "ImageVersionMismatch",
+		Message: message,
+	})
+	return false, nil
+}
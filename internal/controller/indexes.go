@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+const (
+	// VMIDField selects FreeboxMachines by the ID of their Freebox VM. It is both a
+	// field index of the manager cache and a selectable field of the CRD, so that
+	// the same field selector works against the cache and the API server.
+	VMIDField = "status.vmID"
+
+	// ProviderIDField selects FreeboxMachines by their provider ID.
+	ProviderIDField = "spec.providerID"
+)
+
+// SetupFreeboxMachineIndexes registers the field indexes of FreeboxMachines.
+func SetupFreeboxMachineIndexes(ctx context.Context, indexer client.FieldIndexer) error {
+	if err := indexer.IndexField(ctx, &infrastructurev1alpha1.FreeboxMachine{}, VMIDField, func(obj client.Object) []string {
+		machine := obj.(*infrastructurev1alpha1.FreeboxMachine)
+		if machine.Status.VMID == nil {
+			return nil
+		}
+		return []string{strconv.FormatInt(*machine.Status.VMID, 10)}
+	}); err != nil {
+		return err
+	}
+	return indexer.IndexField(ctx, &infrastructurev1alpha1.FreeboxMachine{}, ProviderIDField, func(obj client.Object) []string {
+		machine := obj.(*infrastructurev1alpha1.FreeboxMachine)
+		if machine.Spec.ProviderID == "" {
+			return nil
+		}
+		return []string{machine.Spec.ProviderID}
+	})
+}
+
+// freeboxMachinesForVM returns the FreeboxMachines recording vmID as their VM.
+func freeboxMachinesForVM(ctx context.Context, reader client.Reader, vmID int64) ([]infrastructurev1alpha1.FreeboxMachine, error) {
+	var machines infrastructurev1alpha1.FreeboxMachineList
+	if err := reader.List(ctx, &machines, client.MatchingFields{VMIDField: strconv.FormatInt(vmID, 10)}); err != nil {
+		return nil, err
+	}
+	return machines.Items, nil
+}
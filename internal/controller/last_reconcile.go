@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// LastReconcileAnnotation records when the controller last reconciled a
+// FreeboxMachine or FreeboxCluster, how it went and the phase it left the object
+// in, e.g. "time=2025-06-01T12:00:00Z outcome=Failed phase=download", so that
+// GitOps tools and users can tell the provider is alive without its metrics.
+// Paused objects are not reconciled, so their annotation is left as is.
+const LastReconcileAnnotation = "infrastructure.cluster.x-k8s.io/last-reconcile"
+
+// lastReconcileRefresh is how old LastReconcileAnnotation gets before it is written
+// again while the outcome and phase stay the same, so that the periodic reconciles
+// do not all write it.
+const lastReconcileRefresh = time.Minute
+
+// Outcomes recorded in LastReconcileAnnotation.
+const (
+	reconcileSucceeded = "Succeeded"
+	reconcileFailed    = "Failed"
+	reconcileFrozen    = "Frozen"
+)
+
+// setLastReconcile records in the LastReconcileAnnotation of obj a reconcile that
+// ended at now with outcome, leaving obj in phase, which may be empty. It reports
+// whether the annotation changed.
+func setLastReconcile(obj client.Object, now time.Time, outcome, phase string) bool {
+	state := "outcome=" + outcome
+	if phase != "" {
+		state += " phase=" + phase
+	}
+	annotations := obj.GetAnnotations()
+	if at, ok := strings.CutSuffix(annotations[LastReconcileAnnotation], " "+state); ok {
+		last, err := time.Parse(time.RFC3339, strings.TrimPrefix(at, "time="))
+		if err == nil && now.Sub(last) < lastReconcileRefresh {
+			return false
+		}
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[LastReconcileAnnotation] = "time=" + now.UTC().Format(time.RFC3339) + " " + state
+	obj.SetAnnotations(annotations)
+	return true
+}
+
+// patchLastReconcile writes the LastReconcileAnnotation of obj when it changes. The
+// patch only holds the annotation, so that it is not bound to the resource version.
+func patchLastReconcile(ctx context.Context, c client.Client, obj client.Object, outcome, phase string) error {
+	base := obj.DeepCopyObject().(client.Object)
+	if !setLastReconcile(obj, time.Now(), outcome, phase) {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), statusUpdateTimeout)
+	defer cancel()
+	// The object is gone once its finalizer is removed.
+	return client.IgnoreNotFound(c.Patch(ctx, obj, client.MergeFrom(base)))
+}
+
+// ignoreLastReconcileUpdates filters out the updates that only write the
+// LastReconcileAnnotation, so that recording a reconcile does not trigger another.
+var ignoreLastReconcileUpdates = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld == nil || e.ObjectNew == nil || !onlyLastReconcileChanged(e.ObjectOld, e.ObjectNew)
+	},
+}
+
+// onlyLastReconcileChanged reports whether oldObj and newObj only differ by their
+// LastReconcileAnnotation and the metadata the API server maintains.
+func onlyLastReconcileChanged(oldObj, newObj client.Object) bool {
+	if oldObj.GetAnnotations()[LastReconcileAnnotation] == newObj.GetAnnotations()[LastReconcileAnnotation] {
+		return false
+	}
+	objs := []client.Object{oldObj.DeepCopyObject().(client.Object), newObj.DeepCopyObject().(client.Object)}
+	for _, obj := range objs {
+		annotations := obj.GetAnnotations()
+		delete(annotations, LastReconcileAnnotation)
+		obj.SetAnnotations(annotations)
+		obj.SetResourceVersion("")
+		obj.SetManagedFields(nil)
+	}
+	return equality.Semantic.DeepEqual(objs[0], objs[1])
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+const (
+	// metricsPhasePending is the phase label of machines whose image download has not started yet.
+	metricsPhasePending = "pending"
+	// metricsPhaseFailed is the phase label of machines whose provisioning failed.
+	metricsPhaseFailed = "failed"
+)
+
+// metricsPhases are the phase label values always reported, so that dashboards
+// show zero instead of no data.
+var metricsPhases = []string{
+	metricsPhasePending,
+	phaseDownload,
+	phaseExtract,
+	phaseCopy,
+	phaseRename,
+	phaseVerify,
+	phaseResize,
+	phaseVMCreated,
+	phaseDone,
+	metricsPhaseFailed,
+}
+
+// machinesByPhase counts FreeboxMachines by provisioning phase. The depth of the
+// reconcile queue is already exposed by controller-runtime as
+// workqueue_depth{name="freeboxmachine"}.
+var machinesByPhase = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "capfb_machines_by_phase",
+		Help: "Number of FreeboxMachines by provisioning phase.",
+	},
+	[]string{"phase"},
+)
+
+// imagePhaseDuration times the phases of the image pipeline. Their averages also
+// estimate when the images being prepared are ready.
+var imagePhaseDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "capfb_image_phase_duration_seconds",
+		Help:    "Duration of the phases of the image pipeline of FreeboxMachines.",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+	},
+	[]string{"phase"},
+)
+
+// imageETASeconds reports, for each FreeboxMachine whose image is being prepared,
+// the estimated time left until it is ready, as recorded in status.imageETA.
+var imageETASeconds = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "capfb_image_eta_seconds",
+		Help: "Estimated seconds until the image of a FreeboxMachine is ready.",
+	},
+	[]string{"namespace", "name"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(machinesByPhase, imagePhaseDuration, imageETASeconds)
+}
+
+// machineMetricsPhase returns the phase label of a FreeboxMachine.
+func machineMetricsPhase(machine *infrastructurev1alpha1.FreeboxMachine) string {
+	if ready := meta.FindStatusCondition(machine.Status.Conditions, ReadyCondition); ready != nil && ready.Reason == reasonProvisioningFailed {
+		return metricsPhaseFailed
+	}
+	if machine.Status.Phase == "" {
+		return metricsPhasePending
+	}
+	return machine.Status.Phase
+}
+
+// updatePhaseMetrics recomputes machinesByPhase and imageETASeconds from the
+// FreeboxMachines in the cache.
+func (r *FreeboxMachineReconciler) updatePhaseMetrics(ctx context.Context) {
+	var machines infrastructurev1alpha1.FreeboxMachineList
+	if err := r.List(ctx, &machines); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list FreeboxMachines for metrics")
+		return
+	}
+
+	counts := make(map[string]int, len(metricsPhases))
+	for _, phase := range metricsPhases {
+		counts[phase] = 0
+	}
+	imageETASeconds.Reset()
+	for i := range machines.Items {
+		m := &machines.Items[i]
+		counts[machineMetricsPhase(m)]++
+		if m.Status.ImageETA != nil {
+			imageETASeconds.WithLabelValues(m.Namespace, m.Name).Set(max(time.Until(m.Status.ImageETA.Time).Seconds(), 0))
+		}
+	}
+	for phase, count := range counts {
+		machinesByPhase.WithLabelValues(phase).Set(float64(count))
+	}
+}
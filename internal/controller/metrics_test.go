@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+var _ = Describe("Phase metrics", func() {
+	It("counts FreeboxMachines by phase", func() {
+		machine := func(name, phase string, conditions ...metav1.Condition) *infrastructurev1alpha1.FreeboxMachine {
+			m := newMachineForPhaseTest(name, infrastructurev1alpha1.FreeboxMachineSpec{})
+			m.Status.Phase = phase
+			m.Status.Conditions = conditions
+			return m
+		}
+		c := fake.NewClientBuilder().WithScheme(k8sClient.Scheme()).WithObjects(
+			machine("pending", ""),
+			machine("downloading-1", phaseDownload),
+			machine("downloading-2", phaseDownload),
+			machine("running", phaseDone),
+			machine("failed", phaseCopy, metav1.Condition{
+				Type:   ReadyCondition,
+				Status: metav1.ConditionFalse,
+				Reason: "ProvisioningFailed",
+			}),
+		).Build()
+
+		r := &FreeboxMachineReconciler{Client: c}
+		r.updatePhaseMetrics(context.Background())
+
+		Expect(testutil.ToFloat64(machinesByPhase.WithLabelValues(metricsPhasePending))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(machinesByPhase.WithLabelValues(phaseDownload))).To(Equal(2.0))
+		Expect(testutil.ToFloat64(machinesByPhase.WithLabelValues(phaseDone))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(machinesByPhase.WithLabelValues(phaseCopy))).To(Equal(0.0))
+		Expect(testutil.ToFloat64(machinesByPhase.WithLabelValues(metricsPhaseFailed))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(machinesByPhase.WithLabelValues(phaseResize))).To(Equal(0.0))
+	})
+})
@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// ownerEvent records an event regarding machine, and the same event regarding its
+// owner Machine and Cluster, so that users watching only Cluster API resources
+// see the provider activity too.
+func (r *FreeboxMachineReconciler) ownerEvent(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, eventType, reason, action, note string, args ...any) {
+	if r.Recorder == nil {
+		return
+	}
+	logger := logf.FromContext(ctx)
+	r.event(machine, nil, eventType, reason, action, note, args...)
+
+	if owner, err := util.GetOwnerMachine(ctx, r.Client, machine.ObjectMeta); err != nil {
+		logger.V(1).Info("Could not get owner Machine to record event", "reason", reason, "error", err)
+	} else if owner != nil {
+		r.event(owner, machine, eventType, reason, action, note, args...)
+	}
+
+	if _, ok := machine.Labels[clusterv1.ClusterNameLabel]; !ok {
+		return
+	}
+	if cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machine.ObjectMeta); err != nil {
+		logger.V(1).Info("Could not get Cluster to record event", "reason", reason, "error", err)
+	} else {
+		r.event(cluster, machine, eventType, reason, action, note, args...)
+	}
+}
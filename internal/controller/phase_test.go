@@ -17,19 +17,33 @@ limitations under the License.
 package controller
 
 import (
+	"bytes"
 	"context"
 	"crypto/rsa"
+	"encoding/json"
 	"fmt"
-	"io"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
 	freeboxclient "github.com/nikolalohinski/free-go/client"
 	freeboxTypes "github.com/nikolalohinski/free-go/types"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/ptr"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/controllers/clustercache"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -39,294 +53,169 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/imagepolicy"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/freebox/mock"
 )
 
-// fakeClient is a minimal fake implementation of freeboxclient.Client for phase-transition tests.
-// Only the methods exercised by the reconciler's image-phase logic are implemented;
-// all others panic to surface unexpected calls during testing.
-type fakeClient struct {
-	listDownloadTasksFn  func(ctx context.Context) ([]freeboxTypes.DownloadTask, error)
-	addDownloadTaskFn    func(ctx context.Context, req freeboxTypes.DownloadRequest) (int64, error)
-	getDownloadTaskFn    func(ctx context.Context, id int64) (freeboxTypes.DownloadTask, error)
-	deleteDownloadTaskFn func(ctx context.Context, id int64) error
-	extractFileFn        func(ctx context.Context, p freeboxTypes.ExtractFilePayload) (freeboxTypes.FileSystemTask, error)
-	copyFilesFn          func(ctx context.Context, srcs []string, dst string, mode freeboxTypes.FileCopyMode) (freeboxTypes.FileSystemTask, error)
-	moveFilesFn          func(ctx context.Context, srcs []string, dst string, mode freeboxTypes.FileMoveMode) (freeboxTypes.FileSystemTask, error)
-	getFileSystemTaskFn  func(ctx context.Context, id int64) (freeboxTypes.FileSystemTask, error)
-	removeFilesFn        func(ctx context.Context, paths []string) (freeboxTypes.FileSystemTask, error)
-	resizeVirtualDiskFn  func(ctx context.Context, p freeboxTypes.VirtualDisksResizePayload) (int64, error)
-	getVirtualDiskTaskFn func(ctx context.Context, id int64) (freeboxTypes.VirtualMachineDiskTask, error)
-	getVirtualMachineFn  func(ctx context.Context, id int64) (freeboxTypes.VirtualMachine, error)
-	getLanInterfaceFn    func(ctx context.Context, name string) ([]freeboxTypes.LanInterfaceHost, error)
+// newMachineForPhaseTest creates a FreeboxMachine with the given name in the default namespace.
+func newMachineForPhaseTest(name string, spec infrastructurev1alpha1.FreeboxMachineSpec) *infrastructurev1alpha1.FreeboxMachine {
+	return &infrastructurev1alpha1.FreeboxMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+		},
+		Spec: spec,
+	}
 }
 
-func (f *fakeClient) ListDownloadTasks(ctx context.Context) ([]freeboxTypes.DownloadTask, error) {
-	if f.listDownloadTasksFn != nil {
-		return f.listDownloadTasksFn(ctx)
+// createOwnerMachine creates a CAPI Machine owning machine, with a bootstrap data
+// secret holding bootstrapData, and deletes them when the spec ends.
+func createOwnerMachine(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, version string, bootstrapData []byte) *clusterv1.Machine {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: machine.Name + "-bootstrap", Namespace: machine.Namespace},
+		Data:       map[string][]byte{"value": bootstrapData},
 	}
-	return nil, nil
-}
-func (f *fakeClient) AddDownloadTask(ctx context.Context, req freeboxTypes.DownloadRequest) (int64, error) {
-	if f.addDownloadTaskFn != nil {
-		return f.addDownloadTaskFn(ctx, req)
+	Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+	DeferCleanup(func() { Expect(k8sClient.Delete(ctx, secret)).To(Succeed()) })
+
+	owner := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: machine.Name, Namespace: machine.Namespace},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: machine.Name,
+			Version:     version,
+			Bootstrap:   clusterv1.Bootstrap{DataSecretName: ptr.To(secret.Name)},
+			InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+				APIGroup: infrastructurev1alpha1.GroupVersion.Group,
+				Kind:     "FreeboxMachine",
+				Name:     machine.Name,
+			},
+		},
 	}
-	panic("AddDownloadTask not expected")
+	Expect(k8sClient.Create(ctx, owner)).To(Succeed())
+	DeferCleanup(func() { Expect(k8sClient.Delete(ctx, owner)).To(Succeed()) })
+
+	machine.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: clusterv1.GroupVersion.String(),
+		Kind:       "Machine",
+		Name:       owner.Name,
+		UID:        owner.UID,
+	}}
+	Expect(k8sClient.Update(ctx, machine)).To(Succeed())
+	return owner
 }
-func (f *fakeClient) GetDownloadTask(ctx context.Context, id int64) (freeboxTypes.DownloadTask, error) {
-	if f.getDownloadTaskFn != nil {
-		return f.getDownloadTaskFn(ctx, id)
-	}
-	panic("GetDownloadTask not expected")
+
+// eventRecorder records the kind of the object and the reason of the events it receives.
+type eventRecorder struct {
+	events []string
 }
-func (f *fakeClient) DeleteDownloadTask(ctx context.Context, id int64) error {
-	if f.deleteDownloadTaskFn != nil {
-		return f.deleteDownloadTaskFn(ctx, id)
-	}
-	return nil
+
+func (r *eventRecorder) Eventf(regarding, _ runtime.Object, _, reason, _, _ string, _ ...any) {
+	kind := reflect.TypeOf(regarding).Elem().Name()
+	r.events = append(r.events, kind+" "+reason)
 }
-func (f *fakeClient) ExtractFile(ctx context.Context, p freeboxTypes.ExtractFilePayload) (freeboxTypes.FileSystemTask, error) {
-	if f.extractFileFn != nil {
-		return f.extractFileFn(ctx, p)
-	}
-	panic("ExtractFile not expected")
+
+// statusWriteCounter counts the status writes made through it.
+type statusWriteCounter struct {
+	client.Client
+	writes int
 }
-func (f *fakeClient) CopyFiles(ctx context.Context, srcs []string, dst string, mode freeboxTypes.FileCopyMode) (freeboxTypes.FileSystemTask, error) {
-	if f.copyFilesFn != nil {
-		return f.copyFilesFn(ctx, srcs, dst, mode)
-	}
-	panic("CopyFiles not expected")
+
+func (c *statusWriteCounter) Status() client.SubResourceWriter {
+	return countingStatusWriter{SubResourceWriter: c.Client.Status(), writes: &c.writes}
 }
-func (f *fakeClient) MoveFiles(ctx context.Context, srcs []string, dst string, mode freeboxTypes.FileMoveMode) (freeboxTypes.FileSystemTask, error) {
-	if f.moveFilesFn != nil {
-		return f.moveFilesFn(ctx, srcs, dst, mode)
-	}
-	panic("MoveFiles not expected")
+
+type countingStatusWriter struct {
+	client.SubResourceWriter
+	writes *int
 }
-func (f *fakeClient) GetFileSystemTask(ctx context.Context, id int64) (freeboxTypes.FileSystemTask, error) {
-	if f.getFileSystemTaskFn != nil {
-		return f.getFileSystemTaskFn(ctx, id)
-	}
-	panic("GetFileSystemTask not expected")
+
+func (w countingStatusWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	*w.writes++
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
 }
-func (f *fakeClient) RemoveFiles(ctx context.Context, paths []string) (freeboxTypes.FileSystemTask, error) {
-	if f.removeFilesFn != nil {
-		return f.removeFilesFn(ctx, paths)
-	}
-	return freeboxTypes.FileSystemTask{}, nil
+
+func (w countingStatusWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	*w.writes++
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
 }
-func (f *fakeClient) ResizeVirtualDisk(ctx context.Context, p freeboxTypes.VirtualDisksResizePayload) (int64, error) {
-	if f.resizeVirtualDiskFn != nil {
-		return f.resizeVirtualDiskFn(ctx, p)
-	}
-	panic("ResizeVirtualDisk not expected")
+
+// staleClient returns the object named key with a past resource version, like a
+// cache that has not yet seen the latest write.
+type staleClient struct {
+	client.Client
+	key             client.ObjectKey
+	resourceVersion string
 }
-func (f *fakeClient) GetVirtualDiskTask(ctx context.Context, id int64) (freeboxTypes.VirtualMachineDiskTask, error) {
-	if f.getVirtualDiskTaskFn != nil {
-		return f.getVirtualDiskTaskFn(ctx, id)
+
+func (c *staleClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if err := c.Client.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	if key == c.key {
+		obj.SetResourceVersion(c.resourceVersion)
 	}
-	panic("GetVirtualDiskTask not expected")
+	return nil
 }
 
-// Unused interface methods — panic on unexpected calls.
-func (f *fakeClient) WithAppID(string) freeboxclient.Client { panic("not implemented") }
-func (f *fakeClient) WithPrivateToken(freeboxTypes.PrivateToken) freeboxclient.Client {
-	panic("not implemented")
-}
-func (f *fakeClient) WithHTTPClient(freeboxclient.HTTPClient) freeboxclient.Client {
-	panic("not implemented")
-}
-func (f *fakeClient) APIVersion(context.Context) (freeboxTypes.APIVersion, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) Authorize(context.Context, freeboxTypes.AuthorizationRequest) (freeboxTypes.PrivateToken, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) Login(context.Context) (freeboxTypes.Permissions, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) Logout(context.Context) error { panic("not implemented") }
-func (f *fakeClient) ListPortForwardingRules(context.Context) ([]freeboxTypes.PortForwardingRule, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetPortForwardingRule(ctx context.Context, identifier int64) (freeboxTypes.PortForwardingRule, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) CreatePortForwardingRule(ctx context.Context, payload freeboxTypes.PortForwardingRulePayload) (freeboxTypes.PortForwardingRule, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) UpdatePortForwardingRule(ctx context.Context, identifier int64, payload freeboxTypes.PortForwardingRulePayload) (freeboxTypes.PortForwardingRule, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) DeletePortForwardingRule(ctx context.Context, identifier int64) error {
-	panic("not implemented")
-}
-func (f *fakeClient) ListDHCPStaticLease(context.Context) ([]freeboxTypes.DHCPStaticLeaseInfo, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetDHCPStaticLease(ctx context.Context, identifier string) (freeboxTypes.DHCPStaticLeaseInfo, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) UpdateDHCPStaticLease(ctx context.Context, identifier string, payload freeboxTypes.DHCPStaticLeasePayload) (freeboxTypes.LanInterfaceHost, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) CreateDHCPStaticLease(ctx context.Context, payload freeboxTypes.DHCPStaticLeasePayload) (freeboxTypes.LanInterfaceHost, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) DeleteDHCPStaticLease(ctx context.Context, identifier string) error {
-	panic("not implemented")
-}
-func (f *fakeClient) GetLanConfig(ctx context.Context) (freeboxTypes.LanConfig, error) {
-	panic("not implemented")
+// laggingClient serves every other read of a FreeboxMachine from the first version
+// it read, like a cache that has not caught up with the API server yet.
+type laggingClient struct {
+	client.Client
+	mu    sync.Mutex
+	reads int
+	first *infrastructurev1alpha1.FreeboxMachine
 }
-func (f *fakeClient) UpdateLanConfig(ctx context.Context, payload freeboxTypes.LanConfig) (freeboxTypes.LanConfig, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) ListLanInterfaceInfo(context.Context) ([]freeboxTypes.LanInfo, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetLanInterface(ctx context.Context, name string) ([]freeboxTypes.LanInterfaceHost, error) {
-	if f.getLanInterfaceFn != nil {
-		return f.getLanInterfaceFn(ctx, name)
+
+func (c *laggingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	machine, ok := obj.(*infrastructurev1alpha1.FreeboxMachine)
+	if !ok {
+		return c.Client.Get(ctx, key, obj, opts...)
 	}
-	panic("GetLanInterface not expected")
-}
-func (f *fakeClient) GetLanInterfaceHost(ctx context.Context, interfaceName, identifier string) (freeboxTypes.LanInterfaceHost, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetVirtualMachineInfo(context.Context) (freeboxTypes.VirtualMachinesInfo, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetVirtualMachineDistributions(context.Context) ([]freeboxTypes.VirtualMachineDistribution, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) ListVirtualMachines(context.Context) ([]freeboxTypes.VirtualMachine, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) CreateVirtualMachine(ctx context.Context, payload freeboxTypes.VirtualMachinePayload) (freeboxTypes.VirtualMachine, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetVirtualMachine(ctx context.Context, identifier int64) (freeboxTypes.VirtualMachine, error) {
-	if f.getVirtualMachineFn != nil {
-		return f.getVirtualMachineFn(ctx, identifier)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reads++
+	if c.first != nil && c.reads%2 == 0 {
+		c.first.DeepCopyInto(machine)
+		return nil
 	}
-	panic("GetVirtualMachine not expected")
-}
-func (f *fakeClient) UpdateVirtualMachine(ctx context.Context, identifier int64, payload freeboxTypes.VirtualMachinePayload) (freeboxTypes.VirtualMachine, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) DeleteVirtualMachine(ctx context.Context, identifier int64) error {
-	panic("not implemented")
-}
-func (f *fakeClient) StartVirtualMachine(ctx context.Context, identifier int64) error {
-	panic("not implemented")
-}
-func (f *fakeClient) KillVirtualMachine(ctx context.Context, identifier int64) error {
-	panic("not implemented")
-}
-func (f *fakeClient) StopVirtualMachine(ctx context.Context, identifier int64) error {
-	panic("not implemented")
-}
-func (f *fakeClient) GetVirtualDiskInfo(ctx context.Context, path string) (freeboxTypes.VirtualDiskInfo, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) CreateVirtualDisk(ctx context.Context, payload freeboxTypes.VirtualDisksCreatePayload) (int64, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) DeleteVirtualDiskTask(ctx context.Context, identifier int64) error {
-	panic("not implemented")
-}
-func (f *fakeClient) ListenEvents(ctx context.Context, events []freeboxTypes.EventDescription) (chan freeboxTypes.Event, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetFileInfo(ctx context.Context, path string) (freeboxTypes.FileInfo, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) UpdateFileSystemTask(ctx context.Context, identifier int64, payload freeboxTypes.FileSytemTaskUpdate) (freeboxTypes.FileSystemTask, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) ListFileSystemTasks(ctx context.Context) ([]freeboxTypes.FileSystemTask, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) DeleteFileSystemTask(ctx context.Context, identifier int64) error {
-	panic("not implemented")
-}
-func (f *fakeClient) CreateDirectory(ctx context.Context, parent, name string) (string, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) AddHashFileTask(ctx context.Context, payload freeboxTypes.HashPayload) (freeboxTypes.FileSystemTask, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetHashResult(ctx context.Context, identifier int64) (string, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetFile(ctx context.Context, path string) (freeboxTypes.File, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) EraseDownloadTask(ctx context.Context, identifier int64) error {
-	panic("not implemented")
-}
-func (f *fakeClient) UpdateDownloadTask(ctx context.Context, identifier int64, payload freeboxTypes.DownloadTaskUpdate) error {
-	panic("not implemented")
-}
-func (f *fakeClient) FileUploadStart(ctx context.Context, input freeboxTypes.FileUploadStartActionInput) (io.WriteCloser, int64, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetUploadTask(ctx context.Context, identifier int64) (freeboxTypes.UploadTask, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) ListUploadTasks(ctx context.Context) ([]freeboxTypes.UploadTask, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) CancelUploadTask(ctx context.Context, identifier int64) error {
-	panic("not implemented")
-}
-func (f *fakeClient) DeleteUploadTask(ctx context.Context, identifier int64) error {
-	panic("not implemented")
-}
-func (f *fakeClient) CleanUploadTasks(ctx context.Context) error { panic("not implemented") }
-func (f *fakeClient) CreateVPNUser(ctx context.Context, payload freeboxTypes.VPNUserPayload) (freeboxTypes.VPNUser, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) DeleteVPNUser(ctx context.Context, identifier string) error {
-	panic("not implemented")
-}
-func (f *fakeClient) GetDownloadConfiguration(ctx context.Context) (freeboxTypes.DownloadConfiguration, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetOpenVPNServerConfig(ctx context.Context) (freeboxTypes.OpenVPNServerConfig, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetSystemInfo(ctx context.Context) (freeboxTypes.SystemConfig, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetVPNUser(ctx context.Context, identifier string) (freeboxTypes.VPNUser, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) GetVPNUserClientConfig(ctx context.Context, identifier string) (string, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) ListVPNUsers(ctx context.Context) ([]freeboxTypes.VPNUser, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) UpdateOpenVPNServerConfig(ctx context.Context, payload freeboxTypes.OpenVPNServerConfig) (freeboxTypes.OpenVPNServerConfig, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) UpdateVPNUser(ctx context.Context, login string, payload freeboxTypes.VPNUserPayload) (freeboxTypes.VPNUser, error) {
-	panic("not implemented")
-}
-func (f *fakeClient) UpdateDownloadConfiguration(ctx context.Context, payload freeboxTypes.DownloadConfiguration) (freeboxTypes.DownloadConfiguration, error) {
-	panic("not implemented")
+	if err := c.Client.Get(ctx, key, obj, opts...); err != nil {
+		return err
+	}
+	if c.first == nil {
+		c.first = machine.DeepCopy()
+	}
+	return nil
 }
 
-// newMachineForPhaseTest creates a FreeboxMachine with the given name in the default namespace.
-func newMachineForPhaseTest(name string, spec infrastructurev1alpha1.FreeboxMachineSpec) *infrastructurev1alpha1.FreeboxMachine {
-	return &infrastructurev1alpha1.FreeboxMachine{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: "default",
+// uploadBuffer records a file uploaded to the Freebox.
+type uploadBuffer struct{ bytes.Buffer }
+
+func (*uploadBuffer) Close() error { return nil }
+
+// createFreeboxCluster creates a CAPI Cluster and the FreeboxCluster it references,
+// both with the given name, and deletes them when the spec ends.
+func createFreeboxCluster(ctx context.Context, name string, spec infrastructurev1alpha1.FreeboxClusterSpec) *infrastructurev1alpha1.FreeboxCluster {
+	spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "192.168.1.10", Port: 6443}
+	fbCluster := &infrastructurev1alpha1.FreeboxCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       spec,
+	}
+	Expect(k8sClient.Create(ctx, fbCluster)).To(Succeed())
+	DeferCleanup(func() { Expect(k8sClient.Delete(ctx, fbCluster)).To(Succeed()) })
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+				APIGroup: infrastructurev1alpha1.GroupVersion.Group,
+				Kind:     "FreeboxCluster",
+				Name:     name,
+			},
 		},
-		Spec: spec,
 	}
+	Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+	DeferCleanup(func() { Expect(k8sClient.Delete(ctx, cluster)).To(Succeed()) })
+	return fbCluster
 }
 
 var _ = Describe("FreeboxMachine phase transitions", func() {
@@ -341,7 +230,7 @@ var _ = Describe("FreeboxMachine phase transitions", func() {
 
 	testCtx := context.Background()
 
-	newReconciler := func(fc *fakeClient) *FreeboxMachineReconciler {
+	newReconciler := func(fc *mock.Client) *FreeboxMachineReconciler {
 		return &FreeboxMachineReconciler{
 			Client:             k8sClient,
 			Scheme:             k8sClient.Scheme(),
@@ -369,18 +258,21 @@ var _ = Describe("FreeboxMachine phase transitions", func() {
 		AfterEach(func() {
 			machine := &infrastructurev1alpha1.FreeboxMachine{}
 			_ = k8sClient.Get(testCtx, nn, machine)
+			machine.Finalizers = nil
+			_ = k8sClient.Update(testCtx, machine)
 			_ = k8sClient.Delete(testCtx, machine)
 		})
 
 		It("reconcile with empty phase starts download and sets Phase=download", func() {
-			fc := &fakeClient{
-				listDownloadTasksFn: func(ctx context.Context) ([]freeboxTypes.DownloadTask, error) {
+			fc := &mock.Client{
+				ListDownloadTasksStub: func(ctx context.Context) ([]freeboxTypes.DownloadTask, error) {
 					return nil, nil // No existing tasks
 				},
-				addDownloadTaskFn: func(ctx context.Context, req freeboxTypes.DownloadRequest) (int64, error) {
+				AddDownloadTaskStub: func(ctx context.Context, req freeboxTypes.DownloadRequest) (int64, error) {
 					return 42, nil
 				},
 			}
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
 			r := newReconciler(fc)
 			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
 			Expect(err).NotTo(HaveOccurred())
@@ -390,99 +282,1626 @@ var _ = Describe("FreeboxMachine phase transitions", func() {
 			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
 			Expect(updated.Status.Phase).To(Equal(phaseDownload))
 			Expect(updated.Status.TaskID).To(Equal(int64(42)))
+			Expect(updated.Status.DiskPath).To(Equal(vmStoragePath + "/" + resourceName + ".raw"))
 		})
-	})
 
-	Describe("TestPhaseExtract", func() {
-		const resourceName = "phase-extract-test"
-		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		It("validates the image URL and records the image size before downloading it", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				Expect(req.Method).To(Equal(http.MethodHead))
+				if req.URL.Path != "/debian.qcow2" {
+					http.NotFound(w, req)
+					return
+				}
+				w.Header().Set("Content-Length", "4096")
+			}))
+			DeferCleanup(server.Close)
 
-		BeforeEach(func() {
-			machine := newMachineForPhaseTest(resourceName, infrastructurev1alpha1.FreeboxMachineSpec{
-				Name:          "my-vm",
-				VCPUs:         1,
-				MemoryMB:      512,
-				DiskSizeBytes: 10 * 1024 * 1024 * 1024,
-				ImageURL:      imageURL,
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.ImageURL = server.URL + "/debain.qcow2"
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			fc.AddDownloadTaskReturns(42, nil)
+			r := newReconciler(fc)
+			r.ImageProbeClient = server.Client()
+			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			cond := meta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal("ImageURLUnavailable"))
+			Expect(cond.Message).To(ContainSubstring("404"))
+
+			updated.Spec.ImageURL = server.URL + "/debian.qcow2"
+			Expect(k8sClient.Update(testCtx, updated)).To(Succeed())
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.AddDownloadTaskCallCount()).To(Equal(1))
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseDownload))
+			Expect(updated.Status.ImageSizeBytes).To(Equal(int64(4096)))
+		})
+
+		It("names the downloaded image after the server when its URL has no extension", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				switch req.URL.Query().Get("id") {
+				case "talos":
+					w.Header().Set("Content-Type", "application/x-xz")
+				case "debian":
+					w.Header().Set("Content-Disposition", `attachment; filename="debian-13-generic-arm64.qcow2"`)
+				}
+			}))
+			DeferCleanup(server.Close)
+
+			for id, want := range map[string]string{"talos": "download.xz", "debian": "debian-13-generic-arm64.qcow2"} {
+				machine := &infrastructurev1alpha1.FreeboxMachine{}
+				Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+				machine.Spec.ImageURL = server.URL + "/download?id=" + id
+				Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+
+				fc := &mock.Client{}
+				fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+				fc.AddDownloadTaskReturns(42, nil)
+				r := newReconciler(fc)
+				r.ImageProbeClient = server.Client()
+				// The first reconcile records the file name, the second starts the download.
+				for range 2 {
+					_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(fc.AddDownloadTaskCallCount()).To(Equal(1))
+
+				_, req := fc.AddDownloadTaskArgsForCall(0)
+				Expect(req.Filename).To(Equal(want))
+				updated := &infrastructurev1alpha1.FreeboxMachine{}
+				Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+				Expect(updated.Status.ImageFileName).To(Equal(want))
+				updated.Status.Phase = ""
+				Expect(k8sClient.Status().Update(testCtx, updated)).To(Succeed())
+			}
+		})
+
+		It("names the disk after the name template", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.NameTemplate = "{{ .Namespace }}-{{ .MachineName }}"
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.AddDownloadTaskReturns(42, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.DiskPath).To(Equal(vmStoragePath + "/default-" + resourceName + ".raw"))
+		})
+
+		It("downloads the image URL expanded from the owner Machine version", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.ImageURL = "https://factory.talos.dev/image/abc/{channel}/{k8sVersion}/nocloud-{arch}.raw.xz"
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			createOwnerMachine(testCtx, machine, "v1.34.1", nil)
+
+			fc := &mock.Client{}
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			fc.AddDownloadTaskReturns(42, nil)
+			r := newReconciler(fc)
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.AddDownloadTaskCallCount()).To(Equal(1))
+			_, req := fc.AddDownloadTaskArgsForCall(0)
+			Expect(req.DownloadURLs).To(ConsistOf("https://factory.talos.dev/image/abc/v1.34/v1.34.1/nocloud-arm64.raw.xz"))
+		})
+
+		It("does not use an image built for another Kubernetes version than the owner Machine", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.ImageURL = "https://images.example.com/ubuntu-2404-kube-v1.33.2.qcow2"
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			createOwnerMachine(testCtx, machine, "v1.34.1", nil)
+
+			fc := &mock.Client{}
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			fc.AddDownloadTaskReturns(42, nil)
+			r := newReconciler(fc)
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			mismatch := meta.FindStatusCondition(updated.Status.Conditions, ConditionImageVersionMismatch)
+			Expect(mismatch).NotTo(BeNil())
+			Expect(mismatch.Status).To(Equal(metav1.ConditionTrue))
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, ReadyCondition).Reason).To(Equal("ImageVersionMismatch"))
+
+			By("downloading an image of the minor release of the Machine")
+			updated.Spec.ImageURL = "https://images.example.com/ubuntu-2404-kube-v1.34.qcow2"
+			Expect(k8sClient.Update(testCtx, updated)).To(Succeed())
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.AddDownloadTaskCallCount()).To(Equal(1))
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, ConditionImageVersionMismatch)).To(BeTrue())
+		})
+
+		It("reports an existing disk, and replaces it when existingDiskPolicy is Overwrite", func() {
+			fc := &mock.Client{}
+			fc.AddDownloadTaskReturns(42, nil)
+			r := newReconciler(fc)
+
+			By("waiting for the existing disk to be removed")
+			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			Expect(machine.Status.Phase).To(BeEmpty())
+			ready := meta.FindStatusCondition(machine.Status.Conditions, ReadyCondition)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal("DiskAlreadyExists"))
+
+			By("removing the existing disk before downloading the image")
+			machine.Spec.ExistingDiskPolicy = infrastructurev1alpha1.ExistingDiskOverwrite
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.RemoveFilesCallCount()).To(Equal(1))
+			_, files := fc.RemoveFilesArgsForCall(0)
+			Expect(files).To(ContainElement(machine.Status.DiskPath))
+			Expect(fc.AddDownloadTaskCallCount()).To(Equal(1))
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			Expect(machine.Status.Phase).To(Equal(phaseDownload))
+		})
+
+		It("creates the VM on an existing disk when existingDiskPolicy is Adopt", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.ExistingDiskPolicy = infrastructurev1alpha1.ExistingDiskAdopt
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+			Expect(fc.RemoveFilesCallCount()).To(BeZero())
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			Expect(machine.Status.Phase).To(Equal(phaseResize))
+		})
+
+		It("waits for a download slot when too many downloads are in progress", func() {
+			other := newMachineForPhaseTest("phase-download-other", infrastructurev1alpha1.FreeboxMachineSpec{
+				Name:     "other-vm",
+				VCPUs:    1,
+				MemoryMB: 512,
+				ImageURL: "https://example.com/images/other.raw",
 			})
-			Expect(k8sClient.Create(testCtx, machine)).To(Succeed())
-			// Simulate download just completed: set phase=download, task done -> will transition to extract
-			machine.Status.Phase = phaseDownload
-			machine.Status.TaskID = 99
-			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+			Expect(k8sClient.Create(testCtx, other)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(testCtx, other)).To(Succeed()) })
+			other.Status.Phase = phaseDownload
+			other.Status.TaskID = 5
+			Expect(k8sClient.Status().Update(testCtx, other)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			r := newReconciler(fc)
+			r.MaxConcurrentDownloads = 1
+			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(BeEmpty())
+			ready := meta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal("WaitingForDownloadSlot"))
 		})
 
-		AfterEach(func() {
+		It("waits while the FreeboxCluster reports degraded storage", func() {
+			fbCluster := createFreeboxCluster(testCtx, "phase-storage", infrastructurev1alpha1.FreeboxClusterSpec{})
+			meta.SetStatusCondition(&fbCluster.Status.Conditions, metav1.Condition{
+				Type:   ConditionStorageDegraded,
+				Status: metav1.ConditionTrue,
+				Reason: "DiskError",
+			})
+			Expect(k8sClient.Status().Update(testCtx, fbCluster)).To(Succeed())
+
 			machine := &infrastructurev1alpha1.FreeboxMachine{}
-			_ = k8sClient.Get(testCtx, nn, machine)
-			_ = k8sClient.Delete(testCtx, machine)
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Labels = map[string]string{clusterv1.ClusterNameLabel: "phase-storage"}
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			result, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(1 * time.Minute))
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(BeEmpty())
+			ready := meta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal("StorageDegraded"))
+		})
+
+		It("prepares the disk from the image prefetched by the FreeboxCluster", func() {
+			cachePath := downloadDir + "/prefetch-default-phase-prefetch/" + imageName
+			fbCluster := createFreeboxCluster(testCtx, "phase-prefetch", infrastructurev1alpha1.FreeboxClusterSpec{
+				PrefetchImages: []infrastructurev1alpha1.ImageRef{{URL: imageURL}},
+			})
+			fbCluster.Status.PrefetchedImages = []infrastructurev1alpha1.PrefetchedImage{{URL: imageURL, Path: cachePath, Ready: true}}
+			Expect(k8sClient.Status().Update(testCtx, fbCluster)).To(Succeed())
+
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Labels = map[string]string{clusterv1.ClusterNameLabel: "phase-prefetch"}
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			r := newReconciler(fc)
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseExtract))
+			Expect(updated.Status.ImageCachePath).To(Equal(cachePath))
+
+			fc.ExtractFileReturns(freeboxTypes.FileSystemTask{ID: 9}, nil)
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.ExtractFileCallCount()).To(Equal(1))
+			_, payload := fc.ExtractFileArgsForCall(0)
+			Expect(payload.Src).To(Equal(freeboxTypes.Base64Path(cachePath)))
+		})
+
+		It("copies an image stored on the Freebox instead of downloading it", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.ImageURL = "file:///Freebox/images/debian-13-generic-arm64.qcow2"
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			r := newReconciler(fc)
+			r.ImagePolicy = imagepolicy.Policy{AirGapped: true}
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseCopy))
+			Expect(updated.Status.ImageCachePath).To(Equal("/Freebox/images/debian-13-generic-arm64.qcow2"))
+			Expect(updated.Status.DiskPath).To(Equal(vmStoragePath + "/" + resourceName + ".qcow2"))
+		})
+
+		It("places the disk in the storage failure domain of the owner Machine", func() {
+			createFreeboxCluster(testCtx, "phase-failure-domains", infrastructurev1alpha1.FreeboxClusterSpec{
+				StorageFailureDomains: []infrastructurev1alpha1.FreeboxStorageFailureDomain{
+					{Name: "internal", Path: vmStoragePath},
+					{Name: "usb", Path: "/USB/VMs"},
+				},
+			})
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Labels = map[string]string{clusterv1.ClusterNameLabel: "phase-failure-domains"}
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			fc.AddDownloadTaskReturns(42, nil)
+			r := newReconciler(fc)
+
+			By("waiting for Cluster API to place the owner Machine")
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.DiskPath).To(BeEmpty())
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+
+			owner := createOwnerMachine(testCtx, updated, "v1.34.1", nil)
+			owner.Spec.FailureDomain = "usb"
+			Expect(k8sClient.Update(testCtx, owner)).To(Succeed())
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseDownload))
+			Expect(updated.Status.DiskPath).To(Equal("/USB/VMs/" + resourceName + ".raw"))
+		})
+
+		It("refuses to download external images in air-gapped mode", func() {
+			fc := &mock.Client{}
+			r := newReconciler(fc)
+			r.ImagePolicy = imagepolicy.Policy{AirGapped: true}
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).To(MatchError(ContainSubstring("not allowed in air-gapped mode")))
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			ready := meta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal("ImageNotAllowed"))
+		})
+
+		It("waits for resources when the cluster quota is exceeded", func() {
+			createFreeboxCluster(testCtx, "phase-quota", infrastructurev1alpha1.FreeboxClusterSpec{
+				Quota: &infrastructurev1alpha1.FreeboxResourceQuota{VCPUs: 2},
+			})
+
+			other := newMachineForPhaseTest("phase-quota-other", infrastructurev1alpha1.FreeboxMachineSpec{
+				VCPUs:    2,
+				MemoryMB: 512,
+				ImageURL: imageURL,
+			})
+			other.Labels = map[string]string{clusterv1.ClusterNameLabel: "phase-quota"}
+			Expect(k8sClient.Create(testCtx, other)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(testCtx, other)).To(Succeed()) })
+			other.Status.Phase = phaseDone
+			Expect(k8sClient.Status().Update(testCtx, other)).To(Succeed())
+
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Labels = map[string]string{clusterv1.ClusterNameLabel: "phase-quota"}
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			result, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(BeEmpty())
+			ready := meta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal("QuotaExceeded"))
+			Expect(ready.Message).To(ContainSubstring("3 vCPUs requested, 2 allowed"))
+		})
+	})
+
+	Describe("TestPhaseExtract", func() {
+		const resourceName = "phase-extract-test"
+		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		BeforeEach(func() {
+			machine := newMachineForPhaseTest(resourceName, infrastructurev1alpha1.FreeboxMachineSpec{
+				Name:          "my-vm",
+				VCPUs:         1,
+				MemoryMB:      512,
+				DiskSizeBytes: 10 * 1024 * 1024 * 1024,
+				ImageURL:      imageURL,
+			})
+			Expect(k8sClient.Create(testCtx, machine)).To(Succeed())
+			// Simulate download just completed: set phase=download, task done -> will transition to extract
+			machine.Status.Phase = phaseDownload
+			machine.Status.TaskID = 99
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			_ = k8sClient.Get(testCtx, nn, machine)
+			// Reconciling adds the finalizer, which would keep the object around for the next test.
+			machine.Finalizers = nil
+			_ = k8sClient.Update(testCtx, machine)
+			_ = k8sClient.Delete(testCtx, machine)
+		})
+
+		It("when download task is done, transitions to extract phase with taskID=0", func() {
+			fc := &mock.Client{
+				GetDownloadTaskStub: func(ctx context.Context, id int64) (freeboxTypes.DownloadTask, error) {
+					Expect(id).To(Equal(int64(99)))
+					return freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusDone}, nil
+				},
+			}
+			r := newReconciler(fc)
+			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseExtract))
+			Expect(updated.Status.TaskID).To(Equal(int64(0)))
+		})
+
+		setExtractPhase := func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.Phase = phaseExtract
+			machine.Status.TaskID = 0
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+		}
+
+		It("estimates when the image is ready from the download rate and the timed phases", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.PhaseTransitionTime = &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			downloadTask := freeboxTypes.DownloadTask{
+				Status:        freeboxTypes.DownloadTaskStatusDownloading,
+				SizeBytes:     1200_000_000,
+				ReceivedBytes: 600_000_000,
+				ReceiveRate:   1_000_000,
+			}
+			fc := &mock.Client{}
+			fc.GetDownloadTaskStub = func(ctx context.Context, id int64) (freeboxTypes.DownloadTask, error) {
+				return downloadTask, nil
+			}
+			r := newReconciler(fc)
+			r.phaseDurations.observe(phaseExtract, 2*time.Minute)
+			r.phaseDurations.observe(phaseRename, time.Minute)
+			r.phaseDurations.observe(phaseResize, time.Minute)
+
+			By("adding the rest of the download to the average of the following phases")
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			imageReady := meta.FindStatusCondition(updated.Status.Conditions, ConditionImageReady)
+			Expect(imageReady).NotTo(BeNil())
+			Expect(imageReady.Reason).To(Equal("PreparingImage"))
+			Expect(imageReady.Message).To(Equal("Downloading the image, ready in about 14m"))
+			Expect(updated.Status.ImageETA).NotTo(BeNil())
+			Expect(updated.Status.ImageETA.Time).To(BeTemporally("~", time.Now().Add(14*time.Minute), time.Minute))
+			Expect(testutil.ToFloat64(imageETASeconds.WithLabelValues("default", resourceName))).To(BeNumerically("~", 14*60, 60))
+
+			By("timing the download once it completes")
+			downloadTask = freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusDone}
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseExtract))
+			Expect(updated.Status.PhaseTransitionTime.Time).To(BeTemporally("~", time.Now(), 10*time.Second))
+			downloadDuration, ok := r.phaseDurations.average(phaseDownload)
+			Expect(ok).To(BeTrue())
+			Expect(downloadDuration).To(BeNumerically("~", 10*time.Minute, 10*time.Second))
+		})
+
+		It("records the extraction task even when the controller shuts down right after starting it", func() {
+			setExtractPhase()
+			ctx, cancel := context.WithCancel(testCtx)
+			fc := &mock.Client{
+				ExtractFileStub: func(context.Context, freeboxTypes.ExtractFilePayload) (freeboxTypes.FileSystemTask, error) {
+					cancel()
+					return freeboxTypes.FileSystemTask{ID: 7}, nil
+				},
+			}
+			_, err := newReconciler(fc).Reconcile(ctx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseExtract))
+			Expect(updated.Status.TaskID).To(Equal(int64(7)))
+		})
+
+		It("extracts archives to a directory of their own and moves the disk image found in it", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.ImageURL = "https://example.com/images/gce.tar.gz"
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			setExtractPhase()
+
+			fc := &mock.Client{}
+			fc.ExtractFileReturns(freeboxTypes.FileSystemTask{ID: 7}, nil)
+			r := newReconciler(fc)
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			extractDir := updated.Status.DiskPath + ".extract"
+			Expect(fc.CreateDirectoryCallCount()).To(Equal(1))
+			_, parent, name := fc.CreateDirectoryArgsForCall(0)
+			Expect(path.Join(parent, name)).To(Equal(extractDir))
+			_, payload := fc.ExtractFileArgsForCall(0)
+			Expect(payload.Dst).To(Equal(freeboxTypes.Base64Path(extractDir)))
+
+			fc.GetFileSystemTaskReturns(freeboxTypes.FileSystemTask{ID: 7, State: taskStateDone}, nil)
+			fc.GetFileInfoStub = func(_ context.Context, p string) (freeboxTypes.FileInfo, error) {
+				if p != extractDir+"/disk.raw" {
+					return freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound
+				}
+				return freeboxTypes.FileInfo{Name: "disk.raw", SizeBytes: 2 << 30}, nil
+			}
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseRename))
+			Expect(updated.Status.RenameSrc).To(Equal(extractDir + "/disk.raw"))
+			Expect(updated.Status.RenameDst).To(Equal(updated.Status.DiskPath))
+
+			fc.MoveFilesReturns(freeboxTypes.FileSystemTask{ID: 8}, nil)
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			fc.GetFileSystemTaskReturns(freeboxTypes.FileSystemTask{ID: 8, State: taskStateDone}, nil)
+			removeCalls := fc.RemoveFilesCallCount()
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.RemoveFilesCallCount()).To(Equal(removeCalls + 1))
+			_, removed := fc.RemoveFilesArgsForCall(removeCalls)
+			Expect(removed).To(ConsistOf(extractDir))
+		})
+
+		It("reports an extraction that produced no usable disk image and keeps the downloaded image", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.Phase = phaseExtract
+			machine.Status.TaskID = 7
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetFileSystemTaskReturns(freeboxTypes.FileSystemTask{ID: 7, State: taskStateDone}, nil)
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).To(HaveOccurred())
+			Expect(fc.RemoveFilesCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseExtract))
+			cond := meta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal("ExtractedDiskNotFound"))
+			Expect(cond.Message).To(ContainSubstring(vmStoragePath))
+		})
+
+		It("reports an extraction that wrote an empty disk image", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.Phase = phaseExtract
+			machine.Status.TaskID = 7
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetFileSystemTaskReturns(freeboxTypes.FileSystemTask{ID: 7, State: taskStateDone}, nil)
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).To(MatchError(ContainSubstring("is empty")))
+			Expect(fc.RemoveFilesCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseExtract))
+			cond := meta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Message).To(HavePrefix("Image extraction verification failed"))
+		})
+
+		It("resumes an unfinished extraction whose task ID was not recorded instead of starting another one", func() {
+			setExtractPhase()
+			fc := &mock.Client{}
+			fc.ListFileSystemTasksReturns([]freeboxTypes.FileSystemTask{
+				{ID: 5, Type: freeboxTypes.FileTaskTypeExtract, State: freeboxTypes.FileTaskStateDone, Sources: []string{downloadPath}},
+				{ID: 6, Type: freeboxTypes.FileTaskTypeCopy, State: freeboxTypes.FileTaskStateRunning, Sources: []string{downloadPath}},
+				{ID: 8, Type: freeboxTypes.FileTaskTypeExtract, State: freeboxTypes.FileTaskStateRunning, Sources: []string{downloadPath}},
+			}, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.ExtractFileCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.TaskID).To(Equal(int64(8)))
+		})
+	})
+
+	Describe("TestPhaseCopy", func() {
+		const resourceName = "phase-copy-test"
+		// Use an uncompressed image so the copy path is exercised
+		const uncompressedURL = "https://example.com/images/nocloud.raw"
+		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		BeforeEach(func() {
+			machine := newMachineForPhaseTest(resourceName, infrastructurev1alpha1.FreeboxMachineSpec{
+				Name:          "my-vm",
+				VCPUs:         1,
+				MemoryMB:      512,
+				DiskSizeBytes: 10 * 1024 * 1024 * 1024,
+				ImageURL:      uncompressedURL,
+			})
+			Expect(k8sClient.Create(testCtx, machine)).To(Succeed())
+			machine.Status.Phase = phaseDownload
+			machine.Status.TaskID = 77
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			_ = k8sClient.Get(testCtx, nn, machine)
+			machine.Finalizers = nil
+			_ = k8sClient.Update(testCtx, machine)
+			_ = k8sClient.Delete(testCtx, machine)
+		})
+
+		It("when download task done for uncompressed image on another volume, transitions to copy phase", func() {
+			fc := &mock.Client{
+				GetDownloadTaskStub: func(ctx context.Context, id int64) (freeboxTypes.DownloadTask, error) {
+					return freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusDone}, nil
+				},
+			}
+			r := newReconciler(fc)
+			r.FreeboxDownloadDir = "/USB/downloads"
+			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseCopy))
+			Expect(updated.Status.TaskID).To(Equal(int64(0)))
+		})
+
+		It("creates the VM storage directory before copying when it does not exist", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.Phase = phaseCopy
+			machine.Status.TaskID = 0
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetFileInfoStub = func(_ context.Context, p string) (freeboxTypes.FileInfo, error) {
+				if p == vmStoragePath {
+					return freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound
+				}
+				return freeboxTypes.FileInfo{}, nil
+			}
+			fc.CopyFilesReturns(freeboxTypes.FileSystemTask{ID: 12}, nil)
+			r := newReconciler(fc)
+			r.FreeboxDownloadDir = "/USB/downloads"
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.CreateDirectoryCallCount()).To(Equal(1))
+			_, parent, name := fc.CreateDirectoryArgsForCall(0)
+			Expect(path.Join(parent, name)).To(Equal(vmStoragePath))
+			Expect(fc.CopyFilesCallCount()).To(Equal(1))
+
+			By("reusing the directory once it exists")
+			fc.GetFileInfoStub = nil
+			Expect(r.ensureVMStorageDir(testCtx, vmStoragePath)).To(Succeed())
+			Expect(fc.CreateDirectoryCallCount()).To(Equal(1))
+		})
+
+		It("when download task done for uncompressed image on the VM storage volume, moves it instead of copying", func() {
+			fc := &mock.Client{}
+			fc.GetDownloadTaskReturns(freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusDone}, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseRename))
+			Expect(updated.Status.TaskID).To(Equal(int64(0)))
+			Expect(updated.Status.RenameSrc).To(Equal(downloadDir + "/nocloud.raw"))
+			Expect(updated.Status.RenameDst).To(Equal(vmStoragePath + "/" + resourceName + ".raw"))
+			Expect(fc.CopyFilesCallCount()).To(BeZero())
+		})
+
+		DescribeTable("verifies the size of the copied image",
+			func(copiedSize uint64, wantPhase string, wantTaskID int64) {
+				machine := &infrastructurev1alpha1.FreeboxMachine{}
+				Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+				machine.Status.Phase = phaseCopy
+				machine.Status.TaskID = 12
+				Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+				fc := &mock.Client{}
+				fc.GetFileSystemTaskReturns(freeboxTypes.FileSystemTask{ID: 12, State: taskStateDone}, nil)
+				fc.GetFileInfoStub = func(_ context.Context, p string) (freeboxTypes.FileInfo, error) {
+					if p == downloadDir+"/nocloud.raw" {
+						return freeboxTypes.FileInfo{SizeBytes: 1024}, nil
+					}
+					return freeboxTypes.FileInfo{SizeBytes: copiedSize}, nil
+				}
+				_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+
+				updated := &infrastructurev1alpha1.FreeboxMachine{}
+				Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+				Expect(updated.Status.Phase).To(Equal(wantPhase))
+				Expect(updated.Status.TaskID).To(Equal(wantTaskID))
+				if copiedSize != 1024 {
+					Expect(err).To(HaveOccurred())
+					Expect(fc.RemoveFilesCallCount()).To(BeZero())
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fc.RemoveFilesCallCount()).To(Equal(1))
+				}
+			},
+			Entry("complete copy goes on with the rename", uint64(1024), phaseRename, int64(0)),
+			Entry("truncated copy is started again", uint64(512), phaseCopy, int64(0)),
+		)
+	})
+
+	Describe("TestPhaseRename", func() {
+		const resourceName = "phase-rename-test"
+		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		BeforeEach(func() {
+			machine := newMachineForPhaseTest(resourceName, infrastructurev1alpha1.FreeboxMachineSpec{
+				Name:          "my-vm",
+				VCPUs:         1,
+				MemoryMB:      512,
+				DiskSizeBytes: 10 * 1024 * 1024 * 1024,
+				ImageURL:      imageURL,
+			})
+			Expect(k8sClient.Create(testCtx, machine)).To(Succeed())
+			// Simulate extract done -> rename pending
+			machine.Status.Phase = phaseRename
+			machine.Status.TaskID = 0
+			machine.Status.RenameSrc = vmStoragePath + "/" + extractedBase
+			machine.Status.RenameDst = vmStoragePath + "/my-vm.raw"
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			_ = k8sClient.Get(testCtx, nn, machine)
+			machine.Finalizers = nil
+			_ = k8sClient.Update(testCtx, machine)
+			_ = k8sClient.Delete(testCtx, machine)
+		})
+
+		It("waits for the extracted image to be indexed before renaming it", func() {
+			fc := &mock.Client{}
+			fc.GetFileInfoReturnsOnCall(0, freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			fc.MoveFilesReturns(freeboxTypes.FileSystemTask{ID: 55}, nil)
+			r := newReconciler(fc)
+
+			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+			Expect(fc.MoveFilesCallCount()).To(BeZero())
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			imageReady := meta.FindStatusCondition(updated.Status.Conditions, ConditionImageReady)
+			Expect(imageReady).NotTo(BeNil())
+			Expect(imageReady.Reason).To(Equal("WaitingForFileIndexing"))
+
+			By("renaming it once it is visible")
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.MoveFilesCallCount()).To(Equal(1))
+		})
+
+		It("waits for the copied image to be indexed rather than copying it again", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.Phase = phaseCopy
+			machine.Status.TaskID = 12
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetFileSystemTaskReturns(freeboxTypes.FileSystemTask{ID: 12, State: taskStateDone, DoneTimestamp: time.Now().Unix()}, nil)
+			fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+			result, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Requeue).To(BeTrue())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseCopy))
+			Expect(updated.Status.TaskID).To(Equal(int64(12)))
+		})
+
+		It("when rename task started and done, transitions to resize phase", func() {
+			callCount := 0
+			fc := &mock.Client{
+				MoveFilesStub: func(ctx context.Context, srcs []string, dst string, mode freeboxTypes.FileMoveMode) (freeboxTypes.FileSystemTask, error) {
+					Expect(srcs).To(ConsistOf(vmStoragePath + "/" + extractedBase))
+					Expect(dst).To(Equal(vmStoragePath + "/my-vm.raw"))
+					callCount++
+					return freeboxTypes.FileSystemTask{ID: 55}, nil
+				},
+			}
+			r := newReconciler(fc)
+
+			// First reconcile: starts the rename task
+			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(callCount).To(Equal(1))
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.TaskID).To(Equal(int64(55)))
+
+			// Second reconcile: task is done → transition to resize
+			fc.MoveFilesStub = nil
+			fc.GetFileSystemTaskStub = func(ctx context.Context, id int64) (freeboxTypes.FileSystemTask, error) {
+				Expect(id).To(Equal(int64(55)))
+				return freeboxTypes.FileSystemTask{State: taskStateDone}, nil
+			}
+			result, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseResize))
+			Expect(updated.Status.TaskID).To(Equal(int64(0)))
+			Expect(updated.Status.RenameSrc).To(BeEmpty())
+			Expect(updated.Status.RenameDst).To(BeEmpty())
+		})
+	})
+
+	Describe("TestPhaseVerify", func() {
+		const (
+			resourceName = "phase-verify-test"
+			diskHash     = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+		)
+		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		BeforeEach(func() {
+			machine := newMachineForPhaseTest(resourceName, infrastructurev1alpha1.FreeboxMachineSpec{
+				Name:            "my-vm",
+				VCPUs:           1,
+				MemoryMB:        512,
+				DiskSizeBytes:   10 * 1024 * 1024 * 1024,
+				ImageURL:        imageURL,
+				DiskImageSHA256: diskHash,
+			})
+			Expect(k8sClient.Create(testCtx, machine)).To(Succeed())
+			machine.Status.Phase = phaseRename
+			machine.Status.TaskID = 8
+			machine.Status.RenameSrc = vmStoragePath + "/" + extractedBase
+			machine.Status.RenameDst = vmStoragePath + "/" + resourceName + ".raw"
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			_ = k8sClient.Get(testCtx, nn, machine)
+			machine.Finalizers = nil
+			_ = k8sClient.Update(testCtx, machine)
+			_ = k8sClient.Delete(testCtx, machine)
+		})
+
+		DescribeTable("hashes the disk image before resizing it",
+			func(hash string, wantPhase string) {
+				fc := &mock.Client{}
+				fc.GetFileSystemTaskReturns(freeboxTypes.FileSystemTask{ID: 8, State: taskStateDone}, nil)
+				r := newReconciler(fc)
+
+				By("verifying the renamed disk")
+				_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+				Expect(err).NotTo(HaveOccurred())
+				updated := &infrastructurev1alpha1.FreeboxMachine{}
+				Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+				Expect(updated.Status.Phase).To(Equal(phaseVerify))
+
+				By("starting a hash task of the Freebox")
+				fc.AddHashFileTaskReturns(freeboxTypes.FileSystemTask{ID: 9}, nil)
+				_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fc.AddHashFileTaskCallCount()).To(Equal(1))
+				_, payload := fc.AddHashFileTaskArgsForCall(0)
+				Expect(payload.HashType).To(Equal(freeboxTypes.HashTypeSHA256))
+				Expect(payload.Path).To(Equal(freeboxTypes.Base64Path(vmStoragePath + "/" + resourceName + ".raw")))
+				Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+				Expect(updated.Status.TaskID).To(Equal(int64(9)))
+
+				By("comparing its result")
+				fc.GetFileSystemTaskReturns(freeboxTypes.FileSystemTask{ID: 9, State: taskStateDone}, nil)
+				fc.GetHashResultReturns(hash, nil)
+				_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+				Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+				Expect(updated.Status.Phase).To(Equal(wantPhase))
+				if wantPhase == phaseVerify {
+					Expect(err).To(HaveOccurred())
+					Expect(updated.Status.TaskID).To(Equal(int64(9)))
+					cond := meta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+					Expect(cond).NotTo(BeNil())
+					Expect(cond.Reason).To(Equal("DiskImageHashMismatch"))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(updated.Status.TaskID).To(BeZero())
+				}
+				Expect(fc.ResizeVirtualDiskCallCount()).To(BeZero())
+			},
+			Entry("matching disk goes on with the resize", strings.ToUpper(diskHash), phaseResize),
+			Entry("corrupted disk is reported", "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855", phaseVerify),
+		)
+	})
+
+	Describe("TestPhaseResize", func() {
+		const resourceName = "phase-resize-test"
+		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
+
+		BeforeEach(func() {
+			machine := newMachineForPhaseTest(resourceName, infrastructurev1alpha1.FreeboxMachineSpec{
+				Name:          "my-vm",
+				VCPUs:         1,
+				MemoryMB:      512,
+				DiskSizeBytes: 20 * 1024 * 1024 * 1024,
+				ImageURL:      imageURL,
+			})
+			Expect(k8sClient.Create(testCtx, machine)).To(Succeed())
+			machine.Status.Phase = phaseResize
+			machine.Status.TaskID = 0
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			_ = k8sClient.Get(testCtx, nn, machine)
+			machine.Finalizers = nil
+			_ = k8sClient.Update(testCtx, machine)
+			_ = k8sClient.Delete(testCtx, machine)
+		})
+
+		It("when resize task started and done, sets ImageReady condition (Phase stays resize until fully provisioned)", func() {
+			callCount := 0
+			fc := &mock.Client{
+				ResizeVirtualDiskStub: func(ctx context.Context, p freeboxTypes.VirtualDisksResizePayload) (int64, error) {
+					callCount++
+					return 88, nil
+				},
+			}
+			r := newReconciler(fc)
+
+			// First reconcile: starts resize task
+			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(callCount).To(Equal(1))
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.TaskID).To(Equal(int64(88)))
+			Expect(updated.Status.TaskHistory).To(HaveLen(1))
+			Expect(updated.Status.TaskHistory[0].ID).To(Equal(int64(88)))
+			Expect(updated.Status.TaskHistory[0].Type).To(Equal(phaseResize))
+			Expect(updated.Status.TaskHistory[0].Result).To(Equal(infrastructurev1alpha1.TaskRunning))
+
+			// Second reconcile: task done → ImageReady condition set, Phase stays "resize"
+			fc.ResizeVirtualDiskStub = nil
+			fc.GetVirtualDiskTaskStub = func(ctx context.Context, id int64) (freeboxTypes.VirtualMachineDiskTask, error) {
+				Expect(id).To(Equal(int64(88)))
+				return freeboxTypes.VirtualMachineDiskTask{Done: true, Error: false}, nil
+			}
+			// The reconciler proceeds to VM creation after resize; since there is no CAPI
+			// Machine owner it returns early. We verify ImageReady is set but Phase is NOT
+			// prematurely "done" — that would break the IP-polling requeue loop.
+			fc.ListDownloadTasksStub = nil // not called in this path
+			// May return error or requeue — the VM creation path requires owner Machine; that's fine.
+			_, _ = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			// Phase must remain "resize" until VM creation + IP assignment are complete.
+			Expect(updated.Status.Phase).To(Equal(phaseResize))
+
+			var imageReadyCond *metav1.Condition
+			for i := range updated.Status.Conditions {
+				if updated.Status.Conditions[i].Type == ConditionImageReady {
+					imageReadyCond = &updated.Status.Conditions[i]
+					break
+				}
+			}
+			Expect(imageReadyCond).NotTo(BeNil())
+			Expect(imageReadyCond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(updated.Status.TaskHistory[0].Result).To(Equal(infrastructurev1alpha1.TaskSucceeded))
+			Expect(updated.Status.TaskHistory[0].Duration).NotTo(BeNil())
+		})
+
+		It("stops once the disk of an image-only machine is prepared, without creating a VM", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.ImageManagement = infrastructurev1alpha1.ImageManagementImageOnly
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			r := newReconciler(fc)
+			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseResize))
+			Expect(updated.Status.TaskID).To(BeZero())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionImageReady)).To(BeTrue())
+			ready := meta.FindStatusCondition(updated.Status.Conditions, ReadyCondition)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+			Expect(ready.Reason).To(Equal(reasonImagePrepared))
+
+			// Later reconciles leave the prepared disk alone.
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.ResizeVirtualDiskCallCount()).To(BeZero())
+			Expect(fc.CreateVirtualMachineCallCount()).To(BeZero())
+		})
+
+		It("reports it is waiting for bootstrap data until the Machine references its secret", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			owner := createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			owner.Spec.Bootstrap.DataSecretName = nil
+			owner.Spec.Bootstrap.ConfigRef = clusterv1.ContractVersionedObjectReference{
+				APIGroup: "bootstrap.cluster.x-k8s.io",
+				Kind:     "KubeadmConfig",
+				Name:     machine.Name,
+			}
+			Expect(k8sClient.Update(testCtx, owner)).To(Succeed())
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			result, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(fc.CreateVirtualMachineCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionBootstrapDataReady)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("WaitingForBootstrapData"))
+		})
+
+		It("creates the VM and reports the resources it was created with", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			diskPath := vmStoragePath + "/" + resourceName + ".raw"
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			fc.GetVirtualDiskInfoReturns(freeboxTypes.VirtualDiskInfo{Type: freeboxTypes.QCow2Disk, VirtualSize: 20 * 1024 * 1024 * 1024}, nil)
+			upload := &uploadBuffer{}
+			fc.FileUploadStartReturns(upload, 0, nil)
+			fc.CreateVirtualMachineStub = func(_ context.Context, p freeboxTypes.VirtualMachinePayload) (freeboxTypes.VirtualMachine, error) {
+				return freeboxTypes.VirtualMachine{ID: 7, VirtualMachinePayload: p}, nil
+			}
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.CreateVirtualMachineCallCount()).To(Equal(1))
+			Expect(fc.StartVirtualMachineCallCount()).To(Equal(1))
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseVMCreated))
+			Expect(updated.Status.DiskPath).To(Equal(diskPath))
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionBootstrapDataReady)).To(BeTrue())
+			Expect(updated.Status.Resources).To(Equal(&infrastructurev1alpha1.FreeboxMachineResources{
+				VCPUs:         1,
+				MemoryMB:      512,
+				DiskSizeBytes: 20 * 1024 * 1024 * 1024,
+				DiskType:      freeboxTypes.QCow2Disk,
+			}))
+
+			Expect(fc.FileUploadStartCallCount()).To(Equal(1))
+			_, uploadInput := fc.FileUploadStartArgsForCall(0)
+			Expect(uploadInput.Filename).To(Equal(resourceName + ".raw.meta.json"))
+			var metadata vmMetadata
+			Expect(json.Unmarshal(upload.Bytes(), &metadata)).To(Succeed())
+			Expect(metadata.VMID).To(Equal(int64(7)))
+			Expect(metadata.Namespace).To(Equal("default"))
+			Expect(metadata.FreeboxMachine).To(Equal(resourceName))
+			Expect(metadata.Instance).To(BeEmpty())
+			Expect(updated.Status.Template).NotTo(BeNil())
+			Expect(updated.Status.Template.Hash).NotTo(BeEmpty())
+			Expect(metadata.TemplateHash).To(Equal(updated.Status.Template.Hash))
+		})
+
+		It("creates the VM on an unmanaged disk without preparing an image", func() {
+			diskPath := "/Freebox/VMs/prepared.qcow2"
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.ImageManagement = infrastructurev1alpha1.ImageManagementUnmanaged
+			machine.Spec.DiskPath = diskPath
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			machine.Status.Phase = ""
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+
+			fc := &mock.Client{}
+			fc.GetVirtualDiskInfoReturns(freeboxTypes.VirtualDiskInfo{}, fmt.Errorf("no such file"))
+			fc.FileUploadStartReturns(&uploadBuffer{}, 0, nil)
+			fc.CreateVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: 7}, nil)
+			r := newReconciler(fc)
+
+			By("waiting for the disk")
+			for range 2 {
+				_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(fc.CreateVirtualMachineCallCount()).To(BeZero())
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.DiskPath).To(Equal(diskPath))
+			cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionImageReady)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Reason).To(Equal("DiskNotFound"))
+
+			By("creating the VM once the disk exists")
+			fc.GetVirtualDiskInfoReturns(freeboxTypes.VirtualDiskInfo{Type: freeboxTypes.QCow2Disk}, nil)
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.AddDownloadTaskCallCount()).To(BeZero())
+			Expect(fc.ResizeVirtualDiskCallCount()).To(BeZero())
+			Expect(fc.CreateVirtualMachineCallCount()).To(Equal(1))
+			_, payload := fc.CreateVirtualMachineArgsForCall(0)
+			Expect(payload.DiskPath).To(Equal(freeboxTypes.Base64Path(diskPath)))
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseVMCreated))
+		})
+
+		It("requests a refresh instead of creating the VM when the join token is about to expire", func() {
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       clusterv1.ClusterSpec{Paused: ptr.To(false)},
+			}
+			Expect(k8sClient.Create(testCtx, cluster)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(testCtx, cluster)).To(Succeed()) })
+			config := &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					JoinConfiguration: bootstrapv1.JoinConfiguration{
+						Discovery: bootstrapv1.Discovery{
+							BootstrapToken: bootstrapv1.BootstrapTokenDiscovery{Token: "abcdef.0123456789abcdef"},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, config)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(testCtx, config)).To(Succeed()) })
+
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Labels = map[string]string{clusterv1.ClusterNameLabel: cluster.Name}
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			owner := createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			owner.Spec.Bootstrap.ConfigRef = clusterv1.ContractVersionedObjectReference{
+				APIGroup: bootstrapv1.GroupVersion.Group,
+				Kind:     "KubeadmConfig",
+				Name:     config.Name,
+			}
+			Expect(k8sClient.Update(testCtx, owner)).To(Succeed())
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			token := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "bootstrap-token-abcdef", Namespace: metav1.NamespaceSystem},
+				Data:       map[string][]byte{"expiration": []byte(time.Now().Add(time.Minute).UTC().Format(time.RFC3339))},
+			}
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			r := newReconciler(fc)
+			r.ClusterCache = &fakeClusterCache{workloadClient: newFakeWorkloadClient(token)}
+			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).NotTo(BeZero())
+			Expect(fc.CreateVirtualMachineCallCount()).To(BeZero())
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			cond := meta.FindStatusCondition(updated.Status.Conditions, ConditionBootstrapDataReady)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+			Expect(cond.Reason).To(Equal("BootstrapTokenExpiring"))
+			Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(config), config)).To(Succeed())
+			Expect(config.Annotations).To(HaveKey(BootstrapTokenRefreshAnnotation))
+		})
+
+		It("writes the status once per reconcile", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			fc.FileUploadStartReturns(&uploadBuffer{}, 0, nil)
+			fc.CreateVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: 7}, nil)
+			counter := &statusWriteCounter{Client: k8sClient}
+			r := newReconciler(fc)
+			r.Client = counter
+			// Finishing the resize, creating and starting the VM used to write the status twice.
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.StartVirtualMachineCallCount()).To(Equal(1))
+			Expect(counter.writes).To(Equal(1))
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseVMCreated))
+			Expect(updated.Status.VMID).To(Equal(ptr.To[int64](7)))
+		})
+
+		It("refuses to reuse a VM whose metadata names another FreeboxMachine", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			diskPath := vmStoragePath + "/" + resourceName + ".raw"
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			fc.ListVirtualMachinesReturns([]freeboxTypes.VirtualMachine{{
+				ID: 5,
+				VirtualMachinePayload: freeboxTypes.VirtualMachinePayload{
+					Name:     resourceName,
+					DiskPath: freeboxTypes.Base64Path(diskPath),
+				},
+			}}, nil)
+			fc.GetFileReturns(freeboxTypes.File{
+				Content: strings.NewReader(`{"vmID":5,"namespace":"other","freeboxMachine":"` + resourceName + `"}`),
+			}, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).To(MatchError(ContainSubstring("belongs to FreeboxMachine other/" + resourceName)))
+			Expect(fc.CreateVirtualMachineCallCount()).To(BeZero())
+		})
+
+		It("refuses to reuse a VM whose metadata names another provider instance", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			diskPath := vmStoragePath + "/" + resourceName + ".raw"
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			fc.ListVirtualMachinesReturns([]freeboxTypes.VirtualMachine{{
+				ID: 5,
+				VirtualMachinePayload: freeboxTypes.VirtualMachinePayload{
+					Name:     resourceName,
+					DiskPath: freeboxTypes.Base64Path(diskPath),
+				},
+			}}, nil)
+			fc.GetFileReturns(freeboxTypes.File{
+				Content: strings.NewReader(`{"vmID":5,"namespace":"default","freeboxMachine":"` + resourceName + `","instance":"staging"}`),
+			}, nil)
+			r := newReconciler(fc)
+			r.InstanceID = "production"
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).To(MatchError(ContainSubstring("of provider instance staging")))
+			Expect(fc.CreateVirtualMachineCallCount()).To(BeZero())
+		})
+
+		It("refuses to reuse a VM already recorded by another FreeboxMachine", func() {
+			other := newMachineForPhaseTest(resourceName+"-other", infrastructurev1alpha1.FreeboxMachineSpec{
+				Name:          "other-vm",
+				VCPUs:         1,
+				MemoryMB:      512,
+				DiskSizeBytes: 10 * 1024 * 1024 * 1024,
+			})
+			Expect(k8sClient.Create(testCtx, other)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(testCtx, other)).To(Succeed()) })
+			other.Status.VMID = ptr.To[int64](5)
+			Expect(k8sClient.Status().Update(testCtx, other)).To(Succeed())
+
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			diskPath := vmStoragePath + "/" + resourceName + ".raw"
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			fc.ListVirtualMachinesReturns([]freeboxTypes.VirtualMachine{{
+				ID: 5,
+				VirtualMachinePayload: freeboxTypes.VirtualMachinePayload{
+					Name:     resourceName,
+					DiskPath: freeboxTypes.Base64Path(diskPath),
+				},
+			}}, nil)
+			fc.GetFileReturns(freeboxTypes.File{}, freeboxclient.ErrPathNotFound)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).To(MatchError(ContainSubstring("already used by FreeboxMachine default/" + other.Name)))
+			Expect(fc.CreateVirtualMachineCallCount()).To(BeZero())
+		})
+
+		It("stops VMs of lower-priority clusters to make room for the VM", func() {
+			createFreeboxCluster(testCtx, "phase-priority-high", infrastructurev1alpha1.FreeboxClusterSpec{Priority: ptr.To[int32](10)})
+			createFreeboxCluster(testCtx, "phase-priority-low", infrastructurev1alpha1.FreeboxClusterSpec{Priority: ptr.To[int32](1)})
+
+			victim := newMachineForPhaseTest("phase-priority-victim", infrastructurev1alpha1.FreeboxMachineSpec{
+				VCPUs:    2,
+				MemoryMB: 1024,
+				ImageURL: imageURL,
+			})
+			victim.Labels = map[string]string{clusterv1.ClusterNameLabel: "phase-priority-low"}
+			Expect(k8sClient.Create(testCtx, victim)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(testCtx, victim)).To(Succeed()) })
+			victim.Status.Phase = phaseDone
+			victim.Status.VMID = ptr.To[int64](3)
+			Expect(k8sClient.Status().Update(testCtx, victim)).To(Succeed())
+
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Labels = map[string]string{clusterv1.ClusterNameLabel: "phase-priority-high"}
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			fc.FileUploadStartReturns(&uploadBuffer{}, 0, nil)
+			fc.CreateVirtualMachineStub = func(_ context.Context, p freeboxTypes.VirtualMachinePayload) (freeboxTypes.VirtualMachine, error) {
+				return freeboxTypes.VirtualMachine{ID: 7, VirtualMachinePayload: p}, nil
+			}
+			fc.GetVirtualMachineInfoReturns(freeboxTypes.VirtualMachinesInfo{TotalCPUs: 2, UsedCPUs: 2, TotalMemory: 2048, UsedMemory: 1024}, nil)
+			fc.GetVirtualMachineReturns(freeboxTypes.VirtualMachine{
+				ID:                    3,
+				Status:                "running",
+				VirtualMachinePayload: freeboxTypes.VirtualMachinePayload{VCPUs: 2, Memory: 1024},
+			}, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.StopVirtualMachineCallCount()).To(Equal(1))
+			_, stoppedID := fc.StopVirtualMachineArgsForCall(0)
+			Expect(stoppedID).To(Equal(int64(3)))
+			Expect(fc.StartVirtualMachineCallCount()).To(Equal(1))
+		})
+
+		It("only stops VMs of lower-priority clusters on the same Freebox", func() {
+			createFreeboxCluster(testCtx, "phase-priority-high", infrastructurev1alpha1.FreeboxClusterSpec{Priority: ptr.To[int32](10)})
+			createFreeboxCluster(testCtx, "phase-priority-low", infrastructurev1alpha1.FreeboxClusterSpec{Priority: ptr.To[int32](1)})
+
+			// The machine of the other Freebox is listed first.
+			victims := []struct {
+				name   string
+				serial string
+				vmID   int64
+			}{
+				{name: "phase-priority-a-other-box", serial: "box-b", vmID: 3},
+				{name: "phase-priority-b-same-box", serial: "box-a", vmID: 4},
+			}
+			for _, v := range victims {
+				victim := newMachineForPhaseTest(v.name, infrastructurev1alpha1.FreeboxMachineSpec{
+					VCPUs:    2,
+					MemoryMB: 1024,
+					ImageURL: imageURL,
+				})
+				victim.Labels = map[string]string{clusterv1.ClusterNameLabel: "phase-priority-low"}
+				Expect(k8sClient.Create(testCtx, victim)).To(Succeed())
+				DeferCleanup(func() { Expect(k8sClient.Delete(testCtx, victim)).To(Succeed()) })
+				victim.Status.Phase = phaseDone
+				victim.Status.VMID = ptr.To(v.vmID)
+				victim.Status.FreeboxSerial = v.serial
+				Expect(k8sClient.Status().Update(testCtx, victim)).To(Succeed())
+			}
+
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Labels = map[string]string{clusterv1.ClusterNameLabel: "phase-priority-high"}
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			fc.FileUploadStartReturns(&uploadBuffer{}, 0, nil)
+			fc.CreateVirtualMachineStub = func(_ context.Context, p freeboxTypes.VirtualMachinePayload) (freeboxTypes.VirtualMachine, error) {
+				return freeboxTypes.VirtualMachine{ID: 7, VirtualMachinePayload: p}, nil
+			}
+			fc.GetVirtualMachineInfoReturns(freeboxTypes.VirtualMachinesInfo{TotalCPUs: 2, UsedCPUs: 2, TotalMemory: 4096, UsedMemory: 2048}, nil)
+			fc.GetVirtualMachineStub = func(_ context.Context, id int64) (freeboxTypes.VirtualMachine, error) {
+				return freeboxTypes.VirtualMachine{
+					ID:                    id,
+					Status:                "running",
+					VirtualMachinePayload: freeboxTypes.VirtualMachinePayload{VCPUs: 2, Memory: 1024},
+				}, nil
+			}
+			reconciler := newReconciler(fc)
+			reconciler.FreeboxSerial = "box-a"
+			_, err := reconciler.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.StopVirtualMachineCallCount()).To(Equal(1))
+			_, stoppedID := fc.StopVirtualMachineArgsForCall(0)
+			Expect(stoppedID).To(Equal(int64(4)))
+		})
+
+		It("leaves the VM stopped when autoStart is disabled", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.AutoStart = ptr.To(false)
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			fc.FileUploadStartReturns(&uploadBuffer{}, 0, nil)
+			fc.CreateVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: 7, Status: "stopped"}, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.CreateVirtualMachineCallCount()).To(Equal(1))
+			Expect(fc.StartVirtualMachineCallCount()).To(BeZero())
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			Expect(updated.Status.Phase).To(Equal(phaseVMCreated))
+		})
+
+		It("creates the VM without cloud-init when it is skipped", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.CloudInit = infrastructurev1alpha1.CloudInitSkip
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			fc.FileUploadStartReturns(&uploadBuffer{}, 0, nil)
+			fc.CreateVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: 7}, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.CreateVirtualMachineCallCount()).To(Equal(1))
+			_, payload := fc.CreateVirtualMachineArgsForCall(0)
+			Expect(payload.EnableCloudInit).To(BeFalse())
+			Expect(payload.CloudInitUserData).To(BeEmpty())
+		})
+
+		It("merges the files and commands of the machine into the cloud-config", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.Files = []infrastructurev1alpha1.CloudInitFile{{Path: "/etc/sysctl.d/99-inotify.conf", Content: "fs.inotify.max_user_instances = 8192\n"}}
+			machine.Spec.Commands = []string{"sysctl --system"}
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("## template: jinja\n#cloud-config\nruncmd:\n- kubeadm join\n"))
+			machine.Status.TaskID = 88
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{Done: true}, nil)
+			fc.FileUploadStartReturns(&uploadBuffer{}, 0, nil)
+			fc.CreateVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: 7}, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.CreateVirtualMachineCallCount()).To(Equal(1))
+			_, payload := fc.CreateVirtualMachineArgsForCall(0)
+			Expect(payload.CloudInitUserData).To(HavePrefix("## template: jinja\n#cloud-config\n"))
+			Expect(payload.CloudInitUserData).To(ContainSubstring("path: /etc/sysctl.d/99-inotify.conf"))
+			Expect(payload.CloudInitUserData).To(ContainSubstring("runcmd:\n- sysctl --system\n- kubeadm join\n"))
 		})
 
-		It("when download task is done, transitions to extract phase with taskID=0", func() {
-			fc := &fakeClient{
-				getDownloadTaskFn: func(ctx context.Context, id int64) (freeboxTypes.DownloadTask, error) {
-					Expect(id).To(Equal(int64(99)))
-					return freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusDone}, nil
-				},
-			}
-			r := newReconciler(fc)
-			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+		It("resizes the disk recorded in status rather than recomputing its path", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.DiskPath = vmStoragePath + "/legacy-name.raw"
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.ResizeVirtualDiskReturns(88, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).NotTo(BeZero())
 
-			updated := &infrastructurev1alpha1.FreeboxMachine{}
-			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
-			Expect(updated.Status.Phase).To(Equal(phaseExtract))
-			Expect(updated.Status.TaskID).To(Equal(int64(0)))
+			Expect(fc.ResizeVirtualDiskCallCount()).To(Equal(1))
+			_, payload := fc.ResizeVirtualDiskArgsForCall(0)
+			Expect(payload.DiskPath).To(Equal(freeboxTypes.Base64Path(vmStoragePath + "/legacy-name.raw")))
 		})
 	})
 
-	Describe("TestPhaseCopy", func() {
-		const resourceName = "phase-copy-test"
-		// Use an uncompressed image so the copy path is exercised
-		const uncompressedURL = "https://example.com/images/nocloud.raw"
+	Describe("TestConcurrentReconciles", func() {
+		const resourceName = "phase-concurrency-test"
 		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
 
 		BeforeEach(func() {
 			machine := newMachineForPhaseTest(resourceName, infrastructurev1alpha1.FreeboxMachineSpec{
-				Name:          "my-vm",
 				VCPUs:         1,
 				MemoryMB:      512,
-				DiskSizeBytes: 10 * 1024 * 1024 * 1024,
-				ImageURL:      uncompressedURL,
+				DiskSizeBytes: 20 * 1024 * 1024 * 1024,
+				ImageURL:      "https://example.com/images/debian.qcow2",
 			})
 			Expect(k8sClient.Create(testCtx, machine)).To(Succeed())
-			machine.Status.Phase = phaseDownload
-			machine.Status.TaskID = 77
-			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
 		})
 
 		AfterEach(func() {
 			machine := &infrastructurev1alpha1.FreeboxMachine{}
 			_ = k8sClient.Get(testCtx, nn, machine)
+			machine.Finalizers = nil
+			_ = k8sClient.Update(testCtx, machine)
 			_ = k8sClient.Delete(testCtx, machine)
 		})
 
-		It("when download task done for uncompressed image, transitions to copy phase", func() {
-			fc := &fakeClient{
-				getDownloadTaskFn: func(ctx context.Context, id int64) (freeboxTypes.DownloadTask, error) {
-					return freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusDone}, nil
+		It("never starts a Freebox task twice when workers reconcile a machine in parallel from a lagging cache", func() {
+			// The Freebox lists the download tasks that were started and not removed.
+			var mu sync.Mutex
+			var downloads []freeboxTypes.DownloadTask
+			fc := &mock.Client{
+				ListDownloadTasksStub: func(context.Context) ([]freeboxTypes.DownloadTask, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					return slices.Clone(downloads), nil
+				},
+				AddDownloadTaskStub: func(_ context.Context, req freeboxTypes.DownloadRequest) (int64, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					downloads = append(downloads, freeboxTypes.DownloadTask{
+						ID: 42, Name: req.Filename, DownloadDirectory: freeboxTypes.Base64Path(req.DownloadDirectory), Status: freeboxTypes.DownloadTaskStatusDone,
+					})
+					return 42, nil
+				},
+				DeleteDownloadTaskStub: func(_ context.Context, id int64) error {
+					mu.Lock()
+					defer mu.Unlock()
+					downloads = slices.DeleteFunc(downloads, func(t freeboxTypes.DownloadTask) bool { return t.ID == id })
+					return nil
 				},
 			}
+			// The download is visible, and the disk once the download was moved to it.
+			fc.GetFileInfoStub = func(_ context.Context, p string) (freeboxTypes.FileInfo, error) {
+				if p == downloadDir+"/debian.qcow2" || (p == vmStoragePath+"/"+resourceName+".qcow2" && fc.MoveFilesCallCount() > 0) {
+					return freeboxTypes.FileInfo{}, nil
+				}
+				return freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound
+			}
+			fc.GetDownloadTaskReturns(freeboxTypes.DownloadTask{ID: 42, Status: freeboxTypes.DownloadTaskStatusDone}, nil)
+			fc.MoveFilesReturns(freeboxTypes.FileSystemTask{ID: 8}, nil)
+			fc.GetFileSystemTaskReturns(freeboxTypes.FileSystemTask{ID: 8, State: taskStateDone}, nil)
+			fc.ResizeVirtualDiskReturns(88, nil)
+			// The resize keeps running, so that the pipeline stops there.
+			fc.GetVirtualDiskTaskReturns(freeboxTypes.VirtualMachineDiskTask{}, nil)
+
 			r := newReconciler(fc)
-			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).NotTo(BeZero())
+			r.Client = &laggingClient{Client: k8sClient}
+			r.APIReader = k8sClient
+
+			var wg sync.WaitGroup
+			for range 8 {
+				wg.Go(func() {
+					defer GinkgoRecover()
+					for range 10 {
+						// Conflicts are expected from the workers reading a stale machine.
+						_, _ = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+					}
+				})
+			}
+			wg.Wait()
 
+			Expect(fc.AddDownloadTaskCallCount()).To(Equal(1))
+			Expect(fc.MoveFilesCallCount()).To(Equal(1))
+			Expect(fc.ResizeVirtualDiskCallCount()).To(Equal(1))
 			updated := &infrastructurev1alpha1.FreeboxMachine{}
 			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
-			Expect(updated.Status.Phase).To(Equal(phaseCopy))
-			Expect(updated.Status.TaskID).To(Equal(int64(0)))
+			Expect(updated.Status.Phase).To(Equal(phaseResize))
+			Expect(updated.Status.TaskID).To(Equal(int64(88)))
+			Expect(updated.Finalizers).To(ConsistOf(FreeboxMachineFinalizer))
 		})
 	})
 
-	Describe("TestPhaseRename", func() {
-		const resourceName = "phase-rename-test"
+	Describe("TestPhaseError", func() {
+		const resourceName = "phase-error-test"
 		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
 
 		BeforeEach(func() {
@@ -494,62 +1913,108 @@ var _ = Describe("FreeboxMachine phase transitions", func() {
 				ImageURL:      imageURL,
 			})
 			Expect(k8sClient.Create(testCtx, machine)).To(Succeed())
-			// Simulate extract done -> rename pending
-			machine.Status.Phase = phaseRename
-			machine.Status.TaskID = 0
-			machine.Status.RenameSrc = vmStoragePath + "/" + extractedBase
-			machine.Status.RenameDst = vmStoragePath + "/my-vm.raw"
+			machine.Status.Phase = phaseDownload
+			machine.Status.TaskID = 111
 			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
 		})
 
 		AfterEach(func() {
 			machine := &infrastructurev1alpha1.FreeboxMachine{}
 			_ = k8sClient.Get(testCtx, nn, machine)
+			machine.Finalizers = nil
+			_ = k8sClient.Update(testCtx, machine)
 			_ = k8sClient.Delete(testCtx, machine)
 		})
 
-		It("when rename task started and done, transitions to resize phase", func() {
-			callCount := 0
-			fc := &fakeClient{
-				moveFilesFn: func(ctx context.Context, srcs []string, dst string, mode freeboxTypes.FileMoveMode) (freeboxTypes.FileSystemTask, error) {
-					Expect(srcs).To(ConsistOf(vmStoragePath + "/" + extractedBase))
-					Expect(dst).To(Equal(vmStoragePath + "/my-vm.raw"))
-					callCount++
-					return freeboxTypes.FileSystemTask{ID: 55}, nil
-				},
+		It("records the failure on the FreeboxMachine and its owner Machine and Cluster once", func() {
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: "default"},
+				Spec:       clusterv1.ClusterSpec{Paused: ptr.To(false)},
 			}
+			Expect(k8sClient.Create(testCtx, cluster)).To(Succeed())
+			DeferCleanup(func() { Expect(k8sClient.Delete(testCtx, cluster)).To(Succeed()) })
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Labels = map[string]string{clusterv1.ClusterNameLabel: cluster.Name}
+			createOwnerMachine(testCtx, machine, "v1.34.1", []byte("#cloud-config\n"))
+
+			fc := &mock.Client{}
+			fc.GetDownloadTaskReturns(freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusError, Error: freeboxTypes.DownloadTaskErrorInvalidURL}, nil)
+			recorder := &eventRecorder{}
 			r := newReconciler(fc)
+			r.Recorder = recorder
+			for range 2 {
+				_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+				Expect(err).To(HaveOccurred())
+			}
+			Expect(recorder.events).To(ConsistOf(
+				"FreeboxMachine ProvisioningFailed",
+				"Machine ProvisioningFailed",
+				"Cluster ProvisioningFailed",
+			))
+		})
 
-			// First reconcile: starts the rename task
-			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
-			Expect(err).NotTo(HaveOccurred())
-			Expect(result.RequeueAfter).NotTo(BeZero())
-			Expect(callCount).To(Equal(1))
+		It("when download task fails, sets ProvisioningFailed condition and returns error", func() {
+			fc := &mock.Client{
+				GetDownloadTaskStub: func(ctx context.Context, id int64) (freeboxTypes.DownloadTask, error) {
+					return freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusError, Error: freeboxTypes.DownloadTaskErrorInvalidURL}, nil
+				},
+			}
+			r := newReconciler(fc)
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("download failed"))
 
 			updated := &infrastructurev1alpha1.FreeboxMachine{}
 			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
-			Expect(updated.Status.TaskID).To(Equal(int64(55)))
 
-			// Second reconcile: task is done → transition to resize
-			fc.moveFilesFn = nil
-			fc.getFileSystemTaskFn = func(ctx context.Context, id int64) (freeboxTypes.FileSystemTask, error) {
-				Expect(id).To(Equal(int64(55)))
-				return freeboxTypes.FileSystemTask{State: taskStateDone}, nil
+			var readyCond *metav1.Condition
+			for i := range updated.Status.Conditions {
+				if updated.Status.Conditions[i].Type == ReadyCondition {
+					readyCond = &updated.Status.Conditions[i]
+					break
+				}
 			}
-			result, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(readyCond).NotTo(BeNil())
+			Expect(readyCond.Reason).To(Equal("ProvisioningFailed"))
+			Expect(fc.UpdateDownloadTaskCallCount()).To(BeZero())
+		})
+
+		It("when download task fails with a transient error, resumes it", func() {
+			fc := &mock.Client{}
+			fc.GetDownloadTaskReturns(freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusError, Error: freeboxTypes.DownloadTaskErrorInternalError}, nil)
+			result, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result.RequeueAfter).NotTo(BeZero())
 
+			Expect(fc.UpdateDownloadTaskCallCount()).To(Equal(1))
+			_, id, update := fc.UpdateDownloadTaskArgsForCall(0)
+			Expect(id).To(Equal(int64(111)))
+			Expect(string(update.Status)).To(Equal(freeboxTypes.DownloadTaskStatusRetry))
+
+			updated := &infrastructurev1alpha1.FreeboxMachine{}
 			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
-			Expect(updated.Status.Phase).To(Equal(phaseResize))
-			Expect(updated.Status.TaskID).To(Equal(int64(0)))
-			Expect(updated.Status.RenameSrc).To(BeEmpty())
-			Expect(updated.Status.RenameDst).To(BeEmpty())
+			Expect(updated.Status.Phase).To(Equal(phaseDownload))
+			Expect(updated.Status.TaskID).To(Equal(int64(111)))
+			Expect(updated.Status.DownloadRetries).To(Equal(int32(1)))
+		})
+
+		It("when download task keeps failing, gives up after the last retry", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.DownloadRetries = maxDownloadRetries
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetDownloadTaskReturns(freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusError, Error: freeboxTypes.DownloadTaskErrorInternalError}, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).To(HaveOccurred())
+			Expect(fc.UpdateDownloadTaskCallCount()).To(BeZero())
 		})
 	})
 
-	Describe("TestPhaseResize", func() {
-		const resourceName = "phase-resize-test"
+	Describe("TestDeletion", func() {
+		const resourceName = "deletion-test"
 		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
 
 		BeforeEach(func() {
@@ -557,118 +2022,243 @@ var _ = Describe("FreeboxMachine phase transitions", func() {
 				Name:          "my-vm",
 				VCPUs:         1,
 				MemoryMB:      512,
-				DiskSizeBytes: 20 * 1024 * 1024 * 1024,
+				DiskSizeBytes: 10 * 1024 * 1024 * 1024,
 				ImageURL:      imageURL,
 			})
+			machine.Finalizers = []string{FreeboxMachineFinalizer}
 			Expect(k8sClient.Create(testCtx, machine)).To(Succeed())
-			machine.Status.Phase = phaseResize
-			machine.Status.TaskID = 0
-			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+			Expect(k8sClient.Delete(testCtx, machine)).To(Succeed())
 		})
 
 		AfterEach(func() {
 			machine := &infrastructurev1alpha1.FreeboxMachine{}
-			_ = k8sClient.Get(testCtx, nn, machine)
-			_ = k8sClient.Delete(testCtx, machine)
+			if err := k8sClient.Get(testCtx, nn, machine); err == nil {
+				machine.Finalizers = nil
+				_ = k8sClient.Update(testCtx, machine)
+			}
 		})
 
-		It("when resize task started and done, sets ImageReady condition (Phase stays resize until fully provisioned)", func() {
-			callCount := 0
-			fc := &fakeClient{
-				resizeVirtualDiskFn: func(ctx context.Context, p freeboxTypes.VirtualDisksResizePayload) (int64, error) {
-					callCount++
-					return 88, nil
-				},
-			}
+		It("shuts the VM down before deleting it, and kills it when it does not stop in time", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.VMID = ptr.To[int64](42)
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: 42, Status: "running"}, nil)
+			recorder := &eventRecorder{}
 			r := newReconciler(fc)
+			r.Recorder = recorder
 
-			// First reconcile: starts resize task
+			By("requesting a shutdown")
 			result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result.RequeueAfter).NotTo(BeZero())
-			Expect(callCount).To(Equal(1))
+			Expect(fc.StopVirtualMachineCallCount()).To(Equal(1))
+			Expect(fc.KillVirtualMachineCallCount()).To(BeZero())
+			Expect(fc.DeleteVirtualMachineCallCount()).To(BeZero())
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			shutdown := meta.FindStatusCondition(machine.Status.Conditions, ConditionShutdownEscalated)
+			Expect(shutdown).NotTo(BeNil())
+			Expect(shutdown.Reason).To(Equal("ShutdownRequested"))
+
+			By("waiting while the shutdown is in progress")
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.StopVirtualMachineCallCount()).To(Equal(1))
+			Expect(fc.KillVirtualMachineCallCount()).To(BeZero())
 
-			updated := &infrastructurev1alpha1.FreeboxMachine{}
-			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
-			Expect(updated.Status.TaskID).To(Equal(int64(88)))
+			By("killing the VM once the shutdown timed out")
+			shutdown.LastTransitionTime = metav1.NewTime(time.Now().Add(-vmShutdownTimeout - time.Minute))
+			meta.RemoveStatusCondition(&machine.Status.Conditions, ConditionShutdownEscalated)
+			machine.Status.Conditions = append(machine.Status.Conditions, *shutdown)
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.KillVirtualMachineCallCount()).To(Equal(1))
+			Expect(fc.DeleteVirtualMachineCallCount()).To(BeZero())
+			Expect(recorder.events).To(ContainElement("FreeboxMachine VMKilled"))
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(machine.Status.Conditions, ConditionShutdownEscalated)).To(BeTrue())
+
+			By("deleting the VM once it is stopped")
+			fc.GetVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: 42, Status: "stopped"}, nil)
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.DeleteVirtualMachineCallCount()).To(Equal(1))
+			Expect(k8sClient.Get(testCtx, nn, &infrastructurev1alpha1.FreeboxMachine{})).NotTo(Succeed())
+		})
 
-			// Second reconcile: task done → ImageReady condition set, Phase stays "resize"
-			fc.resizeVirtualDiskFn = nil
-			fc.getVirtualDiskTaskFn = func(ctx context.Context, id int64) (freeboxTypes.VirtualMachineDiskTask, error) {
-				Expect(id).To(Equal(int64(88)))
-				return freeboxTypes.VirtualMachineDiskTask{Done: true, Error: false}, nil
-			}
-			// The reconciler proceeds to VM creation after resize; since there is no CAPI
-			// Machine owner it returns early. We verify ImageReady is set but Phase is NOT
-			// prematurely "done" — that would break the IP-polling requeue loop.
-			fc.listDownloadTasksFn = nil // not called in this path
-			// May return error or requeue — the VM creation path requires owner Machine; that's fine.
-			_, _ = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+		It("holds the deletion of a frozen machine while mirroring its VM", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Annotations = map[string]string{FreezeAnnotation: ""}
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			machine.Status.VMID = ptr.To[int64](42)
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
 
-			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
-			// Phase must remain "resize" until VM creation + IP assignment are complete.
-			Expect(updated.Status.Phase).To(Equal(phaseResize))
+			fc := &mock.Client{}
+			fc.GetVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: 42, Status: "stopped"}, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
 
-			var imageReadyCond *metav1.Condition
-			for i := range updated.Status.Conditions {
-				if updated.Status.Conditions[i].Type == ConditionImageReady {
-					imageReadyCond = &updated.Status.Conditions[i]
-					break
-				}
-			}
-			Expect(imageReadyCond).NotTo(BeNil())
-			Expect(imageReadyCond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(fc.DeleteVirtualMachineCallCount()).To(BeZero())
+			Expect(fc.RemoveFilesCallCount()).To(BeZero())
+			Expect(fc.DeleteDHCPStaticLeaseCallCount()).To(BeZero())
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			Expect(machine.Status.VMState).To(Equal("stopped"))
+			Expect(meta.IsStatusConditionTrue(machine.Status.Conditions, ConditionReconciliationFrozen)).To(BeTrue())
 		})
-	})
-
-	Describe("TestPhaseError", func() {
-		const resourceName = "phase-error-test"
-		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
 
-		BeforeEach(func() {
-			machine := newMachineForPhaseTest(resourceName, infrastructurev1alpha1.FreeboxMachineSpec{
-				Name:          "my-vm",
-				VCPUs:         1,
-				MemoryMB:      512,
-				DiskSizeBytes: 10 * 1024 * 1024 * 1024,
-				ImageURL:      imageURL,
-			})
-			Expect(k8sClient.Create(testCtx, machine)).To(Succeed())
-			machine.Status.Phase = phaseDownload
-			machine.Status.TaskID = 111
+		It("holds the deletion of a machine provisioned on another Freebox until it is confirmed", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.VMID = ptr.To[int64](42)
+			machine.Status.FreeboxSerial = "old-serial"
 			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: 42, Status: "stopped"}, nil)
+			r := newReconciler(fc)
+			r.FreeboxSerial = "new-serial"
+			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.Invocations()).To(BeEmpty())
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(machine.Status.Conditions, ConditionFreeboxTargetChanged)).To(BeTrue())
+
+			By("deleting the VM on the new Freebox once confirmed")
+			machine.Annotations = map[string]string{ConfirmFreeboxTargetAnnotation: "new-serial"}
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.DeleteVirtualMachineCallCount()).To(Equal(1))
 		})
 
-		AfterEach(func() {
+		It("truncates the disk before removing it when secureWipe is set", func() {
 			machine := &infrastructurev1alpha1.FreeboxMachine{}
-			_ = k8sClient.Get(testCtx, nn, machine)
-			_ = k8sClient.Delete(testCtx, machine)
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.SecureWipe = true
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			machine.Status.DiskPath = "/Freebox/VMs/my-vm.raw"
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.FileUploadStartReturns(&uploadBuffer{}, 0, nil)
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.FileUploadStartCallCount()).To(Equal(1))
+			_, input := fc.FileUploadStartArgsForCall(0)
+			Expect(input.Filename).To(Equal("my-vm.raw"))
+			Expect(input.Size).To(BeZero())
+			Expect(input.Force).To(Equal(freeboxTypes.FileUploadStartActionForceOverwrite))
+			Expect(fc.RemoveFilesCallCount()).To(Equal(1))
+			_, files := fc.RemoveFilesArgsForCall(0)
+			Expect(files).To(ContainElement("/Freebox/VMs/my-vm.raw"))
 		})
 
-		It("when download task fails, sets ProvisioningFailed condition and returns error", func() {
-			fc := &fakeClient{
-				getDownloadTaskFn: func(ctx context.Context, id int64) (freeboxTypes.DownloadTask, error) {
-					return freeboxTypes.DownloadTask{Status: freeboxTypes.DownloadTaskStatusError}, nil
-				},
-			}
+		It("finishes the deletion once a failed wipe succeeds, without deleting the VM again", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.SecureWipe = true
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			machine.Status.VMID = ptr.To[int64](42)
+			machine.Status.DiskPath = "/Freebox/VMs/my-vm.raw"
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			fc.GetVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: 42, Status: "stopped"}, nil)
+			fc.FileUploadStartReturnsOnCall(0, nil, 0, fmt.Errorf("connection reset"))
+			fc.FileUploadStartReturnsOnCall(1, &uploadBuffer{}, 0, nil)
 			r := newReconciler(fc)
 			_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
 			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("download failed"))
+			Expect(fc.DeleteVirtualMachineCallCount()).To(Equal(1))
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			Expect(machine.Status.VMID).To(BeNil())
 
-			updated := &infrastructurev1alpha1.FreeboxMachine{}
-			Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+			_, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fc.DeleteVirtualMachineCallCount()).To(Equal(1))
+			Expect(fc.RemoveFilesCallCount()).To(Equal(1))
+			Expect(k8sClient.Get(testCtx, nn, &infrastructurev1alpha1.FreeboxMachine{})).NotTo(Succeed())
+		})
 
-			var readyCond *metav1.Condition
-			for i := range updated.Status.Conditions {
-				if updated.Status.Conditions[i].Type == ReadyCondition {
-					readyCond = &updated.Status.Conditions[i]
-					break
-				}
-			}
-			Expect(readyCond).NotTo(BeNil())
-			Expect(readyCond.Reason).To(Equal("ProvisioningFailed"))
+		It("keeps an unmanaged disk", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Spec.ImageManagement = infrastructurev1alpha1.ImageManagementUnmanaged
+			machine.Spec.SecureWipe = true
+			Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+			machine.Status.DiskPath = "/Freebox/VMs/my-vm.raw"
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.FileUploadStartCallCount()).To(BeZero())
+			Expect(fc.RemoveFilesCallCount()).To(Equal(1))
+			_, files := fc.RemoveFilesArgsForCall(0)
+			Expect(files).To(ConsistOf("/Freebox/VMs/my-vm.raw.efivars", "/Freebox/VMs/my-vm.raw.meta.json"))
+		})
+
+		It("removes exactly the files recorded in status", func() {
+			machine := &infrastructurev1alpha1.FreeboxMachine{}
+			Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+			machine.Status.DiskPath = "/Freebox/VMs/my-vm.qcow2"
+			machine.Status.ManagedFiles = []string{"/Freebox/VMs/my-vm.qcow2", "/Freebox/VMs/my-vm.qcow2.efivars", "/Freebox/VMs/my-vm-seed.iso"}
+			Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+			fc := &mock.Client{}
+			_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fc.RemoveFilesCallCount()).To(Equal(1))
+			_, files := fc.RemoveFilesArgsForCall(0)
+			Expect(files).To(Equal(machine.Status.ManagedFiles))
 		})
+
+		DescribeTable("removes the partial files of a cancelled extraction, but never a directory of the task",
+			func(task freeboxTypes.FileSystemTask, want []string) {
+				machine := &infrastructurev1alpha1.FreeboxMachine{}
+				Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+				machine.Status.Phase = phaseExtract
+				machine.Status.TaskID = 9
+				machine.Status.DiskPath = "/mnt/VMs/my-vm.raw"
+				Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+
+				fc := &mock.Client{}
+				fc.GetFileSystemTaskReturns(task, nil)
+				_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fc.DeleteFileSystemTaskCallCount()).To(Equal(1))
+				var removed []string
+				for i := range fc.RemoveFilesCallCount() {
+					_, files := fc.RemoveFilesArgsForCall(i)
+					removed = append(removed, files...)
+				}
+				for _, f := range want {
+					Expect(removed).To(ContainElement(f))
+				}
+				Expect(removed).NotTo(ContainElement(vmStoragePath))
+				if want == nil {
+					Expect(removed).NotTo(ContainElement(HavePrefix("/mnt/VMs/image.")))
+				}
+			},
+			Entry("archive", freeboxTypes.FileSystemTask{
+				ID: 9, Type: freeboxTypes.FileTaskTypeExtract,
+				Sources: []string{"/mnt/VMs/image.tar.xz"}, Destination: "/mnt/VMs/my-vm.raw.extract",
+			}, []string{"/mnt/VMs/image.tar.xz", "/mnt/VMs/my-vm.raw.extract"}),
+			Entry("archive extracted to an unknown destination", freeboxTypes.FileSystemTask{
+				ID: 9, Type: freeboxTypes.FileTaskTypeExtract, Sources: []string{"/mnt/VMs/image.tar.xz"},
+			}, nil),
+			Entry("compressed image extracted to an unknown destination", freeboxTypes.FileSystemTask{
+				ID: 9, Type: freeboxTypes.FileTaskTypeExtract, Sources: []string{"/mnt/VMs/image.raw.xz"},
+			}, nil),
+		)
 	})
 })
 
@@ -770,6 +2360,8 @@ var _ = Describe("FreeboxMachine phaseVMCreated provisioning", func() {
 	AfterEach(func() {
 		machine := &infrastructurev1alpha1.FreeboxMachine{}
 		_ = k8sClient.Get(testCtx, nn, machine)
+		machine.Finalizers = nil
+		_ = k8sClient.Update(testCtx, machine)
 		_ = k8sClient.Delete(testCtx, machine)
 
 		cluster := &clusterv1.Cluster{}
@@ -778,12 +2370,15 @@ var _ = Describe("FreeboxMachine phaseVMCreated provisioning", func() {
 	})
 
 	It("sets provisioned=true and addresses even when the workload cluster is unreachable", func() {
-		fc := &fakeClient{
-			getVirtualMachineFn: func(_ context.Context, id int64) (freeboxTypes.VirtualMachine, error) {
+		fc := &mock.Client{
+			GetVirtualMachineStub: func(_ context.Context, id int64) (freeboxTypes.VirtualMachine, error) {
 				Expect(id).To(Equal(vmID))
 				return freeboxTypes.VirtualMachine{ID: vmID, Mac: vmMac}, nil
 			},
-			getLanInterfaceFn: func(_ context.Context, name string) ([]freeboxTypes.LanInterfaceHost, error) {
+			ListVirtualMachinesStub: func(context.Context) ([]freeboxTypes.VirtualMachine, error) {
+				return []freeboxTypes.VirtualMachine{{ID: vmID, Mac: strings.ToUpper(vmMac)}}, nil
+			},
+			GetLanInterfaceStub: func(_ context.Context, name string) ([]freeboxTypes.LanInterfaceHost, error) {
 				Expect(name).To(Equal("pub"))
 				return []freeboxTypes.LanInterfaceHost{
 					{
@@ -801,11 +2396,22 @@ var _ = Describe("FreeboxMachine phaseVMCreated provisioning", func() {
 			Scheme:        k8sClient.Scheme(),
 			FreeboxClient: fc,
 			// Simulate an unreachable workload cluster
-			ClusterCache: &fakeClusterCache{getClientErr: fmt.Errorf("cluster not connected")},
+			ClusterCache:           &fakeClusterCache{getClientErr: fmt.Errorf("cluster not connected")},
+			AddressRequeueInterval: 7 * time.Second,
+			NodeRequeueInterval:    3 * time.Second,
 		}
 
-		_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+		By("waiting for the address to be discovered")
+		result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(7 * time.Second))
+		Expect(fc.GetLanInterfaceCallCount()).To(BeZero())
+
+		Expect((&AddressDiscovery{Client: k8sClient, FreeboxClient: fc}).discover(testCtx)).To(Succeed())
+		By("waiting for the Node to register")
+		result, err = r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
 		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(3 * time.Second))
 
 		updated := &infrastructurev1alpha1.FreeboxMachine{}
 		Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
@@ -814,6 +2420,7 @@ var _ = Describe("FreeboxMachine phaseVMCreated provisioning", func() {
 		Expect(updated.Status.Initialization.Provisioned).NotTo(BeNil())
 		Expect(*updated.Status.Initialization.Provisioned).To(BeTrue(),
 			"provisioned must be true even when the workload cluster is unreachable")
+		Expect(updated.Status.Ready).To(BeTrue(), "ready mirrors provisioned for v1beta1 consumers")
 
 		// addresses must be set so CAPI can propagate them to Machine.status.addresses
 		Expect(updated.Status.Addresses).NotTo(BeEmpty(),
@@ -836,6 +2443,35 @@ var _ = Describe("FreeboxMachine phaseVMCreated provisioning", func() {
 		Expect(readyCond.Status).To(Equal(metav1.ConditionTrue),
 			"Ready condition must be True once provisioned")
 	})
+
+	It("sets the providerID when the cached FreeboxMachine is stale", func() {
+		machine := &infrastructurev1alpha1.FreeboxMachine{}
+		Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+		machine.Finalizers = []string{FreeboxMachineFinalizer}
+		Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+		staleVersion := machine.ResourceVersion
+		machine.Labels["touched"] = "true"
+		Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+
+		fc := &mock.Client{}
+		fc.GetVirtualMachineReturns(freeboxTypes.VirtualMachine{ID: vmID, Mac: vmMac}, nil)
+		machine.Status.Addresses = []clusterv1.MachineAddress{{Type: clusterv1.MachineInternalIP, Address: vmIP}}
+		Expect(k8sClient.Status().Update(testCtx, machine)).To(Succeed())
+		r := &FreeboxMachineReconciler{
+			Client:        &staleClient{Client: k8sClient, key: nn, resourceVersion: staleVersion},
+			Scheme:        k8sClient.Scheme(),
+			FreeboxClient: fc,
+			ClusterCache:  &fakeClusterCache{getClientErr: fmt.Errorf("cluster not connected")},
+		}
+		_, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &infrastructurev1alpha1.FreeboxMachine{}
+		Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+		Expect(updated.Spec.ProviderID).To(Equal(fmt.Sprintf("freebox://%d", vmID)))
+		Expect(updated.Labels).To(HaveKeyWithValue("touched", "true"))
+		Expect(updated.Status.Initialization.Provisioned).To(Equal(ptr.To(true)))
+	})
 })
 
 // newFakeWorkloadClient builds a fake client seeded with the given objects,
@@ -994,4 +2630,34 @@ var _ = Describe("reconcileNodeProviderID — Talos random node names", func() {
 		Expect(patchedNode.Spec.ProviderID).To(Equal(expectedProviderID),
 			"node providerID must be set to freebox://0 after fix")
 	})
+
+	It("mirrors the VM state periodically once the node is patched", func() {
+		resourceName := "vm-state-resync-test"
+		setupResources(resourceName)
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: machineNodeName},
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: vmIP}},
+			},
+		}
+
+		fc := &mock.Client{}
+		fc.GetVirtualMachineReturns(freeboxTypes.VirtualMachine{Status: "stopped"}, nil)
+		r := &FreeboxMachineReconciler{
+			Client:              k8sClient,
+			Scheme:              k8sClient.Scheme(),
+			FreeboxClient:       fc,
+			ClusterCache:        &fakeClusterCache{workloadClient: newFakeWorkloadClient(node)},
+			VMStateResyncPeriod: 5 * time.Minute,
+		}
+
+		nn := types.NamespacedName{Name: resourceName, Namespace: "default"}
+		result, err := r.Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(5 * time.Minute))
+
+		updated := &infrastructurev1alpha1.FreeboxMachine{}
+		Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+		Expect(updated.Status.VMState).To(Equal("stopped"))
+	})
 })
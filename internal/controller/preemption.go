@@ -0,0 +1,135 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// preemptForCapacity stops running VMs of clusters with a lower priority than the
+// cluster of machine until the Freebox has enough free vCPUs and memory to start the
+// VM of machine. Nothing is stopped when the cluster of machine has no priority.
+// Only the machines provisioned on the same Freebox are candidates, as VM IDs are
+// only unique within a Freebox.
+func (r *FreeboxMachineReconciler) preemptForCapacity(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) error {
+	logger := logf.FromContext(ctx)
+
+	priorities := map[types.NamespacedName]*int32{}
+	priority, err := r.clusterPriority(ctx, machine, priorities)
+	if err != nil || priority == nil {
+		return err
+	}
+
+	info, err := r.freeboxClient(ctx).GetVirtualMachineInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("getting Freebox VM capacity: %w", err)
+	}
+	freeCPUs := info.TotalCPUs - info.UsedCPUs
+	freeMemory := info.TotalMemory - info.UsedMemory
+	if freeCPUs >= machine.Spec.VCPUs && freeMemory >= machine.Spec.MemoryMB {
+		return nil
+	}
+
+	type candidate struct {
+		machine  *infrastructurev1alpha1.FreeboxMachine
+		priority int32
+	}
+	var machines infrastructurev1alpha1.FreeboxMachineList
+	if err := r.List(ctx, &machines); err != nil {
+		return err
+	}
+	serial := r.freeboxSerial(ctx)
+	var candidates []candidate
+	for i := range machines.Items {
+		m := &machines.Items[i]
+		if m.Status.VMID == nil || !m.DeletionTimestamp.IsZero() || m.Status.FreeboxSerial != serial {
+			continue
+		}
+		p, err := r.clusterPriority(ctx, m, priorities)
+		if err != nil {
+			return err
+		}
+		if p != nil && *p < *priority {
+			candidates = append(candidates, candidate{machine: m, priority: *p})
+		}
+	}
+	// Preempt the lowest priorities first, and the most recent machines first within a priority.
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].priority != candidates[j].priority {
+			return candidates[i].priority < candidates[j].priority
+		}
+		return candidates[j].machine.CreationTimestamp.Before(&candidates[i].machine.CreationTimestamp)
+	})
+
+	for _, c := range candidates {
+		if freeCPUs >= machine.Spec.VCPUs && freeMemory >= machine.Spec.MemoryMB {
+			break
+		}
+		vm, err := r.freeboxClient(ctx).GetVirtualMachine(ctx, *c.machine.Status.VMID)
+		if err != nil {
+			return fmt.Errorf("getting VM %d of FreeboxMachine %s/%s: %w", *c.machine.Status.VMID, c.machine.Namespace, c.machine.Name, err)
+		}
+		if vm.Status != "running" {
+			continue
+		}
+		if err := r.freeboxClient(ctx).StopVirtualMachine(ctx, vm.ID); err != nil {
+			return fmt.Errorf("stopping VM %d of FreeboxMachine %s/%s: %w", vm.ID, c.machine.Namespace, c.machine.Name, err)
+		}
+		freeCPUs += vm.VCPUs
+		freeMemory += vm.Memory
+		logger.Info("Stopped VM of a lower-priority cluster to make room", "vmID", vm.ID,
+			"preempted", types.NamespacedName{Namespace: c.machine.Namespace, Name: c.machine.Name}, "priority", c.priority)
+		r.event(c.machine, machine, corev1.EventTypeWarning, "Preempted", "Stop",
+			"VM %d stopped to make room for FreeboxMachine %s/%s of a cluster with priority %d", vm.ID, machine.Namespace, machine.Name, *priority)
+		r.event(machine, c.machine, corev1.EventTypeNormal, "Preempting", "Stop",
+			"Stopped VM %d of FreeboxMachine %s/%s of a cluster with priority %d", vm.ID, c.machine.Namespace, c.machine.Name, c.priority)
+	}
+
+	if freeCPUs < machine.Spec.VCPUs || freeMemory < machine.Spec.MemoryMB {
+		logger.Info("Not enough lower-priority VMs to preempt, starting the VM anyway",
+			"freeCPUs", freeCPUs, "freeMemoryMB", freeMemory)
+	}
+	return nil
+}
+
+// clusterPriority returns the priority of the FreeboxCluster of machine, or nil when
+// it has none or cannot be found. Priorities are cached by Cluster in priorities.
+func (r *FreeboxMachineReconciler) clusterPriority(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, priorities map[types.NamespacedName]*int32) (*int32, error) {
+	key := types.NamespacedName{Namespace: machine.Namespace, Name: machine.Labels[clusterv1.ClusterNameLabel]}
+	if p, ok := priorities[key]; ok {
+		return p, nil
+	}
+	freeboxCluster, err := r.freeboxClusterOf(ctx, machine)
+	if err != nil {
+		return nil, err
+	}
+	var priority *int32
+	if freeboxCluster != nil {
+		priority = freeboxCluster.Spec.Priority
+	}
+	priorities[key] = priority
+	return priority, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+// markProvisioned records that the infrastructure of a FreeboxMachine or
+// FreeboxCluster is provisioned, in initialization.provisioned as the Cluster API
+// v1beta2 contract expects, and in a True Ready condition.
+func markProvisioned(provisioned **bool, conditions *[]metav1.Condition, message string) {
+	*provisioned = ptr.To(true)
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:    ReadyCondition,
+		Status:  metav1.ConditionTrue,
+		Reason:  "InfrastructureReady",
+		Message: message,
+	})
+}
+
+// setPausedCondition records in the Paused condition whether the reconciliation of
+// a FreeboxMachine or FreeboxCluster is paused, as Cluster API v1beta2 objects do.
+func setPausedCondition(conditions *[]metav1.Condition, paused bool) {
+	condition := metav1.Condition{
+		Type:   ConditionPaused,
+		Status: metav1.ConditionFalse,
+		Reason: clusterv1.NotPausedReason,
+	}
+	if paused {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = clusterv1.PausedReason
+		condition.Message = "Reconciliation is paused by the Cluster or the cluster.x-k8s.io/paused annotation"
+	}
+	meta.SetStatusCondition(conditions, condition)
+}
+
+// legacyReady returns the status.ready field of the Cluster API v1beta1 contract,
+// which some tooling still reads, for initialization.provisioned. Both controllers
+// derive it when writing the status, so that the two fields never disagree, even
+// for objects provisioned before status.ready was reported.
+func legacyReady(provisioned *bool) bool {
+	return ptr.Deref(provisioned, false)
+}
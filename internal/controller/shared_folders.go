@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// freeboxSMBHost is the name the Freebox answers to on its LAN, resolved by the
+// DNS server it hands out to the VMs.
+const freeboxSMBHost = "mafreebox.freebox.fr"
+
+// sharedFolderCredentialsDir is where the credentials of the shared folders are
+// written on the VM.
+const sharedFolderCredentialsDir = "/etc/freebox-smb"
+
+// sharedFolderCloudConfig returns the files and commands mounting the shared
+// folders of machine from the SMB share of the Freebox. Each folder is added to
+// /etc/fstab, so that it is mounted again when the VM reboots, and mounted right
+// away. Credentials are read from their Secrets and written readable by root only.
+func (r *FreeboxMachineReconciler) sharedFolderCloudConfig(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) ([]infrastructurev1alpha1.CloudInitFile, []string, error) {
+	var files []infrastructurev1alpha1.CloudInitFile
+	var commands []string
+	credentials := map[string]string{}
+	for _, folder := range machine.Spec.SharedFolders {
+		options := []string{"_netdev", "nofail", "rw"}
+		if folder.ReadOnly {
+			options[2] = "ro"
+		}
+		if name := folder.CredentialsSecretName; name == "" {
+			options = append(options, "guest")
+		} else {
+			file, ok := credentials[name]
+			if !ok {
+				secret := &corev1.Secret{}
+				if err := r.secretReader().Get(ctx, client.ObjectKey{Namespace: machine.Namespace, Name: name}, secret); err != nil {
+					return nil, nil, fmt.Errorf("getting the credentials of shared folder %s: %w", folder.Path, err)
+				}
+				username, password := secret.Data["username"], secret.Data["password"]
+				if len(username) == 0 {
+					return nil, nil, fmt.Errorf("secret %s of shared folder %s has no username", name, folder.Path)
+				}
+				file = path.Join(sharedFolderCredentialsDir, name)
+				files = append(files, infrastructurev1alpha1.CloudInitFile{
+					Path:        file,
+					Content:     fmt.Sprintf("username=%s\npassword=%s\n", username, password),
+					Owner:       "root:root",
+					Permissions: "0600",
+				})
+				credentials[name] = file
+			}
+			options = append(options, "credentials="+file)
+		}
+
+		entry := strings.Join([]string{
+			fstabEscape("//" + freeboxSMBHost + path.Clean(folder.Path)),
+			fstabEscape(path.Clean(folder.MountPath)),
+			"cifs",
+			strings.Join(options, ","),
+			"0", "0",
+		}, " ")
+		mountPath := shellQuote(path.Clean(folder.MountPath))
+		commands = append(commands,
+			"mkdir -p "+mountPath,
+			"echo "+shellQuote(entry)+" >> /etc/fstab",
+			"mount "+mountPath,
+		)
+	}
+	return files, commands, nil
+}
+
+// fstabEscape escapes the whitespace and backslashes of an /etc/fstab field.
+func fstabEscape(field string) string {
+	return strings.NewReplacer(`\`, `\134`, " ", `\040`, "\t", `\011`, "\n", `\012`).Replace(field)
+}
+
+// shellQuote quotes s as a single word for sh.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+var _ = Describe("sharedFolderCloudConfig", func() {
+	It("mounts the shared folders from the SMB share of the Freebox", func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "smb-credentials", Namespace: "default"},
+			Data:       map[string][]byte{"username": []byte("kube"), "password": []byte("secret")},
+		}
+		Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, ctx, secret)
+
+		machine := &infrastructurev1alpha1.FreeboxMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-folders", Namespace: "default"},
+			Spec: infrastructurev1alpha1.FreeboxMachineSpec{SharedFolders: []infrastructurev1alpha1.SharedFolder{
+				{Path: "/Disque dur/data", MountPath: "/mnt/data", CredentialsSecretName: "smb-credentials"},
+				{Path: "/Freebox/isos/", MountPath: "/mnt/isos", ReadOnly: true},
+			}},
+		}
+		r := &FreeboxMachineReconciler{Client: k8sClient}
+		files, commands, err := r.sharedFolderCloudConfig(ctx, machine)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(Equal([]infrastructurev1alpha1.CloudInitFile{{
+			Path: "/etc/freebox-smb/smb-credentials", Content: "username=kube\npassword=secret\n", Owner: "root:root", Permissions: "0600",
+		}}))
+		Expect(commands).To(Equal([]string{
+			"mkdir -p '/mnt/data'",
+			`echo '//mafreebox.freebox.fr/Disque\040dur/data /mnt/data cifs _netdev,nofail,rw,credentials=/etc/freebox-smb/smb-credentials 0 0' >> /etc/fstab`,
+			"mount '/mnt/data'",
+			"mkdir -p '/mnt/isos'",
+			"echo '//mafreebox.freebox.fr/Freebox/isos /mnt/isos cifs _netdev,nofail,ro,guest 0 0' >> /etc/fstab",
+			"mount '/mnt/isos'",
+		}))
+	})
+
+	It("fails when the credentials are missing", func() {
+		machine := &infrastructurev1alpha1.FreeboxMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared-folders", Namespace: "default"},
+			Spec: infrastructurev1alpha1.FreeboxMachineSpec{SharedFolders: []infrastructurev1alpha1.SharedFolder{
+				{Path: "/Freebox/data", MountPath: "/mnt/data", CredentialsSecretName: "missing"},
+			}},
+		}
+		r := &FreeboxMachineReconciler{Client: k8sClient}
+		_, _, err := r.sharedFolderCloudConfig(ctx, machine)
+		Expect(err).To(MatchError(ContainSubstring("/Freebox/data")))
+	})
+})
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// storageFailureDomainPathAttribute is the attribute of the published failure
+// domains holding the directory of their storage device.
+const storageFailureDomainPathAttribute = "path"
+
+// storageFailureDomains returns the storage failure domains of freeboxCluster in
+// the form Cluster API reads them from its status.
+func storageFailureDomains(freeboxCluster *infrastructurev1alpha1.FreeboxCluster) []clusterv1.FailureDomain {
+	var failureDomains []clusterv1.FailureDomain
+	for _, fd := range freeboxCluster.Spec.StorageFailureDomains {
+		failureDomains = append(failureDomains, clusterv1.FailureDomain{
+			Name:         fd.Name,
+			ControlPlane: ptr.To(ptr.Deref(fd.ControlPlane, true)),
+			Attributes:   map[string]string{storageFailureDomainPathAttribute: fd.Path},
+		})
+	}
+	return failureDomains
+}
+
+// machineStorageDir returns the directory the disk of machine is stored in: that of
+// the storage failure domain Cluster API placed its owner Machine in, or VMStoragePath.
+// It returns false while the owner Machine is not known yet in a cluster with storage
+// failure domains, as the disk cannot move once it is prepared.
+func (r *FreeboxMachineReconciler) machineStorageDir(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) (string, bool, error) {
+	freeboxCluster, err := r.freeboxClusterOf(ctx, machine)
+	if err != nil {
+		return "", false, fmt.Errorf("getting FreeboxCluster: %w", err)
+	}
+	if freeboxCluster == nil || len(freeboxCluster.Spec.StorageFailureDomains) == 0 {
+		return r.vmStoragePath(ctx), true, nil
+	}
+	ownerMachine, err := util.GetOwnerMachine(ctx, r.Client, machine.ObjectMeta)
+	if err != nil {
+		return "", false, fmt.Errorf("getting owner Machine: %w", err)
+	}
+	if ownerMachine == nil {
+		return "", false, nil
+	}
+	for _, fd := range freeboxCluster.Spec.StorageFailureDomains {
+		if fd.Name == ownerMachine.Spec.FailureDomain {
+			// Provider instances sharing the Freebox keep their disks apart, as in VM storage.
+			return path.Join(fd.Path, r.InstanceID), true, nil
+		}
+	}
+	return r.vmStoragePath(ctx), true, nil
+}
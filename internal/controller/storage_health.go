@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// reconcileStorageHealth reports in the StorageDegraded condition of freeboxCluster
+// whether the Freebox internal disk is in error, and returns false when it is. The
+// condition is left unchanged when the disk status cannot be read.
+func (r *FreeboxClusterReconciler) reconcileStorageHealth(ctx context.Context, freeboxCluster *infrastructurev1alpha1.FreeboxCluster) bool {
+	if r.FreeboxClient == nil {
+		return true
+	}
+	logger := logf.FromContext(ctx)
+
+	system, err := r.freeboxClient(ctx).GetSystemInfo(ctx)
+	if err != nil {
+		logger.Info("Could not get the Freebox disk status", "error", err)
+		return true
+	}
+	if system.DiskStatus == "" {
+		return true
+	}
+
+	if system.DiskStatus == freeboxTypes.DiskStatusError {
+		logger.Info("Freebox disk reports errors, pausing image writes")
+		meta.SetStatusCondition(&freeboxCluster.Status.Conditions, metav1.Condition{
+			Type:    ConditionStorageDegraded,
+			Status:  metav1.ConditionTrue,
+			Reason:  "DiskError",
+			Message: "The Freebox internal disk reports errors; no image is written until it recovers",
+		})
+		return false
+	}
+	meta.SetStatusCondition(&freeboxCluster.Status.Conditions, metav1.Condition{
+		Type:    ConditionStorageDegraded,
+		Status:  metav1.ConditionFalse,
+		Reason:  "DiskHealthy",
+		Message: fmt.Sprintf("The Freebox internal disk is %s", system.DiskStatus),
+	})
+	return true
+}
+
+// storageDegraded reports whether the FreeboxCluster of machine reports that the
+// Freebox disk is in error.
+func (r *FreeboxMachineReconciler) storageDegraded(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) (bool, error) {
+	freeboxCluster, err := r.freeboxClusterOf(ctx, machine)
+	if err != nil || freeboxCluster == nil {
+		return false, err
+	}
+	return meta.IsStatusConditionTrue(freeboxCluster.Status.Conditions, ConditionStorageDegraded), nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// maxTaskHistory is how many tasks status.taskHistory keeps.
+const maxTaskHistory = 10
+
+// recordTaskStart adds the task machine just started, status.taskID, to its task
+// history, dropping the oldest tasks beyond maxTaskHistory. The previous task is
+// over by then.
+func recordTaskStart(machine *infrastructurev1alpha1.FreeboxMachine) {
+	trackTaskHistory(machine, "")
+	machine.Status.TaskHistory = append(machine.Status.TaskHistory, infrastructurev1alpha1.FreeboxTaskRecord{
+		ID:        machine.Status.TaskID,
+		Type:      machine.Status.Phase,
+		Result:    infrastructurev1alpha1.TaskRunning,
+		StartTime: metav1.Now(),
+	})
+	if n := len(machine.Status.TaskHistory); n > maxTaskHistory {
+		machine.Status.TaskHistory = machine.Status.TaskHistory[n-maxTaskHistory:]
+	}
+}
+
+// trackTaskHistory records the result of the last task of machine once it is over.
+// failure is the message of a provisioning failure reported by the reconcile, which
+// the task caused, or empty. Otherwise the task succeeded when the machine moved on
+// to another task, or when the resized disk is ready, as the resize task is kept
+// until the VM is created.
+func trackTaskHistory(machine *infrastructurev1alpha1.FreeboxMachine, failure string) {
+	if len(machine.Status.TaskHistory) == 0 {
+		return
+	}
+	last := &machine.Status.TaskHistory[len(machine.Status.TaskHistory)-1]
+	if last.Result != infrastructurev1alpha1.TaskRunning {
+		return
+	}
+	switch {
+	case failure != "":
+		last.Result, last.Message = infrastructurev1alpha1.TaskFailed, failure
+	case machine.Status.TaskID != last.ID,
+		last.Type == phaseResize && meta.IsStatusConditionTrue(machine.Status.Conditions, ConditionImageReady):
+		last.Result = infrastructurev1alpha1.TaskSucceeded
+	default:
+		return
+	}
+	last.Duration = &metav1.Duration{Duration: time.Since(last.StartTime.Time).Round(time.Second)}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+var _ = Describe("task history", func() {
+	It("keeps the last tasks with their result", func() {
+		machine := &infrastructurev1alpha1.FreeboxMachine{}
+		machine.Status.Phase = phaseDownload
+		for id := int64(1); id <= maxTaskHistory+2; id++ {
+			machine.Status.TaskID = id
+			recordTaskStart(machine)
+		}
+		Expect(machine.Status.TaskHistory).To(HaveLen(maxTaskHistory))
+		Expect(machine.Status.TaskHistory[0].ID).To(Equal(int64(3)))
+		Expect(machine.Status.TaskHistory[0].Result).To(Equal(infrastructurev1alpha1.TaskSucceeded))
+		Expect(machine.Status.TaskHistory[maxTaskHistory-1].Result).To(Equal(infrastructurev1alpha1.TaskRunning))
+
+		trackTaskHistory(machine, "Image download failed: http_4xx")
+		last := machine.Status.TaskHistory[maxTaskHistory-1]
+		Expect(last.Result).To(Equal(infrastructurev1alpha1.TaskFailed))
+		Expect(last.Message).To(Equal("Image download failed: http_4xx"))
+		Expect(last.Duration).NotTo(BeNil())
+	})
+})
@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// lockTaskStart is called before a Freebox task of the image pipeline of machine
+// is started, with the phase and task ID the machine was read with. It makes
+// reconciles running in parallel look for existing tasks and start new ones one at
+// a time, so that two of them cannot both find no task and start one each, and it
+// checks on the API server that the pipeline has not moved on meanwhile, which a
+// reconcile working on a stale copy of the machine would otherwise start again.
+//
+// The task is recorded in status with an optimistic lock on the resource version
+// checked, which machine takes, so that writes made since machine was read and
+// not moving the pipeline do not make the record fail. The returned function
+// releases the lock once the task is recorded in status. It is nil when the task
+// must not be started, in which case the caller requeues, or when err is not nil.
+func (r *FreeboxMachineReconciler) lockTaskStart(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, phase string, taskID int64) (func(), error) {
+	r.taskStartMu.Lock()
+	unlockWrites := r.lockMachineWrites(machine)
+	unlock := func() {
+		unlockWrites()
+		r.taskStartMu.Unlock()
+	}
+
+	var current infrastructurev1alpha1.FreeboxMachine
+	if err := r.apiReader().Get(ctx, client.ObjectKeyFromObject(machine), &current); err != nil {
+		unlock()
+		return nil, fmt.Errorf("checking the image pipeline of %s: %w", machine.Name, err)
+	}
+	if current.Status.Phase != phase || current.Status.TaskID != taskID {
+		unlock()
+		logf.FromContext(ctx).Info("Image pipeline moved on meanwhile, not starting a task",
+			"phase", phase, "currentPhase", current.Status.Phase, "currentTaskID", current.Status.TaskID)
+		return nil, nil
+	}
+	machine.ResourceVersion = current.ResourceVersion
+	return unlock, nil
+}
+
+// lockMachineWrites keeps the writes to machine that change its resource version
+// out of the start of its tasks, which are recorded with an optimistic lock on it.
+// The machines are locked independently of each other.
+func (r *FreeboxMachineReconciler) lockMachineWrites(machine client.Object) func() {
+	r.machineWritesMu.Lock()
+	if r.machineWrites == nil {
+		r.machineWrites = map[types.NamespacedName]*sync.Mutex{}
+	}
+	key := client.ObjectKeyFromObject(machine)
+	mu, ok := r.machineWrites[key]
+	if !ok {
+		mu = &sync.Mutex{}
+		r.machineWrites[key] = mu
+	}
+	r.machineWritesMu.Unlock()
+
+	mu.Lock()
+	return mu.Unlock
+}
+
+// apiReader returns the reader of FreeboxMachines checked before starting a task.
+func (r *FreeboxMachineReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// templateRevision returns the revision of the template machine was created from.
+// Machines of a MachineDeployment carry the machine-template-hash of their
+// MachineSet. Other machines, e.g. those of a KubeadmControlPlane, are identified
+// by a hash of their spec, leaving out the fields that differ between the machines
+// of a template.
+func templateRevision(machine *infrastructurev1alpha1.FreeboxMachine) *infrastructurev1alpha1.FreeboxMachineTemplateRevision {
+	revision := &infrastructurev1alpha1.FreeboxMachineTemplateRevision{
+		Name: machine.Annotations[clusterv1.TemplateClonedFromNameAnnotation],
+		Hash: machine.Labels[clusterv1.MachineDeploymentUniqueLabel],
+	}
+	if revision.Hash == "" {
+		spec := machine.Spec.DeepCopy()
+		spec.Name = ""
+		spec.ProviderID = ""
+		// Marshalling a spec cannot fail.
+		content, _ := json.Marshal(spec)
+		h := fnv.New32a()
+		_, _ = h.Write(content)
+		revision.Hash = fmt.Sprintf("%08x", h.Sum32())
+	}
+	return revision
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+var _ = Describe("templateRevision", func() {
+	newMachine := func(name string) *infrastructurev1alpha1.FreeboxMachine {
+		return &infrastructurev1alpha1.FreeboxMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: map[string]string{clusterv1.TemplateClonedFromNameAnnotation: "control-plane-v2"},
+			},
+			Spec: infrastructurev1alpha1.FreeboxMachineSpec{Name: name, VCPUs: 2, MemoryMB: 4096, ImageURL: "https://example.com/images/nocloud.raw"},
+		}
+	}
+
+	It("uses the machine-template-hash of machines of a MachineDeployment", func() {
+		machine := newMachine("md-0-abcde")
+		machine.Labels = map[string]string{clusterv1.MachineDeploymentUniqueLabel: "5f7c9d8b4"}
+		Expect(templateRevision(machine)).To(Equal(&infrastructurev1alpha1.FreeboxMachineTemplateRevision{
+			Name: "control-plane-v2",
+			Hash: "5f7c9d8b4",
+		}))
+	})
+
+	It("hashes the spec of other machines, ignoring what differs between machines of a template", func() {
+		first, second := newMachine("cp-abcde"), newMachine("cp-fghij")
+		second.Spec.ProviderID = "freebox://7"
+		Expect(templateRevision(first)).To(Equal(templateRevision(second)))
+
+		second.Spec.VCPUs = 4
+		Expect(templateRevision(first).Hash).NotTo(Equal(templateRevision(second).Hash))
+	})
+})
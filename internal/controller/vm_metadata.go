@@ -0,0 +1,131 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/freebox"
+)
+
+// vmMetadataSuffix is appended to the disk path of a VM to name its metadata file.
+const vmMetadataSuffix = ".meta.json"
+
+// vmMetadata attributes a Freebox VM to the objects it was created for, so that
+// tooling on the Freebox side can tell which cluster a VM belongs to. The Freebox
+// VM API has no description field, so it is stored in a file next to the VM disk.
+type vmMetadata struct {
+	VMID           int64             `json:"vmID"`
+	VMName         string            `json:"vmName"`
+	Namespace      string            `json:"namespace"`
+	FreeboxMachine string            `json:"freeboxMachine"`
+	Cluster        string            `json:"cluster,omitempty"`
+	Machine        string            `json:"machine,omitempty"`
+	Instance       string            `json:"instance,omitempty"`
+	TemplateName   string            `json:"templateName,omitempty"`
+	TemplateHash   string            `json:"templateHash,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+}
+
+// ownedBy reports whether the metadata was written for machine by the provider
+// instance identified by instance.
+func (m *vmMetadata) ownedBy(machine *infrastructurev1alpha1.FreeboxMachine, instance string) bool {
+	return m.Namespace == machine.Namespace && m.FreeboxMachine == machine.Name && m.Instance == instance
+}
+
+// addManagedFiles records in the status of machine files created for its VM, which
+// are removed when it is deleted.
+func addManagedFiles(machine *infrastructurev1alpha1.FreeboxMachine, files ...string) {
+	for _, file := range files {
+		if !slices.Contains(machine.Status.ManagedFiles, file) {
+			machine.Status.ManagedFiles = append(machine.Status.ManagedFiles, file)
+		}
+	}
+}
+
+// vmMetadataPath returns the path of the metadata file of the VM using diskPath.
+func vmMetadataPath(diskPath string) string {
+	return diskPath + vmMetadataSuffix
+}
+
+// writeVMMetadata writes the metadata file of vm, created for machine, next to its
+// disk at machine.Status.DiskPath.
+func (r *FreeboxMachineReconciler) writeVMMetadata(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, vm freeboxTypes.VirtualMachine) error {
+	metadata := vmMetadata{
+		VMID:           vm.ID,
+		VMName:         vm.Name,
+		Namespace:      machine.Namespace,
+		FreeboxMachine: machine.Name,
+		Cluster:        machine.Labels[clusterv1.ClusterNameLabel],
+		Labels:         machine.Labels,
+		Instance:       r.InstanceID,
+	}
+	if template := machine.Status.Template; template != nil {
+		metadata.TemplateName = template.Name
+		metadata.TemplateHash = template.Hash
+	}
+	for _, ref := range machine.OwnerReferences {
+		if ref.Kind == "Machine" && strings.HasPrefix(ref.APIVersion, clusterv1.GroupVersion.Group+"/") {
+			metadata.Machine = ref.Name
+		}
+	}
+	content, err := json.MarshalIndent(metadata, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	metadataPath := vmMetadataPath(machine.Status.DiskPath)
+	w, _, err := r.freeboxClient(ctx).FileUploadStart(ctx, freeboxTypes.FileUploadStartActionInput{
+		Size:     len(content),
+		Dirname:  freebox.Base64Path(path.Dir(metadataPath)),
+		Filename: path.Base(metadataPath),
+		Force:    freeboxTypes.FileUploadStartActionForceOverwrite,
+	})
+	if err != nil {
+		return fmt.Errorf("uploading VM metadata to %s: %w", metadataPath, err)
+	}
+	if _, err := w.Write(content); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("writing VM metadata to %s: %w", metadataPath, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("writing VM metadata to %s: %w", metadataPath, err)
+	}
+	return nil
+}
+
+// readVMMetadata reads the metadata file of the VM using diskPath.
+func (r *FreeboxMachineReconciler) readVMMetadata(ctx context.Context, diskPath string) (*vmMetadata, error) {
+	file, err := r.freeboxClient(ctx).GetFile(ctx, vmMetadataPath(diskPath))
+	if err != nil {
+		return nil, fmt.Errorf("reading VM metadata of %s: %w", diskPath, err)
+	}
+	var metadata vmMetadata
+	if err := json.NewDecoder(file.Content).Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("parsing VM metadata of %s: %w", diskPath, err)
+	}
+	return &metadata, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+const (
+	// vmShutdownTimeout is how long the VM of a deleted machine is given to shut
+	// down after the ACPI shutdown request, before it is killed.
+	vmShutdownTimeout = 2 * time.Minute
+
+	// vmShutdownPollInterval is how often the VM is checked while it shuts down.
+	vmShutdownPollInterval = 5 * time.Second
+)
+
+// Reasons of the ShutdownEscalated condition.
+const (
+	reasonShutdownRequested  = "ShutdownRequested"
+	reasonGracefulShutdown   = "GracefulShutdown"
+	reasonKilledAfterTimeout = "KilledAfterTimeout"
+	reasonKilledNoShutdown   = "KilledWithoutShutdown"
+)
+
+// deleteVM deletes the stopped VM of a deleted machine. A VM that is already gone
+// was deleted by a previous attempt, whose cleanup failed afterwards.
+func (r *FreeboxMachineReconciler) deleteVM(ctx context.Context, vmID int64) error {
+	if err := r.freeboxClient(ctx).DeleteVirtualMachine(ctx, vmID); err != nil && !errors.Is(err, freeboxclient.ErrVirtualMachineNotFound) {
+		return err
+	}
+	return nil
+}
+
+// stopVMForDeletion shuts the VM of a deleted machine down, as the Freebox only
+// deletes stopped VMs. The VM is asked to shut down, and killed when it is still
+// running after vmShutdownTimeout. The time of the request and the escalation to a
+// kill, which may corrupt the filesystems of the disk, are tracked in the
+// ShutdownEscalated condition. It returns true once the VM can be deleted.
+func (r *FreeboxMachineReconciler) stopVMForDeletion(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, vmID int64) bool {
+	logger := logf.FromContext(ctx)
+
+	vm, err := r.freeboxClient(ctx).GetVirtualMachine(ctx, vmID)
+	if err != nil {
+		// Deleting the VM reports whether it is really gone.
+		logger.Error(err, "Failed to get VM status before deletion", "vmID", vmID)
+		return true
+	}
+	shutdown := meta.FindStatusCondition(machine.Status.Conditions, ConditionShutdownEscalated)
+	if vm.Status == "stopped" {
+		if shutdown != nil && shutdown.Reason == reasonShutdownRequested {
+			meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+				Type:    ConditionShutdownEscalated,
+				Status:  metav1.ConditionFalse,
+				Reason:  reasonGracefulShutdown,
+				Message: fmt.Sprintf("VM %d shut down after %s", vmID, time.Since(shutdown.LastTransitionTime.Time).Round(time.Second)),
+			})
+		}
+		logger.Info("VM is stopped", "vmID", vmID)
+		return true
+	}
+
+	switch {
+	case shutdown == nil:
+		logger.Info("Requesting VM shutdown before deletion", "vmID", vmID, "status", vm.Status)
+		if err := r.freeboxClient(ctx).StopVirtualMachine(ctx, vmID); err != nil {
+			// VMs that are not running, e.g. still starting, cannot be shut down.
+			logger.Info("Could not request VM shutdown, killing it", "vmID", vmID, "error", err.Error())
+			r.killVM(ctx, machine, vmID, 0)
+			return false
+		}
+		meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+			Type:    ConditionShutdownEscalated,
+			Status:  metav1.ConditionFalse,
+			Reason:  reasonShutdownRequested,
+			Message: fmt.Sprintf("Waiting up to %s for VM %d to shut down", vmShutdownTimeout, vmID),
+		})
+	case shutdown.Reason == reasonShutdownRequested:
+		if waited := time.Since(shutdown.LastTransitionTime.Time); waited >= vmShutdownTimeout {
+			r.killVM(ctx, machine, vmID, waited)
+		} else {
+			logger.Info("VM not yet stopped, waiting...", "vmID", vmID, "status", vm.Status, "waited", waited.Round(time.Second))
+		}
+	default:
+		logger.Info("Killed VM not yet stopped, waiting...", "vmID", vmID, "status", vm.Status)
+	}
+	return false
+}
+
+// killVM force stops a VM that did not shut down after waiting for waited, or that
+// could not be asked to when waited is zero, and reports the escalation in an event
+// and in the ShutdownEscalated condition.
+func (r *FreeboxMachineReconciler) killVM(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine, vmID int64, waited time.Duration) {
+	logger := logf.FromContext(ctx)
+	logger.Info("Force stopping VM before deletion", "vmID", vmID, "waited", waited.Round(time.Second))
+	if err := r.freeboxClient(ctx).KillVirtualMachine(ctx, vmID); err != nil {
+		logger.Error(err, "Failed to force stop VM (may already be stopped)")
+	}
+
+	reason := reasonKilledAfterTimeout
+	message := fmt.Sprintf("VM %d was killed after waiting %s for it to shut down; its disk may not have been cleanly unmounted", vmID, waited.Round(time.Second))
+	if waited == 0 {
+		reason = reasonKilledNoShutdown
+		message = fmt.Sprintf("VM %d could not be asked to shut down and was killed; its disk may not have been cleanly unmounted", vmID)
+	}
+	meta.SetStatusCondition(&machine.Status.Conditions, metav1.Condition{
+		Type:    ConditionShutdownEscalated,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+	r.ownerEvent(ctx, machine, corev1.EventTypeWarning, "VMKilled", "Delete", "%s", message)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+)
+
+// ensureVMStorageDir creates the VM storage directory dir when it does not exist
+// yet, e.g. on a disk that never hosted a VM, as the file system tasks writing to
+// it fail otherwise. An existing directory is used as is.
+func (r *FreeboxMachineReconciler) ensureVMStorageDir(ctx context.Context, dir string) error {
+	return r.ensureDir(ctx, dir)
+}
+
+// ensureDir creates dir on the Freebox, along with its missing parents.
+func (r *FreeboxMachineReconciler) ensureDir(ctx context.Context, dir string) error {
+	parent := path.Dir(dir)
+	if parent == dir {
+		return nil
+	}
+	_, err := r.freeboxClient(ctx).GetFileInfo(ctx, dir)
+	switch {
+	case err == nil:
+		return nil
+	case !errors.Is(err, freeboxclient.ErrPathNotFound):
+		return fmt.Errorf("looking for directory %s: %w", dir, err)
+	}
+	if err := r.ensureDir(ctx, parent); err != nil {
+		return err
+	}
+	if _, err := r.freeboxClient(ctx).CreateDirectory(ctx, parent, path.Base(dir)); err != nil && !errors.Is(err, freeboxclient.ErrDestinationConflict) {
+		return fmt.Errorf("creating directory %s: %w", dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+)
+
+// Credentials identify an application authorized on a Freebox.
+type Credentials struct {
+	// Endpoint and APIVersion locate the Freebox. The Freebox of the manager is
+	// used when Endpoint is empty.
+	Endpoint   string
+	APIVersion string
+
+	AppID string
+	Token string
+}
+
+// Settings are where the provider works on a Freebox.
+type Settings struct {
+	// DownloadDir is the directory images are downloaded to.
+	DownloadDir string
+	// VMStoragePath is the directory VM disks are stored in.
+	VMStoragePath string
+	// Serial is the serial number of the Freebox.
+	Serial string
+}
+
+// Connection is a client logged in to a Freebox, with the Settings of the provider
+// on that Freebox.
+type Connection struct {
+	Client freeboxclient.Client
+	Settings
+}
+
+// Discover returns the Settings of the provider on the Freebox client is logged in
+// to. Instances sharing a Freebox each work in their own directories, named after
+// instanceID, which are created when missing, so that they never pick up or remove
+// the files and download tasks of one another.
+func Discover(ctx context.Context, client freeboxclient.Client, instanceID string) (Settings, error) {
+	downloadConfig, err := client.GetDownloadConfiguration(ctx)
+	if err != nil {
+		return Settings{}, fmt.Errorf("fetching the download configuration: %w", err)
+	}
+	systemConfig, err := client.GetSystemInfo(ctx)
+	if err != nil {
+		return Settings{}, fmt.Errorf("fetching the system configuration: %w", err)
+	}
+	settings := Settings{
+		DownloadDir: PlainPath(downloadConfig.DownloadDir),
+		// Unlike the download directory, user_main_storage is not base64-encoded.
+		VMStoragePath: systemConfig.UserMainStorage,
+		Serial:        systemConfig.Serial,
+	}
+	if instanceID != "" {
+		if _, err := client.CreateDirectory(ctx, settings.DownloadDir, instanceID); err != nil && !errors.Is(err, freeboxclient.ErrDestinationConflict) {
+			return Settings{}, fmt.Errorf("creating the download directory of instance %s: %w", instanceID, err)
+		}
+		settings.DownloadDir = path.Join(settings.DownloadDir, instanceID)
+		settings.VMStoragePath = path.Join(settings.VMStoragePath, instanceID)
+	}
+	return settings, nil
+}
+
+// Clients builds the free-go clients of the applications clusters talk to their
+// Freebox with, and keeps them logged in across reconciles. A client is kept per
+// application of each Freebox, and replaced when the token of the application
+// changes.
+type Clients struct {
+	// OtherHTTPClient sends the requests to the Freeboxes other than the one of the
+	// manager, so that they are not mixed with its diagnostics. The HTTP client of
+	// the manager is used when nil.
+	OtherHTTPClient freeboxclient.HTTPClient
+
+	mu         sync.Mutex
+	endpoint   string
+	version    string
+	http       freeboxclient.HTTPClient
+	instanceID string
+	// settings are those of the Freebox of the manager, which are not discovered
+	// again.
+	settings Settings
+	clients  map[clientKey]cachedClient
+	// newClient builds an unauthenticated client, see freeboxclient.New.
+	newClient func(endpoint, version string) (freeboxclient.Client, error)
+}
+
+type clientKey struct {
+	endpoint string
+	version  string
+	appID    string
+}
+
+type cachedClient struct {
+	token      string
+	connection *Connection
+}
+
+// NewClients returns Clients sending requests with httpClient. endpoint, version
+// and settings are those of the Freebox of the manager, and instanceID identifies
+// the provider instance, see Discover.
+func NewClients(endpoint, version string, httpClient freeboxclient.HTTPClient, instanceID string, settings Settings) *Clients {
+	return &Clients{
+		endpoint:   endpoint,
+		version:    version,
+		http:       httpClient,
+		instanceID: instanceID,
+		settings:   settings,
+		clients:    map[clientKey]cachedClient{},
+		newClient:  freeboxclient.New,
+	}
+}
+
+// Get returns a connection logged in with credentials. A client that fails to log
+// in is not kept, so that it is tried again on the next call.
+func (c *Clients) Get(ctx context.Context, credentials Credentials) (*Connection, error) {
+	key := clientKey{endpoint: credentials.Endpoint, version: credentials.APIVersion, appID: credentials.AppID}
+	if key.endpoint == "" {
+		key.endpoint = c.endpoint
+	}
+	if key.version == "" {
+		key.version = c.version
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.clients[key]; ok && cached.token == credentials.Token {
+		return cached.connection, nil
+	}
+
+	client, err := c.newClient(key.endpoint, key.version)
+	if err != nil {
+		return nil, fmt.Errorf("creating Freebox client for %s: %w", key.endpoint, err)
+	}
+	httpClient := c.http
+	if key.endpoint != c.endpoint && c.OtherHTTPClient != nil {
+		httpClient = c.OtherHTTPClient
+	}
+	// The version of the manager was negotiated with its Freebox at startup.
+	if key.endpoint != c.endpoint {
+		client.WithHTTPClient(httpClient)
+		version, err := NegotiateAPIVersion(ctx, client, key.version)
+		if err != nil {
+			return nil, fmt.Errorf("negotiating the API version of the Freebox at %s: %w", key.endpoint, err)
+		}
+		if version != key.version {
+			if client, err = c.newClient(key.endpoint, version); err != nil {
+				return nil, fmt.Errorf("creating Freebox client for %s: %w", key.endpoint, err)
+			}
+		}
+	}
+	client.WithHTTPClient(&SessionRenewer{Next: httpClient, Client: client})
+	client = NewLockedClient(client)
+	client.WithAppID(credentials.AppID)
+	client.WithPrivateToken(freeboxTypes.PrivateToken(credentials.Token))
+	if _, err := client.Login(ctx); err != nil {
+		return nil, fmt.Errorf("logging in to the Freebox at %s as %s: %w", key.endpoint, credentials.AppID, err)
+	}
+
+	connection := &Connection{Client: client, Settings: c.settings}
+	if key.endpoint != c.endpoint {
+		if connection.Settings, err = Discover(ctx, client, c.instanceID); err != nil {
+			return nil, fmt.Errorf("discovering the Freebox at %s: %w", key.endpoint, err)
+		}
+	}
+	c.clients[key] = cachedClient{token: credentials.Token, connection: connection}
+	return connection, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/freebox/mock"
+)
+
+func TestClientsGet(t *testing.T) {
+	var built []*mock.Client
+	settings := Settings{DownloadDir: "/Freebox/Téléchargements", VMStoragePath: "/Freebox/VMs", Serial: "1234"}
+	clients := NewClients("http://mafreebox.freebox.fr", "latest", nil, "", settings)
+	clients.newClient = func(string, string) (freeboxclient.Client, error) {
+		c := &mock.Client{}
+		built = append(built, c)
+		return c, nil
+	}
+	ctx := context.Background()
+
+	first, err := clients.Get(ctx, Credentials{AppID: "team-a", Token: "one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := clients.Get(ctx, Credentials{AppID: "team-a", Token: "one"}); again != first {
+		t.Error("the client of the same credentials was built again")
+	}
+	if first.Settings != settings {
+		t.Errorf("settings = %+v, want those of the manager %+v", first.Settings, settings)
+	}
+	if got := built[0].WithPrivateTokenArgsForCall(0); got != freeboxTypes.PrivateToken("one") {
+		t.Errorf("token = %q, want one", got)
+	}
+
+	rotated, err := clients.Get(ctx, Credentials{AppID: "team-a", Token: "two"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rotated == first {
+		t.Error("the client was kept after the token changed")
+	}
+	if len(built) != 2 || built[1].LoginCallCount() != 1 {
+		t.Errorf("built %d clients, want a second one logged in", len(built))
+	}
+}
+
+func TestClientsGetLoginFailure(t *testing.T) {
+	clients := NewClients("http://mafreebox.freebox.fr", "latest", nil, "", Settings{})
+	fails := true
+	clients.newClient = func(string, string) (freeboxclient.Client, error) {
+		c := &mock.Client{}
+		if fails {
+			c.LoginReturns(freeboxTypes.Permissions{}, errors.New("invalid token"))
+		}
+		return c, nil
+	}
+	ctx := context.Background()
+
+	if _, err := clients.Get(ctx, Credentials{AppID: "team-a", Token: "pending"}); err == nil {
+		t.Fatal("expected the login failure to be returned")
+	}
+	fails = false
+	if _, err := clients.Get(ctx, Credentials{AppID: "team-a", Token: "pending"}); err != nil {
+		t.Errorf("the failed client was kept: %v", err)
+	}
+}
+
+func TestClientsGetOtherFreebox(t *testing.T) {
+	var endpoints []string
+	var built []*mock.Client
+	clients := NewClients("http://mafreebox.freebox.fr", "latest", nil, "staging", Settings{Serial: "1234"})
+	clients.newClient = func(endpoint, version string) (freeboxclient.Client, error) {
+		endpoints = append(endpoints, endpoint+" "+version)
+		c := &mock.Client{}
+		built = append(built, c)
+		c.APIVersionReturns(freeboxTypes.APIVersion{APIVersion: "10.2"}, nil)
+		c.GetDownloadConfigurationReturns(freeboxTypes.DownloadConfiguration{DownloadDir: "/Disque 1/Téléchargements"}, nil)
+		c.GetSystemInfoReturns(freeboxTypes.SystemConfig{Serial: "5678", UserMainStorage: "/Disque 1"}, nil)
+		return c, nil
+	}
+
+	connection, err := clients.Get(context.Background(), Credentials{Endpoint: "https://box.example.com", AppID: "team-a", Token: "one"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The first client only asks the Freebox for its API version.
+	if !slices.Equal(endpoints, []string{"https://box.example.com latest", "https://box.example.com v10"}) {
+		t.Errorf("built clients for %v, want the other Freebox with the version of its firmware", endpoints)
+	}
+	want := Settings{DownloadDir: "/Disque 1/Téléchargements/staging", VMStoragePath: "/Disque 1/staging", Serial: "5678"}
+	if connection.Settings != want {
+		t.Errorf("settings = %+v, want %+v", connection.Settings, want)
+	}
+	if _, ok := connection.Client.(*LockedClient); !ok {
+		t.Errorf("client = %T, want a LockedClient", connection.Client)
+	}
+	if c := built[1]; c.CreateDirectoryCallCount() != 1 {
+		t.Errorf("created %d instance directories, want 1", c.CreateDirectoryCallCount())
+	}
+}
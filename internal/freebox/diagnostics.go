@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+)
+
+// DiagnosticsPath is where the Freebox diagnostics are served on the metrics server.
+const DiagnosticsPath = "/freebox"
+
+// diagnosticsWindow is how far back the recent error rate looks.
+const diagnosticsWindow = 5 * time.Minute
+
+// Diagnostics is a free-go HTTP client recording the outcome of every call to
+// a Freebox, served as JSON so that an unreachable Freebox can be told apart
+// from a broken provider.
+type Diagnostics struct {
+	mu       sync.Mutex
+	endpoint string
+	next     freeboxclient.HTTPClient
+	now      func() time.Time
+
+	lastSuccess  time.Time
+	lastError    time.Time
+	lastErrorMsg string
+	sessionStart time.Time
+	recent       []callOutcome
+
+	// clockSkew is how far the Freebox clock was ahead of the controller one
+	// according to the Date header of the last successful response.
+	clockSkew      time.Duration
+	clockSkewKnown bool
+}
+
+type callOutcome struct {
+	at     time.Time
+	failed bool
+}
+
+// DiagnosticsReport is the JSON document served by Diagnostics.
+type DiagnosticsReport struct {
+	Endpoint         string     `json:"endpoint"`
+	LastSuccess      *time.Time `json:"lastSuccess,omitempty"`
+	LastError        *time.Time `json:"lastError,omitempty"`
+	LastErrorMessage string     `json:"lastErrorMessage,omitempty"`
+	SessionAge       string     `json:"sessionAge,omitempty"`
+	ClockSkew        string     `json:"clockSkew,omitempty"`
+	RecentCalls      int        `json:"recentCalls"`
+	RecentErrors     int        `json:"recentErrors"`
+	RecentErrorRate  float64    `json:"recentErrorRate"`
+	Window           string     `json:"window"`
+}
+
+// NewDiagnostics returns Diagnostics for the Freebox at endpoint, sending requests with next.
+func NewDiagnostics(endpoint string, next freeboxclient.HTTPClient) *Diagnostics {
+	return &Diagnostics{
+		endpoint: endpoint,
+		next:     next,
+		now:      time.Now,
+	}
+}
+
+// Do implements freeboxclient.HTTPClient.
+func (d *Diagnostics) Do(req *http.Request) (*http.Response, error) {
+	resp, err := d.next.Do(req)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	switch {
+	case err != nil:
+		d.recordError(now, fmt.Sprintf("%s %s: %v", req.Method, req.URL.Path, err))
+	case resp.StatusCode >= http.StatusBadRequest:
+		d.recordError(now, fmt.Sprintf("%s %s: %s", req.Method, req.URL.Path, resp.Status))
+	default:
+		d.lastSuccess = now
+		d.recent = append(d.recent, callOutcome{at: now})
+		if date, err := http.ParseTime(resp.Header.Get("Date")); err == nil {
+			d.clockSkew, d.clockSkewKnown = date.Sub(now), true
+		}
+		if req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/login/session") {
+			d.sessionStart = now
+		}
+	}
+	d.prune(now)
+	return resp, err
+}
+
+func (d *Diagnostics) recordError(now time.Time, msg string) {
+	d.lastError = now
+	d.lastErrorMsg = msg
+	d.recent = append(d.recent, callOutcome{at: now, failed: true})
+}
+
+// prune drops outcomes older than the diagnostics window.
+func (d *Diagnostics) prune(now time.Time) {
+	i := 0
+	for i < len(d.recent) && now.Sub(d.recent[i].at) > diagnosticsWindow {
+		i++
+	}
+	d.recent = d.recent[i:]
+}
+
+// ClockSkew returns how far the Freebox clock is ahead of the controller one, negative
+// when it is behind, and false until a response of the Freebox told its time. The
+// Date header only has a precision of one second.
+func (d *Diagnostics) ClockSkew() (time.Duration, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.clockSkew, d.clockSkewKnown
+}
+
+// Report returns the current diagnostics.
+func (d *Diagnostics) Report() DiagnosticsReport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	d.prune(now)
+
+	report := DiagnosticsReport{
+		Endpoint:         d.endpoint,
+		LastErrorMessage: d.lastErrorMsg,
+		RecentCalls:      len(d.recent),
+		Window:           diagnosticsWindow.String(),
+	}
+	if !d.lastSuccess.IsZero() {
+		lastSuccess := d.lastSuccess
+		report.LastSuccess = &lastSuccess
+	}
+	if !d.lastError.IsZero() {
+		lastError := d.lastError
+		report.LastError = &lastError
+	}
+	if !d.sessionStart.IsZero() {
+		report.SessionAge = now.Sub(d.sessionStart).Round(time.Second).String()
+	}
+	if d.clockSkewKnown {
+		report.ClockSkew = d.clockSkew.Round(time.Second).String()
+	}
+	for _, outcome := range d.recent {
+		if outcome.failed {
+			report.RecentErrors++
+		}
+	}
+	if report.RecentCalls > 0 {
+		report.RecentErrorRate = float64(report.RecentErrors) / float64(report.RecentCalls)
+	}
+	return report
+}
+
+// ServeHTTP serves the diagnostics report as JSON.
+func (d *Diagnostics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(d.Report())
+}
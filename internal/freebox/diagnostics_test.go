@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stubHTTPClient func(*http.Request) (*http.Response, error)
+
+func (f stubHTTPClient) Do(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestDiagnosticsReport(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	var status int
+	var callErr error
+	d := NewDiagnostics("http://mafreebox.freebox.fr", stubHTTPClient(func(*http.Request) (*http.Response, error) {
+		if callErr != nil {
+			return nil, callErr
+		}
+		return &http.Response{StatusCode: status, Status: http.StatusText(status)}, nil
+	}))
+	d.now = func() time.Time { return now }
+
+	call := func(method, path string, code int, err error) {
+		t.Helper()
+		status, callErr = code, err
+		req, _ := http.NewRequest(method, "http://mafreebox.freebox.fr/api/latest"+path, nil)
+		_, _ = d.Do(req)
+	}
+
+	if report := d.Report(); report.LastSuccess != nil || report.RecentCalls != 0 {
+		t.Fatalf("empty report = %+v", report)
+	}
+
+	// An old failure falls out of the window but stays the last error.
+	call(http.MethodGet, "/vm/1", 0, errors.New("connection refused"))
+	now = start.Add(10 * time.Minute)
+	call(http.MethodPost, "/login/session", http.StatusOK, nil)
+	now = now.Add(time.Minute)
+	call(http.MethodGet, "/vm/1", http.StatusOK, nil)
+	call(http.MethodGet, "/vm/2", http.StatusNotFound, nil)
+	now = now.Add(30 * time.Second)
+
+	report := d.Report()
+	if report.LastSuccess == nil || !report.LastSuccess.Equal(start.Add(11*time.Minute)) {
+		t.Errorf("LastSuccess = %v, want %v", report.LastSuccess, start.Add(11*time.Minute))
+	}
+	if report.LastError == nil || !report.LastError.Equal(start.Add(11*time.Minute)) {
+		t.Errorf("LastError = %v, want %v", report.LastError, start.Add(11*time.Minute))
+	}
+	if !strings.Contains(report.LastErrorMessage, "/vm/2") {
+		t.Errorf("LastErrorMessage = %q, want the failed path", report.LastErrorMessage)
+	}
+	if report.SessionAge != "1m30s" {
+		t.Errorf("SessionAge = %q, want 1m30s", report.SessionAge)
+	}
+	if report.RecentCalls != 3 || report.RecentErrors != 1 {
+		t.Errorf("RecentCalls, RecentErrors = %d, %d, want 3, 1", report.RecentCalls, report.RecentErrors)
+	}
+	if report.RecentErrorRate < 0.33 || report.RecentErrorRate > 0.34 {
+		t.Errorf("RecentErrorRate = %v, want 1/3", report.RecentErrorRate)
+	}
+}
+
+func TestDiagnosticsClockSkew(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	date := ""
+	d := NewDiagnostics("http://mafreebox.freebox.fr", stubHTTPClient(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Date": {date}}}, nil
+	}))
+	d.now = func() time.Time { return now }
+	call := func() {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodGet, "http://mafreebox.freebox.fr/api/latest/system/", nil)
+		_, _ = d.Do(req)
+	}
+
+	call()
+	if _, ok := d.ClockSkew(); ok {
+		t.Fatal("ClockSkew() known without a Date header")
+	}
+
+	date = now.Add(-3 * time.Minute).Format(http.TimeFormat)
+	call()
+	if skew, ok := d.ClockSkew(); !ok || skew != -3*time.Minute {
+		t.Errorf("ClockSkew() = %v, %v, want -3m0s, true", skew, ok)
+	}
+	if report := d.Report(); report.ClockSkew != "-3m0s" {
+		t.Errorf("ClockSkew = %q, want -3m0s", report.ClockSkew)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"net/http"
+	"time"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	"golang.org/x/time/rate"
+)
+
+// HTTPOptions tunes the HTTP client talking to the Freebox. The HTTP server of the
+// Freebox handles few connections: the default Go transport, which keeps only two
+// idle connections per host, closes and reopens connections as soon as a few
+// controllers call it at once.
+type HTTPOptions struct {
+	// Timeout bounds each request, including the wait for a connection. Zero means
+	// no limit.
+	Timeout time.Duration
+
+	// IdleConnTimeout is how long an idle connection is kept open for reuse.
+	IdleConnTimeout time.Duration
+
+	// MaxIdleConns is how many idle connections are kept open for reuse.
+	MaxIdleConns int
+
+	// MaxConns limits the connections open at once, requests beyond it waiting for
+	// one to be free. Zero means no limit.
+	MaxConns int
+}
+
+// NewHTTPClient returns an HTTP client for the Freebox tuned with opts.
+func NewHTTPClient(opts HTTPOptions) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.IdleConnTimeout = opts.IdleConnTimeout
+	transport.MaxIdleConns = opts.MaxIdleConns
+	transport.MaxIdleConnsPerHost = opts.MaxIdleConns
+	transport.MaxConnsPerHost = opts.MaxConns
+	return &http.Client{Transport: transport, Timeout: opts.Timeout}
+}
+
+// RateLimiter is a free-go HTTP client sending the requests at a steady rate, so
+// that controllers reconciling many machines at once do not overload the Freebox,
+// which then fails the requests.
+type RateLimiter struct {
+	next    freeboxclient.HTTPClient
+	limiter *rate.Limiter
+}
+
+// NewRateLimiter returns an HTTP client sending the requests with next, at most qps
+// per second on average and burst at once. next is returned as is when qps is zero.
+func NewRateLimiter(next freeboxclient.HTTPClient, qps float64, burst int) freeboxclient.HTTPClient {
+	if qps <= 0 {
+		return next
+	}
+	return &RateLimiter{next: next, limiter: rate.NewLimiter(rate.Limit(qps), max(burst, 1))}
+}
+
+// Do implements freeboxclient.HTTPClient.
+func (r *RateLimiter) Do(req *http.Request) (*http.Response, error) {
+	if err := r.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return r.next.Do(req)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClientReusesConnections(t *testing.T) {
+	var connections atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			connections.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	c := NewHTTPClient(HTTPOptions{Timeout: 5 * time.Second, IdleConnTimeout: time.Minute, MaxIdleConns: 4, MaxConns: 4})
+	for range 10 {
+		resp, err := c.Get(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}
+	if got := connections.Load(); got != 1 {
+		t.Errorf("opened %d connections for sequential requests, want 1", got)
+	}
+	if c.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %s, want 5s", c.Timeout)
+	}
+	if got := c.Transport.(*http.Transport).MaxConnsPerHost; got != 4 {
+		t.Errorf("MaxConnsPerHost = %d, want 4", got)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer server.Close()
+
+	c := NewRateLimiter(server.Client(), 20, 2)
+	start := time.Now()
+	for range 4 {
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := c.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+	}
+	// The first 2 requests are sent right away, the next ones every 50ms.
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("sent 4 requests in %s, want them spread over 100ms", elapsed)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("the server got %d requests, want 4", got)
+	}
+
+	if NewRateLimiter(server.Client(), 0, 0) != server.Client() {
+		t.Error("NewRateLimiter() with no QPS wraps the client, want it as is")
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"context"
+	"sync"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+)
+
+// LockedClient is a free-go client whose calls are serialized. free-go keeps the
+// private token and the session of the client in plain fields, which every request
+// reads and logs in again to replace when the session expires, so a client shared
+// by the reconcilers, the token watcher and its SessionRenewer must not be called
+// concurrently. The Freebox requests are rate limited anyway, see NewRateLimiter.
+//
+// The SessionRenewer of the client logs in with the wrapped client, as it does so
+// while the request it renews the session of holds the lock.
+//
+// The methods implementing freeboxclient.Client are generated by
+// hack/generate-locked-client, see "make generate". The lock is held for the whole
+// call, as free-go reads the session, and logs in again, while it sends the request;
+// only the streams returned by ListenEvents and FileUploadStart outlive it.
+type LockedClient struct {
+	mu     sync.Mutex
+	client freeboxclient.Client
+}
+
+var _ freeboxclient.Client = &LockedClient{}
+
+// NewLockedClient returns a LockedClient serializing the calls to client.
+func NewLockedClient(client freeboxclient.Client) *LockedClient {
+	return &LockedClient{client: client}
+}
+
+// LoginWithToken logs in with token, and keeps it as the private token of the
+// client. The client keeps its previous token and session when that fails, without
+// any call seeing the token that failed.
+func (c *LockedClient) LoginWithToken(ctx context.Context, token, previous freeboxTypes.PrivateToken) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.client.WithPrivateToken(token)
+	if _, err := c.client.Login(ctx); err != nil {
+		c.client.WithPrivateToken(previous)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+)
+
+// TestLockedClientSerializesLogins is meant to be run with -race: the requests, the
+// renewals of their rejected sessions and the token reloads all log the same client in.
+func TestLockedClientSerializesLogins(t *testing.T) {
+	box := &fakeFreebox{}
+	server := httptest.NewServer(box)
+	defer server.Close()
+
+	unlocked, err := freeboxclient.New(server.URL, "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlocked.WithHTTPClient(&SessionRenewer{Next: server.Client(), Client: unlocked})
+	client := NewLockedClient(unlocked)
+	client.WithAppID("capi")
+	client.WithPrivateToken(freeboxTypes.PrivateToken("old"))
+	ctx := context.Background()
+	if _, err := client.Login(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			for range 10 {
+				if _, err := client.ListVirtualMachines(ctx); err != nil {
+					t.Errorf("ListVirtualMachines() error = %v", err)
+				}
+			}
+		})
+	}
+	wg.Go(func() {
+		for range 5 {
+			box.expire()
+			if err := client.LoginWithToken(ctx, "new", "old"); err != nil {
+				t.Errorf("LoginWithToken() error = %v", err)
+			}
+		}
+	})
+	wg.Wait()
+}
+
+// streamingFreebox adds to a fakeFreebox the websockets streaming its events and
+// the files uploaded to it. The event stream sends notifications events, and is
+// closed by the Freebox once done is closed.
+type streamingFreebox struct {
+	*fakeFreebox
+	notifications int
+	done          chan struct{}
+
+	mu     sync.Mutex
+	upload freeboxTypes.FileUploadStartAction
+}
+
+func (f *streamingFreebox) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/api/latest/ws/event":
+		f.serveEvents(w, r)
+	case "/api/latest/ws/upload":
+		f.serveUpload(w, r)
+	case "/api/latest/upload/":
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"success":true,"result":[{"id":7,"size":%d,"uploaded":0,"status":"in_progress",`+
+			`"start_date":1700000000,"last_update":1700000000,"upload_name":%q,"dirname":%q}]}`,
+			f.upload.Size, f.upload.Filename, f.upload.Dirname)
+	default:
+		f.fakeFreebox.ServeHTTP(w, r)
+	}
+}
+
+func (f *streamingFreebox) serveEvents(w http.ResponseWriter, r *http.Request) {
+	ws, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = ws.Close() }()
+	var register map[string]any
+	if ws.ReadJSON(&register) != nil || ws.WriteJSON(map[string]any{"success": true, "action": "register"}) != nil {
+		return
+	}
+	for range f.notifications {
+		if ws.WriteJSON(map[string]any{"success": true, "action": "notification", "source": "vm", "event": "state_changed", "result": map[string]any{}}) != nil {
+			return
+		}
+	}
+	<-f.done
+}
+
+func (f *streamingFreebox) serveUpload(w http.ResponseWriter, r *http.Request) {
+	ws, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer func() { _ = ws.Close() }()
+	var start freeboxTypes.FileUploadStartAction
+	if ws.ReadJSON(&start) != nil {
+		return
+	}
+	f.mu.Lock()
+	f.upload = start
+	f.mu.Unlock()
+	if ws.WriteJSON(map[string]any{"success": true, "action": start.Action, "request_id": start.RequestID}) != nil {
+		return
+	}
+	total := 0
+	for {
+		messageType, data, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+		response := map[string]any{"success": true, "action": "upload_data", "request_id": start.RequestID, "result": map[string]any{"total_len": total + len(data)}}
+		if messageType == websocket.BinaryMessage {
+			total += len(data)
+		} else {
+			response["action"], response["result"] = "upload_finalize", map[string]any{"complete": true}
+		}
+		if ws.WriteJSON(response) != nil {
+			return
+		}
+	}
+}
+
+// TestLockedClientStreams is meant to be run with -race: the streams returned by
+// ListenEvents and FileUploadStart are used without the lock, while the client logs
+// in again with another token.
+func TestLockedClientStreams(t *testing.T) {
+	box := &streamingFreebox{fakeFreebox: &fakeFreebox{}, notifications: 5, done: make(chan struct{})}
+	server := httptest.NewServer(box)
+	defer server.Close()
+
+	unlocked, err := freeboxclient.New(server.URL, "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlocked.WithHTTPClient(&SessionRenewer{Next: server.Client(), Client: unlocked})
+	client := NewLockedClient(unlocked)
+	client.WithAppID("capi")
+	client.WithPrivateToken(freeboxTypes.PrivateToken("old"))
+	ctx := context.Background()
+
+	events, err := client.ListenEvents(ctx, []freeboxTypes.EventDescription{{Source: "vm", Name: "state_changed"}})
+	if err != nil {
+		t.Fatalf("ListenEvents() error = %v", err)
+	}
+	data := []byte("disk image")
+	upload, id, err := client.FileUploadStart(ctx, freeboxTypes.FileUploadStartActionInput{
+		Size:     len(data),
+		Dirname:  "/mnt/VMs",
+		Filename: "disk.qcow2",
+	})
+	if err != nil {
+		t.Fatalf("FileUploadStart() error = %v", err)
+	}
+	if id != 7 {
+		t.Errorf("FileUploadStart() task = %d, want 7", id)
+	}
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for range 5 {
+			box.expire()
+			if err := client.LoginWithToken(ctx, "new", "old"); err != nil {
+				t.Errorf("LoginWithToken() error = %v", err)
+			}
+			if _, err := client.ListVirtualMachines(ctx); err != nil {
+				t.Errorf("ListVirtualMachines() error = %v", err)
+			}
+		}
+	})
+	wg.Go(func() {
+		if _, err := upload.Write(data); err != nil {
+			t.Errorf("Write() error = %v", err)
+		}
+		if err := upload.Close(); err != nil {
+			t.Errorf("Close() error = %v", err)
+		}
+	})
+	wg.Go(func() {
+		received := 0
+		for event := range events {
+			if event.Error != nil {
+				continue
+			}
+			if received++; received == box.notifications {
+				close(box.done)
+			}
+		}
+		if received != box.notifications {
+			t.Errorf("received %d events, want %d", received, box.notifications)
+		}
+	})
+	wg.Wait()
+}
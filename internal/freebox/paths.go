@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"encoding/base64"
+	"path"
+	"strings"
+
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+)
+
+// The Freebox API encodes most paths in base64, but not all of them: the download
+// configuration, download tasks, file system tasks, virtual disks and VMs do, while
+// the system configuration reports user_main_storage as a plain path. free-go
+// decodes the fields typed as freeboxTypes.Base64Path and encodes them back, so
+// they hold plain paths in memory, but the sources and destination of file system
+// tasks are plain strings left as the Freebox sent them.
+//
+// The helpers below are the only conversions between plain paths and the paths of
+// the Freebox API, so that an encoded path is never used as a plain one.
+
+// Base64Path returns the plain path p as a path that free-go encodes when sending
+// it to the Freebox.
+func Base64Path(p string) freeboxTypes.Base64Path {
+	return freeboxTypes.Base64Path(p)
+}
+
+// PlainPath returns the plain path held by p, which free-go decoded when receiving
+// it from the Freebox.
+func PlainPath(p freeboxTypes.Base64Path) string {
+	return string(p)
+}
+
+// SamePath reports whether p, received from the Freebox, designates the plain path
+// want, ignoring trailing slashes and redundant elements.
+func SamePath(p freeboxTypes.Base64Path, want string) bool {
+	return path.Clean(PlainPath(p)) == path.Clean(want)
+}
+
+// DecodeTaskPath returns the plain path of a file system task source or
+// destination, which the Freebox reports either plain or base64-encoded.
+func DecodeTaskPath(p string) string {
+	if decoded, err := base64.StdEncoding.DecodeString(p); err == nil && strings.HasPrefix(string(decoded), "/") {
+		return string(decoded)
+	}
+	return p
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"encoding/json"
+	"testing"
+
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+)
+
+func TestBase64PathRoundTrip(t *testing.T) {
+	const p = "/Freebox/VMs/my vm.qcow2"
+
+	data, err := json.Marshal(Base64Path(p))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `"L0ZyZWVib3gvVk1zL215IHZtLnFjb3cy"`; string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var decoded freeboxTypes.Base64Path
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := PlainPath(decoded); got != p {
+		t.Errorf("PlainPath() = %q, want %q", got, p)
+	}
+}
+
+func TestSamePath(t *testing.T) {
+	tests := []struct {
+		name string
+		p    freeboxTypes.Base64Path
+		want string
+		same bool
+	}{
+		{name: "identical", p: "/Freebox/Downloads", want: "/Freebox/Downloads", same: true},
+		{name: "trailing slash", p: "/Freebox/Downloads/", want: "/Freebox/Downloads", same: true},
+		{name: "subdirectory", p: "/Freebox/Downloads/staging", want: "/Freebox/Downloads", same: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SamePath(tt.p, tt.want); got != tt.same {
+				t.Errorf("SamePath(%q, %q) = %v, want %v", tt.p, tt.want, got, tt.same)
+			}
+		})
+	}
+}
+
+func TestDecodeTaskPath(t *testing.T) {
+	tests := []struct {
+		name string
+		p    string
+		want string
+	}{
+		{name: "plain path", p: "/Freebox/Downloads/nocloud.raw.xz", want: "/Freebox/Downloads/nocloud.raw.xz"},
+		{name: "encoded path", p: "L0ZyZWVib3gvRG93bmxvYWRzL25vY2xvdWQucmF3Lnh6", want: "/Freebox/Downloads/nocloud.raw.xz"},
+		{name: "encoded relative path is kept", p: "bm9jbG91ZA==", want: "bm9jbG91ZA=="},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DecodeTaskPath(tt.p); got != tt.want {
+				t.Errorf("DecodeTaskPath(%q) = %q, want %q", tt.p, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Loginer logs a Freebox client in, opening a new session.
+type Loginer interface {
+	Login(ctx context.Context) (freeboxTypes.Permissions, error)
+}
+
+// SessionRenewer is a free-go HTTP client that logs in again when the Freebox
+// rejects the session of a request, e.g. after it rebooted, and sends the request
+// again with the new session. free-go only opens a new session once the previous
+// one is as old as the Freebox lets them live, so calls would fail until then.
+//
+// The client must be a LockedClient, so that the logins do not race with the other
+// requests: they are then serialized too, and the requests rejected together only
+// open one session.
+type SessionRenewer struct {
+	// Next sends the requests.
+	Next freeboxclient.HTTPClient
+	// Client is the client using the SessionRenewer, logged in again on rejections.
+	// It is the client wrapped by the LockedClient, whose lock is already held.
+	Client Loginer
+
+	mu sync.Mutex
+	// token is the session token of the last login.
+	token string
+}
+
+// rejectedSessionCodes are the error codes of the Freebox API rejecting a session.
+var rejectedSessionCodes = map[string]bool{
+	"auth_required":   true,
+	"invalid_session": true,
+}
+
+// apiResponse is the envelope of the Freebox API responses.
+type apiResponse struct {
+	Success   bool   `json:"success"`
+	ErrorCode string `json:"error_code"`
+	Result    struct {
+		SessionToken string `json:"session_token"`
+	} `json:"result"`
+}
+
+// Do implements freeboxclient.HTTPClient.
+func (s *SessionRenewer) Do(req *http.Request) (*http.Response, error) {
+	resp, err := s.Next.Do(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if req.Method == http.MethodPost && strings.HasSuffix(strings.TrimSuffix(req.URL.Path, "/"), "/login/session") {
+		body, err := readBody(resp)
+		if err != nil {
+			return nil, err
+		}
+		var response apiResponse
+		if json.Unmarshal(body, &response) == nil && response.Success && response.Result.SessionToken != "" {
+			s.mu.Lock()
+			s.token = response.Result.SessionToken
+			s.mu.Unlock()
+		}
+		return resp, nil
+	}
+
+	rejected := req.Header.Get(freeboxclient.AuthHeader)
+	if rejected == "" || s.Client == nil || (resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusUnauthorized) {
+		return resp, nil
+	}
+	body, err := readBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	var response apiResponse
+	if json.Unmarshal(body, &response) != nil || !rejectedSessionCodes[response.ErrorCode] {
+		return resp, nil
+	}
+	// The request cannot be sent again without its body.
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	token, err := s.renew(req.Context(), rejected)
+	if err != nil {
+		logf.FromContext(req.Context()).Error(err, "Failed to log in to the Freebox again after it rejected the session")
+		return resp, nil
+	}
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	retry.Header.Set(freeboxclient.AuthHeader, token)
+	return s.Next.Do(retry)
+}
+
+// renew returns the token of a session replacing rejected, logging in unless
+// another request already did.
+func (s *SessionRenewer) renew(ctx context.Context, rejected string) (string, error) {
+	s.mu.Lock()
+	token := s.token
+	s.mu.Unlock()
+	if token != "" && token != rejected {
+		return token, nil
+	}
+
+	if _, err := s.Client.Login(ctx); err != nil {
+		return "", err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == "" || s.token == rejected {
+		return "", fmt.Errorf("no new session after logging in")
+	}
+	logf.FromContext(ctx).Info("Logged in to the Freebox again after it rejected the session")
+	return s.token, nil
+}
+
+// readBody reads the body of resp, and replaces it so that it can be read again.
+func readBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading the Freebox response: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
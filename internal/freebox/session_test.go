@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+)
+
+// fakeFreebox serves the login and VM list endpoints of a Freebox, handing out
+// numbered sessions and accepting the last one only.
+type fakeFreebox struct {
+	mu       sync.Mutex
+	sessions int
+}
+
+func (f *fakeFreebox) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/api/latest/login":
+		_, _ = fmt.Fprint(w, `{"success":true,"result":{"challenge":"challenge"}}`)
+	case "/api/latest/login/session":
+		f.sessions++
+		_, _ = fmt.Fprintf(w, `{"success":true,"result":{"session_token":"session-%d"}}`, f.sessions)
+	case "/api/latest/vm/":
+		if r.Header.Get(freeboxclient.AuthHeader) != fmt.Sprintf("session-%d", f.sessions) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = fmt.Fprint(w, `{"success":false,"error_code":"auth_required","msg":"Invalid session token, or no session token sent"}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"success":true,"result":[{"id":1,"name":"vm"}]}`)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// expire makes the Freebox forget the current session, as when it reboots.
+func (f *fakeFreebox) expire() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sessions++
+}
+
+func TestSessionRenewerLogsInAgain(t *testing.T) {
+	box := &fakeFreebox{}
+	server := httptest.NewServer(box)
+	defer server.Close()
+
+	client, err := freeboxclient.New(server.URL, "latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	renewer := &SessionRenewer{Next: server.Client(), Client: client}
+	client.WithHTTPClient(renewer)
+	client.WithAppID("capi")
+	client.WithPrivateToken(freeboxTypes.PrivateToken("token"))
+	ctx := context.Background()
+	if _, err := client.Login(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	box.expire()
+	vms, err := client.ListVirtualMachines(ctx)
+	if err != nil {
+		t.Fatalf("ListVirtualMachines() after the session was rejected: %v", err)
+	}
+	if len(vms) != 1 {
+		t.Errorf("got %d VMs, want 1", len(vms))
+	}
+	if box.sessions != 3 {
+		t.Errorf("the Freebox handed out %d sessions, want a new one after the rejection", box.sessions)
+	}
+
+	if _, err := client.ListVirtualMachines(ctx); err != nil {
+		t.Errorf("ListVirtualMachines() with the new session: %v", err)
+	}
+	if box.sessions != 3 {
+		t.Errorf("the Freebox handed out %d sessions, want the new one to be reused", box.sessions)
+	}
+}
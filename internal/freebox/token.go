@@ -0,0 +1,161 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package freebox holds the manager-side plumbing around the free-go client.
+package freebox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ReadTokenFile returns the Freebox app token stored in a file, without surrounding whitespace.
+func ReadTokenFile(path string) (string, error) {
+	return readCredentialFile(path, "token")
+}
+
+// ReadAppIDFile returns the Freebox app ID stored in a file, without surrounding whitespace.
+func ReadAppIDFile(path string) (string, error) {
+	return readCredentialFile(path, "app ID")
+}
+
+// readCredentialFile returns the content of the file holding the Freebox credential
+// named name, without surrounding whitespace.
+func readCredentialFile(path, name string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading Freebox %s file: %w", name, err)
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return "", fmt.Errorf("freebox %s file %s is empty", name, path)
+	}
+	return value, nil
+}
+
+// tokenRetryInterval is how long TokenWatcher waits before trying again a token it failed to log in with.
+const tokenRetryInterval = 30 * time.Second
+
+// TokenWatcher keeps the private token of a Freebox client in sync with a file,
+// logging in again as soon as the token changes so that a rotated token is
+// picked up without restarting the manager.
+//
+// A token that cannot log in yet, e.g. because it still awaits approval on the
+// Freebox, does not replace the current one: it is retried periodically while
+// the client keeps using the previous token.
+type TokenWatcher struct {
+	mu            sync.Mutex
+	path          string
+	token         string
+	client        *LockedClient
+	retryInterval time.Duration
+}
+
+// NewTokenWatcher reads the token file and configures the client with it.
+func NewTokenWatcher(path string, client *LockedClient) (*TokenWatcher, error) {
+	token, err := ReadTokenFile(path)
+	if err != nil {
+		return nil, err
+	}
+	client.WithPrivateToken(freeboxTypes.PrivateToken(token))
+	return &TokenWatcher{
+		path:          path,
+		token:         token,
+		client:        client,
+		retryInterval: tokenRetryInterval,
+	}, nil
+}
+
+// Start watches the directory of the token file until the context is done.
+// The directory is watched rather than the file because Secret volumes are
+// updated by swapping a symlink.
+func (w *TokenWatcher) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating Freebox token watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		return fmt.Errorf("watching Freebox token file: %w", err)
+	}
+
+	log := logf.FromContext(ctx).WithName("freebox-token-watcher")
+	log.Info("Watching Freebox token file", "path", w.path)
+	var retry <-chan time.Time
+	reload := func() {
+		retry = nil
+		if err := w.reload(ctx); err != nil {
+			log.Error(err, "Failed to reload Freebox token, keeping the current one", "path", w.path, "retryIn", w.retryInterval)
+			retry = time.After(w.retryInterval)
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-retry:
+			reload()
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			reload()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Error(err, "Freebox token watcher error")
+		}
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that every
+// replica keeps its client logged in, not only the leader.
+func (w *TokenWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// reload logs in with the token file content if it changed. The client keeps its
+// current token when that login fails.
+func (w *TokenWatcher) reload(ctx context.Context) error {
+	token, err := ReadTokenFile(w.path)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if token == w.token {
+		return nil
+	}
+
+	if err := w.client.LoginWithToken(ctx, freeboxTypes.PrivateToken(token), freeboxTypes.PrivateToken(w.token)); err != nil {
+		return fmt.Errorf("logging in with the new Freebox token: %w", err)
+	}
+	w.token = token
+	logf.FromContext(ctx).Info("Reloaded Freebox token", "path", w.path)
+	return nil
+}
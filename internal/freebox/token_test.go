@@ -0,0 +1,167 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/freebox/mock"
+)
+
+func TestReadTokenFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{name: "trims trailing newline", content: "secret\n", want: "secret"},
+		{name: "trims surrounding spaces", content: "  secret  ", want: "secret"},
+		{name: "rejects empty file", content: "\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "token")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := ReadTokenFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadTokenFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReadTokenFile() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadAppIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "appID")
+	if err := os.WriteFile(path, []byte("fr.freebox.capi\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadAppIDFile(path)
+	if err != nil {
+		t.Fatalf("ReadAppIDFile() error = %v", err)
+	}
+	if got != "fr.freebox.capi" {
+		t.Errorf("ReadAppIDFile() = %q, want %q", got, "fr.freebox.capi")
+	}
+	if _, err := ReadAppIDFile(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("ReadAppIDFile() of a missing file succeeded")
+	}
+}
+
+func TestTokenWatcherReloadsRotatedToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("old\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fc := &mock.Client{}
+	w, err := NewTokenWatcher(path, NewLockedClient(fc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := fc.WithPrivateTokenArgsForCall(0); got != freeboxTypes.PrivateToken("old") {
+		t.Fatalf("initial token = %q, want %q", got, "old")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- w.Start(ctx) }()
+
+	// Rewrite the file until the watcher, which may not be watching yet, picks it up.
+	deadline := time.Now().Add(10 * time.Second)
+	for fc.LoginCallCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("token watcher did not log in with the rotated token")
+		}
+		if err := os.WriteFile(path, []byte("new\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if got := fc.WithPrivateTokenArgsForCall(1); got != freeboxTypes.PrivateToken("new") {
+		t.Errorf("reloaded token = %q, want %q", got, "new")
+	}
+	if calls := fc.LoginCallCount(); calls != 1 {
+		t.Errorf("Login called %d times, want 1", calls)
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+}
+
+func TestTokenWatcherKeepsCurrentTokenUntilRotatedTokenLogsIn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fc := &mock.Client{}
+	// The rotated token is rejected once, as when it still awaits approval on the Freebox.
+	fc.LoginReturnsOnCall(0, freeboxTypes.Permissions{}, errors.New("invalid_token"))
+	w, err := NewTokenWatcher(path, NewLockedClient(fc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.retryInterval = 100 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = w.Start(ctx) }()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for fc.LoginCallCount() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("token watcher did not try the rotated token")
+		}
+		if err := os.WriteFile(path, []byte("new"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	// The failed attempt restores the current token right after trying the new one.
+	if got := fc.WithPrivateTokenArgsForCall(2); got != freeboxTypes.PrivateToken("old") {
+		t.Errorf("token after failed login = %q, want %q", got, "old")
+	}
+
+	// The rotated token is retried until it logs in.
+	for fc.LoginCallCount() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("token watcher did not retry the rotated token")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	last := fc.WithPrivateTokenCallCount() - 1
+	if got := fc.WithPrivateTokenArgsForCall(last); got != freeboxTypes.PrivateToken("new") {
+		t.Errorf("token after retry = %q, want %q", got, "new")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+)
+
+// MinVMAPIVersion is the first major version of the Freebox API with the VM endpoints.
+const MinVMAPIVersion = 8
+
+// NegotiateAPIVersion asks the Freebox client talks to which API version its
+// firmware provides, and returns the version to use when version is configured:
+// the major version of the firmware for "latest", so that the requests keep the
+// same payloads when the firmware is updated, and for a version newer than the
+// firmware, which would reject the requests. It fails when the firmware, or
+// version, predates the VM endpoints.
+func NegotiateAPIVersion(ctx context.Context, client freeboxclient.Client, version string) (string, error) {
+	advertised, err := client.APIVersion(ctx)
+	if err != nil {
+		return "", fmt.Errorf("fetching the API version of the Freebox: %w", err)
+	}
+	return negotiateAPIVersion(version, advertised.APIVersion)
+}
+
+// negotiateAPIVersion returns the version to use when version is configured on a
+// Freebox advertising the advertised api_version, e.g. "10.2".
+func negotiateAPIVersion(version, advertised string) (string, error) {
+	latest, ok := APIMajorVersion(advertised)
+	if !ok {
+		return "", fmt.Errorf("unexpected API version %q advertised by the Freebox", advertised)
+	}
+	if latest < MinVMAPIVersion {
+		return "", fmt.Errorf("the Freebox provides API v%d, whose firmware has no VM endpoints: they need API v%d or later", latest, MinVMAPIVersion)
+	}
+	if version == "" || version == "latest" {
+		return fmt.Sprintf("v%d", latest), nil
+	}
+	configured, ok := APIMajorVersion(version)
+	switch {
+	case !ok:
+		return version, nil
+	case configured < MinVMAPIVersion:
+		return "", fmt.Errorf("API %s has no VM endpoints: they need API v%d or later", version, MinVMAPIVersion)
+	case configured > latest:
+		return fmt.Sprintf("v%d", latest), nil
+	}
+	return version, nil
+}
+
+// APIMajorVersion returns the major version of a Freebox API version, e.g. 10 for
+// the "10.2" advertised by the Freebox or the "v10" of its URLs.
+func APIMajorVersion(version string) (int, bool) {
+	major, _, _ := strings.Cut(strings.TrimPrefix(version, "v"), ".")
+	n, err := strconv.Atoi(major)
+	return n, err == nil
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package freebox
+
+import "testing"
+
+func TestNegotiateAPIVersion(t *testing.T) {
+	tests := []struct {
+		version    string
+		advertised string
+		want       string
+		wantErr    bool
+	}{
+		{version: "latest", advertised: "10.2", want: "v10"},
+		{version: "", advertised: "12.0", want: "v12"},
+		{version: "v9", advertised: "10.2", want: "v9"},
+		{version: "v12", advertised: "10.2", want: "v10"},
+		{version: "v10", advertised: "10.2", want: "v10"},
+		{version: "v7", advertised: "10.2", wantErr: true},
+		{version: "latest", advertised: "6.0", wantErr: true},
+		{version: "latest", advertised: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := negotiateAPIVersion(tt.version, tt.advertised)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("negotiateAPIVersion(%q, %q) error = %v, wantErr %v", tt.version, tt.advertised, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("negotiateAPIVersion(%q, %q) = %q, want %q", tt.version, tt.advertised, got, tt.want)
+		}
+	}
+}
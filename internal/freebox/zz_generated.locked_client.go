@@ -0,0 +1,556 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by hack/generate-locked-client. DO NOT EDIT.
+
+package freebox
+
+import (
+	"context"
+	"io"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+)
+
+// APIVersion implements freeboxclient.Client.
+func (c *LockedClient) APIVersion(ctx context.Context) (freeboxTypes.APIVersion, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.APIVersion(ctx)
+}
+
+// AddDownloadTask implements freeboxclient.Client.
+func (c *LockedClient) AddDownloadTask(ctx context.Context, request freeboxTypes.DownloadRequest) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.AddDownloadTask(ctx, request)
+}
+
+// AddHashFileTask implements freeboxclient.Client.
+func (c *LockedClient) AddHashFileTask(ctx context.Context, payload freeboxTypes.HashPayload) (freeboxTypes.FileSystemTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.AddHashFileTask(ctx, payload)
+}
+
+// Authorize implements freeboxclient.Client.
+func (c *LockedClient) Authorize(ctx context.Context, arg1 freeboxTypes.AuthorizationRequest) (freeboxTypes.PrivateToken, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.Authorize(ctx, arg1)
+}
+
+// CancelUploadTask implements freeboxclient.Client.
+func (c *LockedClient) CancelUploadTask(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.CancelUploadTask(ctx, identifier)
+}
+
+// CleanUploadTasks implements freeboxclient.Client.
+func (c *LockedClient) CleanUploadTasks(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.CleanUploadTasks(ctx)
+}
+
+// CopyFiles implements freeboxclient.Client.
+func (c *LockedClient) CopyFiles(ctx context.Context, sources []string, destination string, mode freeboxTypes.FileCopyMode) (freeboxTypes.FileSystemTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.CopyFiles(ctx, sources, destination, mode)
+}
+
+// CreateDHCPStaticLease implements freeboxclient.Client.
+func (c *LockedClient) CreateDHCPStaticLease(ctx context.Context, payload freeboxTypes.DHCPStaticLeasePayload) (freeboxTypes.LanInterfaceHost, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.CreateDHCPStaticLease(ctx, payload)
+}
+
+// CreateDirectory implements freeboxclient.Client.
+func (c *LockedClient) CreateDirectory(ctx context.Context, parent string, name string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.CreateDirectory(ctx, parent, name)
+}
+
+// CreatePortForwardingRule implements freeboxclient.Client.
+func (c *LockedClient) CreatePortForwardingRule(ctx context.Context, payload freeboxTypes.PortForwardingRulePayload) (freeboxTypes.PortForwardingRule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.CreatePortForwardingRule(ctx, payload)
+}
+
+// CreateVPNUser implements freeboxclient.Client.
+func (c *LockedClient) CreateVPNUser(ctx context.Context, payload freeboxTypes.VPNUserPayload) (freeboxTypes.VPNUser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.CreateVPNUser(ctx, payload)
+}
+
+// CreateVirtualDisk implements freeboxclient.Client.
+func (c *LockedClient) CreateVirtualDisk(ctx context.Context, payload freeboxTypes.VirtualDisksCreatePayload) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.CreateVirtualDisk(ctx, payload)
+}
+
+// CreateVirtualMachine implements freeboxclient.Client.
+func (c *LockedClient) CreateVirtualMachine(ctx context.Context, payload freeboxTypes.VirtualMachinePayload) (freeboxTypes.VirtualMachine, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.CreateVirtualMachine(ctx, payload)
+}
+
+// DeleteDHCPStaticLease implements freeboxclient.Client.
+func (c *LockedClient) DeleteDHCPStaticLease(ctx context.Context, identifier string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.DeleteDHCPStaticLease(ctx, identifier)
+}
+
+// DeleteDownloadTask implements freeboxclient.Client.
+func (c *LockedClient) DeleteDownloadTask(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.DeleteDownloadTask(ctx, identifier)
+}
+
+// DeleteFileSystemTask implements freeboxclient.Client.
+func (c *LockedClient) DeleteFileSystemTask(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.DeleteFileSystemTask(ctx, identifier)
+}
+
+// DeletePortForwardingRule implements freeboxclient.Client.
+func (c *LockedClient) DeletePortForwardingRule(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.DeletePortForwardingRule(ctx, identifier)
+}
+
+// DeleteUploadTask implements freeboxclient.Client.
+func (c *LockedClient) DeleteUploadTask(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.DeleteUploadTask(ctx, identifier)
+}
+
+// DeleteVPNUser implements freeboxclient.Client.
+func (c *LockedClient) DeleteVPNUser(ctx context.Context, login string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.DeleteVPNUser(ctx, login)
+}
+
+// DeleteVirtualDiskTask implements freeboxclient.Client.
+func (c *LockedClient) DeleteVirtualDiskTask(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.DeleteVirtualDiskTask(ctx, identifier)
+}
+
+// DeleteVirtualMachine implements freeboxclient.Client.
+func (c *LockedClient) DeleteVirtualMachine(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.DeleteVirtualMachine(ctx, identifier)
+}
+
+// EraseDownloadTask implements freeboxclient.Client.
+func (c *LockedClient) EraseDownloadTask(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.EraseDownloadTask(ctx, identifier)
+}
+
+// ExtractFile implements freeboxclient.Client.
+func (c *LockedClient) ExtractFile(ctx context.Context, payload freeboxTypes.ExtractFilePayload) (freeboxTypes.FileSystemTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ExtractFile(ctx, payload)
+}
+
+// FileUploadStart implements freeboxclient.Client.
+//
+// The lock is only held while free-go opens the stream with the session of the
+// client. The returned io.WriteCloser is then used without it, as
+// free-go no longer reads the client, see TestLockedClientStreams.
+func (c *LockedClient) FileUploadStart(ctx context.Context, input freeboxTypes.FileUploadStartActionInput) (io.WriteCloser, int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.FileUploadStart(ctx, input)
+}
+
+// GetDHCPStaticLease implements freeboxclient.Client.
+func (c *LockedClient) GetDHCPStaticLease(ctx context.Context, identifier string) (freeboxTypes.DHCPStaticLeaseInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetDHCPStaticLease(ctx, identifier)
+}
+
+// GetDownloadConfiguration implements freeboxclient.Client.
+func (c *LockedClient) GetDownloadConfiguration(ctx context.Context) (freeboxTypes.DownloadConfiguration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetDownloadConfiguration(ctx)
+}
+
+// GetDownloadTask implements freeboxclient.Client.
+func (c *LockedClient) GetDownloadTask(ctx context.Context, identifier int64) (freeboxTypes.DownloadTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetDownloadTask(ctx, identifier)
+}
+
+// GetFile implements freeboxclient.Client.
+func (c *LockedClient) GetFile(ctx context.Context, path string) (freeboxTypes.File, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetFile(ctx, path)
+}
+
+// GetFileInfo implements freeboxclient.Client.
+func (c *LockedClient) GetFileInfo(ctx context.Context, path string) (freeboxTypes.FileInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetFileInfo(ctx, path)
+}
+
+// GetFileSystemTask implements freeboxclient.Client.
+func (c *LockedClient) GetFileSystemTask(ctx context.Context, identifier int64) (freeboxTypes.FileSystemTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetFileSystemTask(ctx, identifier)
+}
+
+// GetHashResult implements freeboxclient.Client.
+func (c *LockedClient) GetHashResult(ctx context.Context, identifier int64) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetHashResult(ctx, identifier)
+}
+
+// GetLanConfig implements freeboxclient.Client.
+func (c *LockedClient) GetLanConfig(ctx context.Context) (freeboxTypes.LanConfig, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetLanConfig(ctx)
+}
+
+// GetLanInterface implements freeboxclient.Client.
+func (c *LockedClient) GetLanInterface(ctx context.Context, name string) ([]freeboxTypes.LanInterfaceHost, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetLanInterface(ctx, name)
+}
+
+// GetLanInterfaceHost implements freeboxclient.Client.
+func (c *LockedClient) GetLanInterfaceHost(ctx context.Context, interfaceName string, identifier string) (freeboxTypes.LanInterfaceHost, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetLanInterfaceHost(ctx, interfaceName, identifier)
+}
+
+// GetOpenVPNServerConfig implements freeboxclient.Client.
+func (c *LockedClient) GetOpenVPNServerConfig(ctx context.Context) (freeboxTypes.OpenVPNServerConfig, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetOpenVPNServerConfig(ctx)
+}
+
+// GetPortForwardingRule implements freeboxclient.Client.
+func (c *LockedClient) GetPortForwardingRule(ctx context.Context, identifier int64) (freeboxTypes.PortForwardingRule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetPortForwardingRule(ctx, identifier)
+}
+
+// GetSystemInfo implements freeboxclient.Client.
+func (c *LockedClient) GetSystemInfo(ctx context.Context) (freeboxTypes.SystemConfig, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetSystemInfo(ctx)
+}
+
+// GetUploadTask implements freeboxclient.Client.
+func (c *LockedClient) GetUploadTask(ctx context.Context, identifier int64) (freeboxTypes.UploadTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetUploadTask(ctx, identifier)
+}
+
+// GetVPNUser implements freeboxclient.Client.
+func (c *LockedClient) GetVPNUser(ctx context.Context, login string) (freeboxTypes.VPNUser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetVPNUser(ctx, login)
+}
+
+// GetVPNUserClientConfig implements freeboxclient.Client.
+func (c *LockedClient) GetVPNUserClientConfig(ctx context.Context, login string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetVPNUserClientConfig(ctx, login)
+}
+
+// GetVirtualDiskInfo implements freeboxclient.Client.
+func (c *LockedClient) GetVirtualDiskInfo(ctx context.Context, path string) (freeboxTypes.VirtualDiskInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetVirtualDiskInfo(ctx, path)
+}
+
+// GetVirtualDiskTask implements freeboxclient.Client.
+func (c *LockedClient) GetVirtualDiskTask(ctx context.Context, identifier int64) (freeboxTypes.VirtualMachineDiskTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetVirtualDiskTask(ctx, identifier)
+}
+
+// GetVirtualMachine implements freeboxclient.Client.
+func (c *LockedClient) GetVirtualMachine(ctx context.Context, identifier int64) (freeboxTypes.VirtualMachine, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetVirtualMachine(ctx, identifier)
+}
+
+// GetVirtualMachineDistributions implements freeboxclient.Client.
+func (c *LockedClient) GetVirtualMachineDistributions(ctx context.Context) ([]freeboxTypes.VirtualMachineDistribution, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetVirtualMachineDistributions(ctx)
+}
+
+// GetVirtualMachineInfo implements freeboxclient.Client.
+func (c *LockedClient) GetVirtualMachineInfo(ctx context.Context) (freeboxTypes.VirtualMachinesInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.GetVirtualMachineInfo(ctx)
+}
+
+// KillVirtualMachine implements freeboxclient.Client.
+func (c *LockedClient) KillVirtualMachine(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.KillVirtualMachine(ctx, identifier)
+}
+
+// ListDHCPStaticLease implements freeboxclient.Client.
+func (c *LockedClient) ListDHCPStaticLease(ctx context.Context) ([]freeboxTypes.DHCPStaticLeaseInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ListDHCPStaticLease(ctx)
+}
+
+// ListDownloadTasks implements freeboxclient.Client.
+func (c *LockedClient) ListDownloadTasks(ctx context.Context) ([]freeboxTypes.DownloadTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ListDownloadTasks(ctx)
+}
+
+// ListFileSystemTasks implements freeboxclient.Client.
+func (c *LockedClient) ListFileSystemTasks(ctx context.Context) ([]freeboxTypes.FileSystemTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ListFileSystemTasks(ctx)
+}
+
+// ListLanInterfaceInfo implements freeboxclient.Client.
+func (c *LockedClient) ListLanInterfaceInfo(ctx context.Context) ([]freeboxTypes.LanInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ListLanInterfaceInfo(ctx)
+}
+
+// ListPortForwardingRules implements freeboxclient.Client.
+func (c *LockedClient) ListPortForwardingRules(ctx context.Context) ([]freeboxTypes.PortForwardingRule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ListPortForwardingRules(ctx)
+}
+
+// ListUploadTasks implements freeboxclient.Client.
+func (c *LockedClient) ListUploadTasks(ctx context.Context) ([]freeboxTypes.UploadTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ListUploadTasks(ctx)
+}
+
+// ListVPNUsers implements freeboxclient.Client.
+func (c *LockedClient) ListVPNUsers(ctx context.Context) ([]freeboxTypes.VPNUser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ListVPNUsers(ctx)
+}
+
+// ListVirtualMachines implements freeboxclient.Client.
+func (c *LockedClient) ListVirtualMachines(ctx context.Context) ([]freeboxTypes.VirtualMachine, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ListVirtualMachines(ctx)
+}
+
+// ListenEvents implements freeboxclient.Client.
+//
+// The lock is only held while free-go opens the stream with the session of the
+// client. The returned chan freeboxTypes.Event is then used without it, as
+// free-go no longer reads the client, see TestLockedClientStreams.
+func (c *LockedClient) ListenEvents(ctx context.Context, events []freeboxTypes.EventDescription) (chan freeboxTypes.Event, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ListenEvents(ctx, events)
+}
+
+// Login implements freeboxclient.Client.
+func (c *LockedClient) Login(ctx context.Context) (freeboxTypes.Permissions, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.Login(ctx)
+}
+
+// Logout implements freeboxclient.Client.
+func (c *LockedClient) Logout(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.Logout(ctx)
+}
+
+// MoveFiles implements freeboxclient.Client.
+func (c *LockedClient) MoveFiles(ctx context.Context, sources []string, destination string, mode freeboxTypes.FileMoveMode) (freeboxTypes.FileSystemTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.MoveFiles(ctx, sources, destination, mode)
+}
+
+// RemoveFiles implements freeboxclient.Client.
+func (c *LockedClient) RemoveFiles(ctx context.Context, paths []string) (freeboxTypes.FileSystemTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.RemoveFiles(ctx, paths)
+}
+
+// ResizeVirtualDisk implements freeboxclient.Client.
+func (c *LockedClient) ResizeVirtualDisk(ctx context.Context, payload freeboxTypes.VirtualDisksResizePayload) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.ResizeVirtualDisk(ctx, payload)
+}
+
+// StartVirtualMachine implements freeboxclient.Client.
+func (c *LockedClient) StartVirtualMachine(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.StartVirtualMachine(ctx, identifier)
+}
+
+// StopVirtualMachine implements freeboxclient.Client.
+func (c *LockedClient) StopVirtualMachine(ctx context.Context, identifier int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.StopVirtualMachine(ctx, identifier)
+}
+
+// UpdateDHCPStaticLease implements freeboxclient.Client.
+func (c *LockedClient) UpdateDHCPStaticLease(ctx context.Context, identifier string, payload freeboxTypes.DHCPStaticLeasePayload) (freeboxTypes.LanInterfaceHost, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.UpdateDHCPStaticLease(ctx, identifier, payload)
+}
+
+// UpdateDownloadConfiguration implements freeboxclient.Client.
+func (c *LockedClient) UpdateDownloadConfiguration(ctx context.Context, payload freeboxTypes.DownloadConfiguration) (freeboxTypes.DownloadConfiguration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.UpdateDownloadConfiguration(ctx, payload)
+}
+
+// UpdateDownloadTask implements freeboxclient.Client.
+func (c *LockedClient) UpdateDownloadTask(ctx context.Context, identifier int64, payload freeboxTypes.DownloadTaskUpdate) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.UpdateDownloadTask(ctx, identifier, payload)
+}
+
+// UpdateFileSystemTask implements freeboxclient.Client.
+func (c *LockedClient) UpdateFileSystemTask(ctx context.Context, identifier int64, payload freeboxTypes.FileSytemTaskUpdate) (freeboxTypes.FileSystemTask, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.UpdateFileSystemTask(ctx, identifier, payload)
+}
+
+// UpdateLanConfig implements freeboxclient.Client.
+func (c *LockedClient) UpdateLanConfig(ctx context.Context, payload freeboxTypes.LanConfig) (freeboxTypes.LanConfig, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.UpdateLanConfig(ctx, payload)
+}
+
+// UpdateOpenVPNServerConfig implements freeboxclient.Client.
+func (c *LockedClient) UpdateOpenVPNServerConfig(ctx context.Context, payload freeboxTypes.OpenVPNServerConfig) (freeboxTypes.OpenVPNServerConfig, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.UpdateOpenVPNServerConfig(ctx, payload)
+}
+
+// UpdatePortForwardingRule implements freeboxclient.Client.
+func (c *LockedClient) UpdatePortForwardingRule(ctx context.Context, identifier int64, payload freeboxTypes.PortForwardingRulePayload) (freeboxTypes.PortForwardingRule, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.UpdatePortForwardingRule(ctx, identifier, payload)
+}
+
+// UpdateVPNUser implements freeboxclient.Client.
+func (c *LockedClient) UpdateVPNUser(ctx context.Context, login string, payload freeboxTypes.VPNUserPayload) (freeboxTypes.VPNUser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.UpdateVPNUser(ctx, login, payload)
+}
+
+// UpdateVirtualMachine implements freeboxclient.Client.
+func (c *LockedClient) UpdateVirtualMachine(ctx context.Context, identifier int64, payload freeboxTypes.VirtualMachinePayload) (freeboxTypes.VirtualMachine, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.client.UpdateVirtualMachine(ctx, identifier, payload)
+}
+
+// WithAppID implements freeboxclient.Client.
+func (c *LockedClient) WithAppID(arg0 string) freeboxclient.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.client.WithAppID(arg0)
+	return c
+}
+
+// WithHTTPClient implements freeboxclient.Client.
+func (c *LockedClient) WithHTTPClient(arg0 freeboxclient.HTTPClient) freeboxclient.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.client.WithHTTPClient(arg0)
+	return c
+}
+
+// WithPrivateToken implements freeboxclient.Client.
+func (c *LockedClient) WithPrivateToken(arg0 freeboxTypes.PrivateToken) freeboxclient.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.client.WithPrivateToken(arg0)
+	return c
+}
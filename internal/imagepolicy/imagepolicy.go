@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package imagepolicy restricts where machine images may be fetched from.
+package imagepolicy
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// FreeboxLocalScheme is the URL scheme of images already stored on the Freebox,
+// e.g. "file:///Freebox/VMs/images/debian-13-generic-arm64.qcow2".
+const FreeboxLocalScheme = "file"
+
+// FreeboxLocalPath returns the path on the Freebox of a file:// image URL.
+func FreeboxLocalPath(imageURL string) (string, bool) {
+	u, err := url.Parse(imageURL)
+	if err != nil || u.Scheme != FreeboxLocalScheme || u.Path == "" {
+		return "", false
+	}
+	return u.Path, true
+}
+
+// Policy restricts the image URLs machines may use.
+type Policy struct {
+	// AirGapped forbids images the Freebox would fetch from outside the local
+	// network. Only Freebox-local paths, private IP addresses and AllowedHosts
+	// are then accepted.
+	AirGapped bool
+
+	// AllowedHosts lists the internal hosts images may be downloaded from in
+	// air-gapped mode, e.g. "images.lan" or "192.168.1.10:8080".
+	AllowedHosts []string
+}
+
+// Check returns an error when imageURL is not allowed by the policy.
+func (p Policy) Check(imageURL string) error {
+	if !p.AirGapped {
+		return nil
+	}
+	if _, ok := FreeboxLocalPath(imageURL); ok {
+		return nil
+	}
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return fmt.Errorf("invalid image URL: %w", err)
+	}
+	if slices.Contains(p.AllowedHosts, u.Host) || slices.Contains(p.AllowedHosts, u.Hostname()) {
+		return nil
+	}
+	if ip := net.ParseIP(u.Hostname()); ip != nil && (ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast()) {
+		return nil
+	}
+	allowed := "Freebox-local file:// paths and private IP addresses"
+	if len(p.AllowedHosts) > 0 {
+		allowed += ", or hosts " + strings.Join(p.AllowedHosts, ", ")
+	}
+	return fmt.Errorf("host %q is not allowed in air-gapped mode: images must come from %s", u.Host, allowed)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package imagepolicy
+
+import "testing"
+
+func TestPolicyCheck(t *testing.T) {
+	airGapped := Policy{AirGapped: true, AllowedHosts: []string{"images.lan", "mirror.lan:8080"}}
+	for _, tc := range []struct {
+		policy   Policy
+		imageURL string
+		allowed  bool
+	}{
+		{Policy{}, "https://cloud.debian.org/images/debian.qcow2", true},
+		{airGapped, "https://cloud.debian.org/images/debian.qcow2", false},
+		{airGapped, "file:///Freebox/VMs/images/debian.qcow2", true},
+		{airGapped, "http://192.168.1.10/debian.qcow2", true},
+		{airGapped, "http://10.0.0.5:8080/debian.qcow2", true},
+		{airGapped, "http://8.8.8.8/debian.qcow2", false},
+		{airGapped, "https://images.lan/debian.qcow2", true},
+		{airGapped, "http://mirror.lan:8080/debian.qcow2", true},
+		{airGapped, "http://mirror.lan/debian.qcow2", false},
+	} {
+		if err := tc.policy.Check(tc.imageURL); (err == nil) != tc.allowed {
+			t.Errorf("Check(%q) with air-gapped=%t: error %v, want allowed=%t", tc.imageURL, tc.policy.AirGapped, err, tc.allowed)
+		}
+	}
+}
+
+func TestFreeboxLocalPath(t *testing.T) {
+	for imageURL, want := range map[string]string{
+		"file:///Freebox/VMs/images/debian.qcow2":      "/Freebox/VMs/images/debian.qcow2",
+		"file:///Disque%201/images/debian.qcow2":       "/Disque 1/images/debian.qcow2",
+		"https://cloud.debian.org/images/debian.qcow2": "",
+	} {
+		got, ok := FreeboxLocalPath(imageURL)
+		if got != want || ok != (want != "") {
+			t.Errorf("FreeboxLocalPath(%q) = %q, %t, want %q", imageURL, got, ok, want)
+		}
+	}
+}
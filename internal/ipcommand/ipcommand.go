@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package ipcommand finds the addresses that node commands, such as kubeadm
+// preKubeadmCommands, add to network interfaces with iproute2.
+package ipcommand
+
+import (
+	"strings"
+)
+
+// AddedAddresses returns the addresses added by the commands that run
+// "ip address add" directly, e.g. "ip addr add 192.168.1.200/24 dev enp0s5",
+// without their prefix length. Commands wrapped in a condition are ignored.
+func AddedAddresses(commands []string) []string {
+	var addresses []string
+	for _, command := range commands {
+		fields := strings.Fields(command)
+		if len(fields) == 0 || fields[0] != "ip" {
+			continue
+		}
+		// Skip the options, e.g. "ip -4 addr add".
+		i := 1
+		for i < len(fields) && strings.HasPrefix(fields[i], "-") {
+			i++
+		}
+		if i+2 >= len(fields) || !isAddressObject(fields[i]) || fields[i+1] != "add" {
+			continue
+		}
+		address, _, _ := strings.Cut(fields[i+2], "/")
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+// isAddressObject reports whether object abbreviates the "address" object of
+// iproute2, which accepts any prefix of it.
+func isAddressObject(object string) bool {
+	return object != "" && strings.HasPrefix("address", object)
+}
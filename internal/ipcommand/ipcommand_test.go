@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ipcommand
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAddedAddresses(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     []string
+	}{
+		{name: "no commands"},
+		{
+			name:     "address with prefix length",
+			commands: []string{"modprobe br_netfilter", "ip addr add 192.168.1.200/24 dev enp0s5 || true"},
+			want:     []string{"192.168.1.200"},
+		},
+		{
+			name:     "abbreviated object and options",
+			commands: []string{"ip -4 a add 192.168.1.201 dev enp0s5", "ip address add 192.168.1.202/32 dev lo"},
+			want:     []string{"192.168.1.201", "192.168.1.202"},
+		},
+		{
+			name:     "other ip commands",
+			commands: []string{"ip addr show", "ip route add default via 192.168.1.254", "ip addr del 192.168.1.200/24 dev enp0s5"},
+		},
+		{
+			name:     "conditional command",
+			commands: []string{"if [ -f /run/kubeadm/kubeadm.yaml ]; then ip addr add 192.168.1.200/24 dev enp0s5; fi"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AddedAddresses(tt.commands); !slices.Equal(got, tt.want) {
+				t.Errorf("AddedAddresses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
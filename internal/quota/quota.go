@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package quota enforces the resource quota of FreeboxClusters on their machines.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// ExceededError reports the limits of a quota that a machine would exceed.
+type ExceededError struct {
+	// Cluster is the name of the FreeboxCluster holding the quota.
+	Cluster string
+	// Exceeded describes each exceeded limit.
+	Exceeded []string
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("quota of FreeboxCluster %s exceeded: %s", e.Cluster, strings.Join(e.Exceeded, ", "))
+}
+
+// IsExceeded reports whether err is, or wraps, an *ExceededError.
+func IsExceeded(err error) bool {
+	var exceeded *ExceededError
+	return errors.As(err, &exceeded)
+}
+
+// Check returns an *ExceededError when machine, added to the machines counted
+// against the quota of its FreeboxCluster, exceeds that quota. Machines whose
+// Cluster or FreeboxCluster cannot be found yet are not limited.
+//
+// When counted is nil, every other machine of the cluster is counted. Otherwise
+// only the other machines for which counted returns true are.
+func Check(ctx context.Context, c client.Reader, machine *infrastructurev1alpha1.FreeboxMachine, counted func(*infrastructurev1alpha1.FreeboxMachine) bool) error {
+	clusterName := machine.Labels[clusterv1.ClusterNameLabel]
+	if clusterName == "" {
+		return nil
+	}
+
+	var cluster clusterv1.Cluster
+	if err := c.Get(ctx, types.NamespacedName{Namespace: machine.Namespace, Name: clusterName}, &cluster); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	ref := cluster.Spec.InfrastructureRef
+	if ref.Kind != "FreeboxCluster" || ref.Name == "" {
+		return nil
+	}
+	var freeboxCluster infrastructurev1alpha1.FreeboxCluster
+	if err := c.Get(ctx, types.NamespacedName{Namespace: machine.Namespace, Name: ref.Name}, &freeboxCluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	quota := freeboxCluster.Spec.Quota
+	if quota == nil {
+		return nil
+	}
+
+	var machines infrastructurev1alpha1.FreeboxMachineList
+	if err := c.List(ctx, &machines, client.InNamespace(machine.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName}); err != nil {
+		return err
+	}
+	used := machine.Spec
+	for i := range machines.Items {
+		m := &machines.Items[i]
+		if m.Name == machine.Name || !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if counted != nil && !counted(m) {
+			continue
+		}
+		used.VCPUs += m.Spec.VCPUs
+		used.MemoryMB += m.Spec.MemoryMB
+		used.DiskSizeBytes += m.Spec.DiskSizeBytes
+	}
+
+	var exceeded []string
+	if quota.VCPUs > 0 && used.VCPUs > quota.VCPUs {
+		exceeded = append(exceeded, fmt.Sprintf("%d vCPUs requested, %d allowed", used.VCPUs, quota.VCPUs))
+	}
+	if quota.MemoryMB > 0 && used.MemoryMB > quota.MemoryMB {
+		exceeded = append(exceeded, fmt.Sprintf("%d MB of memory requested, %d allowed", used.MemoryMB, quota.MemoryMB))
+	}
+	if quota.DiskSizeBytes > 0 && used.DiskSizeBytes > quota.DiskSizeBytes {
+		exceeded = append(exceeded, fmt.Sprintf("%d bytes of disk requested, %d allowed", used.DiskSizeBytes, quota.DiskSizeBytes))
+	}
+	if len(exceeded) > 0 {
+		return &ExceededError{Cluster: freeboxCluster.Name, Exceeded: exceeded}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var freeboxclusterlog = logf.Log.WithName("freeboxcluster-resource")
+
+// SetupFreeboxClusterWebhookWithManager registers the webhook for FreeboxCluster in the manager.
+func SetupFreeboxClusterWebhookWithManager(mgr ctrl.Manager, freeboxEndpoint string) error {
+	return ctrl.NewWebhookManagedBy(mgr, &infrastructurev1alpha1.FreeboxCluster{}).
+		WithValidator(&FreeboxClusterCustomValidator{Client: mgr.GetClient(), FreeboxEndpoint: freeboxEndpoint}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-freeboxcluster,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=freeboxclusters,verbs=create;update,versions=v1alpha1,name=vfreeboxcluster-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// FreeboxClusterCustomValidator validates FreeboxCluster resources when they are created or updated.
+type FreeboxClusterCustomValidator struct {
+	// Client lists the other FreeboxClusters. They are not checked when nil.
+	Client client.Reader
+
+	// FreeboxEndpoint is the endpoint of the Freebox of the manager, used by the
+	// FreeboxClusters without an endpoint.
+	FreeboxEndpoint string
+}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type FreeboxCluster.
+func (v *FreeboxClusterCustomValidator) ValidateCreate(ctx context.Context, fc *infrastructurev1alpha1.FreeboxCluster) (admission.Warnings, error) {
+	freeboxclusterlog.Info("Validation for FreeboxCluster upon creation", "name", fc.GetName())
+
+	return nil, v.validateControlPlaneEndpoint(ctx, fc)
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type FreeboxCluster.
+func (v *FreeboxClusterCustomValidator) ValidateUpdate(ctx context.Context, oldCluster, fc *infrastructurev1alpha1.FreeboxCluster) (admission.Warnings, error) {
+	freeboxclusterlog.Info("Validation for FreeboxCluster upon update", "name", fc.GetName())
+
+	// A cluster admitted before keeps being admitted, e.g. to remove its finalizer.
+	if oldCluster.Spec.ControlPlaneEndpoint == fc.Spec.ControlPlaneEndpoint && v.freeboxOf(oldCluster) == v.freeboxOf(fc) {
+		return nil, nil
+	}
+	return nil, v.validateControlPlaneEndpoint(ctx, fc)
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type FreeboxCluster.
+func (v *FreeboxClusterCustomValidator) ValidateDelete(_ context.Context, _ *infrastructurev1alpha1.FreeboxCluster) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateControlPlaneEndpoint denies a FreeboxCluster whose controlPlaneEndpoint is
+// the one of another FreeboxCluster on the same Freebox: their port forwards and
+// virtual IPs collide, which only shows much later as TLS errors of the API servers.
+func (v *FreeboxClusterCustomValidator) validateControlPlaneEndpoint(ctx context.Context, fc *infrastructurev1alpha1.FreeboxCluster) error {
+	endpoint := fc.Spec.ControlPlaneEndpoint
+	if v.Client == nil || !endpoint.IsValid() {
+		return nil
+	}
+	clusters := &infrastructurev1alpha1.FreeboxClusterList{}
+	if err := v.Client.List(ctx, clusters); err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	for i := range clusters.Items {
+		other := &clusters.Items[i]
+		if other.Namespace == fc.Namespace && other.Name == fc.Name {
+			continue
+		}
+		if v.freeboxOf(other) != v.freeboxOf(fc) || other.Spec.ControlPlaneEndpoint.Port != endpoint.Port ||
+			!strings.EqualFold(other.Spec.ControlPlaneEndpoint.Host, endpoint.Host) {
+			continue
+		}
+		return apierrors.NewInvalid(infrastructurev1alpha1.GroupVersion.WithKind("FreeboxCluster").GroupKind(), fc.Name, field.ErrorList{
+			field.Duplicate(field.NewPath("spec", "controlPlaneEndpoint"),
+				fmt.Sprintf("%s:%d is the control plane endpoint of FreeboxCluster %s/%s on the same Freebox", endpoint.Host, endpoint.Port, other.Namespace, other.Name)),
+		})
+	}
+	return nil
+}
+
+// freeboxOf identifies the Freebox hosting the VMs of fc by its endpoint.
+func (v *FreeboxClusterCustomValidator) freeboxOf(fc *infrastructurev1alpha1.FreeboxCluster) string {
+	return normalizeEndpoint(fc.Spec.Endpoint, v.FreeboxEndpoint)
+}
+
+// normalizeEndpoint returns the endpoint of a Freebox in a form that can be
+// compared, managerEndpoint standing for an empty endpoint.
+func normalizeEndpoint(endpoint, managerEndpoint string) string {
+	if endpoint == "" {
+		endpoint = managerEndpoint
+	}
+	return strings.ToLower(strings.TrimSuffix(endpoint, "/"))
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+var _ = Describe("FreeboxCluster Webhook", func() {
+	var (
+		existing  *infrastructurev1alpha1.FreeboxCluster
+		validator FreeboxClusterCustomValidator
+	)
+
+	newCluster := func(namespace, name, endpoint string) *infrastructurev1alpha1.FreeboxCluster {
+		return &infrastructurev1alpha1.FreeboxCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: infrastructurev1alpha1.FreeboxClusterSpec{
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "192.168.1.200", Port: 6443},
+				Endpoint:             endpoint,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(infrastructurev1alpha1.AddToScheme(scheme)).To(Succeed())
+		existing = newCluster("default", "homelab", "")
+		validator = FreeboxClusterCustomValidator{
+			Client:          fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build(),
+			FreeboxEndpoint: "http://mafreebox.freebox.fr",
+		}
+	})
+
+	It("Should deny the control plane endpoint of another cluster on the same Freebox", func() {
+		_, err := validator.ValidateCreate(ctx, newCluster("lab", "other", ""))
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("FreeboxCluster default/homelab")))
+	})
+
+	It("Should deny it on the Freebox of the manager set as endpoint", func() {
+		_, err := validator.ValidateCreate(ctx, newCluster("lab", "other", "http://mafreebox.freebox.fr/"))
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	})
+
+	It("Should admit the same control plane endpoint on another Freebox", func() {
+		Expect(validator.ValidateCreate(ctx, newCluster("lab", "other", "https://box.example.com"))).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should admit another port of the same host", func() {
+		other := newCluster("lab", "other", "")
+		other.Spec.ControlPlaneEndpoint.Port = 6444
+		Expect(validator.ValidateCreate(ctx, other)).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should admit updates of the cluster itself", func() {
+		updated := existing.DeepCopy()
+		updated.Spec.Priority = new(int32)
+		Expect(validator.ValidateUpdate(ctx, existing, updated)).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should deny moving a cluster to the control plane endpoint of another", func() {
+		old := newCluster("lab", "other", "")
+		old.Spec.ControlPlaneEndpoint.Port = 6444
+		_, err := validator.ValidateUpdate(ctx, old, newCluster("lab", "other", ""))
+		Expect(apierrors.IsInvalid(err)).To(BeTrue())
+	})
+})
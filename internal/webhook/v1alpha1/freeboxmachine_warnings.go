@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/diskimage"
+)
+
+// largeRawDiskBytes is the size above which raw disks are worth a warning: unlike
+// qcow2 disks, they take up their full size on the Freebox storage.
+const largeRawDiskBytes = 32 << 30
+
+// memoryCheckTimeout bounds the request to the Freebox made during the admission,
+// so that an unresponsive Freebox does not make kubectl wait for the webhook timeout.
+const memoryCheckTimeout = 2 * time.Second
+
+// amd64Markers lists image name fragments identifying images built for amd64, which
+// Freebox VMs cannot boot. They are not rejected as some names are misleading.
+var amd64Markers = []string{"amd64", "x86_64", "x86-64"}
+
+// freeboxMachineSpecWarnings returns the soft issues of spec: settings that are valid
+// but most likely a mistake, reported in the kubectl output instead of rejected.
+func freeboxMachineSpecWarnings(spec *infrastructurev1alpha1.FreeboxMachineSpec, fldPath *field.Path) admission.Warnings {
+	// Errors in the image URL are reported by the validation.
+	imageURL, err := infrastructurev1alpha1.ExpandImageURL(spec.ImageURL, "arm64", "v1.0.0")
+	if err != nil || imageURL == "" {
+		return nil
+	}
+	var warnings admission.Warnings
+	imageName := diskimage.FileName(imageURL)
+
+	lowerName := strings.ToLower(imageName)
+	for _, marker := range amd64Markers {
+		if strings.Contains(lowerName, marker) {
+			warnings = append(warnings, fmt.Sprintf("%s: image looks like an %s image, but Freebox VMs only boot arm64 guests",
+				fldPath.Child("imageURL"), marker))
+			break
+		}
+	}
+
+	if spec.DiskSizeBytes > largeRawDiskBytes && isRawImage(imageName, spec.ImageArchiveMember) {
+		warnings = append(warnings, fmt.Sprintf("%s: raw disks take up their full %s on the Freebox storage; consider a qcow2 image, which only grows as it is written",
+			fldPath.Child("diskSizeBytes"), resource.NewQuantity(spec.DiskSizeBytes, resource.BinarySI)))
+	}
+	return warnings
+}
+
+// isRawImage reports whether the disk image found in imageName, or in its
+// archiveMember, is a raw disk. Archives whose disk is looked for are not known to be.
+func isRawImage(imageName, archiveMember string) bool {
+	diskName := imageName
+	switch {
+	case diskimage.IsArchive(imageName):
+		if archiveMember == "" {
+			return false
+		}
+		diskName = path.Base(archiveMember)
+	case diskimage.IsCompressed(imageName):
+		diskName = diskimage.StripCompressionSuffix(imageName)
+	}
+	switch strings.ToLower(path.Ext(diskName)) {
+	case ".raw", ".img":
+		return true
+	}
+	return false
+}
+
+// warningFieldsChanged reports whether the fields the warnings are about differ
+// between oldSpec and spec.
+func warningFieldsChanged(oldSpec, spec *infrastructurev1alpha1.FreeboxMachineSpec) bool {
+	return oldSpec.ImageURL != spec.ImageURL || oldSpec.ImageArchiveMember != spec.ImageArchiveMember ||
+		oldSpec.DiskSizeBytes != spec.DiskSizeBytes || oldSpec.MemoryMB != spec.MemoryMB
+}
+
+// memoryWarnings warns about machines taking more than half of the memory the
+// Freebox has for VMs, which leaves little room for the other machines. Nothing is
+// reported when the Freebox cannot be reached, which must not prevent the creation.
+func memoryWarnings(ctx context.Context, freeboxClient freeboxclient.Client, memoryMB int64, fldPath *field.Path) admission.Warnings {
+	if freeboxClient == nil || memoryMB == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, memoryCheckTimeout)
+	defer cancel()
+	info, err := freeboxClient.GetVirtualMachineInfo(ctx)
+	if err != nil {
+		freeboxmachinelog.Info("Could not get the Freebox VM capacity, not checking the memory", "error", err.Error())
+		return nil
+	}
+	if info.TotalMemory == 0 || memoryMB*2 <= info.TotalMemory {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("%s: %d MB is more than half of the %d MB the Freebox has for VMs",
+		fldPath.Child("memoryMB"), memoryMB, info.TotalMemory)}
+}
@@ -0,0 +1,425 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/imagepolicy"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/quota"
+)
+
+// deleteForMoveAnnotation is set by clusterctl move on the objects it deletes from
+// the source cluster once they have been moved.
+const deleteForMoveAnnotation = "clusterctl.cluster.x-k8s.io/delete-for-move"
+
+// log is for logging in this package.
+var freeboxmachinelog = logf.Log.WithName("freeboxmachine-resource")
+
+// SetupFreeboxMachineWebhookWithManager registers the webhook for FreeboxMachine in the manager.
+func SetupFreeboxMachineWebhookWithManager(mgr ctrl.Manager, imagePolicy imagepolicy.Policy, freeboxClient freeboxclient.Client, freeboxEndpoint string) error {
+	return ctrl.NewWebhookManagedBy(mgr, &infrastructurev1alpha1.FreeboxMachine{}).
+		WithValidator(&FreeboxMachineCustomValidator{
+			Client:          mgr.GetClient(),
+			ImagePolicy:     imagePolicy,
+			FreeboxClient:   freeboxClient,
+			FreeboxEndpoint: freeboxEndpoint,
+		}).
+		WithDefaulter(&FreeboxMachineCustomDefaulter{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1alpha1-freeboxmachine,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=freeboxmachines,verbs=create,versions=v1alpha1,name=mfreeboxmachine-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// FreeboxMachineCustomDefaulter sets default values on FreeboxMachine resources when they are created.
+type FreeboxMachineCustomDefaulter struct {
+	// Client reads the machineDefaults of the FreeboxCluster. They are not applied when nil.
+	Client client.Reader
+}
+
+// Default implements admission.Defaulter so a webhook will be registered for the type FreeboxMachine.
+// It fills the fields left unset with the machineDefaults of the FreeboxCluster, and sets spec.name
+// to the name of the VM rendered from spec.nameTemplate, so that it matches the VM the controller
+// creates. Machines cloned from a FreeboxMachineTemplate all share the spec.name of the template otherwise.
+func (d *FreeboxMachineCustomDefaulter) Default(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) error {
+	freeboxmachinelog.Info("Defaulting for FreeboxMachine", "name", machine.GetName())
+
+	if d.Client != nil {
+		freeboxCluster, err := freeboxClusterOf(ctx, d.Client, machine)
+		if err != nil {
+			return apierrors.NewInternalError(err)
+		}
+		if freeboxCluster != nil {
+			applyMachineDefaults(&machine.Spec, freeboxCluster.Spec.MachineDefaults)
+		}
+	}
+
+	// The name is unknown until generated; an invalid template is reported by the validator.
+	if machine.Name == "" {
+		return nil
+	}
+	if vmName, err := machine.VMName(); err == nil {
+		machine.Spec.Name = vmName
+	}
+	return nil
+}
+
+// applyMachineDefaults fills the fields of spec left unset with defaults.
+func applyMachineDefaults(spec *infrastructurev1alpha1.FreeboxMachineSpec, defaults *infrastructurev1alpha1.FreeboxMachineDefaults) {
+	if defaults == nil {
+		return
+	}
+	// Machines bringing their own disk have no image to default.
+	if spec.ImageURL == "" && spec.ImageManagement != infrastructurev1alpha1.ImageManagementUnmanaged {
+		spec.ImageURL = defaults.ImageURL
+	}
+	if spec.DiskSizeBytes == 0 {
+		spec.DiskSizeBytes = defaults.DiskSizeBytes
+	}
+	if spec.VCPUs == 0 {
+		spec.VCPUs = defaults.VCPUs
+	}
+	if spec.MemoryMB == 0 {
+		spec.MemoryMB = defaults.MemoryMB
+	}
+}
+
+// freeboxClusterOf returns the FreeboxCluster of machine, or nil when the machine has
+// no cluster yet or its Cluster or FreeboxCluster cannot be found.
+func freeboxClusterOf(ctx context.Context, c client.Reader, machine *infrastructurev1alpha1.FreeboxMachine) (*infrastructurev1alpha1.FreeboxCluster, error) {
+	clusterName := machine.Labels[clusterv1.ClusterNameLabel]
+	if clusterName == "" {
+		return nil, nil
+	}
+	var cluster clusterv1.Cluster
+	if err := c.Get(ctx, client.ObjectKey{Namespace: machine.Namespace, Name: clusterName}, &cluster); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	ref := cluster.Spec.InfrastructureRef
+	if ref.Kind != "FreeboxCluster" || ref.Name == "" {
+		return nil, nil
+	}
+	var freeboxCluster infrastructurev1alpha1.FreeboxCluster
+	if err := c.Get(ctx, client.ObjectKey{Namespace: machine.Namespace, Name: ref.Name}, &freeboxCluster); err != nil {
+		return nil, client.IgnoreNotFound(err)
+	}
+	return &freeboxCluster, nil
+}
+
+// +kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-freeboxmachine,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=freeboxmachines,verbs=create;update;delete,versions=v1alpha1,name=vfreeboxmachine-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// FreeboxMachineCustomValidator validates FreeboxMachine resources when they are created, updated or deleted.
+type FreeboxMachineCustomValidator struct {
+	// Client reads the quota of the FreeboxCluster and the machines counted against it,
+	// and the owner Machine of protected machines. The quota is not checked when nil.
+	Client client.Reader
+
+	// ImagePolicy restricts where images may be fetched from.
+	ImagePolicy imagepolicy.Policy
+
+	// FreeboxClient reads the VM capacity of the Freebox to warn about machines taking
+	// most of its memory. It is not checked when nil.
+	FreeboxClient freeboxclient.Client
+
+	// FreeboxEndpoint is the endpoint of the Freebox FreeboxClient talks to. The
+	// memory of machines whose FreeboxCluster sets another endpoint is not checked.
+	FreeboxEndpoint string
+}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type FreeboxMachine.
+func (v *FreeboxMachineCustomValidator) ValidateCreate(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) (admission.Warnings, error) {
+	freeboxmachinelog.Info("Validation for FreeboxMachine upon creation", "name", machine.GetName())
+
+	if err := validateFreeboxMachine(machine, true, true); err != nil {
+		return nil, err
+	}
+	if err := validateSizingRequired(machine); err != nil {
+		return nil, err
+	}
+	if err := v.validateImagePolicy(machine); err != nil {
+		return nil, err
+	}
+	if v.Client != nil {
+		if err := quota.Check(ctx, v.Client, machine, nil); err != nil {
+			if quota.IsExceeded(err) {
+				return nil, apierrors.NewForbidden(infrastructurev1alpha1.GroupVersion.WithResource("freeboxmachines").GroupResource(), machine.Name, err)
+			}
+			return nil, apierrors.NewInternalError(err)
+		}
+	}
+	return v.warnings(ctx, machine), nil
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type FreeboxMachine.
+func (v *FreeboxMachineCustomValidator) ValidateUpdate(ctx context.Context, oldMachine, machine *infrastructurev1alpha1.FreeboxMachine) (admission.Warnings, error) {
+	freeboxmachinelog.Info("Validation for FreeboxMachine upon update", "name", machine.GetName())
+
+	// Machines created before spec.name was defaulted keep their diverging name, and
+	// those created before an image was required keep their empty imageURL.
+	imageChanged := machine.Spec.ImageURL != oldMachine.Spec.ImageURL || machine.Spec.ImageManagement != oldMachine.Spec.ImageManagement
+	if err := validateFreeboxMachine(machine, machine.Spec.Name != oldMachine.Spec.Name, imageChanged); err != nil {
+		return nil, err
+	}
+	// Machines created before the image policy was enforced keep their image.
+	if machine.Spec.ImageURL != oldMachine.Spec.ImageURL {
+		if err := v.validateImagePolicy(machine); err != nil {
+			return nil, err
+		}
+	}
+	// Updates by the controllers, which do not change the spec, are not warned about.
+	if warningFieldsChanged(&oldMachine.Spec, &machine.Spec) {
+		return v.warnings(ctx, machine), nil
+	}
+	return nil, nil
+}
+
+// warnings returns the soft issues of machine, including its memory compared to the
+// capacity of its Freebox when it is the one of the manager.
+func (v *FreeboxMachineCustomValidator) warnings(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) admission.Warnings {
+	fldPath := field.NewPath("spec")
+	warnings := freeboxMachineSpecWarnings(&machine.Spec, fldPath)
+	if !v.onManagerFreebox(ctx, machine) {
+		return warnings
+	}
+	return append(warnings, memoryWarnings(ctx, v.FreeboxClient, machine.Spec.MemoryMB, fldPath)...)
+}
+
+// onManagerFreebox reports whether the VM of machine runs on the Freebox of the
+// manager, assuming it does when its FreeboxCluster cannot be read.
+func (v *FreeboxMachineCustomValidator) onManagerFreebox(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) bool {
+	if v.Client == nil {
+		return true
+	}
+	freeboxCluster, err := freeboxClusterOf(ctx, v.Client, machine)
+	if err != nil || freeboxCluster == nil {
+		return true
+	}
+	return normalizeEndpoint(freeboxCluster.Spec.Endpoint, v.FreeboxEndpoint) == normalizeEndpoint("", v.FreeboxEndpoint)
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type FreeboxMachine.
+// Machines with spec.deletionProtection are only deleted along with their owner Machine,
+// or by clusterctl move.
+func (v *FreeboxMachineCustomValidator) ValidateDelete(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) (admission.Warnings, error) {
+	freeboxmachinelog.Info("Validation for FreeboxMachine upon deletion", "name", machine.GetName())
+
+	if !machine.Spec.DeletionProtection {
+		return nil, nil
+	}
+	if _, ok := machine.Annotations[deleteForMoveAnnotation]; ok {
+		return nil, nil
+	}
+	deleting, err := v.ownerMachineDeleting(ctx, machine)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	if !deleting {
+		return nil, apierrors.NewForbidden(infrastructurev1alpha1.GroupVersion.WithResource("freeboxmachines").GroupResource(), machine.Name,
+			fmt.Errorf("spec.deletionProtection is set: delete the owner Machine instead, or clear spec.deletionProtection first"))
+	}
+	return nil, nil
+}
+
+// ownerMachineDeleting reports whether the Machine owning machine is being deleted or
+// is already gone. Machines without an owner, or when the client is nil, are not.
+func (v *FreeboxMachineCustomValidator) ownerMachineDeleting(ctx context.Context, machine *infrastructurev1alpha1.FreeboxMachine) (bool, error) {
+	if v.Client == nil {
+		return false, nil
+	}
+	for _, ref := range machine.OwnerReferences {
+		if ref.Kind != "Machine" || !strings.HasPrefix(ref.APIVersion, clusterv1.GroupVersion.Group+"/") {
+			continue
+		}
+		var owner clusterv1.Machine
+		if err := v.Client.Get(ctx, client.ObjectKey{Namespace: machine.Namespace, Name: ref.Name}, &owner); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, fmt.Errorf("getting owner Machine %s: %w", ref.Name, err)
+		}
+		return !owner.DeletionTimestamp.IsZero(), nil
+	}
+	return false, nil
+}
+
+// validateImagePolicy rejects images the image policy does not allow.
+func (v *FreeboxMachineCustomValidator) validateImagePolicy(machine *infrastructurev1alpha1.FreeboxMachine) error {
+	// Placeholders do not change the host, so a sample expansion is enough.
+	imageURL, err := infrastructurev1alpha1.ExpandImageURL(machine.Spec.ImageURL, "arm64", "v1.0.0")
+	if err != nil || imageURL == "" {
+		return nil
+	}
+	if err := v.ImagePolicy.Check(imageURL); err != nil {
+		return apierrors.NewInvalid(infrastructurev1alpha1.GroupVersion.WithKind("FreeboxMachine").GroupKind(), machine.Name,
+			field.ErrorList{field.Invalid(field.NewPath("spec", "imageURL"), machine.Spec.ImageURL, err.Error())})
+	}
+	return nil
+}
+
+func validateFreeboxMachine(machine *infrastructurev1alpha1.FreeboxMachine, checkName, checkImage bool) error {
+	allErrs := validateFreeboxMachineSpec(&machine.Spec, field.NewPath("spec"))
+	if checkImage {
+		allErrs = append(allErrs, validateImageRequired(&machine.Spec, field.NewPath("spec"))...)
+	}
+	if checkName && machine.Spec.Name != "" && machine.Name != "" {
+		if vmName, err := machine.VMName(); err == nil && machine.Spec.Name != vmName {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "name"), machine.Spec.Name,
+				fmt.Sprintf("must match the VM name %q rendered from spec.nameTemplate", vmName)))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(infrastructurev1alpha1.GroupVersion.WithKind("FreeboxMachine").GroupKind(), machine.Name, allErrs)
+}
+
+// validateImageRequired rejects specs without an image for the controller to
+// prepare the disk from, which would leave the machine waiting forever.
+func validateImageRequired(spec *infrastructurev1alpha1.FreeboxMachineSpec, fldPath *field.Path) field.ErrorList {
+	if spec.ImageURL != "" || spec.ImageManagement == infrastructurev1alpha1.ImageManagementUnmanaged {
+		return nil
+	}
+	return field.ErrorList{field.Required(fldPath.Child("imageURL"),
+		"an image is required to prepare the disk; set it, or the machineDefaults of the FreeboxCluster, or set imageManagement to Unmanaged to bring your own disk")}
+}
+
+// validateSizingRequired rejects machines left without a size once the machineDefaults
+// of their FreeboxCluster are applied. The fields are optional in the schema so that
+// templates can leave them to the FreeboxCluster.
+func validateSizingRequired(machine *infrastructurev1alpha1.FreeboxMachine) error {
+	const hint = "set it or the machineDefaults of the FreeboxCluster"
+	fldPath := field.NewPath("spec")
+	var allErrs field.ErrorList
+	if machine.Spec.VCPUs == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("vcpus"), hint))
+	}
+	if machine.Spec.MemoryMB == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("memoryMB"), hint))
+	}
+	// Disks brought with imageManagement Unmanaged are not resized.
+	if machine.Spec.DiskSizeBytes == 0 && machine.Spec.ImageManagement != infrastructurev1alpha1.ImageManagementUnmanaged {
+		allErrs = append(allErrs, field.Required(fldPath.Child("diskSizeBytes"), hint))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(infrastructurev1alpha1.GroupVersion.WithKind("FreeboxMachine").GroupKind(), machine.Name, allErrs)
+}
+
+// unsupportedImageFormats lists image formats that Freebox VMs cannot boot from.
+// Freebox VMs only boot raw or qcow2 disk images.
+var unsupportedImageFormats = []string{".iso", ".vmdk", ".vhd", ".vhdx", ".vdi", ".ova", ".ovf", ".wim", ".esd", ".dmg"}
+
+// unsupportedOSMarkers lists image name tokens identifying operating systems that
+// cannot run on the Freebox VM stack, which only boots UEFI arm64 GNU/Linux guests.
+var unsupportedOSMarkers = []string{"windows", "win10", "win11", "winserver", "macos", "osx"}
+
+// validateFreeboxMachineSpec rejects images the Freebox VM stack cannot boot,
+// instead of letting them produce a VM stuck on a black screen.
+func validateFreeboxMachineSpec(spec *infrastructurev1alpha1.FreeboxMachineSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// The actual names are only known at reconcile time; render sample ones to catch template errors.
+	if _, err := infrastructurev1alpha1.RenderName(spec.NameTemplate, infrastructurev1alpha1.NameTemplateData{
+		ClusterName: "cluster",
+		MachineName: "machine",
+		Namespace:   "default",
+	}); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("nameTemplate"), spec.NameTemplate, err.Error()))
+	}
+
+	if spec.DiskPath != "" && spec.ImageManagement != infrastructurev1alpha1.ImageManagementUnmanaged {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("diskPath"), spec.DiskPath,
+			"is only used when imageManagement is Unmanaged"))
+	}
+
+	// Files, commands and shared folders are carried by the cloud-config of the
+	// NoCloud config drive.
+	if len(spec.Files) > 0 || len(spec.Commands) > 0 || len(spec.SharedFolders) > 0 {
+		switch {
+		case spec.CloudInit == infrastructurev1alpha1.CloudInitSkip:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("cloudInit"),
+				"must not be Skip when files, commands or shared folders are set, as they are delivered by cloud-init"))
+		case spec.BootstrapFormat == infrastructurev1alpha1.BootstrapFormatTalos:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("bootstrapFormat"),
+				"must not be talos when files, commands or shared folders are set, as they are delivered by cloud-init"))
+		}
+	}
+
+	if spec.ImageURL == "" {
+		return allErrs
+	}
+
+	// Placeholders are resolved by the controller; only reject unknown ones here.
+	if _, err := infrastructurev1alpha1.ExpandImageURL(spec.ImageURL, "arm64", "v1.0.0"); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("imageURL"), spec.ImageURL, err.Error()))
+	}
+
+	imagePath := spec.ImageURL
+	if u, err := url.Parse(spec.ImageURL); err == nil {
+		imagePath = u.Path
+	}
+	imageName := strings.ToLower(path.Base(imagePath))
+
+	if marker := unsupportedOSMarker(imageName); marker != "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("imageURL"), spec.ImageURL,
+			fmt.Sprintf("image looks like a %q image, but Freebox VMs can only boot UEFI arm64 GNU/Linux guests", marker)))
+	}
+
+	// Look at every extension so that e.g. "installer.iso.xz" is caught as well.
+	for _, ext := range unsupportedImageFormats {
+		if strings.HasSuffix(imageName, ext) || strings.Contains(imageName, ext+".") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("imageURL"), spec.ImageURL,
+				fmt.Sprintf("%s images are not supported: Freebox VMs boot from raw or qcow2 disk images", ext)))
+			break
+		}
+	}
+
+	return allErrs
+}
+
+// unsupportedOSMarker returns the unsupported OS marker naming imageName, or an
+// empty string. The name is split into tokens on "-", "_" and ".", and a token
+// matches a marker with or without a version suffix, e.g. "windows11" or
+// "winserver2022", so that a marker within a word, as in "twinserver", does not.
+func unsupportedOSMarker(imageName string) string {
+	tokens := strings.FieldsFunc(imageName, func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	})
+	for _, token := range tokens {
+		for _, marker := range unsupportedOSMarkers {
+			if token == marker || strings.TrimRight(token, "0123456789") == marker {
+				return marker
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,514 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"errors"
+
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/imagepolicy"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/freebox/mock"
+)
+
+var _ = Describe("FreeboxMachine Webhook", func() {
+	var (
+		obj       *infrastructurev1alpha1.FreeboxMachine
+		validator FreeboxMachineCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &infrastructurev1alpha1.FreeboxMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: infrastructurev1alpha1.FreeboxMachineSpec{
+				Name:          "test",
+				VCPUs:         1,
+				MemoryMB:      2048,
+				DiskSizeBytes: 10737418240,
+				ImageURL:      "https://cloud.debian.org/images/cloud/trixie/latest/debian-13-generic-arm64.qcow2",
+			},
+		}
+		validator = FreeboxMachineCustomValidator{}
+	})
+
+	Context("When creating or updating FreeboxMachine under Validating Webhook", func() {
+		It("Should admit a GNU/Linux disk image", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should admit a compressed disk image", func() {
+			obj.Spec.ImageURL = "https://factory.talos.dev/image/abc/v1.11.5/nocloud-arm64.raw.xz"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should admit ImageURL placeholders", func() {
+			obj.Spec.ImageURL = "https://factory.talos.dev/image/abc/{channel}/nocloud-{arch}.raw.xz"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny unknown ImageURL placeholders", func() {
+			obj.Spec.ImageURL = "https://example.com/{os}/nocloud-{arch}.raw.xz"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("unknown placeholder {os}")))
+		})
+
+		It("Should admit a name template", func() {
+			obj.Spec.NameTemplate = "{{ .ClusterName }}-{{ .MachineName }}"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a name template using unknown fields", func() {
+			obj.Spec.NameTemplate = "{{ .Hostname }}"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.nameTemplate")))
+		})
+
+		It("Should deny a name template rendering invalid names", func() {
+			obj.Spec.NameTemplate = "{{ .MachineName }}_VM"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("is not valid")))
+		})
+
+		It("Should deny a spec.name diverging from the VM name", func() {
+			obj.Spec.Name = "${CLUSTER_NAME}-control-plane"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.name")))
+		})
+
+		It("Should admit updates of machines created with a diverging spec.name", func() {
+			obj.Spec.Name = "homelab-control-plane"
+			newObj := obj.DeepCopy()
+			newObj.Finalizers = []string{"freeboxmachine.infrastructure.cluster.x-k8s.io/finalizer"}
+			Expect(validator.ValidateUpdate(ctx, obj, newObj)).Error().NotTo(HaveOccurred())
+		})
+
+		DescribeTable("Should deny images of other operating systems",
+			func(imageURL string) {
+				obj.Spec.ImageURL = imageURL
+				_, err := validator.ValidateCreate(ctx, obj)
+				Expect(err).To(MatchError(ContainSubstring("UEFI arm64 GNU/Linux")))
+			},
+			Entry("Windows with a version", "https://example.com/Windows11_InsiderPreview_Client_ARM64.qcow2"),
+			Entry("Windows Server", "https://example.com/windows-server-2025-arm64.raw"),
+			Entry("Windows Server with a version", "https://example.com/winserver2022-arm64.qcow2"),
+			Entry("short Windows name", "https://example.com/Win11_24H2_English_Arm64.qcow2"),
+			Entry("macOS", "https://example.com/macos-sequoia-arm64.raw"),
+		)
+
+		DescribeTable("Should admit images whose name merely contains an OS marker",
+			func(imageURL string) {
+				obj.Spec.ImageURL = imageURL
+				Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+			},
+			Entry("within a word", "https://example.com/twinserver-debian-13-arm64.qcow2"),
+			Entry("as a prefix", "https://example.com/gosxtools-alpine-arm64.qcow2"),
+			Entry("followed by letters", "https://example.com/debian-13-macosx-cross-arm64.qcow2"),
+			Entry("in the directory", "https://example.com/windows/debian-13-generic-arm64.qcow2"),
+		)
+
+		It("Should deny installer ISOs, even compressed", func() {
+			obj.Spec.ImageURL = "https://example.com/debian-13-arm64-netinst.iso.xz"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring(".iso images are not supported")))
+		})
+
+		It("Should deny external images in air-gapped mode", func() {
+			validator.ImagePolicy = imagepolicy.Policy{AirGapped: true}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("not allowed in air-gapped mode")))
+
+			obj.Spec.ImageURL = "file:///Freebox/VMs/images/debian-13-generic-arm64.qcow2"
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should keep the external image of an existing machine in air-gapped mode", func() {
+			validator.ImagePolicy = imagepolicy.Policy{AirGapped: true}
+			oldObj := obj.DeepCopy()
+			obj.Spec.VCPUs = 2
+			Expect(validator.ValidateUpdate(ctx, oldObj, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny unsupported disk formats on update", func() {
+			oldObj := obj.DeepCopy()
+			obj.Spec.ImageURL = "https://example.com/appliance.vmdk?download=1"
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ContainSubstring(".vmdk images are not supported")))
+		})
+
+		It("Should only admit a disk path for unmanaged images", func() {
+			obj.Spec.DiskPath = "/Freebox/VMs/test.qcow2"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.diskPath")))
+
+			obj.Spec.ImageManagement = infrastructurev1alpha1.ImageManagementUnmanaged
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should require an image unless the disk is unmanaged", func() {
+			obj.Spec.ImageURL = ""
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.imageURL: Required value")))
+
+			obj.Spec.ImageManagement = infrastructurev1alpha1.ImageManagementUnmanaged
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should require a size once the defaults are applied", func() {
+			obj.Spec.VCPUs = 0
+			obj.Spec.DiskSizeBytes = 0
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.vcpus: Required value")))
+			Expect(err).To(MatchError(ContainSubstring("spec.diskSizeBytes: Required value")))
+
+			obj.Spec.VCPUs = 2
+			obj.Spec.ImageManagement = infrastructurev1alpha1.ImageManagementUnmanaged
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should admit updates of machines created without an image", func() {
+			obj.Spec.ImageURL = ""
+			updated := obj.DeepCopy()
+			updated.Labels = map[string]string{"role": "worker"}
+			Expect(validator.ValidateUpdate(ctx, obj, updated)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should only admit files, commands and shared folders when they reach cloud-init", func() {
+			obj.Spec.Commands = []string{"sysctl --system"}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+
+			obj.Spec.CloudInit = infrastructurev1alpha1.CloudInitSkip
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.cloudInit")))
+
+			obj.Spec.CloudInit = ""
+			obj.Spec.BootstrapFormat = infrastructurev1alpha1.BootstrapFormatTalos
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.bootstrapFormat")))
+
+			obj.Spec.Commands = nil
+			obj.Spec.SharedFolders = []infrastructurev1alpha1.SharedFolder{{Path: "/Freebox/data", MountPath: "/mnt/data"}}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.bootstrapFormat")))
+		})
+	})
+})
+
+var _ = Describe("FreeboxMachine Warnings", func() {
+	var (
+		obj       *infrastructurev1alpha1.FreeboxMachine
+		fbClient  *mock.Client
+		validator FreeboxMachineCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &infrastructurev1alpha1.FreeboxMachine{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: infrastructurev1alpha1.FreeboxMachineSpec{
+				Name:          "test",
+				VCPUs:         1,
+				MemoryMB:      2048,
+				DiskSizeBytes: 10737418240,
+				ImageURL:      "https://cloud.debian.org/images/cloud/trixie/latest/debian-13-generic-arm64.qcow2",
+			},
+		}
+		fbClient = &mock.Client{}
+		fbClient.GetVirtualMachineInfoReturns(freeboxTypes.VirtualMachinesInfo{TotalMemory: 16384}, nil)
+		validator = FreeboxMachineCustomValidator{FreeboxClient: fbClient}
+	})
+
+	It("Should not warn about a sensible machine", func() {
+		Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+	})
+
+	It("Should warn about an amd64-looking image without rejecting it", func() {
+		obj.Spec.ImageURL = "https://cloud.debian.org/images/cloud/trixie/latest/debian-13-generic-amd64.qcow2"
+		warnings, err := validator.ValidateCreate(ctx, obj)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(ContainSubstring("spec.imageURL: image looks like an amd64 image")))
+	})
+
+	It("Should warn about a large raw disk", func() {
+		obj.Spec.ImageURL = "https://factory.talos.dev/image/abc/v1.11.5/nocloud-arm64.raw.xz"
+		Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+
+		obj.Spec.DiskSizeBytes = 64 << 30
+		Expect(validator.ValidateCreate(ctx, obj)).To(ConsistOf(ContainSubstring("spec.diskSizeBytes: raw disks take up their full 64Gi")))
+
+		By("looking at the disk of archives")
+		obj.Spec.ImageURL = "https://example.com/images/disk.tar.gz"
+		Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+		obj.Spec.ImageArchiveMember = "images/disk.raw"
+		Expect(validator.ValidateCreate(ctx, obj)).To(HaveLen(1))
+	})
+
+	It("Should warn about a machine taking most of the Freebox memory", func() {
+		obj.Spec.MemoryMB = 12288
+		Expect(validator.ValidateCreate(ctx, obj)).To(ConsistOf(ContainSubstring("spec.memoryMB: 12288 MB is more than half of the 16384 MB")))
+
+		By("not checking the memory when the Freebox cannot be reached")
+		fbClient.GetVirtualMachineInfoReturns(freeboxTypes.VirtualMachinesInfo{}, errors.New("unreachable"))
+		Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+	})
+
+	It("Should not check the memory of machines on another Freebox", func() {
+		scheme := runtime.NewScheme()
+		Expect(infrastructurev1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "homelab", Namespace: "default"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+					APIGroup: infrastructurev1alpha1.GroupVersion.Group,
+					Kind:     "FreeboxCluster",
+					Name:     "homelab",
+				},
+			},
+		}
+		fbCluster := &infrastructurev1alpha1.FreeboxCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "homelab", Namespace: "default"},
+			Spec:       infrastructurev1alpha1.FreeboxClusterSpec{Endpoint: "https://box.example.com"},
+		}
+		validator.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, fbCluster).Build()
+		validator.FreeboxEndpoint = "http://mafreebox.freebox.fr"
+		obj.Labels = map[string]string{clusterv1.ClusterNameLabel: "homelab"}
+		obj.Spec.MemoryMB = 12288
+		Expect(validator.ValidateCreate(ctx, obj)).To(BeEmpty())
+		Expect(fbClient.GetVirtualMachineInfoCallCount()).To(BeZero())
+
+		By("checking it again once the cluster uses the Freebox of the manager")
+		fbCluster.Spec.Endpoint = "http://mafreebox.freebox.fr/"
+		Expect(validator.Client.(client.Client).Update(ctx, fbCluster)).To(Succeed())
+		Expect(validator.ValidateCreate(ctx, obj)).To(HaveLen(1))
+	})
+
+	It("Should only warn on updates changing the fields warned about", func() {
+		obj.Spec.MemoryMB = 12288
+		updated := obj.DeepCopy()
+		updated.Labels = map[string]string{"role": "worker"}
+		Expect(validator.ValidateUpdate(ctx, obj, updated)).To(BeEmpty())
+
+		updated.Spec.MemoryMB = 10240
+		Expect(validator.ValidateUpdate(ctx, obj, updated)).To(HaveLen(1))
+	})
+})
+
+var _ = Describe("FreeboxMachine Defaulting Webhook", func() {
+	It("Should set spec.name to the VM name rendered from the name template", func() {
+		obj := &infrastructurev1alpha1.FreeboxMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "homelab-control-plane-abcde",
+				Namespace: "default",
+				Labels:    map[string]string{"cluster.x-k8s.io/cluster-name": "homelab"},
+			},
+			Spec: infrastructurev1alpha1.FreeboxMachineSpec{
+				Name:         "homelab-control-plane",
+				NameTemplate: "{{ .ClusterName }}-{{ .MachineName }}",
+			},
+		}
+		Expect((&FreeboxMachineCustomDefaulter{}).Default(ctx, obj)).To(Succeed())
+		Expect(obj.Spec.Name).To(Equal("homelab-homelab-control-plane-abcde"))
+	})
+
+	Context("When the FreeboxCluster has machine defaults", func() {
+		var defaulter FreeboxMachineCustomDefaulter
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(infrastructurev1alpha1.AddToScheme(scheme)).To(Succeed())
+			Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+			cluster := &clusterv1.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "homelab", Namespace: "default"},
+				Spec: clusterv1.ClusterSpec{
+					InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+						APIGroup: infrastructurev1alpha1.GroupVersion.Group,
+						Kind:     "FreeboxCluster",
+						Name:     "homelab",
+					},
+				},
+			}
+			fbCluster := &infrastructurev1alpha1.FreeboxCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "homelab", Namespace: "default"},
+				Spec: infrastructurev1alpha1.FreeboxClusterSpec{
+					MachineDefaults: &infrastructurev1alpha1.FreeboxMachineDefaults{
+						ImageURL:      "https://factory.talos.dev/image/abc/{channel}/nocloud-{arch}.raw.xz",
+						DiskSizeBytes: 21474836480,
+						VCPUs:         2,
+						MemoryMB:      4096,
+					},
+				},
+			}
+			defaulter = FreeboxMachineCustomDefaulter{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, fbCluster).Build()}
+		})
+
+		newMachine := func(labels map[string]string) *infrastructurev1alpha1.FreeboxMachine {
+			return &infrastructurev1alpha1.FreeboxMachine{
+				ObjectMeta: metav1.ObjectMeta{Name: "homelab-md-0-abcde", Namespace: "default", Labels: labels},
+			}
+		}
+
+		It("Should fill the unset fields of the machines of the cluster", func() {
+			obj := newMachine(map[string]string{clusterv1.ClusterNameLabel: "homelab"})
+			obj.Spec.VCPUs = 4
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.ImageURL).To(Equal("https://factory.talos.dev/image/abc/{channel}/nocloud-{arch}.raw.xz"))
+			Expect(obj.Spec.DiskSizeBytes).To(Equal(int64(21474836480)))
+			Expect(obj.Spec.VCPUs).To(Equal(int64(4)))
+			Expect(obj.Spec.MemoryMB).To(Equal(int64(4096)))
+		})
+
+		It("Should not set an image on machines bringing their own disk", func() {
+			obj := newMachine(map[string]string{clusterv1.ClusterNameLabel: "homelab"})
+			obj.Spec.ImageManagement = infrastructurev1alpha1.ImageManagementUnmanaged
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.ImageURL).To(BeEmpty())
+			Expect(obj.Spec.VCPUs).To(Equal(int64(2)))
+		})
+
+		It("Should leave machines of other clusters alone", func() {
+			obj := newMachine(map[string]string{clusterv1.ClusterNameLabel: "other"})
+			Expect(defaulter.Default(ctx, obj)).To(Succeed())
+			Expect(obj.Spec.ImageURL).To(BeEmpty())
+			Expect(obj.Spec.VCPUs).To(BeZero())
+		})
+	})
+})
+
+var _ = Describe("FreeboxMachine Quota", func() {
+	var (
+		scheme    *runtime.Scheme
+		cluster   *clusterv1.Cluster
+		fbCluster *infrastructurev1alpha1.FreeboxCluster
+	)
+
+	newMachine := func(name string, vcpus int64) *infrastructurev1alpha1.FreeboxMachine {
+		return &infrastructurev1alpha1.FreeboxMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "homelab"},
+			},
+			Spec: infrastructurev1alpha1.FreeboxMachineSpec{
+				VCPUs:         vcpus,
+				MemoryMB:      2048,
+				DiskSizeBytes: 10737418240,
+				ImageURL:      "https://cloud.debian.org/images/cloud/trixie/latest/debian-13-generic-arm64.qcow2",
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(infrastructurev1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+		cluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "homelab", Namespace: "default"},
+			Spec: clusterv1.ClusterSpec{
+				InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+					APIGroup: infrastructurev1alpha1.GroupVersion.Group,
+					Kind:     "FreeboxCluster",
+					Name:     "homelab",
+				},
+			},
+		}
+		fbCluster = &infrastructurev1alpha1.FreeboxCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "homelab", Namespace: "default"},
+			Spec: infrastructurev1alpha1.FreeboxClusterSpec{
+				Quota: &infrastructurev1alpha1.FreeboxResourceQuota{VCPUs: 4},
+			},
+		}
+	})
+
+	It("Should admit a machine within the quota", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, fbCluster, newMachine("existing", 2)).Build()
+		validator := FreeboxMachineCustomValidator{Client: c}
+		Expect(validator.ValidateCreate(ctx, newMachine("new", 2))).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should deny a machine exceeding the quota", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, fbCluster, newMachine("existing", 2)).Build()
+		validator := FreeboxMachineCustomValidator{Client: c}
+		_, err := validator.ValidateCreate(ctx, newMachine("new", 4))
+		Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("6 vCPUs requested, 4 allowed")))
+	})
+
+	It("Should admit any machine when the FreeboxCluster has no quota", func() {
+		fbCluster.Spec.Quota = nil
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, fbCluster, newMachine("existing", 2)).Build()
+		validator := FreeboxMachineCustomValidator{Client: c}
+		Expect(validator.ValidateCreate(ctx, newMachine("new", 8))).Error().NotTo(HaveOccurred())
+	})
+})
+
+var _ = Describe("FreeboxMachine Deletion Protection Webhook", func() {
+	var (
+		scheme  *runtime.Scheme
+		owner   *clusterv1.Machine
+		machine *infrastructurev1alpha1.FreeboxMachine
+	)
+
+	BeforeEach(func() {
+		scheme = runtime.NewScheme()
+		Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+		owner = &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "cp-0", Namespace: "default", Finalizers: []string{clusterv1.MachineFinalizer}}}
+		machine = &infrastructurev1alpha1.FreeboxMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cp-0",
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: clusterv1.GroupVersion.String(), Kind: "Machine", Name: "cp-0",
+				}},
+			},
+			Spec: infrastructurev1alpha1.FreeboxMachineSpec{DeletionProtection: true},
+		}
+	})
+
+	It("Should deny the deletion of a protected machine whose owner is not being deleted", func() {
+		validator := FreeboxMachineCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(owner).Build()}
+		_, err := validator.ValidateDelete(ctx, machine)
+		Expect(apierrors.IsForbidden(err)).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("spec.deletionProtection")))
+
+		machine.Spec.DeletionProtection = false
+		Expect(validator.ValidateDelete(ctx, machine)).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should admit the deletion of a protected machine along with its owner", func() {
+		owner.DeletionTimestamp = ptr.To(metav1.Now())
+		validator := FreeboxMachineCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(owner).Build()}
+		Expect(validator.ValidateDelete(ctx, machine)).Error().NotTo(HaveOccurred())
+
+		validator = FreeboxMachineCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).Build()}
+		Expect(validator.ValidateDelete(ctx, machine)).Error().NotTo(HaveOccurred())
+	})
+
+	It("Should admit the deletion of a protected machine by clusterctl move", func() {
+		machine.Annotations = map[string]string{deleteForMoveAnnotation: ""}
+		validator := FreeboxMachineCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(owner).Build()}
+		Expect(validator.ValidateDelete(ctx, machine)).Error().NotTo(HaveOccurred())
+	})
+})
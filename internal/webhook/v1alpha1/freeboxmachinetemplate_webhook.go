@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// log is for logging in this package.
+var freeboxmachinetemplatelog = logf.Log.WithName("freeboxmachinetemplate-resource")
+
+// SetupFreeboxMachineTemplateWebhookWithManager registers the webhook for FreeboxMachineTemplate in the manager.
+func SetupFreeboxMachineTemplateWebhookWithManager(mgr ctrl.Manager, freeboxClient freeboxclient.Client) error {
+	return ctrl.NewWebhookManagedBy(mgr, &infrastructurev1alpha1.FreeboxMachineTemplate{}).
+		WithValidator(&FreeboxMachineTemplateCustomValidator{FreeboxClient: freeboxClient}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1alpha1-freeboxmachinetemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=freeboxmachinetemplates,verbs=create;update,versions=v1alpha1,name=vfreeboxmachinetemplate-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// FreeboxMachineTemplateCustomValidator validates FreeboxMachineTemplate resources when they are created or updated.
+type FreeboxMachineTemplateCustomValidator struct {
+	// FreeboxClient reads the VM capacity of the Freebox of the manager to warn about
+	// templates whose machines take most of its memory, templates not being tied to
+	// a Freebox. It is not checked when nil.
+	FreeboxClient freeboxclient.Client
+}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type FreeboxMachineTemplate.
+func (v *FreeboxMachineTemplateCustomValidator) ValidateCreate(ctx context.Context, template *infrastructurev1alpha1.FreeboxMachineTemplate) (admission.Warnings, error) {
+	freeboxmachinetemplatelog.Info("Validation for FreeboxMachineTemplate upon creation", "name", template.GetName())
+
+	if err := validateFreeboxMachineTemplate(template); err != nil {
+		return nil, err
+	}
+	return v.warnings(ctx, template), nil
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type FreeboxMachineTemplate.
+func (v *FreeboxMachineTemplateCustomValidator) ValidateUpdate(ctx context.Context, oldTemplate, template *infrastructurev1alpha1.FreeboxMachineTemplate) (admission.Warnings, error) {
+	freeboxmachinetemplatelog.Info("Validation for FreeboxMachineTemplate upon update", "name", template.GetName())
+
+	if err := validateFreeboxMachineTemplate(template); err != nil {
+		return nil, err
+	}
+	if warningFieldsChanged(&oldTemplate.Spec.Template.Spec, &template.Spec.Template.Spec) {
+		return v.warnings(ctx, template), nil
+	}
+	return nil, nil
+}
+
+// warnings returns the soft issues of the machines created from template.
+func (v *FreeboxMachineTemplateCustomValidator) warnings(ctx context.Context, template *infrastructurev1alpha1.FreeboxMachineTemplate) admission.Warnings {
+	fldPath := field.NewPath("spec", "template", "spec")
+	spec := &template.Spec.Template.Spec
+	warnings := freeboxMachineSpecWarnings(spec, fldPath)
+	return append(warnings, memoryWarnings(ctx, v.FreeboxClient, spec.MemoryMB, fldPath)...)
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type FreeboxMachineTemplate.
+func (v *FreeboxMachineTemplateCustomValidator) ValidateDelete(_ context.Context, _ *infrastructurev1alpha1.FreeboxMachineTemplate) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateFreeboxMachineTemplate does not require an image nor a size, which the machines
+// may get from the machineDefaults of their FreeboxCluster; they are checked on the machines.
+func validateFreeboxMachineTemplate(template *infrastructurev1alpha1.FreeboxMachineTemplate) error {
+	allErrs := validateFreeboxMachineSpec(&template.Spec.Template.Spec, field.NewPath("spec", "template", "spec"))
+	// The machines created from a template would all use the same disk.
+	if template.Spec.Template.Spec.DiskPath != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "diskPath"),
+			"machines created from a template cannot share a disk; leave it empty to use disks named after their VM"))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(infrastructurev1alpha1.GroupVersion.WithKind("FreeboxMachineTemplate").GroupKind(), template.Name, allErrs)
+}
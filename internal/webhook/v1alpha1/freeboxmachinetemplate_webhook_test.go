@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+var _ = Describe("FreeboxMachineTemplate Webhook", func() {
+	var (
+		obj       *infrastructurev1alpha1.FreeboxMachineTemplate
+		validator FreeboxMachineTemplateCustomValidator
+	)
+
+	BeforeEach(func() {
+		obj = &infrastructurev1alpha1.FreeboxMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+			Spec: infrastructurev1alpha1.FreeboxMachineTemplateSpec{
+				Template: infrastructurev1alpha1.FreeboxMachineTemplateResource{
+					Spec: infrastructurev1alpha1.FreeboxMachineSpec{
+						Name:          "test",
+						VCPUs:         1,
+						MemoryMB:      2048,
+						DiskSizeBytes: 10737418240,
+						ImageURL:      "https://example.com/nocloud-arm64.raw.xz",
+					},
+				},
+			},
+		}
+		validator = FreeboxMachineTemplateCustomValidator{}
+	})
+
+	Context("When creating or updating FreeboxMachineTemplate under Validating Webhook", func() {
+		It("Should admit a GNU/Linux disk image", func() {
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should leave the image and size to the machineDefaults of the FreeboxCluster", func() {
+			obj.Spec.Template.Spec = infrastructurev1alpha1.FreeboxMachineSpec{}
+			Expect(validator.ValidateCreate(ctx, obj)).Error().NotTo(HaveOccurred())
+		})
+
+		It("Should deny a Windows image and report the template field path", func() {
+			obj.Spec.Template.Spec.ImageURL = "https://example.com/windows-server-2025.raw"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.template.spec.imageURL")))
+		})
+
+		It("Should deny a disk path shared by the machines of the template", func() {
+			obj.Spec.Template.Spec.ImageManagement = infrastructurev1alpha1.ImageManagementUnmanaged
+			obj.Spec.Template.Spec.DiskPath = "/Freebox/VMs/test.qcow2"
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("spec.template.spec.diskPath")))
+		})
+
+		It("Should warn about the machines of the template with the template field path", func() {
+			obj.Spec.Template.Spec.ImageURL = "https://example.com/nocloud-amd64.raw.xz"
+			obj.Spec.Template.Spec.DiskSizeBytes = 64 << 30
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(
+				ContainSubstring("spec.template.spec.imageURL"),
+				ContainSubstring("spec.template.spec.diskSizeBytes"),
+			))
+		})
+	})
+})
@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/ipcommand"
+)
+
+// kubeadmcontrolplanelog is for logging in this package.
+var kubeadmcontrolplanelog = logf.Log.WithName("kubeadmcontrolplane-resource")
+
+// SetupKubeadmControlPlaneWebhookWithManager registers the webhook warning about
+// KubeadmControlPlanes that add the control plane endpoint to their nodes.
+func SetupKubeadmControlPlaneWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &controlplanev1.KubeadmControlPlane{}).
+		WithValidator(&KubeadmControlPlaneCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// The KubeadmControlPlane type belongs to the kubeadm control plane provider, so
+// this webhook only returns warnings, and is ignored when the manager is down.
+// +kubebuilder:webhook:path=/validate-controlplane-cluster-x-k8s-io-v1beta2-kubeadmcontrolplane,mutating=false,failurePolicy=ignore,sideEffects=None,groups=controlplane.cluster.x-k8s.io,resources=kubeadmcontrolplanes,verbs=create;update,versions=v1beta2,name=vkubeadmcontrolplane-freebox.kb.io,admissionReviewVersions=v1
+
+// KubeadmControlPlaneCustomValidator warns about KubeadmControlPlanes whose
+// preKubeadmCommands add the control plane endpoint to the control plane nodes.
+type KubeadmControlPlaneCustomValidator struct {
+	// Client reads the FreeboxCluster holding the control plane endpoint. When nil,
+	// only the controlPlaneEndpoint of the kubeadm cluster configuration is used.
+	Client client.Reader
+}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type KubeadmControlPlane.
+func (v *KubeadmControlPlaneCustomValidator) ValidateCreate(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) (admission.Warnings, error) {
+	return v.warnings(ctx, kcp), nil
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type KubeadmControlPlane.
+func (v *KubeadmControlPlaneCustomValidator) ValidateUpdate(ctx context.Context, _, kcp *controlplanev1.KubeadmControlPlane) (admission.Warnings, error) {
+	return v.warnings(ctx, kcp), nil
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type KubeadmControlPlane.
+func (v *KubeadmControlPlaneCustomValidator) ValidateDelete(_ context.Context, _ *controlplanev1.KubeadmControlPlane) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// warnings warns when the preKubeadmCommands of kcp add its control plane endpoint:
+// every control plane node then claims the address on the Freebox LAN, which breaks
+// as soon as two of them run, be it with several replicas or during a rolling update.
+func (v *KubeadmControlPlaneCustomValidator) warnings(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) admission.Warnings {
+	host, err := v.controlPlaneEndpointHost(ctx, kcp)
+	if err != nil {
+		kubeadmcontrolplanelog.Info("Could not look up the control plane endpoint, not checking the preKubeadmCommands",
+			"name", kcp.GetName(), "error", err.Error())
+		return nil
+	}
+	if host == "" || !slices.Contains(ipcommand.AddedAddresses(kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands), host) {
+		return nil
+	}
+	return admission.Warnings{fmt.Sprintf("spec.kubeadmConfigSpec.preKubeadmCommands adds the control plane endpoint %s to every control plane node, "+
+		"which then conflict on the Freebox LAN as soon as two of them run, including during rolling updates; "+
+		"announce the endpoint with a virtual IP such as kube-vip or an external load balancer instead", host)}
+}
+
+// controlPlaneEndpointHost returns the host of the control plane endpoint of the
+// FreeboxCluster of kcp, falling back to the controlPlaneEndpoint of its kubeadm
+// cluster configuration. It returns an empty string when neither is known.
+func (v *KubeadmControlPlaneCustomValidator) controlPlaneEndpointHost(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) (string, error) {
+	if v.Client != nil {
+		cluster, err := clusterOfControlPlane(ctx, v.Client, kcp)
+		if err != nil {
+			return "", err
+		}
+		if cluster != nil && cluster.Spec.InfrastructureRef.Kind == "FreeboxCluster" && cluster.Spec.InfrastructureRef.Name != "" {
+			ref := cluster.Spec.InfrastructureRef
+			var freeboxCluster infrastructurev1alpha1.FreeboxCluster
+			err := v.Client.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: ref.Name}, &freeboxCluster)
+			if client.IgnoreNotFound(err) != nil {
+				return "", err
+			}
+			if host := freeboxCluster.Spec.ControlPlaneEndpoint.Host; host != "" {
+				return host, nil
+			}
+		}
+	}
+	endpoint := kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.ControlPlaneEndpoint
+	if host, _, err := net.SplitHostPort(endpoint); err == nil {
+		return host, nil
+	}
+	return endpoint, nil
+}
+
+// clusterOfControlPlane returns the Cluster of kcp, found with its cluster name label
+// or else with the controlPlaneRef of the Clusters of its namespace, or nil when the
+// Cluster does not exist yet.
+func clusterOfControlPlane(ctx context.Context, c client.Reader, kcp *controlplanev1.KubeadmControlPlane) (*clusterv1.Cluster, error) {
+	if name := kcp.Labels[clusterv1.ClusterNameLabel]; name != "" {
+		var cluster clusterv1.Cluster
+		if err := c.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: name}, &cluster); err != nil {
+			return nil, client.IgnoreNotFound(err)
+		}
+		return &cluster, nil
+	}
+	var clusters clusterv1.ClusterList
+	if err := c.List(ctx, &clusters, client.InNamespace(kcp.Namespace)); err != nil {
+		return nil, err
+	}
+	for i := range clusters.Items {
+		ref := clusters.Items[i].Spec.ControlPlaneRef
+		if ref.Kind == "KubeadmControlPlane" && ref.APIGroup == controlplanev1.GroupVersion.Group && ref.Name == kcp.Name {
+			return &clusters.Items[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+var _ = Describe("KubeadmControlPlane Webhook", func() {
+	var (
+		kcp       *controlplanev1.KubeadmControlPlane
+		cluster   *clusterv1.Cluster
+		validator KubeadmControlPlaneCustomValidator
+	)
+
+	BeforeEach(func() {
+		kcp = &controlplanev1.KubeadmControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "homelab-control-plane", Namespace: "default"},
+		}
+		kcp.Spec.Replicas = ptr.To[int32](1)
+		kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands = []string{"ip addr add 192.168.1.200/24 dev enp0s5 || true"}
+
+		scheme := runtime.NewScheme()
+		Expect(infrastructurev1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+		cluster = &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "homelab", Namespace: "default"},
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneRef: clusterv1.ContractVersionedObjectReference{
+					APIGroup: controlplanev1.GroupVersion.Group,
+					Kind:     "KubeadmControlPlane",
+					Name:     "homelab-control-plane",
+				},
+				InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+					APIGroup: infrastructurev1alpha1.GroupVersion.Group,
+					Kind:     "FreeboxCluster",
+					Name:     "homelab",
+				},
+			},
+		}
+		fbCluster := &infrastructurev1alpha1.FreeboxCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "homelab", Namespace: "default"},
+			Spec: infrastructurev1alpha1.FreeboxClusterSpec{
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "192.168.1.200", Port: 6443},
+			},
+		}
+		validator = KubeadmControlPlaneCustomValidator{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, fbCluster).Build(),
+		}
+	})
+
+	Context("When creating or updating KubeadmControlPlane under Validating Webhook", func() {
+		It("Should warn about a single control plane node adding the endpoint address", func() {
+			warnings, err := validator.ValidateCreate(ctx, kcp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("adds the control plane endpoint 192.168.1.200 to every control plane node")))
+		})
+
+		It("Should warn about several control plane nodes adding the endpoint address", func() {
+			kcp.Spec.Replicas = ptr.To[int32](3)
+			warnings, err := validator.ValidateUpdate(ctx, kcp, kcp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(HaveLen(1))
+		})
+
+		It("Should find the cluster with the cluster name label", func() {
+			kcp.Name = "another-control-plane"
+			Expect(validator.ValidateCreate(ctx, kcp)).To(BeEmpty())
+
+			kcp.Labels = map[string]string{clusterv1.ClusterNameLabel: cluster.Name}
+			Expect(validator.ValidateCreate(ctx, kcp)).To(HaveLen(1))
+		})
+
+		It("Should not warn about other addresses", func() {
+			kcp.Spec.KubeadmConfigSpec.PreKubeadmCommands = []string{"ip addr add 192.168.1.201/24 dev enp0s5"}
+			Expect(validator.ValidateCreate(ctx, kcp)).To(BeEmpty())
+		})
+
+		It("Should fall back to the endpoint of the kubeadm cluster configuration", func() {
+			kcp.Name = "another-control-plane"
+			Expect(validator.ValidateCreate(ctx, kcp)).To(BeEmpty())
+
+			kcp.Spec.KubeadmConfigSpec.ClusterConfiguration.ControlPlaneEndpoint = "192.168.1.200:6443"
+			Expect(validator.ValidateCreate(ctx, kcp)).To(HaveLen(1))
+
+			By("without a client")
+			validator.Client = nil
+			Expect(validator.ValidateCreate(ctx, kcp)).To(HaveLen(1))
+		})
+	})
+})
@@ -0,0 +1,247 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhookcert provisions the serving certificate of the webhook server
+// without cert-manager: it generates a CA and a serving certificate, stores them
+// in a Secret shared by all the replicas, and injects the CA into the webhook
+// configurations. The permissions it needs are granted on these objects only, by
+// config/default/webhook_cert_rbac.yaml.
+package webhookcert
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// validity is how long the generated certificates are valid.
+	validity = 10 * 365 * 24 * time.Hour
+	// renewBefore is how long before they expire the certificates are regenerated.
+	renewBefore = 30 * 24 * time.Hour
+)
+
+// Options names the objects the certificate is provisioned for.
+type Options struct {
+	// Namespace is the namespace of the webhook Service and of the Secret.
+	Namespace string
+	// ServiceName is the name of the Service in front of the webhook server.
+	ServiceName string
+	// SecretName is the name of the Secret holding the certificates, in the format
+	// of cert-manager: ca.crt, tls.crt and tls.key.
+	SecretName string
+	// MutatingWebhookConfiguration and ValidatingWebhookConfiguration are the names
+	// of the configurations the CA is injected into. Empty names are skipped.
+	MutatingWebhookConfiguration   string
+	ValidatingWebhookConfiguration string
+	// CertDir is the directory the webhook server reads tls.crt and tls.key from.
+	CertDir string
+}
+
+// Provision makes sure the Secret holds a valid certificate for the webhook Service,
+// writes it to CertDir, and injects its CA into the webhook configurations.
+func Provision(ctx context.Context, c client.Client, opts Options) error {
+	secret, err := ensureSecret(ctx, c, opts)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(opts.CertDir, 0o700); err != nil {
+		return fmt.Errorf("creating webhook certificate directory: %w", err)
+	}
+	for _, name := range []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey} {
+		if err := os.WriteFile(filepath.Join(opts.CertDir, name), secret.Data[name], 0o600); err != nil {
+			return fmt.Errorf("writing webhook certificate: %w", err)
+		}
+	}
+	return injectCABundle(ctx, c, opts, secret.Data["ca.crt"])
+}
+
+// ensureSecret returns the Secret holding the certificates, creating or renewing
+// them when they are missing, expiring or not valid for the Service.
+func ensureSecret(ctx context.Context, c client.Client, opts Options) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.SecretName}, secret)
+	switch {
+	case apierrors.IsNotFound(err):
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: opts.Namespace, Name: opts.SecretName},
+			Type:       corev1.SecretTypeTLS,
+		}
+		if secret.Data, err = generate(dnsNames(opts), time.Now()); err != nil {
+			return nil, err
+		}
+		if err := c.Create(ctx, secret); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Another replica created it first; use its certificate.
+				return ensureSecret(ctx, c, opts)
+			}
+			return nil, fmt.Errorf("creating webhook certificate secret: %w", err)
+		}
+		return secret, nil
+	case err != nil:
+		return nil, fmt.Errorf("getting webhook certificate secret: %w", err)
+	}
+
+	if valid(secret.Data, dnsNames(opts), time.Now().Add(renewBefore)) {
+		return secret, nil
+	}
+	if secret.Data, err = generate(dnsNames(opts), time.Now()); err != nil {
+		return nil, err
+	}
+	if err := c.Update(ctx, secret); err != nil {
+		return nil, fmt.Errorf("renewing webhook certificate secret: %w", err)
+	}
+	return secret, nil
+}
+
+// dnsNames returns the names the webhook Service is reached at.
+func dnsNames(opts Options) []string {
+	return []string{
+		opts.ServiceName + "." + opts.Namespace + ".svc",
+		opts.ServiceName + "." + opts.Namespace + ".svc.cluster.local",
+	}
+}
+
+// valid reports whether data holds a certificate for dnsNames signed by its CA,
+// that is still valid at the given time.
+func valid(data map[string][]byte, dnsNames []string, at time.Time) bool {
+	pair, err := tls.X509KeyPair(data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return false
+	}
+	cert, err := x509.ParseCertificate(pair.Certificate[0])
+	if err != nil {
+		return false
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(data["ca.crt"]) {
+		return false
+	}
+	for _, name := range dnsNames {
+		if _, err := cert.Verify(x509.VerifyOptions{DNSName: name, Roots: roots, CurrentTime: at}); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// generate returns a new CA and a serving certificate for dnsNames signed by it,
+// in the format of a cert-manager Secret.
+func generate(dnsNames []string, now time.Time) (map[string][]byte, error) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating webhook CA key: %w", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "cluster-api-provider-freebox-webhook-ca"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("generating webhook CA: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generating webhook serving key: %w", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caTemplate, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, fmt.Errorf("generating webhook serving certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("encoding webhook serving key: %w", err)
+	}
+
+	return map[string][]byte{
+		"ca.crt":                pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		corev1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		corev1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}, nil
+}
+
+// injectCABundle sets caBundle on every webhook of the webhook configurations.
+func injectCABundle(ctx context.Context, c client.Client, opts Options, caBundle []byte) error {
+	if opts.MutatingWebhookConfiguration != "" {
+		config := &admissionregistrationv1.MutatingWebhookConfiguration{}
+		if err := c.Get(ctx, client.ObjectKey{Name: opts.MutatingWebhookConfiguration}, config); err != nil {
+			return fmt.Errorf("getting mutating webhook configuration: %w", err)
+		}
+		changed := false
+		for i := range config.Webhooks {
+			if !bytes.Equal(config.Webhooks[i].ClientConfig.CABundle, caBundle) {
+				config.Webhooks[i].ClientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if changed {
+			if err := c.Update(ctx, config); err != nil {
+				return fmt.Errorf("injecting CA into mutating webhook configuration: %w", err)
+			}
+		}
+	}
+	if opts.ValidatingWebhookConfiguration != "" {
+		config := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+		if err := c.Get(ctx, client.ObjectKey{Name: opts.ValidatingWebhookConfiguration}, config); err != nil {
+			return fmt.Errorf("getting validating webhook configuration: %w", err)
+		}
+		changed := false
+		for i := range config.Webhooks {
+			if !bytes.Equal(config.Webhooks[i].ClientConfig.CABundle, caBundle) {
+				config.Webhooks[i].ClientConfig.CABundle = caBundle
+				changed = true
+			}
+		}
+		if changed {
+			if err := c.Update(ctx, config); err != nil {
+				return fmt.Errorf("injecting CA into validating webhook configuration: %w", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package webhookcert
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestProvision(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		&admissionregistrationv1.MutatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "mutating"},
+			Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "m.example.com"}},
+		},
+		&admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "validating"},
+			Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "v1.example.com"}, {Name: "v2.example.com"}},
+		},
+	).Build()
+	opts := Options{
+		Namespace:                      "capf-system",
+		ServiceName:                    "webhook-service",
+		SecretName:                     "webhook-server-cert",
+		MutatingWebhookConfiguration:   "mutating",
+		ValidatingWebhookConfiguration: "validating",
+		CertDir:                        t.TempDir(),
+	}
+
+	if err := Provision(ctx, c, opts); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.SecretName}, secret); err != nil {
+		t.Fatalf("getting secret: %v", err)
+	}
+	if !valid(secret.Data, []string{"webhook-service.capf-system.svc"}, time.Now()) {
+		t.Error("generated certificate is not valid for the webhook service")
+	}
+	cert, err := os.ReadFile(filepath.Join(opts.CertDir, corev1.TLSCertKey))
+	if err != nil || !bytes.Equal(cert, secret.Data[corev1.TLSCertKey]) {
+		t.Errorf("certificate written to the certificate directory does not match the secret: %v", err)
+	}
+
+	mutating := &admissionregistrationv1.MutatingWebhookConfiguration{}
+	if err := c.Get(ctx, client.ObjectKey{Name: "mutating"}, mutating); err != nil {
+		t.Fatal(err)
+	}
+	validating := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := c.Get(ctx, client.ObjectKey{Name: "validating"}, validating); err != nil {
+		t.Fatal(err)
+	}
+	for _, bundle := range [][]byte{mutating.Webhooks[0].ClientConfig.CABundle, validating.Webhooks[0].ClientConfig.CABundle, validating.Webhooks[1].ClientConfig.CABundle} {
+		if !bytes.Equal(bundle, secret.Data["ca.crt"]) {
+			t.Errorf("caBundle = %q, want the CA of the secret", bundle)
+		}
+	}
+
+	// Other replicas reuse the certificate rather than generating their own.
+	if err := Provision(ctx, c, opts); err != nil {
+		t.Fatalf("second Provision() error = %v", err)
+	}
+	reused := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: opts.Namespace, Name: opts.SecretName}, reused); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(reused.Data[corev1.TLSCertKey], secret.Data[corev1.TLSCertKey]) {
+		t.Error("second Provision() regenerated a valid certificate")
+	}
+}
+
+func TestValid(t *testing.T) {
+	names := []string{"svc.ns.svc", "svc.ns.svc.cluster.local"}
+	data, err := generate(names, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !valid(data, names, time.Now()) {
+		t.Error("valid() = false for a fresh certificate")
+	}
+	if valid(data, names, time.Now().Add(validity)) {
+		t.Error("valid() = true for an expired certificate")
+	}
+	if valid(data, []string{"other.ns.svc"}, time.Now()) {
+		t.Error("valid() = true for another service")
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package conditions holds the types of the conditions reported by FreeboxMachines
+// and FreeboxClusters, and helpers to maintain them.
+package conditions
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+const (
+	// Ready is the main condition type that CAPI watches
+	// It reflects the overall state of the infrastructure
+	Ready = "Ready"
+
+	// Paused is the Cluster API condition that tracks whether the reconciliation
+	// of a FreeboxMachine or FreeboxCluster is paused, by its Cluster or annotation
+	Paused = "Paused"
+
+	// ImageReady is a supplementary FreeboxMachine condition that tracks
+	// whether the disk image has been downloaded, extracted, and prepared
+	ImageReady = "ImageReady"
+
+	// BootstrapDataReady is a supplementary FreeboxMachine condition that tracks
+	// whether the bootstrap provider has generated the bootstrap data secret
+	BootstrapDataReady = "BootstrapDataReady"
+
+	// ImageVersionMismatch is a supplementary FreeboxMachine condition that tracks
+	// whether the Kubernetes version embedded in the image differs from the Machine one
+	ImageVersionMismatch = "ImageVersionMismatch"
+
+	// ReconciliationFrozen is a supplementary FreeboxMachine condition that tracks
+	// whether the controller is stopped from changing the VM on the Freebox
+	ReconciliationFrozen = "ReconciliationFrozen"
+
+	// ShutdownEscalated is a supplementary FreeboxMachine condition that tracks
+	// whether the VM had to be killed because it did not shut down in time
+	ShutdownEscalated = "ShutdownEscalated"
+
+	// FreeboxClockSkewed is a supplementary FreeboxMachine condition that tracks
+	// whether the Freebox clock, which freshly booted VMs start from, is off
+	FreeboxClockSkewed = "FreeboxClockSkewed"
+
+	// FreeboxTargetChanged is a supplementary FreeboxMachine condition that tracks
+	// whether the controller now manages another Freebox than the machine was provisioned on
+	FreeboxTargetChanged = "FreeboxTargetChanged"
+
+	// ControlPlaneEndpointReachable is a supplementary FreeboxCluster condition that
+	// tracks whether the control plane endpoint accepts TCP connections once machines exist
+	ControlPlaneEndpointReachable = "ControlPlaneEndpointReachable"
+
+	// ControlPlaneEndpointConsistent is a supplementary FreeboxCluster condition that
+	// tracks whether the KubeadmControlPlane of the cluster uses its control plane endpoint
+	ControlPlaneEndpointConsistent = "ControlPlaneEndpointConsistent"
+
+	// FreeboxAPIVersionCurrent is a supplementary FreeboxCluster condition that tracks
+	// whether the controller uses the latest Freebox API version advertised by the box
+	FreeboxAPIVersionCurrent = "FreeboxAPIVersionCurrent"
+
+	// StorageDegraded is a supplementary FreeboxCluster condition that tracks
+	// whether the Freebox internal disk reports errors, which pauses image writes
+	StorageDegraded = "StorageDegraded"
+
+	// ClusterReferencesValid is a supplementary FreeboxCluster condition that tracks
+	// whether the FreeboxCluster and FreeboxMachineTemplates of the Cluster are in its namespace
+	ClusterReferencesValid = "ClusterReferencesValid"
+
+	// IdentityReady is a supplementary FreeboxCluster condition that tracks whether
+	// the controller logs in to the Freebox with the FreeboxClusterIdentity of the cluster
+	IdentityReady = "IdentityReady"
+)
+
+// SetObservedGeneration records that each of conditions was computed from the
+// given generation of its object.
+func SetObservedGeneration(conditions []metav1.Condition, generation int64) {
+	for i := range conditions {
+		conditions[i].ObservedGeneration = generation
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package diskimage names the disk images of Freebox VMs after the URL they are
+// downloaded from, and tells how they have to be unpacked before the VM boots.
+package diskimage
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// DefaultFileName names images whose URL has no file name, e.g. "https://mirror.lan/?id=42".
+const DefaultFileName = "image"
+
+// archiveSuffixes are the extensions of archives that may hold other files than
+// the disk image, possibly in a nested directory. Longer suffixes come first.
+var archiveSuffixes = []string{".tar.gz", ".tar.xz", ".tar.bz2", ".tgz", ".txz", ".tbz2", ".tar", ".zip"}
+
+// compressionSuffixes are the extensions of single compressed files.
+var compressionSuffixes = []string{".xz", ".gz", ".bz2"}
+
+// FileName returns the name of the file imageURL is downloaded to, ignoring its
+// query string and fragment.
+func FileName(imageURL string) string {
+	name := imageURL
+	if u, err := url.Parse(imageURL); err == nil {
+		name = u.Path
+	}
+	return SanitizeFileName(name)
+}
+
+// SanitizeFileName returns the last element of name, or DefaultFileName when it
+// does not name a file.
+func SanitizeFileName(name string) string {
+	name = path.Base(name)
+	switch name {
+	case ".", "..", "/", "":
+		return DefaultFileName
+	}
+	return name
+}
+
+// ArchiveSuffix returns the archive extension of name, or an empty string when
+// name is not an archive.
+func ArchiveSuffix(name string) string {
+	lower := strings.ToLower(name)
+	for _, suffix := range archiveSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return suffix
+		}
+	}
+	return ""
+}
+
+// IsArchive reports whether name is an archive whose disk image has to be located
+// among the extracted files.
+func IsArchive(name string) bool {
+	return ArchiveSuffix(name) != ""
+}
+
+// IsCompressed reports whether name is a compressed file or an archive that has
+// to be extracted.
+func IsCompressed(name string) bool {
+	if IsArchive(name) {
+		return true
+	}
+	ext := strings.ToLower(path.Ext(name))
+	for _, suffix := range compressionSuffixes {
+		if ext == suffix {
+			return true
+		}
+	}
+	return false
+}
+
+// StripCompressionSuffix removes the trailing compression or archive extension
+// e.g. "nocloud.raw.xz" -> "nocloud.raw", "disk.tar.gz" -> "disk"
+func StripCompressionSuffix(name string) string {
+	if suffix := ArchiveSuffix(name); suffix != "" {
+		return name[:len(name)-len(suffix)]
+	}
+	lower := strings.ToLower(name)
+	for _, ext := range compressionSuffixes {
+		if strings.HasSuffix(lower, ext) {
+			return name[:len(name)-len(ext)]
+		}
+	}
+	// fallback: use path.Ext trimming once
+	if ext := path.Ext(name); ext != "" {
+		return strings.TrimSuffix(name, ext)
+	}
+	return name
+}
+
+// ArchiveDiskCandidates returns the paths, relative to the extraction directory,
+// where the disk image of the archive imageName is looked for. member overrides
+// them when set.
+func ArchiveDiskCandidates(member, imageName string) []string {
+	if member != "" {
+		return []string{member}
+	}
+	base := StripCompressionSuffix(imageName)
+	return []string{base, base + ".raw", base + ".qcow2", base + ".img", "disk.raw", "disk.qcow2", "disk.img"}
+}
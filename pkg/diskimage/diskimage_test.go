@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package diskimage
+
+import "testing"
+
+func TestStripCompressionSuffix(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"nocloud.raw.xz", "nocloud.raw"},
+		{"image.img.gz", "image.img"},
+		{"archive.tar.bz2", "archive"},
+		{"disk.tar.gz", "disk"},
+		{"image.tgz", "image"},
+		{"file.zip", "file"},
+		{"nocloud.tar", "nocloud"},
+		{"plain.raw", "plain"}, // no compression extension — falls back to path.Ext trimming
+		{"noext", "noext"},
+		{"UPPER.XZ", "UPPER"},
+		{"mixed.Gz", "mixed"},
+	}
+	for _, tc := range tests {
+		got := StripCompressionSuffix(tc.input)
+		if got != tc.want {
+			t.Errorf("StripCompressionSuffix(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestFileName(t *testing.T) {
+	for imageURL, want := range map[string]string{
+		"https://cloud.debian.org/images/debian.qcow2":            "debian.qcow2",
+		"https://mirror.lan/images/nocloud.raw.xz?token=abc#frag": "nocloud.raw.xz",
+		"https://mirror.lan/images/disk%201.raw":                  "disk 1.raw",
+		"https://mirror.lan/?id=42":                               DefaultFileName,
+		"https://mirror.lan":                                      DefaultFileName,
+	} {
+		if got := FileName(imageURL); got != want {
+			t.Errorf("FileName(%q) = %q, want %q", imageURL, got, want)
+		}
+	}
+}
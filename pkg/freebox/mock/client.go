@@ -0,0 +1,5961 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by counterfeiter. DO NOT EDIT.
+package mock
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/nikolalohinski/free-go/client"
+	"github.com/nikolalohinski/free-go/types"
+)
+
+type Client struct {
+	APIVersionStub        func(context.Context) (types.APIVersion, error)
+	aPIVersionMutex       sync.RWMutex
+	aPIVersionArgsForCall []struct {
+		arg1 context.Context
+	}
+	aPIVersionReturns struct {
+		result1 types.APIVersion
+		result2 error
+	}
+	aPIVersionReturnsOnCall map[int]struct {
+		result1 types.APIVersion
+		result2 error
+	}
+	AddDownloadTaskStub        func(context.Context, types.DownloadRequest) (int64, error)
+	addDownloadTaskMutex       sync.RWMutex
+	addDownloadTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.DownloadRequest
+	}
+	addDownloadTaskReturns struct {
+		result1 int64
+		result2 error
+	}
+	addDownloadTaskReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	AddHashFileTaskStub        func(context.Context, types.HashPayload) (types.FileSystemTask, error)
+	addHashFileTaskMutex       sync.RWMutex
+	addHashFileTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.HashPayload
+	}
+	addHashFileTaskReturns struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	addHashFileTaskReturnsOnCall map[int]struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	AuthorizeStub        func(context.Context, types.AuthorizationRequest) (types.PrivateToken, error)
+	authorizeMutex       sync.RWMutex
+	authorizeArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.AuthorizationRequest
+	}
+	authorizeReturns struct {
+		result1 types.PrivateToken
+		result2 error
+	}
+	authorizeReturnsOnCall map[int]struct {
+		result1 types.PrivateToken
+		result2 error
+	}
+	CancelUploadTaskStub        func(context.Context, int64) error
+	cancelUploadTaskMutex       sync.RWMutex
+	cancelUploadTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	cancelUploadTaskReturns struct {
+		result1 error
+	}
+	cancelUploadTaskReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CleanUploadTasksStub        func(context.Context) error
+	cleanUploadTasksMutex       sync.RWMutex
+	cleanUploadTasksArgsForCall []struct {
+		arg1 context.Context
+	}
+	cleanUploadTasksReturns struct {
+		result1 error
+	}
+	cleanUploadTasksReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CopyFilesStub        func(context.Context, []string, string, types.FileCopyMode) (types.FileSystemTask, error)
+	copyFilesMutex       sync.RWMutex
+	copyFilesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 string
+		arg4 types.FileCopyMode
+	}
+	copyFilesReturns struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	copyFilesReturnsOnCall map[int]struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	CreateDHCPStaticLeaseStub        func(context.Context, types.DHCPStaticLeasePayload) (types.LanInterfaceHost, error)
+	createDHCPStaticLeaseMutex       sync.RWMutex
+	createDHCPStaticLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.DHCPStaticLeasePayload
+	}
+	createDHCPStaticLeaseReturns struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}
+	createDHCPStaticLeaseReturnsOnCall map[int]struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}
+	CreateDirectoryStub        func(context.Context, string, string) (string, error)
+	createDirectoryMutex       sync.RWMutex
+	createDirectoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	createDirectoryReturns struct {
+		result1 string
+		result2 error
+	}
+	createDirectoryReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CreatePortForwardingRuleStub        func(context.Context, types.PortForwardingRulePayload) (types.PortForwardingRule, error)
+	createPortForwardingRuleMutex       sync.RWMutex
+	createPortForwardingRuleArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.PortForwardingRulePayload
+	}
+	createPortForwardingRuleReturns struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}
+	createPortForwardingRuleReturnsOnCall map[int]struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}
+	CreateVPNUserStub        func(context.Context, types.VPNUserPayload) (types.VPNUser, error)
+	createVPNUserMutex       sync.RWMutex
+	createVPNUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.VPNUserPayload
+	}
+	createVPNUserReturns struct {
+		result1 types.VPNUser
+		result2 error
+	}
+	createVPNUserReturnsOnCall map[int]struct {
+		result1 types.VPNUser
+		result2 error
+	}
+	CreateVirtualDiskStub        func(context.Context, types.VirtualDisksCreatePayload) (int64, error)
+	createVirtualDiskMutex       sync.RWMutex
+	createVirtualDiskArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.VirtualDisksCreatePayload
+	}
+	createVirtualDiskReturns struct {
+		result1 int64
+		result2 error
+	}
+	createVirtualDiskReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	CreateVirtualMachineStub        func(context.Context, types.VirtualMachinePayload) (types.VirtualMachine, error)
+	createVirtualMachineMutex       sync.RWMutex
+	createVirtualMachineArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.VirtualMachinePayload
+	}
+	createVirtualMachineReturns struct {
+		result1 types.VirtualMachine
+		result2 error
+	}
+	createVirtualMachineReturnsOnCall map[int]struct {
+		result1 types.VirtualMachine
+		result2 error
+	}
+	DeleteDHCPStaticLeaseStub        func(context.Context, string) error
+	deleteDHCPStaticLeaseMutex       sync.RWMutex
+	deleteDHCPStaticLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteDHCPStaticLeaseReturns struct {
+		result1 error
+	}
+	deleteDHCPStaticLeaseReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteDownloadTaskStub        func(context.Context, int64) error
+	deleteDownloadTaskMutex       sync.RWMutex
+	deleteDownloadTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	deleteDownloadTaskReturns struct {
+		result1 error
+	}
+	deleteDownloadTaskReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteFileSystemTaskStub        func(context.Context, int64) error
+	deleteFileSystemTaskMutex       sync.RWMutex
+	deleteFileSystemTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	deleteFileSystemTaskReturns struct {
+		result1 error
+	}
+	deleteFileSystemTaskReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeletePortForwardingRuleStub        func(context.Context, int64) error
+	deletePortForwardingRuleMutex       sync.RWMutex
+	deletePortForwardingRuleArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	deletePortForwardingRuleReturns struct {
+		result1 error
+	}
+	deletePortForwardingRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteUploadTaskStub        func(context.Context, int64) error
+	deleteUploadTaskMutex       sync.RWMutex
+	deleteUploadTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	deleteUploadTaskReturns struct {
+		result1 error
+	}
+	deleteUploadTaskReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteVPNUserStub        func(context.Context, string) error
+	deleteVPNUserMutex       sync.RWMutex
+	deleteVPNUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteVPNUserReturns struct {
+		result1 error
+	}
+	deleteVPNUserReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteVirtualDiskTaskStub        func(context.Context, int64) error
+	deleteVirtualDiskTaskMutex       sync.RWMutex
+	deleteVirtualDiskTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	deleteVirtualDiskTaskReturns struct {
+		result1 error
+	}
+	deleteVirtualDiskTaskReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteVirtualMachineStub        func(context.Context, int64) error
+	deleteVirtualMachineMutex       sync.RWMutex
+	deleteVirtualMachineArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	deleteVirtualMachineReturns struct {
+		result1 error
+	}
+	deleteVirtualMachineReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EraseDownloadTaskStub        func(context.Context, int64) error
+	eraseDownloadTaskMutex       sync.RWMutex
+	eraseDownloadTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	eraseDownloadTaskReturns struct {
+		result1 error
+	}
+	eraseDownloadTaskReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ExtractFileStub        func(context.Context, types.ExtractFilePayload) (types.FileSystemTask, error)
+	extractFileMutex       sync.RWMutex
+	extractFileArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.ExtractFilePayload
+	}
+	extractFileReturns struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	extractFileReturnsOnCall map[int]struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	FileUploadStartStub        func(context.Context, types.FileUploadStartActionInput) (io.WriteCloser, int64, error)
+	fileUploadStartMutex       sync.RWMutex
+	fileUploadStartArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.FileUploadStartActionInput
+	}
+	fileUploadStartReturns struct {
+		result1 io.WriteCloser
+		result2 int64
+		result3 error
+	}
+	fileUploadStartReturnsOnCall map[int]struct {
+		result1 io.WriteCloser
+		result2 int64
+		result3 error
+	}
+	GetDHCPStaticLeaseStub        func(context.Context, string) (types.DHCPStaticLeaseInfo, error)
+	getDHCPStaticLeaseMutex       sync.RWMutex
+	getDHCPStaticLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getDHCPStaticLeaseReturns struct {
+		result1 types.DHCPStaticLeaseInfo
+		result2 error
+	}
+	getDHCPStaticLeaseReturnsOnCall map[int]struct {
+		result1 types.DHCPStaticLeaseInfo
+		result2 error
+	}
+	GetDownloadConfigurationStub        func(context.Context) (types.DownloadConfiguration, error)
+	getDownloadConfigurationMutex       sync.RWMutex
+	getDownloadConfigurationArgsForCall []struct {
+		arg1 context.Context
+	}
+	getDownloadConfigurationReturns struct {
+		result1 types.DownloadConfiguration
+		result2 error
+	}
+	getDownloadConfigurationReturnsOnCall map[int]struct {
+		result1 types.DownloadConfiguration
+		result2 error
+	}
+	GetDownloadTaskStub        func(context.Context, int64) (types.DownloadTask, error)
+	getDownloadTaskMutex       sync.RWMutex
+	getDownloadTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	getDownloadTaskReturns struct {
+		result1 types.DownloadTask
+		result2 error
+	}
+	getDownloadTaskReturnsOnCall map[int]struct {
+		result1 types.DownloadTask
+		result2 error
+	}
+	GetFileStub        func(context.Context, string) (types.File, error)
+	getFileMutex       sync.RWMutex
+	getFileArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getFileReturns struct {
+		result1 types.File
+		result2 error
+	}
+	getFileReturnsOnCall map[int]struct {
+		result1 types.File
+		result2 error
+	}
+	GetFileInfoStub        func(context.Context, string) (types.FileInfo, error)
+	getFileInfoMutex       sync.RWMutex
+	getFileInfoArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getFileInfoReturns struct {
+		result1 types.FileInfo
+		result2 error
+	}
+	getFileInfoReturnsOnCall map[int]struct {
+		result1 types.FileInfo
+		result2 error
+	}
+	GetFileSystemTaskStub        func(context.Context, int64) (types.FileSystemTask, error)
+	getFileSystemTaskMutex       sync.RWMutex
+	getFileSystemTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	getFileSystemTaskReturns struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	getFileSystemTaskReturnsOnCall map[int]struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	GetHashResultStub        func(context.Context, int64) (string, error)
+	getHashResultMutex       sync.RWMutex
+	getHashResultArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	getHashResultReturns struct {
+		result1 string
+		result2 error
+	}
+	getHashResultReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetLanConfigStub        func(context.Context) (types.LanConfig, error)
+	getLanConfigMutex       sync.RWMutex
+	getLanConfigArgsForCall []struct {
+		arg1 context.Context
+	}
+	getLanConfigReturns struct {
+		result1 types.LanConfig
+		result2 error
+	}
+	getLanConfigReturnsOnCall map[int]struct {
+		result1 types.LanConfig
+		result2 error
+	}
+	GetLanInterfaceStub        func(context.Context, string) ([]types.LanInterfaceHost, error)
+	getLanInterfaceMutex       sync.RWMutex
+	getLanInterfaceArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getLanInterfaceReturns struct {
+		result1 []types.LanInterfaceHost
+		result2 error
+	}
+	getLanInterfaceReturnsOnCall map[int]struct {
+		result1 []types.LanInterfaceHost
+		result2 error
+	}
+	GetLanInterfaceHostStub        func(context.Context, string, string) (types.LanInterfaceHost, error)
+	getLanInterfaceHostMutex       sync.RWMutex
+	getLanInterfaceHostArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getLanInterfaceHostReturns struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}
+	getLanInterfaceHostReturnsOnCall map[int]struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}
+	GetOpenVPNServerConfigStub        func(context.Context) (types.OpenVPNServerConfig, error)
+	getOpenVPNServerConfigMutex       sync.RWMutex
+	getOpenVPNServerConfigArgsForCall []struct {
+		arg1 context.Context
+	}
+	getOpenVPNServerConfigReturns struct {
+		result1 types.OpenVPNServerConfig
+		result2 error
+	}
+	getOpenVPNServerConfigReturnsOnCall map[int]struct {
+		result1 types.OpenVPNServerConfig
+		result2 error
+	}
+	GetPortForwardingRuleStub        func(context.Context, int64) (types.PortForwardingRule, error)
+	getPortForwardingRuleMutex       sync.RWMutex
+	getPortForwardingRuleArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	getPortForwardingRuleReturns struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}
+	getPortForwardingRuleReturnsOnCall map[int]struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}
+	GetSystemInfoStub        func(context.Context) (types.SystemConfig, error)
+	getSystemInfoMutex       sync.RWMutex
+	getSystemInfoArgsForCall []struct {
+		arg1 context.Context
+	}
+	getSystemInfoReturns struct {
+		result1 types.SystemConfig
+		result2 error
+	}
+	getSystemInfoReturnsOnCall map[int]struct {
+		result1 types.SystemConfig
+		result2 error
+	}
+	GetUploadTaskStub        func(context.Context, int64) (types.UploadTask, error)
+	getUploadTaskMutex       sync.RWMutex
+	getUploadTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	getUploadTaskReturns struct {
+		result1 types.UploadTask
+		result2 error
+	}
+	getUploadTaskReturnsOnCall map[int]struct {
+		result1 types.UploadTask
+		result2 error
+	}
+	GetVPNUserStub        func(context.Context, string) (types.VPNUser, error)
+	getVPNUserMutex       sync.RWMutex
+	getVPNUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getVPNUserReturns struct {
+		result1 types.VPNUser
+		result2 error
+	}
+	getVPNUserReturnsOnCall map[int]struct {
+		result1 types.VPNUser
+		result2 error
+	}
+	GetVPNUserClientConfigStub        func(context.Context, string) (string, error)
+	getVPNUserClientConfigMutex       sync.RWMutex
+	getVPNUserClientConfigArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getVPNUserClientConfigReturns struct {
+		result1 string
+		result2 error
+	}
+	getVPNUserClientConfigReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetVirtualDiskInfoStub        func(context.Context, string) (types.VirtualDiskInfo, error)
+	getVirtualDiskInfoMutex       sync.RWMutex
+	getVirtualDiskInfoArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getVirtualDiskInfoReturns struct {
+		result1 types.VirtualDiskInfo
+		result2 error
+	}
+	getVirtualDiskInfoReturnsOnCall map[int]struct {
+		result1 types.VirtualDiskInfo
+		result2 error
+	}
+	GetVirtualDiskTaskStub        func(context.Context, int64) (types.VirtualMachineDiskTask, error)
+	getVirtualDiskTaskMutex       sync.RWMutex
+	getVirtualDiskTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	getVirtualDiskTaskReturns struct {
+		result1 types.VirtualMachineDiskTask
+		result2 error
+	}
+	getVirtualDiskTaskReturnsOnCall map[int]struct {
+		result1 types.VirtualMachineDiskTask
+		result2 error
+	}
+	GetVirtualMachineStub        func(context.Context, int64) (types.VirtualMachine, error)
+	getVirtualMachineMutex       sync.RWMutex
+	getVirtualMachineArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	getVirtualMachineReturns struct {
+		result1 types.VirtualMachine
+		result2 error
+	}
+	getVirtualMachineReturnsOnCall map[int]struct {
+		result1 types.VirtualMachine
+		result2 error
+	}
+	GetVirtualMachineDistributionsStub        func(context.Context) ([]types.VirtualMachineDistribution, error)
+	getVirtualMachineDistributionsMutex       sync.RWMutex
+	getVirtualMachineDistributionsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getVirtualMachineDistributionsReturns struct {
+		result1 []types.VirtualMachineDistribution
+		result2 error
+	}
+	getVirtualMachineDistributionsReturnsOnCall map[int]struct {
+		result1 []types.VirtualMachineDistribution
+		result2 error
+	}
+	GetVirtualMachineInfoStub        func(context.Context) (types.VirtualMachinesInfo, error)
+	getVirtualMachineInfoMutex       sync.RWMutex
+	getVirtualMachineInfoArgsForCall []struct {
+		arg1 context.Context
+	}
+	getVirtualMachineInfoReturns struct {
+		result1 types.VirtualMachinesInfo
+		result2 error
+	}
+	getVirtualMachineInfoReturnsOnCall map[int]struct {
+		result1 types.VirtualMachinesInfo
+		result2 error
+	}
+	KillVirtualMachineStub        func(context.Context, int64) error
+	killVirtualMachineMutex       sync.RWMutex
+	killVirtualMachineArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	killVirtualMachineReturns struct {
+		result1 error
+	}
+	killVirtualMachineReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ListDHCPStaticLeaseStub        func(context.Context) ([]types.DHCPStaticLeaseInfo, error)
+	listDHCPStaticLeaseMutex       sync.RWMutex
+	listDHCPStaticLeaseArgsForCall []struct {
+		arg1 context.Context
+	}
+	listDHCPStaticLeaseReturns struct {
+		result1 []types.DHCPStaticLeaseInfo
+		result2 error
+	}
+	listDHCPStaticLeaseReturnsOnCall map[int]struct {
+		result1 []types.DHCPStaticLeaseInfo
+		result2 error
+	}
+	ListDownloadTasksStub        func(context.Context) ([]types.DownloadTask, error)
+	listDownloadTasksMutex       sync.RWMutex
+	listDownloadTasksArgsForCall []struct {
+		arg1 context.Context
+	}
+	listDownloadTasksReturns struct {
+		result1 []types.DownloadTask
+		result2 error
+	}
+	listDownloadTasksReturnsOnCall map[int]struct {
+		result1 []types.DownloadTask
+		result2 error
+	}
+	ListFileSystemTasksStub        func(context.Context) ([]types.FileSystemTask, error)
+	listFileSystemTasksMutex       sync.RWMutex
+	listFileSystemTasksArgsForCall []struct {
+		arg1 context.Context
+	}
+	listFileSystemTasksReturns struct {
+		result1 []types.FileSystemTask
+		result2 error
+	}
+	listFileSystemTasksReturnsOnCall map[int]struct {
+		result1 []types.FileSystemTask
+		result2 error
+	}
+	ListLanInterfaceInfoStub        func(context.Context) ([]types.LanInfo, error)
+	listLanInterfaceInfoMutex       sync.RWMutex
+	listLanInterfaceInfoArgsForCall []struct {
+		arg1 context.Context
+	}
+	listLanInterfaceInfoReturns struct {
+		result1 []types.LanInfo
+		result2 error
+	}
+	listLanInterfaceInfoReturnsOnCall map[int]struct {
+		result1 []types.LanInfo
+		result2 error
+	}
+	ListPortForwardingRulesStub        func(context.Context) ([]types.PortForwardingRule, error)
+	listPortForwardingRulesMutex       sync.RWMutex
+	listPortForwardingRulesArgsForCall []struct {
+		arg1 context.Context
+	}
+	listPortForwardingRulesReturns struct {
+		result1 []types.PortForwardingRule
+		result2 error
+	}
+	listPortForwardingRulesReturnsOnCall map[int]struct {
+		result1 []types.PortForwardingRule
+		result2 error
+	}
+	ListUploadTasksStub        func(context.Context) ([]types.UploadTask, error)
+	listUploadTasksMutex       sync.RWMutex
+	listUploadTasksArgsForCall []struct {
+		arg1 context.Context
+	}
+	listUploadTasksReturns struct {
+		result1 []types.UploadTask
+		result2 error
+	}
+	listUploadTasksReturnsOnCall map[int]struct {
+		result1 []types.UploadTask
+		result2 error
+	}
+	ListVPNUsersStub        func(context.Context) ([]types.VPNUser, error)
+	listVPNUsersMutex       sync.RWMutex
+	listVPNUsersArgsForCall []struct {
+		arg1 context.Context
+	}
+	listVPNUsersReturns struct {
+		result1 []types.VPNUser
+		result2 error
+	}
+	listVPNUsersReturnsOnCall map[int]struct {
+		result1 []types.VPNUser
+		result2 error
+	}
+	ListVirtualMachinesStub        func(context.Context) ([]types.VirtualMachine, error)
+	listVirtualMachinesMutex       sync.RWMutex
+	listVirtualMachinesArgsForCall []struct {
+		arg1 context.Context
+	}
+	listVirtualMachinesReturns struct {
+		result1 []types.VirtualMachine
+		result2 error
+	}
+	listVirtualMachinesReturnsOnCall map[int]struct {
+		result1 []types.VirtualMachine
+		result2 error
+	}
+	ListenEventsStub        func(context.Context, []types.EventDescription) (chan types.Event, error)
+	listenEventsMutex       sync.RWMutex
+	listenEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 []types.EventDescription
+	}
+	listenEventsReturns struct {
+		result1 chan types.Event
+		result2 error
+	}
+	listenEventsReturnsOnCall map[int]struct {
+		result1 chan types.Event
+		result2 error
+	}
+	LoginStub        func(context.Context) (types.Permissions, error)
+	loginMutex       sync.RWMutex
+	loginArgsForCall []struct {
+		arg1 context.Context
+	}
+	loginReturns struct {
+		result1 types.Permissions
+		result2 error
+	}
+	loginReturnsOnCall map[int]struct {
+		result1 types.Permissions
+		result2 error
+	}
+	LogoutStub        func(context.Context) error
+	logoutMutex       sync.RWMutex
+	logoutArgsForCall []struct {
+		arg1 context.Context
+	}
+	logoutReturns struct {
+		result1 error
+	}
+	logoutReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MoveFilesStub        func(context.Context, []string, string, types.FileMoveMode) (types.FileSystemTask, error)
+	moveFilesMutex       sync.RWMutex
+	moveFilesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 string
+		arg4 types.FileMoveMode
+	}
+	moveFilesReturns struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	moveFilesReturnsOnCall map[int]struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	RemoveFilesStub        func(context.Context, []string) (types.FileSystemTask, error)
+	removeFilesMutex       sync.RWMutex
+	removeFilesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []string
+	}
+	removeFilesReturns struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	removeFilesReturnsOnCall map[int]struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	ResizeVirtualDiskStub        func(context.Context, types.VirtualDisksResizePayload) (int64, error)
+	resizeVirtualDiskMutex       sync.RWMutex
+	resizeVirtualDiskArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.VirtualDisksResizePayload
+	}
+	resizeVirtualDiskReturns struct {
+		result1 int64
+		result2 error
+	}
+	resizeVirtualDiskReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	StartVirtualMachineStub        func(context.Context, int64) error
+	startVirtualMachineMutex       sync.RWMutex
+	startVirtualMachineArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	startVirtualMachineReturns struct {
+		result1 error
+	}
+	startVirtualMachineReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StopVirtualMachineStub        func(context.Context, int64) error
+	stopVirtualMachineMutex       sync.RWMutex
+	stopVirtualMachineArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	stopVirtualMachineReturns struct {
+		result1 error
+	}
+	stopVirtualMachineReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateDHCPStaticLeaseStub        func(context.Context, string, types.DHCPStaticLeasePayload) (types.LanInterfaceHost, error)
+	updateDHCPStaticLeaseMutex       sync.RWMutex
+	updateDHCPStaticLeaseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 types.DHCPStaticLeasePayload
+	}
+	updateDHCPStaticLeaseReturns struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}
+	updateDHCPStaticLeaseReturnsOnCall map[int]struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}
+	UpdateDownloadConfigurationStub        func(context.Context, types.DownloadConfiguration) (types.DownloadConfiguration, error)
+	updateDownloadConfigurationMutex       sync.RWMutex
+	updateDownloadConfigurationArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.DownloadConfiguration
+	}
+	updateDownloadConfigurationReturns struct {
+		result1 types.DownloadConfiguration
+		result2 error
+	}
+	updateDownloadConfigurationReturnsOnCall map[int]struct {
+		result1 types.DownloadConfiguration
+		result2 error
+	}
+	UpdateDownloadTaskStub        func(context.Context, int64, types.DownloadTaskUpdate) error
+	updateDownloadTaskMutex       sync.RWMutex
+	updateDownloadTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 types.DownloadTaskUpdate
+	}
+	updateDownloadTaskReturns struct {
+		result1 error
+	}
+	updateDownloadTaskReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateFileSystemTaskStub        func(context.Context, int64, types.FileSytemTaskUpdate) (types.FileSystemTask, error)
+	updateFileSystemTaskMutex       sync.RWMutex
+	updateFileSystemTaskArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 types.FileSytemTaskUpdate
+	}
+	updateFileSystemTaskReturns struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	updateFileSystemTaskReturnsOnCall map[int]struct {
+		result1 types.FileSystemTask
+		result2 error
+	}
+	UpdateLanConfigStub        func(context.Context, types.LanConfig) (types.LanConfig, error)
+	updateLanConfigMutex       sync.RWMutex
+	updateLanConfigArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.LanConfig
+	}
+	updateLanConfigReturns struct {
+		result1 types.LanConfig
+		result2 error
+	}
+	updateLanConfigReturnsOnCall map[int]struct {
+		result1 types.LanConfig
+		result2 error
+	}
+	UpdateOpenVPNServerConfigStub        func(context.Context, types.OpenVPNServerConfig) (types.OpenVPNServerConfig, error)
+	updateOpenVPNServerConfigMutex       sync.RWMutex
+	updateOpenVPNServerConfigArgsForCall []struct {
+		arg1 context.Context
+		arg2 types.OpenVPNServerConfig
+	}
+	updateOpenVPNServerConfigReturns struct {
+		result1 types.OpenVPNServerConfig
+		result2 error
+	}
+	updateOpenVPNServerConfigReturnsOnCall map[int]struct {
+		result1 types.OpenVPNServerConfig
+		result2 error
+	}
+	UpdatePortForwardingRuleStub        func(context.Context, int64, types.PortForwardingRulePayload) (types.PortForwardingRule, error)
+	updatePortForwardingRuleMutex       sync.RWMutex
+	updatePortForwardingRuleArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 types.PortForwardingRulePayload
+	}
+	updatePortForwardingRuleReturns struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}
+	updatePortForwardingRuleReturnsOnCall map[int]struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}
+	UpdateVPNUserStub        func(context.Context, string, types.VPNUserPayload) (types.VPNUser, error)
+	updateVPNUserMutex       sync.RWMutex
+	updateVPNUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 types.VPNUserPayload
+	}
+	updateVPNUserReturns struct {
+		result1 types.VPNUser
+		result2 error
+	}
+	updateVPNUserReturnsOnCall map[int]struct {
+		result1 types.VPNUser
+		result2 error
+	}
+	UpdateVirtualMachineStub        func(context.Context, int64, types.VirtualMachinePayload) (types.VirtualMachine, error)
+	updateVirtualMachineMutex       sync.RWMutex
+	updateVirtualMachineArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 types.VirtualMachinePayload
+	}
+	updateVirtualMachineReturns struct {
+		result1 types.VirtualMachine
+		result2 error
+	}
+	updateVirtualMachineReturnsOnCall map[int]struct {
+		result1 types.VirtualMachine
+		result2 error
+	}
+	WithAppIDStub        func(string) client.Client
+	withAppIDMutex       sync.RWMutex
+	withAppIDArgsForCall []struct {
+		arg1 string
+	}
+	withAppIDReturns struct {
+		result1 client.Client
+	}
+	withAppIDReturnsOnCall map[int]struct {
+		result1 client.Client
+	}
+	WithHTTPClientStub        func(client.HTTPClient) client.Client
+	withHTTPClientMutex       sync.RWMutex
+	withHTTPClientArgsForCall []struct {
+		arg1 client.HTTPClient
+	}
+	withHTTPClientReturns struct {
+		result1 client.Client
+	}
+	withHTTPClientReturnsOnCall map[int]struct {
+		result1 client.Client
+	}
+	WithPrivateTokenStub        func(types.PrivateToken) client.Client
+	withPrivateTokenMutex       sync.RWMutex
+	withPrivateTokenArgsForCall []struct {
+		arg1 types.PrivateToken
+	}
+	withPrivateTokenReturns struct {
+		result1 client.Client
+	}
+	withPrivateTokenReturnsOnCall map[int]struct {
+		result1 client.Client
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Client) APIVersion(arg1 context.Context) (types.APIVersion, error) {
+	fake.aPIVersionMutex.Lock()
+	ret, specificReturn := fake.aPIVersionReturnsOnCall[len(fake.aPIVersionArgsForCall)]
+	fake.aPIVersionArgsForCall = append(fake.aPIVersionArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.APIVersionStub
+	fakeReturns := fake.aPIVersionReturns
+	fake.recordInvocation("APIVersion", []interface{}{arg1})
+	fake.aPIVersionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) APIVersionCallCount() int {
+	fake.aPIVersionMutex.RLock()
+	defer fake.aPIVersionMutex.RUnlock()
+	return len(fake.aPIVersionArgsForCall)
+}
+
+func (fake *Client) APIVersionCalls(stub func(context.Context) (types.APIVersion, error)) {
+	fake.aPIVersionMutex.Lock()
+	defer fake.aPIVersionMutex.Unlock()
+	fake.APIVersionStub = stub
+}
+
+func (fake *Client) APIVersionArgsForCall(i int) context.Context {
+	fake.aPIVersionMutex.RLock()
+	defer fake.aPIVersionMutex.RUnlock()
+	argsForCall := fake.aPIVersionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) APIVersionReturns(result1 types.APIVersion, result2 error) {
+	fake.aPIVersionMutex.Lock()
+	defer fake.aPIVersionMutex.Unlock()
+	fake.APIVersionStub = nil
+	fake.aPIVersionReturns = struct {
+		result1 types.APIVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) APIVersionReturnsOnCall(i int, result1 types.APIVersion, result2 error) {
+	fake.aPIVersionMutex.Lock()
+	defer fake.aPIVersionMutex.Unlock()
+	fake.APIVersionStub = nil
+	if fake.aPIVersionReturnsOnCall == nil {
+		fake.aPIVersionReturnsOnCall = make(map[int]struct {
+			result1 types.APIVersion
+			result2 error
+		})
+	}
+	fake.aPIVersionReturnsOnCall[i] = struct {
+		result1 types.APIVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) AddDownloadTask(arg1 context.Context, arg2 types.DownloadRequest) (int64, error) {
+	fake.addDownloadTaskMutex.Lock()
+	ret, specificReturn := fake.addDownloadTaskReturnsOnCall[len(fake.addDownloadTaskArgsForCall)]
+	fake.addDownloadTaskArgsForCall = append(fake.addDownloadTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.DownloadRequest
+	}{arg1, arg2})
+	stub := fake.AddDownloadTaskStub
+	fakeReturns := fake.addDownloadTaskReturns
+	fake.recordInvocation("AddDownloadTask", []interface{}{arg1, arg2})
+	fake.addDownloadTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) AddDownloadTaskCallCount() int {
+	fake.addDownloadTaskMutex.RLock()
+	defer fake.addDownloadTaskMutex.RUnlock()
+	return len(fake.addDownloadTaskArgsForCall)
+}
+
+func (fake *Client) AddDownloadTaskCalls(stub func(context.Context, types.DownloadRequest) (int64, error)) {
+	fake.addDownloadTaskMutex.Lock()
+	defer fake.addDownloadTaskMutex.Unlock()
+	fake.AddDownloadTaskStub = stub
+}
+
+func (fake *Client) AddDownloadTaskArgsForCall(i int) (context.Context, types.DownloadRequest) {
+	fake.addDownloadTaskMutex.RLock()
+	defer fake.addDownloadTaskMutex.RUnlock()
+	argsForCall := fake.addDownloadTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) AddDownloadTaskReturns(result1 int64, result2 error) {
+	fake.addDownloadTaskMutex.Lock()
+	defer fake.addDownloadTaskMutex.Unlock()
+	fake.AddDownloadTaskStub = nil
+	fake.addDownloadTaskReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) AddDownloadTaskReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.addDownloadTaskMutex.Lock()
+	defer fake.addDownloadTaskMutex.Unlock()
+	fake.AddDownloadTaskStub = nil
+	if fake.addDownloadTaskReturnsOnCall == nil {
+		fake.addDownloadTaskReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.addDownloadTaskReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) AddHashFileTask(arg1 context.Context, arg2 types.HashPayload) (types.FileSystemTask, error) {
+	fake.addHashFileTaskMutex.Lock()
+	ret, specificReturn := fake.addHashFileTaskReturnsOnCall[len(fake.addHashFileTaskArgsForCall)]
+	fake.addHashFileTaskArgsForCall = append(fake.addHashFileTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.HashPayload
+	}{arg1, arg2})
+	stub := fake.AddHashFileTaskStub
+	fakeReturns := fake.addHashFileTaskReturns
+	fake.recordInvocation("AddHashFileTask", []interface{}{arg1, arg2})
+	fake.addHashFileTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) AddHashFileTaskCallCount() int {
+	fake.addHashFileTaskMutex.RLock()
+	defer fake.addHashFileTaskMutex.RUnlock()
+	return len(fake.addHashFileTaskArgsForCall)
+}
+
+func (fake *Client) AddHashFileTaskCalls(stub func(context.Context, types.HashPayload) (types.FileSystemTask, error)) {
+	fake.addHashFileTaskMutex.Lock()
+	defer fake.addHashFileTaskMutex.Unlock()
+	fake.AddHashFileTaskStub = stub
+}
+
+func (fake *Client) AddHashFileTaskArgsForCall(i int) (context.Context, types.HashPayload) {
+	fake.addHashFileTaskMutex.RLock()
+	defer fake.addHashFileTaskMutex.RUnlock()
+	argsForCall := fake.addHashFileTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) AddHashFileTaskReturns(result1 types.FileSystemTask, result2 error) {
+	fake.addHashFileTaskMutex.Lock()
+	defer fake.addHashFileTaskMutex.Unlock()
+	fake.AddHashFileTaskStub = nil
+	fake.addHashFileTaskReturns = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) AddHashFileTaskReturnsOnCall(i int, result1 types.FileSystemTask, result2 error) {
+	fake.addHashFileTaskMutex.Lock()
+	defer fake.addHashFileTaskMutex.Unlock()
+	fake.AddHashFileTaskStub = nil
+	if fake.addHashFileTaskReturnsOnCall == nil {
+		fake.addHashFileTaskReturnsOnCall = make(map[int]struct {
+			result1 types.FileSystemTask
+			result2 error
+		})
+	}
+	fake.addHashFileTaskReturnsOnCall[i] = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) Authorize(arg1 context.Context, arg2 types.AuthorizationRequest) (types.PrivateToken, error) {
+	fake.authorizeMutex.Lock()
+	ret, specificReturn := fake.authorizeReturnsOnCall[len(fake.authorizeArgsForCall)]
+	fake.authorizeArgsForCall = append(fake.authorizeArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.AuthorizationRequest
+	}{arg1, arg2})
+	stub := fake.AuthorizeStub
+	fakeReturns := fake.authorizeReturns
+	fake.recordInvocation("Authorize", []interface{}{arg1, arg2})
+	fake.authorizeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) AuthorizeCallCount() int {
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	return len(fake.authorizeArgsForCall)
+}
+
+func (fake *Client) AuthorizeCalls(stub func(context.Context, types.AuthorizationRequest) (types.PrivateToken, error)) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = stub
+}
+
+func (fake *Client) AuthorizeArgsForCall(i int) (context.Context, types.AuthorizationRequest) {
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	argsForCall := fake.authorizeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) AuthorizeReturns(result1 types.PrivateToken, result2 error) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = nil
+	fake.authorizeReturns = struct {
+		result1 types.PrivateToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) AuthorizeReturnsOnCall(i int, result1 types.PrivateToken, result2 error) {
+	fake.authorizeMutex.Lock()
+	defer fake.authorizeMutex.Unlock()
+	fake.AuthorizeStub = nil
+	if fake.authorizeReturnsOnCall == nil {
+		fake.authorizeReturnsOnCall = make(map[int]struct {
+			result1 types.PrivateToken
+			result2 error
+		})
+	}
+	fake.authorizeReturnsOnCall[i] = struct {
+		result1 types.PrivateToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CancelUploadTask(arg1 context.Context, arg2 int64) error {
+	fake.cancelUploadTaskMutex.Lock()
+	ret, specificReturn := fake.cancelUploadTaskReturnsOnCall[len(fake.cancelUploadTaskArgsForCall)]
+	fake.cancelUploadTaskArgsForCall = append(fake.cancelUploadTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.CancelUploadTaskStub
+	fakeReturns := fake.cancelUploadTaskReturns
+	fake.recordInvocation("CancelUploadTask", []interface{}{arg1, arg2})
+	fake.cancelUploadTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) CancelUploadTaskCallCount() int {
+	fake.cancelUploadTaskMutex.RLock()
+	defer fake.cancelUploadTaskMutex.RUnlock()
+	return len(fake.cancelUploadTaskArgsForCall)
+}
+
+func (fake *Client) CancelUploadTaskCalls(stub func(context.Context, int64) error) {
+	fake.cancelUploadTaskMutex.Lock()
+	defer fake.cancelUploadTaskMutex.Unlock()
+	fake.CancelUploadTaskStub = stub
+}
+
+func (fake *Client) CancelUploadTaskArgsForCall(i int) (context.Context, int64) {
+	fake.cancelUploadTaskMutex.RLock()
+	defer fake.cancelUploadTaskMutex.RUnlock()
+	argsForCall := fake.cancelUploadTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) CancelUploadTaskReturns(result1 error) {
+	fake.cancelUploadTaskMutex.Lock()
+	defer fake.cancelUploadTaskMutex.Unlock()
+	fake.CancelUploadTaskStub = nil
+	fake.cancelUploadTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) CancelUploadTaskReturnsOnCall(i int, result1 error) {
+	fake.cancelUploadTaskMutex.Lock()
+	defer fake.cancelUploadTaskMutex.Unlock()
+	fake.CancelUploadTaskStub = nil
+	if fake.cancelUploadTaskReturnsOnCall == nil {
+		fake.cancelUploadTaskReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cancelUploadTaskReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) CleanUploadTasks(arg1 context.Context) error {
+	fake.cleanUploadTasksMutex.Lock()
+	ret, specificReturn := fake.cleanUploadTasksReturnsOnCall[len(fake.cleanUploadTasksArgsForCall)]
+	fake.cleanUploadTasksArgsForCall = append(fake.cleanUploadTasksArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.CleanUploadTasksStub
+	fakeReturns := fake.cleanUploadTasksReturns
+	fake.recordInvocation("CleanUploadTasks", []interface{}{arg1})
+	fake.cleanUploadTasksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) CleanUploadTasksCallCount() int {
+	fake.cleanUploadTasksMutex.RLock()
+	defer fake.cleanUploadTasksMutex.RUnlock()
+	return len(fake.cleanUploadTasksArgsForCall)
+}
+
+func (fake *Client) CleanUploadTasksCalls(stub func(context.Context) error) {
+	fake.cleanUploadTasksMutex.Lock()
+	defer fake.cleanUploadTasksMutex.Unlock()
+	fake.CleanUploadTasksStub = stub
+}
+
+func (fake *Client) CleanUploadTasksArgsForCall(i int) context.Context {
+	fake.cleanUploadTasksMutex.RLock()
+	defer fake.cleanUploadTasksMutex.RUnlock()
+	argsForCall := fake.cleanUploadTasksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) CleanUploadTasksReturns(result1 error) {
+	fake.cleanUploadTasksMutex.Lock()
+	defer fake.cleanUploadTasksMutex.Unlock()
+	fake.CleanUploadTasksStub = nil
+	fake.cleanUploadTasksReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) CleanUploadTasksReturnsOnCall(i int, result1 error) {
+	fake.cleanUploadTasksMutex.Lock()
+	defer fake.cleanUploadTasksMutex.Unlock()
+	fake.CleanUploadTasksStub = nil
+	if fake.cleanUploadTasksReturnsOnCall == nil {
+		fake.cleanUploadTasksReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cleanUploadTasksReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) CopyFiles(arg1 context.Context, arg2 []string, arg3 string, arg4 types.FileCopyMode) (types.FileSystemTask, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.copyFilesMutex.Lock()
+	ret, specificReturn := fake.copyFilesReturnsOnCall[len(fake.copyFilesArgsForCall)]
+	fake.copyFilesArgsForCall = append(fake.copyFilesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 string
+		arg4 types.FileCopyMode
+	}{arg1, arg2Copy, arg3, arg4})
+	stub := fake.CopyFilesStub
+	fakeReturns := fake.copyFilesReturns
+	fake.recordInvocation("CopyFiles", []interface{}{arg1, arg2Copy, arg3, arg4})
+	fake.copyFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) CopyFilesCallCount() int {
+	fake.copyFilesMutex.RLock()
+	defer fake.copyFilesMutex.RUnlock()
+	return len(fake.copyFilesArgsForCall)
+}
+
+func (fake *Client) CopyFilesCalls(stub func(context.Context, []string, string, types.FileCopyMode) (types.FileSystemTask, error)) {
+	fake.copyFilesMutex.Lock()
+	defer fake.copyFilesMutex.Unlock()
+	fake.CopyFilesStub = stub
+}
+
+func (fake *Client) CopyFilesArgsForCall(i int) (context.Context, []string, string, types.FileCopyMode) {
+	fake.copyFilesMutex.RLock()
+	defer fake.copyFilesMutex.RUnlock()
+	argsForCall := fake.copyFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Client) CopyFilesReturns(result1 types.FileSystemTask, result2 error) {
+	fake.copyFilesMutex.Lock()
+	defer fake.copyFilesMutex.Unlock()
+	fake.CopyFilesStub = nil
+	fake.copyFilesReturns = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CopyFilesReturnsOnCall(i int, result1 types.FileSystemTask, result2 error) {
+	fake.copyFilesMutex.Lock()
+	defer fake.copyFilesMutex.Unlock()
+	fake.CopyFilesStub = nil
+	if fake.copyFilesReturnsOnCall == nil {
+		fake.copyFilesReturnsOnCall = make(map[int]struct {
+			result1 types.FileSystemTask
+			result2 error
+		})
+	}
+	fake.copyFilesReturnsOnCall[i] = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreateDHCPStaticLease(arg1 context.Context, arg2 types.DHCPStaticLeasePayload) (types.LanInterfaceHost, error) {
+	fake.createDHCPStaticLeaseMutex.Lock()
+	ret, specificReturn := fake.createDHCPStaticLeaseReturnsOnCall[len(fake.createDHCPStaticLeaseArgsForCall)]
+	fake.createDHCPStaticLeaseArgsForCall = append(fake.createDHCPStaticLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.DHCPStaticLeasePayload
+	}{arg1, arg2})
+	stub := fake.CreateDHCPStaticLeaseStub
+	fakeReturns := fake.createDHCPStaticLeaseReturns
+	fake.recordInvocation("CreateDHCPStaticLease", []interface{}{arg1, arg2})
+	fake.createDHCPStaticLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) CreateDHCPStaticLeaseCallCount() int {
+	fake.createDHCPStaticLeaseMutex.RLock()
+	defer fake.createDHCPStaticLeaseMutex.RUnlock()
+	return len(fake.createDHCPStaticLeaseArgsForCall)
+}
+
+func (fake *Client) CreateDHCPStaticLeaseCalls(stub func(context.Context, types.DHCPStaticLeasePayload) (types.LanInterfaceHost, error)) {
+	fake.createDHCPStaticLeaseMutex.Lock()
+	defer fake.createDHCPStaticLeaseMutex.Unlock()
+	fake.CreateDHCPStaticLeaseStub = stub
+}
+
+func (fake *Client) CreateDHCPStaticLeaseArgsForCall(i int) (context.Context, types.DHCPStaticLeasePayload) {
+	fake.createDHCPStaticLeaseMutex.RLock()
+	defer fake.createDHCPStaticLeaseMutex.RUnlock()
+	argsForCall := fake.createDHCPStaticLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) CreateDHCPStaticLeaseReturns(result1 types.LanInterfaceHost, result2 error) {
+	fake.createDHCPStaticLeaseMutex.Lock()
+	defer fake.createDHCPStaticLeaseMutex.Unlock()
+	fake.CreateDHCPStaticLeaseStub = nil
+	fake.createDHCPStaticLeaseReturns = struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreateDHCPStaticLeaseReturnsOnCall(i int, result1 types.LanInterfaceHost, result2 error) {
+	fake.createDHCPStaticLeaseMutex.Lock()
+	defer fake.createDHCPStaticLeaseMutex.Unlock()
+	fake.CreateDHCPStaticLeaseStub = nil
+	if fake.createDHCPStaticLeaseReturnsOnCall == nil {
+		fake.createDHCPStaticLeaseReturnsOnCall = make(map[int]struct {
+			result1 types.LanInterfaceHost
+			result2 error
+		})
+	}
+	fake.createDHCPStaticLeaseReturnsOnCall[i] = struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreateDirectory(arg1 context.Context, arg2 string, arg3 string) (string, error) {
+	fake.createDirectoryMutex.Lock()
+	ret, specificReturn := fake.createDirectoryReturnsOnCall[len(fake.createDirectoryArgsForCall)]
+	fake.createDirectoryArgsForCall = append(fake.createDirectoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CreateDirectoryStub
+	fakeReturns := fake.createDirectoryReturns
+	fake.recordInvocation("CreateDirectory", []interface{}{arg1, arg2, arg3})
+	fake.createDirectoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) CreateDirectoryCallCount() int {
+	fake.createDirectoryMutex.RLock()
+	defer fake.createDirectoryMutex.RUnlock()
+	return len(fake.createDirectoryArgsForCall)
+}
+
+func (fake *Client) CreateDirectoryCalls(stub func(context.Context, string, string) (string, error)) {
+	fake.createDirectoryMutex.Lock()
+	defer fake.createDirectoryMutex.Unlock()
+	fake.CreateDirectoryStub = stub
+}
+
+func (fake *Client) CreateDirectoryArgsForCall(i int) (context.Context, string, string) {
+	fake.createDirectoryMutex.RLock()
+	defer fake.createDirectoryMutex.RUnlock()
+	argsForCall := fake.createDirectoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Client) CreateDirectoryReturns(result1 string, result2 error) {
+	fake.createDirectoryMutex.Lock()
+	defer fake.createDirectoryMutex.Unlock()
+	fake.CreateDirectoryStub = nil
+	fake.createDirectoryReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreateDirectoryReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createDirectoryMutex.Lock()
+	defer fake.createDirectoryMutex.Unlock()
+	fake.CreateDirectoryStub = nil
+	if fake.createDirectoryReturnsOnCall == nil {
+		fake.createDirectoryReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.createDirectoryReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreatePortForwardingRule(arg1 context.Context, arg2 types.PortForwardingRulePayload) (types.PortForwardingRule, error) {
+	fake.createPortForwardingRuleMutex.Lock()
+	ret, specificReturn := fake.createPortForwardingRuleReturnsOnCall[len(fake.createPortForwardingRuleArgsForCall)]
+	fake.createPortForwardingRuleArgsForCall = append(fake.createPortForwardingRuleArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.PortForwardingRulePayload
+	}{arg1, arg2})
+	stub := fake.CreatePortForwardingRuleStub
+	fakeReturns := fake.createPortForwardingRuleReturns
+	fake.recordInvocation("CreatePortForwardingRule", []interface{}{arg1, arg2})
+	fake.createPortForwardingRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) CreatePortForwardingRuleCallCount() int {
+	fake.createPortForwardingRuleMutex.RLock()
+	defer fake.createPortForwardingRuleMutex.RUnlock()
+	return len(fake.createPortForwardingRuleArgsForCall)
+}
+
+func (fake *Client) CreatePortForwardingRuleCalls(stub func(context.Context, types.PortForwardingRulePayload) (types.PortForwardingRule, error)) {
+	fake.createPortForwardingRuleMutex.Lock()
+	defer fake.createPortForwardingRuleMutex.Unlock()
+	fake.CreatePortForwardingRuleStub = stub
+}
+
+func (fake *Client) CreatePortForwardingRuleArgsForCall(i int) (context.Context, types.PortForwardingRulePayload) {
+	fake.createPortForwardingRuleMutex.RLock()
+	defer fake.createPortForwardingRuleMutex.RUnlock()
+	argsForCall := fake.createPortForwardingRuleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) CreatePortForwardingRuleReturns(result1 types.PortForwardingRule, result2 error) {
+	fake.createPortForwardingRuleMutex.Lock()
+	defer fake.createPortForwardingRuleMutex.Unlock()
+	fake.CreatePortForwardingRuleStub = nil
+	fake.createPortForwardingRuleReturns = struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreatePortForwardingRuleReturnsOnCall(i int, result1 types.PortForwardingRule, result2 error) {
+	fake.createPortForwardingRuleMutex.Lock()
+	defer fake.createPortForwardingRuleMutex.Unlock()
+	fake.CreatePortForwardingRuleStub = nil
+	if fake.createPortForwardingRuleReturnsOnCall == nil {
+		fake.createPortForwardingRuleReturnsOnCall = make(map[int]struct {
+			result1 types.PortForwardingRule
+			result2 error
+		})
+	}
+	fake.createPortForwardingRuleReturnsOnCall[i] = struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreateVPNUser(arg1 context.Context, arg2 types.VPNUserPayload) (types.VPNUser, error) {
+	fake.createVPNUserMutex.Lock()
+	ret, specificReturn := fake.createVPNUserReturnsOnCall[len(fake.createVPNUserArgsForCall)]
+	fake.createVPNUserArgsForCall = append(fake.createVPNUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.VPNUserPayload
+	}{arg1, arg2})
+	stub := fake.CreateVPNUserStub
+	fakeReturns := fake.createVPNUserReturns
+	fake.recordInvocation("CreateVPNUser", []interface{}{arg1, arg2})
+	fake.createVPNUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) CreateVPNUserCallCount() int {
+	fake.createVPNUserMutex.RLock()
+	defer fake.createVPNUserMutex.RUnlock()
+	return len(fake.createVPNUserArgsForCall)
+}
+
+func (fake *Client) CreateVPNUserCalls(stub func(context.Context, types.VPNUserPayload) (types.VPNUser, error)) {
+	fake.createVPNUserMutex.Lock()
+	defer fake.createVPNUserMutex.Unlock()
+	fake.CreateVPNUserStub = stub
+}
+
+func (fake *Client) CreateVPNUserArgsForCall(i int) (context.Context, types.VPNUserPayload) {
+	fake.createVPNUserMutex.RLock()
+	defer fake.createVPNUserMutex.RUnlock()
+	argsForCall := fake.createVPNUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) CreateVPNUserReturns(result1 types.VPNUser, result2 error) {
+	fake.createVPNUserMutex.Lock()
+	defer fake.createVPNUserMutex.Unlock()
+	fake.CreateVPNUserStub = nil
+	fake.createVPNUserReturns = struct {
+		result1 types.VPNUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreateVPNUserReturnsOnCall(i int, result1 types.VPNUser, result2 error) {
+	fake.createVPNUserMutex.Lock()
+	defer fake.createVPNUserMutex.Unlock()
+	fake.CreateVPNUserStub = nil
+	if fake.createVPNUserReturnsOnCall == nil {
+		fake.createVPNUserReturnsOnCall = make(map[int]struct {
+			result1 types.VPNUser
+			result2 error
+		})
+	}
+	fake.createVPNUserReturnsOnCall[i] = struct {
+		result1 types.VPNUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreateVirtualDisk(arg1 context.Context, arg2 types.VirtualDisksCreatePayload) (int64, error) {
+	fake.createVirtualDiskMutex.Lock()
+	ret, specificReturn := fake.createVirtualDiskReturnsOnCall[len(fake.createVirtualDiskArgsForCall)]
+	fake.createVirtualDiskArgsForCall = append(fake.createVirtualDiskArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.VirtualDisksCreatePayload
+	}{arg1, arg2})
+	stub := fake.CreateVirtualDiskStub
+	fakeReturns := fake.createVirtualDiskReturns
+	fake.recordInvocation("CreateVirtualDisk", []interface{}{arg1, arg2})
+	fake.createVirtualDiskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) CreateVirtualDiskCallCount() int {
+	fake.createVirtualDiskMutex.RLock()
+	defer fake.createVirtualDiskMutex.RUnlock()
+	return len(fake.createVirtualDiskArgsForCall)
+}
+
+func (fake *Client) CreateVirtualDiskCalls(stub func(context.Context, types.VirtualDisksCreatePayload) (int64, error)) {
+	fake.createVirtualDiskMutex.Lock()
+	defer fake.createVirtualDiskMutex.Unlock()
+	fake.CreateVirtualDiskStub = stub
+}
+
+func (fake *Client) CreateVirtualDiskArgsForCall(i int) (context.Context, types.VirtualDisksCreatePayload) {
+	fake.createVirtualDiskMutex.RLock()
+	defer fake.createVirtualDiskMutex.RUnlock()
+	argsForCall := fake.createVirtualDiskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) CreateVirtualDiskReturns(result1 int64, result2 error) {
+	fake.createVirtualDiskMutex.Lock()
+	defer fake.createVirtualDiskMutex.Unlock()
+	fake.CreateVirtualDiskStub = nil
+	fake.createVirtualDiskReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreateVirtualDiskReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.createVirtualDiskMutex.Lock()
+	defer fake.createVirtualDiskMutex.Unlock()
+	fake.CreateVirtualDiskStub = nil
+	if fake.createVirtualDiskReturnsOnCall == nil {
+		fake.createVirtualDiskReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.createVirtualDiskReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreateVirtualMachine(arg1 context.Context, arg2 types.VirtualMachinePayload) (types.VirtualMachine, error) {
+	fake.createVirtualMachineMutex.Lock()
+	ret, specificReturn := fake.createVirtualMachineReturnsOnCall[len(fake.createVirtualMachineArgsForCall)]
+	fake.createVirtualMachineArgsForCall = append(fake.createVirtualMachineArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.VirtualMachinePayload
+	}{arg1, arg2})
+	stub := fake.CreateVirtualMachineStub
+	fakeReturns := fake.createVirtualMachineReturns
+	fake.recordInvocation("CreateVirtualMachine", []interface{}{arg1, arg2})
+	fake.createVirtualMachineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) CreateVirtualMachineCallCount() int {
+	fake.createVirtualMachineMutex.RLock()
+	defer fake.createVirtualMachineMutex.RUnlock()
+	return len(fake.createVirtualMachineArgsForCall)
+}
+
+func (fake *Client) CreateVirtualMachineCalls(stub func(context.Context, types.VirtualMachinePayload) (types.VirtualMachine, error)) {
+	fake.createVirtualMachineMutex.Lock()
+	defer fake.createVirtualMachineMutex.Unlock()
+	fake.CreateVirtualMachineStub = stub
+}
+
+func (fake *Client) CreateVirtualMachineArgsForCall(i int) (context.Context, types.VirtualMachinePayload) {
+	fake.createVirtualMachineMutex.RLock()
+	defer fake.createVirtualMachineMutex.RUnlock()
+	argsForCall := fake.createVirtualMachineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) CreateVirtualMachineReturns(result1 types.VirtualMachine, result2 error) {
+	fake.createVirtualMachineMutex.Lock()
+	defer fake.createVirtualMachineMutex.Unlock()
+	fake.CreateVirtualMachineStub = nil
+	fake.createVirtualMachineReturns = struct {
+		result1 types.VirtualMachine
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) CreateVirtualMachineReturnsOnCall(i int, result1 types.VirtualMachine, result2 error) {
+	fake.createVirtualMachineMutex.Lock()
+	defer fake.createVirtualMachineMutex.Unlock()
+	fake.CreateVirtualMachineStub = nil
+	if fake.createVirtualMachineReturnsOnCall == nil {
+		fake.createVirtualMachineReturnsOnCall = make(map[int]struct {
+			result1 types.VirtualMachine
+			result2 error
+		})
+	}
+	fake.createVirtualMachineReturnsOnCall[i] = struct {
+		result1 types.VirtualMachine
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) DeleteDHCPStaticLease(arg1 context.Context, arg2 string) error {
+	fake.deleteDHCPStaticLeaseMutex.Lock()
+	ret, specificReturn := fake.deleteDHCPStaticLeaseReturnsOnCall[len(fake.deleteDHCPStaticLeaseArgsForCall)]
+	fake.deleteDHCPStaticLeaseArgsForCall = append(fake.deleteDHCPStaticLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteDHCPStaticLeaseStub
+	fakeReturns := fake.deleteDHCPStaticLeaseReturns
+	fake.recordInvocation("DeleteDHCPStaticLease", []interface{}{arg1, arg2})
+	fake.deleteDHCPStaticLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) DeleteDHCPStaticLeaseCallCount() int {
+	fake.deleteDHCPStaticLeaseMutex.RLock()
+	defer fake.deleteDHCPStaticLeaseMutex.RUnlock()
+	return len(fake.deleteDHCPStaticLeaseArgsForCall)
+}
+
+func (fake *Client) DeleteDHCPStaticLeaseCalls(stub func(context.Context, string) error) {
+	fake.deleteDHCPStaticLeaseMutex.Lock()
+	defer fake.deleteDHCPStaticLeaseMutex.Unlock()
+	fake.DeleteDHCPStaticLeaseStub = stub
+}
+
+func (fake *Client) DeleteDHCPStaticLeaseArgsForCall(i int) (context.Context, string) {
+	fake.deleteDHCPStaticLeaseMutex.RLock()
+	defer fake.deleteDHCPStaticLeaseMutex.RUnlock()
+	argsForCall := fake.deleteDHCPStaticLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) DeleteDHCPStaticLeaseReturns(result1 error) {
+	fake.deleteDHCPStaticLeaseMutex.Lock()
+	defer fake.deleteDHCPStaticLeaseMutex.Unlock()
+	fake.DeleteDHCPStaticLeaseStub = nil
+	fake.deleteDHCPStaticLeaseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteDHCPStaticLeaseReturnsOnCall(i int, result1 error) {
+	fake.deleteDHCPStaticLeaseMutex.Lock()
+	defer fake.deleteDHCPStaticLeaseMutex.Unlock()
+	fake.DeleteDHCPStaticLeaseStub = nil
+	if fake.deleteDHCPStaticLeaseReturnsOnCall == nil {
+		fake.deleteDHCPStaticLeaseReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteDHCPStaticLeaseReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteDownloadTask(arg1 context.Context, arg2 int64) error {
+	fake.deleteDownloadTaskMutex.Lock()
+	ret, specificReturn := fake.deleteDownloadTaskReturnsOnCall[len(fake.deleteDownloadTaskArgsForCall)]
+	fake.deleteDownloadTaskArgsForCall = append(fake.deleteDownloadTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeleteDownloadTaskStub
+	fakeReturns := fake.deleteDownloadTaskReturns
+	fake.recordInvocation("DeleteDownloadTask", []interface{}{arg1, arg2})
+	fake.deleteDownloadTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) DeleteDownloadTaskCallCount() int {
+	fake.deleteDownloadTaskMutex.RLock()
+	defer fake.deleteDownloadTaskMutex.RUnlock()
+	return len(fake.deleteDownloadTaskArgsForCall)
+}
+
+func (fake *Client) DeleteDownloadTaskCalls(stub func(context.Context, int64) error) {
+	fake.deleteDownloadTaskMutex.Lock()
+	defer fake.deleteDownloadTaskMutex.Unlock()
+	fake.DeleteDownloadTaskStub = stub
+}
+
+func (fake *Client) DeleteDownloadTaskArgsForCall(i int) (context.Context, int64) {
+	fake.deleteDownloadTaskMutex.RLock()
+	defer fake.deleteDownloadTaskMutex.RUnlock()
+	argsForCall := fake.deleteDownloadTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) DeleteDownloadTaskReturns(result1 error) {
+	fake.deleteDownloadTaskMutex.Lock()
+	defer fake.deleteDownloadTaskMutex.Unlock()
+	fake.DeleteDownloadTaskStub = nil
+	fake.deleteDownloadTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteDownloadTaskReturnsOnCall(i int, result1 error) {
+	fake.deleteDownloadTaskMutex.Lock()
+	defer fake.deleteDownloadTaskMutex.Unlock()
+	fake.DeleteDownloadTaskStub = nil
+	if fake.deleteDownloadTaskReturnsOnCall == nil {
+		fake.deleteDownloadTaskReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteDownloadTaskReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteFileSystemTask(arg1 context.Context, arg2 int64) error {
+	fake.deleteFileSystemTaskMutex.Lock()
+	ret, specificReturn := fake.deleteFileSystemTaskReturnsOnCall[len(fake.deleteFileSystemTaskArgsForCall)]
+	fake.deleteFileSystemTaskArgsForCall = append(fake.deleteFileSystemTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeleteFileSystemTaskStub
+	fakeReturns := fake.deleteFileSystemTaskReturns
+	fake.recordInvocation("DeleteFileSystemTask", []interface{}{arg1, arg2})
+	fake.deleteFileSystemTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) DeleteFileSystemTaskCallCount() int {
+	fake.deleteFileSystemTaskMutex.RLock()
+	defer fake.deleteFileSystemTaskMutex.RUnlock()
+	return len(fake.deleteFileSystemTaskArgsForCall)
+}
+
+func (fake *Client) DeleteFileSystemTaskCalls(stub func(context.Context, int64) error) {
+	fake.deleteFileSystemTaskMutex.Lock()
+	defer fake.deleteFileSystemTaskMutex.Unlock()
+	fake.DeleteFileSystemTaskStub = stub
+}
+
+func (fake *Client) DeleteFileSystemTaskArgsForCall(i int) (context.Context, int64) {
+	fake.deleteFileSystemTaskMutex.RLock()
+	defer fake.deleteFileSystemTaskMutex.RUnlock()
+	argsForCall := fake.deleteFileSystemTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) DeleteFileSystemTaskReturns(result1 error) {
+	fake.deleteFileSystemTaskMutex.Lock()
+	defer fake.deleteFileSystemTaskMutex.Unlock()
+	fake.DeleteFileSystemTaskStub = nil
+	fake.deleteFileSystemTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteFileSystemTaskReturnsOnCall(i int, result1 error) {
+	fake.deleteFileSystemTaskMutex.Lock()
+	defer fake.deleteFileSystemTaskMutex.Unlock()
+	fake.DeleteFileSystemTaskStub = nil
+	if fake.deleteFileSystemTaskReturnsOnCall == nil {
+		fake.deleteFileSystemTaskReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteFileSystemTaskReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeletePortForwardingRule(arg1 context.Context, arg2 int64) error {
+	fake.deletePortForwardingRuleMutex.Lock()
+	ret, specificReturn := fake.deletePortForwardingRuleReturnsOnCall[len(fake.deletePortForwardingRuleArgsForCall)]
+	fake.deletePortForwardingRuleArgsForCall = append(fake.deletePortForwardingRuleArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeletePortForwardingRuleStub
+	fakeReturns := fake.deletePortForwardingRuleReturns
+	fake.recordInvocation("DeletePortForwardingRule", []interface{}{arg1, arg2})
+	fake.deletePortForwardingRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) DeletePortForwardingRuleCallCount() int {
+	fake.deletePortForwardingRuleMutex.RLock()
+	defer fake.deletePortForwardingRuleMutex.RUnlock()
+	return len(fake.deletePortForwardingRuleArgsForCall)
+}
+
+func (fake *Client) DeletePortForwardingRuleCalls(stub func(context.Context, int64) error) {
+	fake.deletePortForwardingRuleMutex.Lock()
+	defer fake.deletePortForwardingRuleMutex.Unlock()
+	fake.DeletePortForwardingRuleStub = stub
+}
+
+func (fake *Client) DeletePortForwardingRuleArgsForCall(i int) (context.Context, int64) {
+	fake.deletePortForwardingRuleMutex.RLock()
+	defer fake.deletePortForwardingRuleMutex.RUnlock()
+	argsForCall := fake.deletePortForwardingRuleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) DeletePortForwardingRuleReturns(result1 error) {
+	fake.deletePortForwardingRuleMutex.Lock()
+	defer fake.deletePortForwardingRuleMutex.Unlock()
+	fake.DeletePortForwardingRuleStub = nil
+	fake.deletePortForwardingRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeletePortForwardingRuleReturnsOnCall(i int, result1 error) {
+	fake.deletePortForwardingRuleMutex.Lock()
+	defer fake.deletePortForwardingRuleMutex.Unlock()
+	fake.DeletePortForwardingRuleStub = nil
+	if fake.deletePortForwardingRuleReturnsOnCall == nil {
+		fake.deletePortForwardingRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePortForwardingRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteUploadTask(arg1 context.Context, arg2 int64) error {
+	fake.deleteUploadTaskMutex.Lock()
+	ret, specificReturn := fake.deleteUploadTaskReturnsOnCall[len(fake.deleteUploadTaskArgsForCall)]
+	fake.deleteUploadTaskArgsForCall = append(fake.deleteUploadTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeleteUploadTaskStub
+	fakeReturns := fake.deleteUploadTaskReturns
+	fake.recordInvocation("DeleteUploadTask", []interface{}{arg1, arg2})
+	fake.deleteUploadTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) DeleteUploadTaskCallCount() int {
+	fake.deleteUploadTaskMutex.RLock()
+	defer fake.deleteUploadTaskMutex.RUnlock()
+	return len(fake.deleteUploadTaskArgsForCall)
+}
+
+func (fake *Client) DeleteUploadTaskCalls(stub func(context.Context, int64) error) {
+	fake.deleteUploadTaskMutex.Lock()
+	defer fake.deleteUploadTaskMutex.Unlock()
+	fake.DeleteUploadTaskStub = stub
+}
+
+func (fake *Client) DeleteUploadTaskArgsForCall(i int) (context.Context, int64) {
+	fake.deleteUploadTaskMutex.RLock()
+	defer fake.deleteUploadTaskMutex.RUnlock()
+	argsForCall := fake.deleteUploadTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) DeleteUploadTaskReturns(result1 error) {
+	fake.deleteUploadTaskMutex.Lock()
+	defer fake.deleteUploadTaskMutex.Unlock()
+	fake.DeleteUploadTaskStub = nil
+	fake.deleteUploadTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteUploadTaskReturnsOnCall(i int, result1 error) {
+	fake.deleteUploadTaskMutex.Lock()
+	defer fake.deleteUploadTaskMutex.Unlock()
+	fake.DeleteUploadTaskStub = nil
+	if fake.deleteUploadTaskReturnsOnCall == nil {
+		fake.deleteUploadTaskReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteUploadTaskReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteVPNUser(arg1 context.Context, arg2 string) error {
+	fake.deleteVPNUserMutex.Lock()
+	ret, specificReturn := fake.deleteVPNUserReturnsOnCall[len(fake.deleteVPNUserArgsForCall)]
+	fake.deleteVPNUserArgsForCall = append(fake.deleteVPNUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteVPNUserStub
+	fakeReturns := fake.deleteVPNUserReturns
+	fake.recordInvocation("DeleteVPNUser", []interface{}{arg1, arg2})
+	fake.deleteVPNUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) DeleteVPNUserCallCount() int {
+	fake.deleteVPNUserMutex.RLock()
+	defer fake.deleteVPNUserMutex.RUnlock()
+	return len(fake.deleteVPNUserArgsForCall)
+}
+
+func (fake *Client) DeleteVPNUserCalls(stub func(context.Context, string) error) {
+	fake.deleteVPNUserMutex.Lock()
+	defer fake.deleteVPNUserMutex.Unlock()
+	fake.DeleteVPNUserStub = stub
+}
+
+func (fake *Client) DeleteVPNUserArgsForCall(i int) (context.Context, string) {
+	fake.deleteVPNUserMutex.RLock()
+	defer fake.deleteVPNUserMutex.RUnlock()
+	argsForCall := fake.deleteVPNUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) DeleteVPNUserReturns(result1 error) {
+	fake.deleteVPNUserMutex.Lock()
+	defer fake.deleteVPNUserMutex.Unlock()
+	fake.DeleteVPNUserStub = nil
+	fake.deleteVPNUserReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteVPNUserReturnsOnCall(i int, result1 error) {
+	fake.deleteVPNUserMutex.Lock()
+	defer fake.deleteVPNUserMutex.Unlock()
+	fake.DeleteVPNUserStub = nil
+	if fake.deleteVPNUserReturnsOnCall == nil {
+		fake.deleteVPNUserReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteVPNUserReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteVirtualDiskTask(arg1 context.Context, arg2 int64) error {
+	fake.deleteVirtualDiskTaskMutex.Lock()
+	ret, specificReturn := fake.deleteVirtualDiskTaskReturnsOnCall[len(fake.deleteVirtualDiskTaskArgsForCall)]
+	fake.deleteVirtualDiskTaskArgsForCall = append(fake.deleteVirtualDiskTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeleteVirtualDiskTaskStub
+	fakeReturns := fake.deleteVirtualDiskTaskReturns
+	fake.recordInvocation("DeleteVirtualDiskTask", []interface{}{arg1, arg2})
+	fake.deleteVirtualDiskTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) DeleteVirtualDiskTaskCallCount() int {
+	fake.deleteVirtualDiskTaskMutex.RLock()
+	defer fake.deleteVirtualDiskTaskMutex.RUnlock()
+	return len(fake.deleteVirtualDiskTaskArgsForCall)
+}
+
+func (fake *Client) DeleteVirtualDiskTaskCalls(stub func(context.Context, int64) error) {
+	fake.deleteVirtualDiskTaskMutex.Lock()
+	defer fake.deleteVirtualDiskTaskMutex.Unlock()
+	fake.DeleteVirtualDiskTaskStub = stub
+}
+
+func (fake *Client) DeleteVirtualDiskTaskArgsForCall(i int) (context.Context, int64) {
+	fake.deleteVirtualDiskTaskMutex.RLock()
+	defer fake.deleteVirtualDiskTaskMutex.RUnlock()
+	argsForCall := fake.deleteVirtualDiskTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) DeleteVirtualDiskTaskReturns(result1 error) {
+	fake.deleteVirtualDiskTaskMutex.Lock()
+	defer fake.deleteVirtualDiskTaskMutex.Unlock()
+	fake.DeleteVirtualDiskTaskStub = nil
+	fake.deleteVirtualDiskTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteVirtualDiskTaskReturnsOnCall(i int, result1 error) {
+	fake.deleteVirtualDiskTaskMutex.Lock()
+	defer fake.deleteVirtualDiskTaskMutex.Unlock()
+	fake.DeleteVirtualDiskTaskStub = nil
+	if fake.deleteVirtualDiskTaskReturnsOnCall == nil {
+		fake.deleteVirtualDiskTaskReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteVirtualDiskTaskReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteVirtualMachine(arg1 context.Context, arg2 int64) error {
+	fake.deleteVirtualMachineMutex.Lock()
+	ret, specificReturn := fake.deleteVirtualMachineReturnsOnCall[len(fake.deleteVirtualMachineArgsForCall)]
+	fake.deleteVirtualMachineArgsForCall = append(fake.deleteVirtualMachineArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.DeleteVirtualMachineStub
+	fakeReturns := fake.deleteVirtualMachineReturns
+	fake.recordInvocation("DeleteVirtualMachine", []interface{}{arg1, arg2})
+	fake.deleteVirtualMachineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) DeleteVirtualMachineCallCount() int {
+	fake.deleteVirtualMachineMutex.RLock()
+	defer fake.deleteVirtualMachineMutex.RUnlock()
+	return len(fake.deleteVirtualMachineArgsForCall)
+}
+
+func (fake *Client) DeleteVirtualMachineCalls(stub func(context.Context, int64) error) {
+	fake.deleteVirtualMachineMutex.Lock()
+	defer fake.deleteVirtualMachineMutex.Unlock()
+	fake.DeleteVirtualMachineStub = stub
+}
+
+func (fake *Client) DeleteVirtualMachineArgsForCall(i int) (context.Context, int64) {
+	fake.deleteVirtualMachineMutex.RLock()
+	defer fake.deleteVirtualMachineMutex.RUnlock()
+	argsForCall := fake.deleteVirtualMachineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) DeleteVirtualMachineReturns(result1 error) {
+	fake.deleteVirtualMachineMutex.Lock()
+	defer fake.deleteVirtualMachineMutex.Unlock()
+	fake.DeleteVirtualMachineStub = nil
+	fake.deleteVirtualMachineReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) DeleteVirtualMachineReturnsOnCall(i int, result1 error) {
+	fake.deleteVirtualMachineMutex.Lock()
+	defer fake.deleteVirtualMachineMutex.Unlock()
+	fake.DeleteVirtualMachineStub = nil
+	if fake.deleteVirtualMachineReturnsOnCall == nil {
+		fake.deleteVirtualMachineReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteVirtualMachineReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) EraseDownloadTask(arg1 context.Context, arg2 int64) error {
+	fake.eraseDownloadTaskMutex.Lock()
+	ret, specificReturn := fake.eraseDownloadTaskReturnsOnCall[len(fake.eraseDownloadTaskArgsForCall)]
+	fake.eraseDownloadTaskArgsForCall = append(fake.eraseDownloadTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.EraseDownloadTaskStub
+	fakeReturns := fake.eraseDownloadTaskReturns
+	fake.recordInvocation("EraseDownloadTask", []interface{}{arg1, arg2})
+	fake.eraseDownloadTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) EraseDownloadTaskCallCount() int {
+	fake.eraseDownloadTaskMutex.RLock()
+	defer fake.eraseDownloadTaskMutex.RUnlock()
+	return len(fake.eraseDownloadTaskArgsForCall)
+}
+
+func (fake *Client) EraseDownloadTaskCalls(stub func(context.Context, int64) error) {
+	fake.eraseDownloadTaskMutex.Lock()
+	defer fake.eraseDownloadTaskMutex.Unlock()
+	fake.EraseDownloadTaskStub = stub
+}
+
+func (fake *Client) EraseDownloadTaskArgsForCall(i int) (context.Context, int64) {
+	fake.eraseDownloadTaskMutex.RLock()
+	defer fake.eraseDownloadTaskMutex.RUnlock()
+	argsForCall := fake.eraseDownloadTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) EraseDownloadTaskReturns(result1 error) {
+	fake.eraseDownloadTaskMutex.Lock()
+	defer fake.eraseDownloadTaskMutex.Unlock()
+	fake.EraseDownloadTaskStub = nil
+	fake.eraseDownloadTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) EraseDownloadTaskReturnsOnCall(i int, result1 error) {
+	fake.eraseDownloadTaskMutex.Lock()
+	defer fake.eraseDownloadTaskMutex.Unlock()
+	fake.EraseDownloadTaskStub = nil
+	if fake.eraseDownloadTaskReturnsOnCall == nil {
+		fake.eraseDownloadTaskReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.eraseDownloadTaskReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) ExtractFile(arg1 context.Context, arg2 types.ExtractFilePayload) (types.FileSystemTask, error) {
+	fake.extractFileMutex.Lock()
+	ret, specificReturn := fake.extractFileReturnsOnCall[len(fake.extractFileArgsForCall)]
+	fake.extractFileArgsForCall = append(fake.extractFileArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.ExtractFilePayload
+	}{arg1, arg2})
+	stub := fake.ExtractFileStub
+	fakeReturns := fake.extractFileReturns
+	fake.recordInvocation("ExtractFile", []interface{}{arg1, arg2})
+	fake.extractFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ExtractFileCallCount() int {
+	fake.extractFileMutex.RLock()
+	defer fake.extractFileMutex.RUnlock()
+	return len(fake.extractFileArgsForCall)
+}
+
+func (fake *Client) ExtractFileCalls(stub func(context.Context, types.ExtractFilePayload) (types.FileSystemTask, error)) {
+	fake.extractFileMutex.Lock()
+	defer fake.extractFileMutex.Unlock()
+	fake.ExtractFileStub = stub
+}
+
+func (fake *Client) ExtractFileArgsForCall(i int) (context.Context, types.ExtractFilePayload) {
+	fake.extractFileMutex.RLock()
+	defer fake.extractFileMutex.RUnlock()
+	argsForCall := fake.extractFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) ExtractFileReturns(result1 types.FileSystemTask, result2 error) {
+	fake.extractFileMutex.Lock()
+	defer fake.extractFileMutex.Unlock()
+	fake.ExtractFileStub = nil
+	fake.extractFileReturns = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ExtractFileReturnsOnCall(i int, result1 types.FileSystemTask, result2 error) {
+	fake.extractFileMutex.Lock()
+	defer fake.extractFileMutex.Unlock()
+	fake.ExtractFileStub = nil
+	if fake.extractFileReturnsOnCall == nil {
+		fake.extractFileReturnsOnCall = make(map[int]struct {
+			result1 types.FileSystemTask
+			result2 error
+		})
+	}
+	fake.extractFileReturnsOnCall[i] = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) FileUploadStart(arg1 context.Context, arg2 types.FileUploadStartActionInput) (io.WriteCloser, int64, error) {
+	fake.fileUploadStartMutex.Lock()
+	ret, specificReturn := fake.fileUploadStartReturnsOnCall[len(fake.fileUploadStartArgsForCall)]
+	fake.fileUploadStartArgsForCall = append(fake.fileUploadStartArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.FileUploadStartActionInput
+	}{arg1, arg2})
+	stub := fake.FileUploadStartStub
+	fakeReturns := fake.fileUploadStartReturns
+	fake.recordInvocation("FileUploadStart", []interface{}{arg1, arg2})
+	fake.fileUploadStartMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *Client) FileUploadStartCallCount() int {
+	fake.fileUploadStartMutex.RLock()
+	defer fake.fileUploadStartMutex.RUnlock()
+	return len(fake.fileUploadStartArgsForCall)
+}
+
+func (fake *Client) FileUploadStartCalls(stub func(context.Context, types.FileUploadStartActionInput) (io.WriteCloser, int64, error)) {
+	fake.fileUploadStartMutex.Lock()
+	defer fake.fileUploadStartMutex.Unlock()
+	fake.FileUploadStartStub = stub
+}
+
+func (fake *Client) FileUploadStartArgsForCall(i int) (context.Context, types.FileUploadStartActionInput) {
+	fake.fileUploadStartMutex.RLock()
+	defer fake.fileUploadStartMutex.RUnlock()
+	argsForCall := fake.fileUploadStartArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) FileUploadStartReturns(result1 io.WriteCloser, result2 int64, result3 error) {
+	fake.fileUploadStartMutex.Lock()
+	defer fake.fileUploadStartMutex.Unlock()
+	fake.FileUploadStartStub = nil
+	fake.fileUploadStartReturns = struct {
+		result1 io.WriteCloser
+		result2 int64
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *Client) FileUploadStartReturnsOnCall(i int, result1 io.WriteCloser, result2 int64, result3 error) {
+	fake.fileUploadStartMutex.Lock()
+	defer fake.fileUploadStartMutex.Unlock()
+	fake.FileUploadStartStub = nil
+	if fake.fileUploadStartReturnsOnCall == nil {
+		fake.fileUploadStartReturnsOnCall = make(map[int]struct {
+			result1 io.WriteCloser
+			result2 int64
+			result3 error
+		})
+	}
+	fake.fileUploadStartReturnsOnCall[i] = struct {
+		result1 io.WriteCloser
+		result2 int64
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *Client) GetDHCPStaticLease(arg1 context.Context, arg2 string) (types.DHCPStaticLeaseInfo, error) {
+	fake.getDHCPStaticLeaseMutex.Lock()
+	ret, specificReturn := fake.getDHCPStaticLeaseReturnsOnCall[len(fake.getDHCPStaticLeaseArgsForCall)]
+	fake.getDHCPStaticLeaseArgsForCall = append(fake.getDHCPStaticLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetDHCPStaticLeaseStub
+	fakeReturns := fake.getDHCPStaticLeaseReturns
+	fake.recordInvocation("GetDHCPStaticLease", []interface{}{arg1, arg2})
+	fake.getDHCPStaticLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetDHCPStaticLeaseCallCount() int {
+	fake.getDHCPStaticLeaseMutex.RLock()
+	defer fake.getDHCPStaticLeaseMutex.RUnlock()
+	return len(fake.getDHCPStaticLeaseArgsForCall)
+}
+
+func (fake *Client) GetDHCPStaticLeaseCalls(stub func(context.Context, string) (types.DHCPStaticLeaseInfo, error)) {
+	fake.getDHCPStaticLeaseMutex.Lock()
+	defer fake.getDHCPStaticLeaseMutex.Unlock()
+	fake.GetDHCPStaticLeaseStub = stub
+}
+
+func (fake *Client) GetDHCPStaticLeaseArgsForCall(i int) (context.Context, string) {
+	fake.getDHCPStaticLeaseMutex.RLock()
+	defer fake.getDHCPStaticLeaseMutex.RUnlock()
+	argsForCall := fake.getDHCPStaticLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetDHCPStaticLeaseReturns(result1 types.DHCPStaticLeaseInfo, result2 error) {
+	fake.getDHCPStaticLeaseMutex.Lock()
+	defer fake.getDHCPStaticLeaseMutex.Unlock()
+	fake.GetDHCPStaticLeaseStub = nil
+	fake.getDHCPStaticLeaseReturns = struct {
+		result1 types.DHCPStaticLeaseInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetDHCPStaticLeaseReturnsOnCall(i int, result1 types.DHCPStaticLeaseInfo, result2 error) {
+	fake.getDHCPStaticLeaseMutex.Lock()
+	defer fake.getDHCPStaticLeaseMutex.Unlock()
+	fake.GetDHCPStaticLeaseStub = nil
+	if fake.getDHCPStaticLeaseReturnsOnCall == nil {
+		fake.getDHCPStaticLeaseReturnsOnCall = make(map[int]struct {
+			result1 types.DHCPStaticLeaseInfo
+			result2 error
+		})
+	}
+	fake.getDHCPStaticLeaseReturnsOnCall[i] = struct {
+		result1 types.DHCPStaticLeaseInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetDownloadConfiguration(arg1 context.Context) (types.DownloadConfiguration, error) {
+	fake.getDownloadConfigurationMutex.Lock()
+	ret, specificReturn := fake.getDownloadConfigurationReturnsOnCall[len(fake.getDownloadConfigurationArgsForCall)]
+	fake.getDownloadConfigurationArgsForCall = append(fake.getDownloadConfigurationArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetDownloadConfigurationStub
+	fakeReturns := fake.getDownloadConfigurationReturns
+	fake.recordInvocation("GetDownloadConfiguration", []interface{}{arg1})
+	fake.getDownloadConfigurationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetDownloadConfigurationCallCount() int {
+	fake.getDownloadConfigurationMutex.RLock()
+	defer fake.getDownloadConfigurationMutex.RUnlock()
+	return len(fake.getDownloadConfigurationArgsForCall)
+}
+
+func (fake *Client) GetDownloadConfigurationCalls(stub func(context.Context) (types.DownloadConfiguration, error)) {
+	fake.getDownloadConfigurationMutex.Lock()
+	defer fake.getDownloadConfigurationMutex.Unlock()
+	fake.GetDownloadConfigurationStub = stub
+}
+
+func (fake *Client) GetDownloadConfigurationArgsForCall(i int) context.Context {
+	fake.getDownloadConfigurationMutex.RLock()
+	defer fake.getDownloadConfigurationMutex.RUnlock()
+	argsForCall := fake.getDownloadConfigurationArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetDownloadConfigurationReturns(result1 types.DownloadConfiguration, result2 error) {
+	fake.getDownloadConfigurationMutex.Lock()
+	defer fake.getDownloadConfigurationMutex.Unlock()
+	fake.GetDownloadConfigurationStub = nil
+	fake.getDownloadConfigurationReturns = struct {
+		result1 types.DownloadConfiguration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetDownloadConfigurationReturnsOnCall(i int, result1 types.DownloadConfiguration, result2 error) {
+	fake.getDownloadConfigurationMutex.Lock()
+	defer fake.getDownloadConfigurationMutex.Unlock()
+	fake.GetDownloadConfigurationStub = nil
+	if fake.getDownloadConfigurationReturnsOnCall == nil {
+		fake.getDownloadConfigurationReturnsOnCall = make(map[int]struct {
+			result1 types.DownloadConfiguration
+			result2 error
+		})
+	}
+	fake.getDownloadConfigurationReturnsOnCall[i] = struct {
+		result1 types.DownloadConfiguration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetDownloadTask(arg1 context.Context, arg2 int64) (types.DownloadTask, error) {
+	fake.getDownloadTaskMutex.Lock()
+	ret, specificReturn := fake.getDownloadTaskReturnsOnCall[len(fake.getDownloadTaskArgsForCall)]
+	fake.getDownloadTaskArgsForCall = append(fake.getDownloadTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetDownloadTaskStub
+	fakeReturns := fake.getDownloadTaskReturns
+	fake.recordInvocation("GetDownloadTask", []interface{}{arg1, arg2})
+	fake.getDownloadTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetDownloadTaskCallCount() int {
+	fake.getDownloadTaskMutex.RLock()
+	defer fake.getDownloadTaskMutex.RUnlock()
+	return len(fake.getDownloadTaskArgsForCall)
+}
+
+func (fake *Client) GetDownloadTaskCalls(stub func(context.Context, int64) (types.DownloadTask, error)) {
+	fake.getDownloadTaskMutex.Lock()
+	defer fake.getDownloadTaskMutex.Unlock()
+	fake.GetDownloadTaskStub = stub
+}
+
+func (fake *Client) GetDownloadTaskArgsForCall(i int) (context.Context, int64) {
+	fake.getDownloadTaskMutex.RLock()
+	defer fake.getDownloadTaskMutex.RUnlock()
+	argsForCall := fake.getDownloadTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetDownloadTaskReturns(result1 types.DownloadTask, result2 error) {
+	fake.getDownloadTaskMutex.Lock()
+	defer fake.getDownloadTaskMutex.Unlock()
+	fake.GetDownloadTaskStub = nil
+	fake.getDownloadTaskReturns = struct {
+		result1 types.DownloadTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetDownloadTaskReturnsOnCall(i int, result1 types.DownloadTask, result2 error) {
+	fake.getDownloadTaskMutex.Lock()
+	defer fake.getDownloadTaskMutex.Unlock()
+	fake.GetDownloadTaskStub = nil
+	if fake.getDownloadTaskReturnsOnCall == nil {
+		fake.getDownloadTaskReturnsOnCall = make(map[int]struct {
+			result1 types.DownloadTask
+			result2 error
+		})
+	}
+	fake.getDownloadTaskReturnsOnCall[i] = struct {
+		result1 types.DownloadTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetFile(arg1 context.Context, arg2 string) (types.File, error) {
+	fake.getFileMutex.Lock()
+	ret, specificReturn := fake.getFileReturnsOnCall[len(fake.getFileArgsForCall)]
+	fake.getFileArgsForCall = append(fake.getFileArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetFileStub
+	fakeReturns := fake.getFileReturns
+	fake.recordInvocation("GetFile", []interface{}{arg1, arg2})
+	fake.getFileMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetFileCallCount() int {
+	fake.getFileMutex.RLock()
+	defer fake.getFileMutex.RUnlock()
+	return len(fake.getFileArgsForCall)
+}
+
+func (fake *Client) GetFileCalls(stub func(context.Context, string) (types.File, error)) {
+	fake.getFileMutex.Lock()
+	defer fake.getFileMutex.Unlock()
+	fake.GetFileStub = stub
+}
+
+func (fake *Client) GetFileArgsForCall(i int) (context.Context, string) {
+	fake.getFileMutex.RLock()
+	defer fake.getFileMutex.RUnlock()
+	argsForCall := fake.getFileArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetFileReturns(result1 types.File, result2 error) {
+	fake.getFileMutex.Lock()
+	defer fake.getFileMutex.Unlock()
+	fake.GetFileStub = nil
+	fake.getFileReturns = struct {
+		result1 types.File
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetFileReturnsOnCall(i int, result1 types.File, result2 error) {
+	fake.getFileMutex.Lock()
+	defer fake.getFileMutex.Unlock()
+	fake.GetFileStub = nil
+	if fake.getFileReturnsOnCall == nil {
+		fake.getFileReturnsOnCall = make(map[int]struct {
+			result1 types.File
+			result2 error
+		})
+	}
+	fake.getFileReturnsOnCall[i] = struct {
+		result1 types.File
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetFileInfo(arg1 context.Context, arg2 string) (types.FileInfo, error) {
+	fake.getFileInfoMutex.Lock()
+	ret, specificReturn := fake.getFileInfoReturnsOnCall[len(fake.getFileInfoArgsForCall)]
+	fake.getFileInfoArgsForCall = append(fake.getFileInfoArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetFileInfoStub
+	fakeReturns := fake.getFileInfoReturns
+	fake.recordInvocation("GetFileInfo", []interface{}{arg1, arg2})
+	fake.getFileInfoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetFileInfoCallCount() int {
+	fake.getFileInfoMutex.RLock()
+	defer fake.getFileInfoMutex.RUnlock()
+	return len(fake.getFileInfoArgsForCall)
+}
+
+func (fake *Client) GetFileInfoCalls(stub func(context.Context, string) (types.FileInfo, error)) {
+	fake.getFileInfoMutex.Lock()
+	defer fake.getFileInfoMutex.Unlock()
+	fake.GetFileInfoStub = stub
+}
+
+func (fake *Client) GetFileInfoArgsForCall(i int) (context.Context, string) {
+	fake.getFileInfoMutex.RLock()
+	defer fake.getFileInfoMutex.RUnlock()
+	argsForCall := fake.getFileInfoArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetFileInfoReturns(result1 types.FileInfo, result2 error) {
+	fake.getFileInfoMutex.Lock()
+	defer fake.getFileInfoMutex.Unlock()
+	fake.GetFileInfoStub = nil
+	fake.getFileInfoReturns = struct {
+		result1 types.FileInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetFileInfoReturnsOnCall(i int, result1 types.FileInfo, result2 error) {
+	fake.getFileInfoMutex.Lock()
+	defer fake.getFileInfoMutex.Unlock()
+	fake.GetFileInfoStub = nil
+	if fake.getFileInfoReturnsOnCall == nil {
+		fake.getFileInfoReturnsOnCall = make(map[int]struct {
+			result1 types.FileInfo
+			result2 error
+		})
+	}
+	fake.getFileInfoReturnsOnCall[i] = struct {
+		result1 types.FileInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetFileSystemTask(arg1 context.Context, arg2 int64) (types.FileSystemTask, error) {
+	fake.getFileSystemTaskMutex.Lock()
+	ret, specificReturn := fake.getFileSystemTaskReturnsOnCall[len(fake.getFileSystemTaskArgsForCall)]
+	fake.getFileSystemTaskArgsForCall = append(fake.getFileSystemTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetFileSystemTaskStub
+	fakeReturns := fake.getFileSystemTaskReturns
+	fake.recordInvocation("GetFileSystemTask", []interface{}{arg1, arg2})
+	fake.getFileSystemTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetFileSystemTaskCallCount() int {
+	fake.getFileSystemTaskMutex.RLock()
+	defer fake.getFileSystemTaskMutex.RUnlock()
+	return len(fake.getFileSystemTaskArgsForCall)
+}
+
+func (fake *Client) GetFileSystemTaskCalls(stub func(context.Context, int64) (types.FileSystemTask, error)) {
+	fake.getFileSystemTaskMutex.Lock()
+	defer fake.getFileSystemTaskMutex.Unlock()
+	fake.GetFileSystemTaskStub = stub
+}
+
+func (fake *Client) GetFileSystemTaskArgsForCall(i int) (context.Context, int64) {
+	fake.getFileSystemTaskMutex.RLock()
+	defer fake.getFileSystemTaskMutex.RUnlock()
+	argsForCall := fake.getFileSystemTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetFileSystemTaskReturns(result1 types.FileSystemTask, result2 error) {
+	fake.getFileSystemTaskMutex.Lock()
+	defer fake.getFileSystemTaskMutex.Unlock()
+	fake.GetFileSystemTaskStub = nil
+	fake.getFileSystemTaskReturns = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetFileSystemTaskReturnsOnCall(i int, result1 types.FileSystemTask, result2 error) {
+	fake.getFileSystemTaskMutex.Lock()
+	defer fake.getFileSystemTaskMutex.Unlock()
+	fake.GetFileSystemTaskStub = nil
+	if fake.getFileSystemTaskReturnsOnCall == nil {
+		fake.getFileSystemTaskReturnsOnCall = make(map[int]struct {
+			result1 types.FileSystemTask
+			result2 error
+		})
+	}
+	fake.getFileSystemTaskReturnsOnCall[i] = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetHashResult(arg1 context.Context, arg2 int64) (string, error) {
+	fake.getHashResultMutex.Lock()
+	ret, specificReturn := fake.getHashResultReturnsOnCall[len(fake.getHashResultArgsForCall)]
+	fake.getHashResultArgsForCall = append(fake.getHashResultArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetHashResultStub
+	fakeReturns := fake.getHashResultReturns
+	fake.recordInvocation("GetHashResult", []interface{}{arg1, arg2})
+	fake.getHashResultMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetHashResultCallCount() int {
+	fake.getHashResultMutex.RLock()
+	defer fake.getHashResultMutex.RUnlock()
+	return len(fake.getHashResultArgsForCall)
+}
+
+func (fake *Client) GetHashResultCalls(stub func(context.Context, int64) (string, error)) {
+	fake.getHashResultMutex.Lock()
+	defer fake.getHashResultMutex.Unlock()
+	fake.GetHashResultStub = stub
+}
+
+func (fake *Client) GetHashResultArgsForCall(i int) (context.Context, int64) {
+	fake.getHashResultMutex.RLock()
+	defer fake.getHashResultMutex.RUnlock()
+	argsForCall := fake.getHashResultArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetHashResultReturns(result1 string, result2 error) {
+	fake.getHashResultMutex.Lock()
+	defer fake.getHashResultMutex.Unlock()
+	fake.GetHashResultStub = nil
+	fake.getHashResultReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetHashResultReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getHashResultMutex.Lock()
+	defer fake.getHashResultMutex.Unlock()
+	fake.GetHashResultStub = nil
+	if fake.getHashResultReturnsOnCall == nil {
+		fake.getHashResultReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getHashResultReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetLanConfig(arg1 context.Context) (types.LanConfig, error) {
+	fake.getLanConfigMutex.Lock()
+	ret, specificReturn := fake.getLanConfigReturnsOnCall[len(fake.getLanConfigArgsForCall)]
+	fake.getLanConfigArgsForCall = append(fake.getLanConfigArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetLanConfigStub
+	fakeReturns := fake.getLanConfigReturns
+	fake.recordInvocation("GetLanConfig", []interface{}{arg1})
+	fake.getLanConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetLanConfigCallCount() int {
+	fake.getLanConfigMutex.RLock()
+	defer fake.getLanConfigMutex.RUnlock()
+	return len(fake.getLanConfigArgsForCall)
+}
+
+func (fake *Client) GetLanConfigCalls(stub func(context.Context) (types.LanConfig, error)) {
+	fake.getLanConfigMutex.Lock()
+	defer fake.getLanConfigMutex.Unlock()
+	fake.GetLanConfigStub = stub
+}
+
+func (fake *Client) GetLanConfigArgsForCall(i int) context.Context {
+	fake.getLanConfigMutex.RLock()
+	defer fake.getLanConfigMutex.RUnlock()
+	argsForCall := fake.getLanConfigArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetLanConfigReturns(result1 types.LanConfig, result2 error) {
+	fake.getLanConfigMutex.Lock()
+	defer fake.getLanConfigMutex.Unlock()
+	fake.GetLanConfigStub = nil
+	fake.getLanConfigReturns = struct {
+		result1 types.LanConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetLanConfigReturnsOnCall(i int, result1 types.LanConfig, result2 error) {
+	fake.getLanConfigMutex.Lock()
+	defer fake.getLanConfigMutex.Unlock()
+	fake.GetLanConfigStub = nil
+	if fake.getLanConfigReturnsOnCall == nil {
+		fake.getLanConfigReturnsOnCall = make(map[int]struct {
+			result1 types.LanConfig
+			result2 error
+		})
+	}
+	fake.getLanConfigReturnsOnCall[i] = struct {
+		result1 types.LanConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetLanInterface(arg1 context.Context, arg2 string) ([]types.LanInterfaceHost, error) {
+	fake.getLanInterfaceMutex.Lock()
+	ret, specificReturn := fake.getLanInterfaceReturnsOnCall[len(fake.getLanInterfaceArgsForCall)]
+	fake.getLanInterfaceArgsForCall = append(fake.getLanInterfaceArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetLanInterfaceStub
+	fakeReturns := fake.getLanInterfaceReturns
+	fake.recordInvocation("GetLanInterface", []interface{}{arg1, arg2})
+	fake.getLanInterfaceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetLanInterfaceCallCount() int {
+	fake.getLanInterfaceMutex.RLock()
+	defer fake.getLanInterfaceMutex.RUnlock()
+	return len(fake.getLanInterfaceArgsForCall)
+}
+
+func (fake *Client) GetLanInterfaceCalls(stub func(context.Context, string) ([]types.LanInterfaceHost, error)) {
+	fake.getLanInterfaceMutex.Lock()
+	defer fake.getLanInterfaceMutex.Unlock()
+	fake.GetLanInterfaceStub = stub
+}
+
+func (fake *Client) GetLanInterfaceArgsForCall(i int) (context.Context, string) {
+	fake.getLanInterfaceMutex.RLock()
+	defer fake.getLanInterfaceMutex.RUnlock()
+	argsForCall := fake.getLanInterfaceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetLanInterfaceReturns(result1 []types.LanInterfaceHost, result2 error) {
+	fake.getLanInterfaceMutex.Lock()
+	defer fake.getLanInterfaceMutex.Unlock()
+	fake.GetLanInterfaceStub = nil
+	fake.getLanInterfaceReturns = struct {
+		result1 []types.LanInterfaceHost
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetLanInterfaceReturnsOnCall(i int, result1 []types.LanInterfaceHost, result2 error) {
+	fake.getLanInterfaceMutex.Lock()
+	defer fake.getLanInterfaceMutex.Unlock()
+	fake.GetLanInterfaceStub = nil
+	if fake.getLanInterfaceReturnsOnCall == nil {
+		fake.getLanInterfaceReturnsOnCall = make(map[int]struct {
+			result1 []types.LanInterfaceHost
+			result2 error
+		})
+	}
+	fake.getLanInterfaceReturnsOnCall[i] = struct {
+		result1 []types.LanInterfaceHost
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetLanInterfaceHost(arg1 context.Context, arg2 string, arg3 string) (types.LanInterfaceHost, error) {
+	fake.getLanInterfaceHostMutex.Lock()
+	ret, specificReturn := fake.getLanInterfaceHostReturnsOnCall[len(fake.getLanInterfaceHostArgsForCall)]
+	fake.getLanInterfaceHostArgsForCall = append(fake.getLanInterfaceHostArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetLanInterfaceHostStub
+	fakeReturns := fake.getLanInterfaceHostReturns
+	fake.recordInvocation("GetLanInterfaceHost", []interface{}{arg1, arg2, arg3})
+	fake.getLanInterfaceHostMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetLanInterfaceHostCallCount() int {
+	fake.getLanInterfaceHostMutex.RLock()
+	defer fake.getLanInterfaceHostMutex.RUnlock()
+	return len(fake.getLanInterfaceHostArgsForCall)
+}
+
+func (fake *Client) GetLanInterfaceHostCalls(stub func(context.Context, string, string) (types.LanInterfaceHost, error)) {
+	fake.getLanInterfaceHostMutex.Lock()
+	defer fake.getLanInterfaceHostMutex.Unlock()
+	fake.GetLanInterfaceHostStub = stub
+}
+
+func (fake *Client) GetLanInterfaceHostArgsForCall(i int) (context.Context, string, string) {
+	fake.getLanInterfaceHostMutex.RLock()
+	defer fake.getLanInterfaceHostMutex.RUnlock()
+	argsForCall := fake.getLanInterfaceHostArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Client) GetLanInterfaceHostReturns(result1 types.LanInterfaceHost, result2 error) {
+	fake.getLanInterfaceHostMutex.Lock()
+	defer fake.getLanInterfaceHostMutex.Unlock()
+	fake.GetLanInterfaceHostStub = nil
+	fake.getLanInterfaceHostReturns = struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetLanInterfaceHostReturnsOnCall(i int, result1 types.LanInterfaceHost, result2 error) {
+	fake.getLanInterfaceHostMutex.Lock()
+	defer fake.getLanInterfaceHostMutex.Unlock()
+	fake.GetLanInterfaceHostStub = nil
+	if fake.getLanInterfaceHostReturnsOnCall == nil {
+		fake.getLanInterfaceHostReturnsOnCall = make(map[int]struct {
+			result1 types.LanInterfaceHost
+			result2 error
+		})
+	}
+	fake.getLanInterfaceHostReturnsOnCall[i] = struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetOpenVPNServerConfig(arg1 context.Context) (types.OpenVPNServerConfig, error) {
+	fake.getOpenVPNServerConfigMutex.Lock()
+	ret, specificReturn := fake.getOpenVPNServerConfigReturnsOnCall[len(fake.getOpenVPNServerConfigArgsForCall)]
+	fake.getOpenVPNServerConfigArgsForCall = append(fake.getOpenVPNServerConfigArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetOpenVPNServerConfigStub
+	fakeReturns := fake.getOpenVPNServerConfigReturns
+	fake.recordInvocation("GetOpenVPNServerConfig", []interface{}{arg1})
+	fake.getOpenVPNServerConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetOpenVPNServerConfigCallCount() int {
+	fake.getOpenVPNServerConfigMutex.RLock()
+	defer fake.getOpenVPNServerConfigMutex.RUnlock()
+	return len(fake.getOpenVPNServerConfigArgsForCall)
+}
+
+func (fake *Client) GetOpenVPNServerConfigCalls(stub func(context.Context) (types.OpenVPNServerConfig, error)) {
+	fake.getOpenVPNServerConfigMutex.Lock()
+	defer fake.getOpenVPNServerConfigMutex.Unlock()
+	fake.GetOpenVPNServerConfigStub = stub
+}
+
+func (fake *Client) GetOpenVPNServerConfigArgsForCall(i int) context.Context {
+	fake.getOpenVPNServerConfigMutex.RLock()
+	defer fake.getOpenVPNServerConfigMutex.RUnlock()
+	argsForCall := fake.getOpenVPNServerConfigArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetOpenVPNServerConfigReturns(result1 types.OpenVPNServerConfig, result2 error) {
+	fake.getOpenVPNServerConfigMutex.Lock()
+	defer fake.getOpenVPNServerConfigMutex.Unlock()
+	fake.GetOpenVPNServerConfigStub = nil
+	fake.getOpenVPNServerConfigReturns = struct {
+		result1 types.OpenVPNServerConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetOpenVPNServerConfigReturnsOnCall(i int, result1 types.OpenVPNServerConfig, result2 error) {
+	fake.getOpenVPNServerConfigMutex.Lock()
+	defer fake.getOpenVPNServerConfigMutex.Unlock()
+	fake.GetOpenVPNServerConfigStub = nil
+	if fake.getOpenVPNServerConfigReturnsOnCall == nil {
+		fake.getOpenVPNServerConfigReturnsOnCall = make(map[int]struct {
+			result1 types.OpenVPNServerConfig
+			result2 error
+		})
+	}
+	fake.getOpenVPNServerConfigReturnsOnCall[i] = struct {
+		result1 types.OpenVPNServerConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetPortForwardingRule(arg1 context.Context, arg2 int64) (types.PortForwardingRule, error) {
+	fake.getPortForwardingRuleMutex.Lock()
+	ret, specificReturn := fake.getPortForwardingRuleReturnsOnCall[len(fake.getPortForwardingRuleArgsForCall)]
+	fake.getPortForwardingRuleArgsForCall = append(fake.getPortForwardingRuleArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetPortForwardingRuleStub
+	fakeReturns := fake.getPortForwardingRuleReturns
+	fake.recordInvocation("GetPortForwardingRule", []interface{}{arg1, arg2})
+	fake.getPortForwardingRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetPortForwardingRuleCallCount() int {
+	fake.getPortForwardingRuleMutex.RLock()
+	defer fake.getPortForwardingRuleMutex.RUnlock()
+	return len(fake.getPortForwardingRuleArgsForCall)
+}
+
+func (fake *Client) GetPortForwardingRuleCalls(stub func(context.Context, int64) (types.PortForwardingRule, error)) {
+	fake.getPortForwardingRuleMutex.Lock()
+	defer fake.getPortForwardingRuleMutex.Unlock()
+	fake.GetPortForwardingRuleStub = stub
+}
+
+func (fake *Client) GetPortForwardingRuleArgsForCall(i int) (context.Context, int64) {
+	fake.getPortForwardingRuleMutex.RLock()
+	defer fake.getPortForwardingRuleMutex.RUnlock()
+	argsForCall := fake.getPortForwardingRuleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetPortForwardingRuleReturns(result1 types.PortForwardingRule, result2 error) {
+	fake.getPortForwardingRuleMutex.Lock()
+	defer fake.getPortForwardingRuleMutex.Unlock()
+	fake.GetPortForwardingRuleStub = nil
+	fake.getPortForwardingRuleReturns = struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetPortForwardingRuleReturnsOnCall(i int, result1 types.PortForwardingRule, result2 error) {
+	fake.getPortForwardingRuleMutex.Lock()
+	defer fake.getPortForwardingRuleMutex.Unlock()
+	fake.GetPortForwardingRuleStub = nil
+	if fake.getPortForwardingRuleReturnsOnCall == nil {
+		fake.getPortForwardingRuleReturnsOnCall = make(map[int]struct {
+			result1 types.PortForwardingRule
+			result2 error
+		})
+	}
+	fake.getPortForwardingRuleReturnsOnCall[i] = struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetSystemInfo(arg1 context.Context) (types.SystemConfig, error) {
+	fake.getSystemInfoMutex.Lock()
+	ret, specificReturn := fake.getSystemInfoReturnsOnCall[len(fake.getSystemInfoArgsForCall)]
+	fake.getSystemInfoArgsForCall = append(fake.getSystemInfoArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetSystemInfoStub
+	fakeReturns := fake.getSystemInfoReturns
+	fake.recordInvocation("GetSystemInfo", []interface{}{arg1})
+	fake.getSystemInfoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetSystemInfoCallCount() int {
+	fake.getSystemInfoMutex.RLock()
+	defer fake.getSystemInfoMutex.RUnlock()
+	return len(fake.getSystemInfoArgsForCall)
+}
+
+func (fake *Client) GetSystemInfoCalls(stub func(context.Context) (types.SystemConfig, error)) {
+	fake.getSystemInfoMutex.Lock()
+	defer fake.getSystemInfoMutex.Unlock()
+	fake.GetSystemInfoStub = stub
+}
+
+func (fake *Client) GetSystemInfoArgsForCall(i int) context.Context {
+	fake.getSystemInfoMutex.RLock()
+	defer fake.getSystemInfoMutex.RUnlock()
+	argsForCall := fake.getSystemInfoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetSystemInfoReturns(result1 types.SystemConfig, result2 error) {
+	fake.getSystemInfoMutex.Lock()
+	defer fake.getSystemInfoMutex.Unlock()
+	fake.GetSystemInfoStub = nil
+	fake.getSystemInfoReturns = struct {
+		result1 types.SystemConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetSystemInfoReturnsOnCall(i int, result1 types.SystemConfig, result2 error) {
+	fake.getSystemInfoMutex.Lock()
+	defer fake.getSystemInfoMutex.Unlock()
+	fake.GetSystemInfoStub = nil
+	if fake.getSystemInfoReturnsOnCall == nil {
+		fake.getSystemInfoReturnsOnCall = make(map[int]struct {
+			result1 types.SystemConfig
+			result2 error
+		})
+	}
+	fake.getSystemInfoReturnsOnCall[i] = struct {
+		result1 types.SystemConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetUploadTask(arg1 context.Context, arg2 int64) (types.UploadTask, error) {
+	fake.getUploadTaskMutex.Lock()
+	ret, specificReturn := fake.getUploadTaskReturnsOnCall[len(fake.getUploadTaskArgsForCall)]
+	fake.getUploadTaskArgsForCall = append(fake.getUploadTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetUploadTaskStub
+	fakeReturns := fake.getUploadTaskReturns
+	fake.recordInvocation("GetUploadTask", []interface{}{arg1, arg2})
+	fake.getUploadTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetUploadTaskCallCount() int {
+	fake.getUploadTaskMutex.RLock()
+	defer fake.getUploadTaskMutex.RUnlock()
+	return len(fake.getUploadTaskArgsForCall)
+}
+
+func (fake *Client) GetUploadTaskCalls(stub func(context.Context, int64) (types.UploadTask, error)) {
+	fake.getUploadTaskMutex.Lock()
+	defer fake.getUploadTaskMutex.Unlock()
+	fake.GetUploadTaskStub = stub
+}
+
+func (fake *Client) GetUploadTaskArgsForCall(i int) (context.Context, int64) {
+	fake.getUploadTaskMutex.RLock()
+	defer fake.getUploadTaskMutex.RUnlock()
+	argsForCall := fake.getUploadTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetUploadTaskReturns(result1 types.UploadTask, result2 error) {
+	fake.getUploadTaskMutex.Lock()
+	defer fake.getUploadTaskMutex.Unlock()
+	fake.GetUploadTaskStub = nil
+	fake.getUploadTaskReturns = struct {
+		result1 types.UploadTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetUploadTaskReturnsOnCall(i int, result1 types.UploadTask, result2 error) {
+	fake.getUploadTaskMutex.Lock()
+	defer fake.getUploadTaskMutex.Unlock()
+	fake.GetUploadTaskStub = nil
+	if fake.getUploadTaskReturnsOnCall == nil {
+		fake.getUploadTaskReturnsOnCall = make(map[int]struct {
+			result1 types.UploadTask
+			result2 error
+		})
+	}
+	fake.getUploadTaskReturnsOnCall[i] = struct {
+		result1 types.UploadTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVPNUser(arg1 context.Context, arg2 string) (types.VPNUser, error) {
+	fake.getVPNUserMutex.Lock()
+	ret, specificReturn := fake.getVPNUserReturnsOnCall[len(fake.getVPNUserArgsForCall)]
+	fake.getVPNUserArgsForCall = append(fake.getVPNUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetVPNUserStub
+	fakeReturns := fake.getVPNUserReturns
+	fake.recordInvocation("GetVPNUser", []interface{}{arg1, arg2})
+	fake.getVPNUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetVPNUserCallCount() int {
+	fake.getVPNUserMutex.RLock()
+	defer fake.getVPNUserMutex.RUnlock()
+	return len(fake.getVPNUserArgsForCall)
+}
+
+func (fake *Client) GetVPNUserCalls(stub func(context.Context, string) (types.VPNUser, error)) {
+	fake.getVPNUserMutex.Lock()
+	defer fake.getVPNUserMutex.Unlock()
+	fake.GetVPNUserStub = stub
+}
+
+func (fake *Client) GetVPNUserArgsForCall(i int) (context.Context, string) {
+	fake.getVPNUserMutex.RLock()
+	defer fake.getVPNUserMutex.RUnlock()
+	argsForCall := fake.getVPNUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetVPNUserReturns(result1 types.VPNUser, result2 error) {
+	fake.getVPNUserMutex.Lock()
+	defer fake.getVPNUserMutex.Unlock()
+	fake.GetVPNUserStub = nil
+	fake.getVPNUserReturns = struct {
+		result1 types.VPNUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVPNUserReturnsOnCall(i int, result1 types.VPNUser, result2 error) {
+	fake.getVPNUserMutex.Lock()
+	defer fake.getVPNUserMutex.Unlock()
+	fake.GetVPNUserStub = nil
+	if fake.getVPNUserReturnsOnCall == nil {
+		fake.getVPNUserReturnsOnCall = make(map[int]struct {
+			result1 types.VPNUser
+			result2 error
+		})
+	}
+	fake.getVPNUserReturnsOnCall[i] = struct {
+		result1 types.VPNUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVPNUserClientConfig(arg1 context.Context, arg2 string) (string, error) {
+	fake.getVPNUserClientConfigMutex.Lock()
+	ret, specificReturn := fake.getVPNUserClientConfigReturnsOnCall[len(fake.getVPNUserClientConfigArgsForCall)]
+	fake.getVPNUserClientConfigArgsForCall = append(fake.getVPNUserClientConfigArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetVPNUserClientConfigStub
+	fakeReturns := fake.getVPNUserClientConfigReturns
+	fake.recordInvocation("GetVPNUserClientConfig", []interface{}{arg1, arg2})
+	fake.getVPNUserClientConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetVPNUserClientConfigCallCount() int {
+	fake.getVPNUserClientConfigMutex.RLock()
+	defer fake.getVPNUserClientConfigMutex.RUnlock()
+	return len(fake.getVPNUserClientConfigArgsForCall)
+}
+
+func (fake *Client) GetVPNUserClientConfigCalls(stub func(context.Context, string) (string, error)) {
+	fake.getVPNUserClientConfigMutex.Lock()
+	defer fake.getVPNUserClientConfigMutex.Unlock()
+	fake.GetVPNUserClientConfigStub = stub
+}
+
+func (fake *Client) GetVPNUserClientConfigArgsForCall(i int) (context.Context, string) {
+	fake.getVPNUserClientConfigMutex.RLock()
+	defer fake.getVPNUserClientConfigMutex.RUnlock()
+	argsForCall := fake.getVPNUserClientConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetVPNUserClientConfigReturns(result1 string, result2 error) {
+	fake.getVPNUserClientConfigMutex.Lock()
+	defer fake.getVPNUserClientConfigMutex.Unlock()
+	fake.GetVPNUserClientConfigStub = nil
+	fake.getVPNUserClientConfigReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVPNUserClientConfigReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getVPNUserClientConfigMutex.Lock()
+	defer fake.getVPNUserClientConfigMutex.Unlock()
+	fake.GetVPNUserClientConfigStub = nil
+	if fake.getVPNUserClientConfigReturnsOnCall == nil {
+		fake.getVPNUserClientConfigReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getVPNUserClientConfigReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVirtualDiskInfo(arg1 context.Context, arg2 string) (types.VirtualDiskInfo, error) {
+	fake.getVirtualDiskInfoMutex.Lock()
+	ret, specificReturn := fake.getVirtualDiskInfoReturnsOnCall[len(fake.getVirtualDiskInfoArgsForCall)]
+	fake.getVirtualDiskInfoArgsForCall = append(fake.getVirtualDiskInfoArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetVirtualDiskInfoStub
+	fakeReturns := fake.getVirtualDiskInfoReturns
+	fake.recordInvocation("GetVirtualDiskInfo", []interface{}{arg1, arg2})
+	fake.getVirtualDiskInfoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetVirtualDiskInfoCallCount() int {
+	fake.getVirtualDiskInfoMutex.RLock()
+	defer fake.getVirtualDiskInfoMutex.RUnlock()
+	return len(fake.getVirtualDiskInfoArgsForCall)
+}
+
+func (fake *Client) GetVirtualDiskInfoCalls(stub func(context.Context, string) (types.VirtualDiskInfo, error)) {
+	fake.getVirtualDiskInfoMutex.Lock()
+	defer fake.getVirtualDiskInfoMutex.Unlock()
+	fake.GetVirtualDiskInfoStub = stub
+}
+
+func (fake *Client) GetVirtualDiskInfoArgsForCall(i int) (context.Context, string) {
+	fake.getVirtualDiskInfoMutex.RLock()
+	defer fake.getVirtualDiskInfoMutex.RUnlock()
+	argsForCall := fake.getVirtualDiskInfoArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetVirtualDiskInfoReturns(result1 types.VirtualDiskInfo, result2 error) {
+	fake.getVirtualDiskInfoMutex.Lock()
+	defer fake.getVirtualDiskInfoMutex.Unlock()
+	fake.GetVirtualDiskInfoStub = nil
+	fake.getVirtualDiskInfoReturns = struct {
+		result1 types.VirtualDiskInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVirtualDiskInfoReturnsOnCall(i int, result1 types.VirtualDiskInfo, result2 error) {
+	fake.getVirtualDiskInfoMutex.Lock()
+	defer fake.getVirtualDiskInfoMutex.Unlock()
+	fake.GetVirtualDiskInfoStub = nil
+	if fake.getVirtualDiskInfoReturnsOnCall == nil {
+		fake.getVirtualDiskInfoReturnsOnCall = make(map[int]struct {
+			result1 types.VirtualDiskInfo
+			result2 error
+		})
+	}
+	fake.getVirtualDiskInfoReturnsOnCall[i] = struct {
+		result1 types.VirtualDiskInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVirtualDiskTask(arg1 context.Context, arg2 int64) (types.VirtualMachineDiskTask, error) {
+	fake.getVirtualDiskTaskMutex.Lock()
+	ret, specificReturn := fake.getVirtualDiskTaskReturnsOnCall[len(fake.getVirtualDiskTaskArgsForCall)]
+	fake.getVirtualDiskTaskArgsForCall = append(fake.getVirtualDiskTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetVirtualDiskTaskStub
+	fakeReturns := fake.getVirtualDiskTaskReturns
+	fake.recordInvocation("GetVirtualDiskTask", []interface{}{arg1, arg2})
+	fake.getVirtualDiskTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetVirtualDiskTaskCallCount() int {
+	fake.getVirtualDiskTaskMutex.RLock()
+	defer fake.getVirtualDiskTaskMutex.RUnlock()
+	return len(fake.getVirtualDiskTaskArgsForCall)
+}
+
+func (fake *Client) GetVirtualDiskTaskCalls(stub func(context.Context, int64) (types.VirtualMachineDiskTask, error)) {
+	fake.getVirtualDiskTaskMutex.Lock()
+	defer fake.getVirtualDiskTaskMutex.Unlock()
+	fake.GetVirtualDiskTaskStub = stub
+}
+
+func (fake *Client) GetVirtualDiskTaskArgsForCall(i int) (context.Context, int64) {
+	fake.getVirtualDiskTaskMutex.RLock()
+	defer fake.getVirtualDiskTaskMutex.RUnlock()
+	argsForCall := fake.getVirtualDiskTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetVirtualDiskTaskReturns(result1 types.VirtualMachineDiskTask, result2 error) {
+	fake.getVirtualDiskTaskMutex.Lock()
+	defer fake.getVirtualDiskTaskMutex.Unlock()
+	fake.GetVirtualDiskTaskStub = nil
+	fake.getVirtualDiskTaskReturns = struct {
+		result1 types.VirtualMachineDiskTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVirtualDiskTaskReturnsOnCall(i int, result1 types.VirtualMachineDiskTask, result2 error) {
+	fake.getVirtualDiskTaskMutex.Lock()
+	defer fake.getVirtualDiskTaskMutex.Unlock()
+	fake.GetVirtualDiskTaskStub = nil
+	if fake.getVirtualDiskTaskReturnsOnCall == nil {
+		fake.getVirtualDiskTaskReturnsOnCall = make(map[int]struct {
+			result1 types.VirtualMachineDiskTask
+			result2 error
+		})
+	}
+	fake.getVirtualDiskTaskReturnsOnCall[i] = struct {
+		result1 types.VirtualMachineDiskTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVirtualMachine(arg1 context.Context, arg2 int64) (types.VirtualMachine, error) {
+	fake.getVirtualMachineMutex.Lock()
+	ret, specificReturn := fake.getVirtualMachineReturnsOnCall[len(fake.getVirtualMachineArgsForCall)]
+	fake.getVirtualMachineArgsForCall = append(fake.getVirtualMachineArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.GetVirtualMachineStub
+	fakeReturns := fake.getVirtualMachineReturns
+	fake.recordInvocation("GetVirtualMachine", []interface{}{arg1, arg2})
+	fake.getVirtualMachineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetVirtualMachineCallCount() int {
+	fake.getVirtualMachineMutex.RLock()
+	defer fake.getVirtualMachineMutex.RUnlock()
+	return len(fake.getVirtualMachineArgsForCall)
+}
+
+func (fake *Client) GetVirtualMachineCalls(stub func(context.Context, int64) (types.VirtualMachine, error)) {
+	fake.getVirtualMachineMutex.Lock()
+	defer fake.getVirtualMachineMutex.Unlock()
+	fake.GetVirtualMachineStub = stub
+}
+
+func (fake *Client) GetVirtualMachineArgsForCall(i int) (context.Context, int64) {
+	fake.getVirtualMachineMutex.RLock()
+	defer fake.getVirtualMachineMutex.RUnlock()
+	argsForCall := fake.getVirtualMachineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) GetVirtualMachineReturns(result1 types.VirtualMachine, result2 error) {
+	fake.getVirtualMachineMutex.Lock()
+	defer fake.getVirtualMachineMutex.Unlock()
+	fake.GetVirtualMachineStub = nil
+	fake.getVirtualMachineReturns = struct {
+		result1 types.VirtualMachine
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVirtualMachineReturnsOnCall(i int, result1 types.VirtualMachine, result2 error) {
+	fake.getVirtualMachineMutex.Lock()
+	defer fake.getVirtualMachineMutex.Unlock()
+	fake.GetVirtualMachineStub = nil
+	if fake.getVirtualMachineReturnsOnCall == nil {
+		fake.getVirtualMachineReturnsOnCall = make(map[int]struct {
+			result1 types.VirtualMachine
+			result2 error
+		})
+	}
+	fake.getVirtualMachineReturnsOnCall[i] = struct {
+		result1 types.VirtualMachine
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVirtualMachineDistributions(arg1 context.Context) ([]types.VirtualMachineDistribution, error) {
+	fake.getVirtualMachineDistributionsMutex.Lock()
+	ret, specificReturn := fake.getVirtualMachineDistributionsReturnsOnCall[len(fake.getVirtualMachineDistributionsArgsForCall)]
+	fake.getVirtualMachineDistributionsArgsForCall = append(fake.getVirtualMachineDistributionsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetVirtualMachineDistributionsStub
+	fakeReturns := fake.getVirtualMachineDistributionsReturns
+	fake.recordInvocation("GetVirtualMachineDistributions", []interface{}{arg1})
+	fake.getVirtualMachineDistributionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetVirtualMachineDistributionsCallCount() int {
+	fake.getVirtualMachineDistributionsMutex.RLock()
+	defer fake.getVirtualMachineDistributionsMutex.RUnlock()
+	return len(fake.getVirtualMachineDistributionsArgsForCall)
+}
+
+func (fake *Client) GetVirtualMachineDistributionsCalls(stub func(context.Context) ([]types.VirtualMachineDistribution, error)) {
+	fake.getVirtualMachineDistributionsMutex.Lock()
+	defer fake.getVirtualMachineDistributionsMutex.Unlock()
+	fake.GetVirtualMachineDistributionsStub = stub
+}
+
+func (fake *Client) GetVirtualMachineDistributionsArgsForCall(i int) context.Context {
+	fake.getVirtualMachineDistributionsMutex.RLock()
+	defer fake.getVirtualMachineDistributionsMutex.RUnlock()
+	argsForCall := fake.getVirtualMachineDistributionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetVirtualMachineDistributionsReturns(result1 []types.VirtualMachineDistribution, result2 error) {
+	fake.getVirtualMachineDistributionsMutex.Lock()
+	defer fake.getVirtualMachineDistributionsMutex.Unlock()
+	fake.GetVirtualMachineDistributionsStub = nil
+	fake.getVirtualMachineDistributionsReturns = struct {
+		result1 []types.VirtualMachineDistribution
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVirtualMachineDistributionsReturnsOnCall(i int, result1 []types.VirtualMachineDistribution, result2 error) {
+	fake.getVirtualMachineDistributionsMutex.Lock()
+	defer fake.getVirtualMachineDistributionsMutex.Unlock()
+	fake.GetVirtualMachineDistributionsStub = nil
+	if fake.getVirtualMachineDistributionsReturnsOnCall == nil {
+		fake.getVirtualMachineDistributionsReturnsOnCall = make(map[int]struct {
+			result1 []types.VirtualMachineDistribution
+			result2 error
+		})
+	}
+	fake.getVirtualMachineDistributionsReturnsOnCall[i] = struct {
+		result1 []types.VirtualMachineDistribution
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVirtualMachineInfo(arg1 context.Context) (types.VirtualMachinesInfo, error) {
+	fake.getVirtualMachineInfoMutex.Lock()
+	ret, specificReturn := fake.getVirtualMachineInfoReturnsOnCall[len(fake.getVirtualMachineInfoArgsForCall)]
+	fake.getVirtualMachineInfoArgsForCall = append(fake.getVirtualMachineInfoArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetVirtualMachineInfoStub
+	fakeReturns := fake.getVirtualMachineInfoReturns
+	fake.recordInvocation("GetVirtualMachineInfo", []interface{}{arg1})
+	fake.getVirtualMachineInfoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) GetVirtualMachineInfoCallCount() int {
+	fake.getVirtualMachineInfoMutex.RLock()
+	defer fake.getVirtualMachineInfoMutex.RUnlock()
+	return len(fake.getVirtualMachineInfoArgsForCall)
+}
+
+func (fake *Client) GetVirtualMachineInfoCalls(stub func(context.Context) (types.VirtualMachinesInfo, error)) {
+	fake.getVirtualMachineInfoMutex.Lock()
+	defer fake.getVirtualMachineInfoMutex.Unlock()
+	fake.GetVirtualMachineInfoStub = stub
+}
+
+func (fake *Client) GetVirtualMachineInfoArgsForCall(i int) context.Context {
+	fake.getVirtualMachineInfoMutex.RLock()
+	defer fake.getVirtualMachineInfoMutex.RUnlock()
+	argsForCall := fake.getVirtualMachineInfoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) GetVirtualMachineInfoReturns(result1 types.VirtualMachinesInfo, result2 error) {
+	fake.getVirtualMachineInfoMutex.Lock()
+	defer fake.getVirtualMachineInfoMutex.Unlock()
+	fake.GetVirtualMachineInfoStub = nil
+	fake.getVirtualMachineInfoReturns = struct {
+		result1 types.VirtualMachinesInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) GetVirtualMachineInfoReturnsOnCall(i int, result1 types.VirtualMachinesInfo, result2 error) {
+	fake.getVirtualMachineInfoMutex.Lock()
+	defer fake.getVirtualMachineInfoMutex.Unlock()
+	fake.GetVirtualMachineInfoStub = nil
+	if fake.getVirtualMachineInfoReturnsOnCall == nil {
+		fake.getVirtualMachineInfoReturnsOnCall = make(map[int]struct {
+			result1 types.VirtualMachinesInfo
+			result2 error
+		})
+	}
+	fake.getVirtualMachineInfoReturnsOnCall[i] = struct {
+		result1 types.VirtualMachinesInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) KillVirtualMachine(arg1 context.Context, arg2 int64) error {
+	fake.killVirtualMachineMutex.Lock()
+	ret, specificReturn := fake.killVirtualMachineReturnsOnCall[len(fake.killVirtualMachineArgsForCall)]
+	fake.killVirtualMachineArgsForCall = append(fake.killVirtualMachineArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.KillVirtualMachineStub
+	fakeReturns := fake.killVirtualMachineReturns
+	fake.recordInvocation("KillVirtualMachine", []interface{}{arg1, arg2})
+	fake.killVirtualMachineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) KillVirtualMachineCallCount() int {
+	fake.killVirtualMachineMutex.RLock()
+	defer fake.killVirtualMachineMutex.RUnlock()
+	return len(fake.killVirtualMachineArgsForCall)
+}
+
+func (fake *Client) KillVirtualMachineCalls(stub func(context.Context, int64) error) {
+	fake.killVirtualMachineMutex.Lock()
+	defer fake.killVirtualMachineMutex.Unlock()
+	fake.KillVirtualMachineStub = stub
+}
+
+func (fake *Client) KillVirtualMachineArgsForCall(i int) (context.Context, int64) {
+	fake.killVirtualMachineMutex.RLock()
+	defer fake.killVirtualMachineMutex.RUnlock()
+	argsForCall := fake.killVirtualMachineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) KillVirtualMachineReturns(result1 error) {
+	fake.killVirtualMachineMutex.Lock()
+	defer fake.killVirtualMachineMutex.Unlock()
+	fake.KillVirtualMachineStub = nil
+	fake.killVirtualMachineReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) KillVirtualMachineReturnsOnCall(i int, result1 error) {
+	fake.killVirtualMachineMutex.Lock()
+	defer fake.killVirtualMachineMutex.Unlock()
+	fake.KillVirtualMachineStub = nil
+	if fake.killVirtualMachineReturnsOnCall == nil {
+		fake.killVirtualMachineReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.killVirtualMachineReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) ListDHCPStaticLease(arg1 context.Context) ([]types.DHCPStaticLeaseInfo, error) {
+	fake.listDHCPStaticLeaseMutex.Lock()
+	ret, specificReturn := fake.listDHCPStaticLeaseReturnsOnCall[len(fake.listDHCPStaticLeaseArgsForCall)]
+	fake.listDHCPStaticLeaseArgsForCall = append(fake.listDHCPStaticLeaseArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListDHCPStaticLeaseStub
+	fakeReturns := fake.listDHCPStaticLeaseReturns
+	fake.recordInvocation("ListDHCPStaticLease", []interface{}{arg1})
+	fake.listDHCPStaticLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ListDHCPStaticLeaseCallCount() int {
+	fake.listDHCPStaticLeaseMutex.RLock()
+	defer fake.listDHCPStaticLeaseMutex.RUnlock()
+	return len(fake.listDHCPStaticLeaseArgsForCall)
+}
+
+func (fake *Client) ListDHCPStaticLeaseCalls(stub func(context.Context) ([]types.DHCPStaticLeaseInfo, error)) {
+	fake.listDHCPStaticLeaseMutex.Lock()
+	defer fake.listDHCPStaticLeaseMutex.Unlock()
+	fake.ListDHCPStaticLeaseStub = stub
+}
+
+func (fake *Client) ListDHCPStaticLeaseArgsForCall(i int) context.Context {
+	fake.listDHCPStaticLeaseMutex.RLock()
+	defer fake.listDHCPStaticLeaseMutex.RUnlock()
+	argsForCall := fake.listDHCPStaticLeaseArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ListDHCPStaticLeaseReturns(result1 []types.DHCPStaticLeaseInfo, result2 error) {
+	fake.listDHCPStaticLeaseMutex.Lock()
+	defer fake.listDHCPStaticLeaseMutex.Unlock()
+	fake.ListDHCPStaticLeaseStub = nil
+	fake.listDHCPStaticLeaseReturns = struct {
+		result1 []types.DHCPStaticLeaseInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListDHCPStaticLeaseReturnsOnCall(i int, result1 []types.DHCPStaticLeaseInfo, result2 error) {
+	fake.listDHCPStaticLeaseMutex.Lock()
+	defer fake.listDHCPStaticLeaseMutex.Unlock()
+	fake.ListDHCPStaticLeaseStub = nil
+	if fake.listDHCPStaticLeaseReturnsOnCall == nil {
+		fake.listDHCPStaticLeaseReturnsOnCall = make(map[int]struct {
+			result1 []types.DHCPStaticLeaseInfo
+			result2 error
+		})
+	}
+	fake.listDHCPStaticLeaseReturnsOnCall[i] = struct {
+		result1 []types.DHCPStaticLeaseInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListDownloadTasks(arg1 context.Context) ([]types.DownloadTask, error) {
+	fake.listDownloadTasksMutex.Lock()
+	ret, specificReturn := fake.listDownloadTasksReturnsOnCall[len(fake.listDownloadTasksArgsForCall)]
+	fake.listDownloadTasksArgsForCall = append(fake.listDownloadTasksArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListDownloadTasksStub
+	fakeReturns := fake.listDownloadTasksReturns
+	fake.recordInvocation("ListDownloadTasks", []interface{}{arg1})
+	fake.listDownloadTasksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ListDownloadTasksCallCount() int {
+	fake.listDownloadTasksMutex.RLock()
+	defer fake.listDownloadTasksMutex.RUnlock()
+	return len(fake.listDownloadTasksArgsForCall)
+}
+
+func (fake *Client) ListDownloadTasksCalls(stub func(context.Context) ([]types.DownloadTask, error)) {
+	fake.listDownloadTasksMutex.Lock()
+	defer fake.listDownloadTasksMutex.Unlock()
+	fake.ListDownloadTasksStub = stub
+}
+
+func (fake *Client) ListDownloadTasksArgsForCall(i int) context.Context {
+	fake.listDownloadTasksMutex.RLock()
+	defer fake.listDownloadTasksMutex.RUnlock()
+	argsForCall := fake.listDownloadTasksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ListDownloadTasksReturns(result1 []types.DownloadTask, result2 error) {
+	fake.listDownloadTasksMutex.Lock()
+	defer fake.listDownloadTasksMutex.Unlock()
+	fake.ListDownloadTasksStub = nil
+	fake.listDownloadTasksReturns = struct {
+		result1 []types.DownloadTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListDownloadTasksReturnsOnCall(i int, result1 []types.DownloadTask, result2 error) {
+	fake.listDownloadTasksMutex.Lock()
+	defer fake.listDownloadTasksMutex.Unlock()
+	fake.ListDownloadTasksStub = nil
+	if fake.listDownloadTasksReturnsOnCall == nil {
+		fake.listDownloadTasksReturnsOnCall = make(map[int]struct {
+			result1 []types.DownloadTask
+			result2 error
+		})
+	}
+	fake.listDownloadTasksReturnsOnCall[i] = struct {
+		result1 []types.DownloadTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListFileSystemTasks(arg1 context.Context) ([]types.FileSystemTask, error) {
+	fake.listFileSystemTasksMutex.Lock()
+	ret, specificReturn := fake.listFileSystemTasksReturnsOnCall[len(fake.listFileSystemTasksArgsForCall)]
+	fake.listFileSystemTasksArgsForCall = append(fake.listFileSystemTasksArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListFileSystemTasksStub
+	fakeReturns := fake.listFileSystemTasksReturns
+	fake.recordInvocation("ListFileSystemTasks", []interface{}{arg1})
+	fake.listFileSystemTasksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ListFileSystemTasksCallCount() int {
+	fake.listFileSystemTasksMutex.RLock()
+	defer fake.listFileSystemTasksMutex.RUnlock()
+	return len(fake.listFileSystemTasksArgsForCall)
+}
+
+func (fake *Client) ListFileSystemTasksCalls(stub func(context.Context) ([]types.FileSystemTask, error)) {
+	fake.listFileSystemTasksMutex.Lock()
+	defer fake.listFileSystemTasksMutex.Unlock()
+	fake.ListFileSystemTasksStub = stub
+}
+
+func (fake *Client) ListFileSystemTasksArgsForCall(i int) context.Context {
+	fake.listFileSystemTasksMutex.RLock()
+	defer fake.listFileSystemTasksMutex.RUnlock()
+	argsForCall := fake.listFileSystemTasksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ListFileSystemTasksReturns(result1 []types.FileSystemTask, result2 error) {
+	fake.listFileSystemTasksMutex.Lock()
+	defer fake.listFileSystemTasksMutex.Unlock()
+	fake.ListFileSystemTasksStub = nil
+	fake.listFileSystemTasksReturns = struct {
+		result1 []types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListFileSystemTasksReturnsOnCall(i int, result1 []types.FileSystemTask, result2 error) {
+	fake.listFileSystemTasksMutex.Lock()
+	defer fake.listFileSystemTasksMutex.Unlock()
+	fake.ListFileSystemTasksStub = nil
+	if fake.listFileSystemTasksReturnsOnCall == nil {
+		fake.listFileSystemTasksReturnsOnCall = make(map[int]struct {
+			result1 []types.FileSystemTask
+			result2 error
+		})
+	}
+	fake.listFileSystemTasksReturnsOnCall[i] = struct {
+		result1 []types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListLanInterfaceInfo(arg1 context.Context) ([]types.LanInfo, error) {
+	fake.listLanInterfaceInfoMutex.Lock()
+	ret, specificReturn := fake.listLanInterfaceInfoReturnsOnCall[len(fake.listLanInterfaceInfoArgsForCall)]
+	fake.listLanInterfaceInfoArgsForCall = append(fake.listLanInterfaceInfoArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListLanInterfaceInfoStub
+	fakeReturns := fake.listLanInterfaceInfoReturns
+	fake.recordInvocation("ListLanInterfaceInfo", []interface{}{arg1})
+	fake.listLanInterfaceInfoMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ListLanInterfaceInfoCallCount() int {
+	fake.listLanInterfaceInfoMutex.RLock()
+	defer fake.listLanInterfaceInfoMutex.RUnlock()
+	return len(fake.listLanInterfaceInfoArgsForCall)
+}
+
+func (fake *Client) ListLanInterfaceInfoCalls(stub func(context.Context) ([]types.LanInfo, error)) {
+	fake.listLanInterfaceInfoMutex.Lock()
+	defer fake.listLanInterfaceInfoMutex.Unlock()
+	fake.ListLanInterfaceInfoStub = stub
+}
+
+func (fake *Client) ListLanInterfaceInfoArgsForCall(i int) context.Context {
+	fake.listLanInterfaceInfoMutex.RLock()
+	defer fake.listLanInterfaceInfoMutex.RUnlock()
+	argsForCall := fake.listLanInterfaceInfoArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ListLanInterfaceInfoReturns(result1 []types.LanInfo, result2 error) {
+	fake.listLanInterfaceInfoMutex.Lock()
+	defer fake.listLanInterfaceInfoMutex.Unlock()
+	fake.ListLanInterfaceInfoStub = nil
+	fake.listLanInterfaceInfoReturns = struct {
+		result1 []types.LanInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListLanInterfaceInfoReturnsOnCall(i int, result1 []types.LanInfo, result2 error) {
+	fake.listLanInterfaceInfoMutex.Lock()
+	defer fake.listLanInterfaceInfoMutex.Unlock()
+	fake.ListLanInterfaceInfoStub = nil
+	if fake.listLanInterfaceInfoReturnsOnCall == nil {
+		fake.listLanInterfaceInfoReturnsOnCall = make(map[int]struct {
+			result1 []types.LanInfo
+			result2 error
+		})
+	}
+	fake.listLanInterfaceInfoReturnsOnCall[i] = struct {
+		result1 []types.LanInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListPortForwardingRules(arg1 context.Context) ([]types.PortForwardingRule, error) {
+	fake.listPortForwardingRulesMutex.Lock()
+	ret, specificReturn := fake.listPortForwardingRulesReturnsOnCall[len(fake.listPortForwardingRulesArgsForCall)]
+	fake.listPortForwardingRulesArgsForCall = append(fake.listPortForwardingRulesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListPortForwardingRulesStub
+	fakeReturns := fake.listPortForwardingRulesReturns
+	fake.recordInvocation("ListPortForwardingRules", []interface{}{arg1})
+	fake.listPortForwardingRulesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ListPortForwardingRulesCallCount() int {
+	fake.listPortForwardingRulesMutex.RLock()
+	defer fake.listPortForwardingRulesMutex.RUnlock()
+	return len(fake.listPortForwardingRulesArgsForCall)
+}
+
+func (fake *Client) ListPortForwardingRulesCalls(stub func(context.Context) ([]types.PortForwardingRule, error)) {
+	fake.listPortForwardingRulesMutex.Lock()
+	defer fake.listPortForwardingRulesMutex.Unlock()
+	fake.ListPortForwardingRulesStub = stub
+}
+
+func (fake *Client) ListPortForwardingRulesArgsForCall(i int) context.Context {
+	fake.listPortForwardingRulesMutex.RLock()
+	defer fake.listPortForwardingRulesMutex.RUnlock()
+	argsForCall := fake.listPortForwardingRulesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ListPortForwardingRulesReturns(result1 []types.PortForwardingRule, result2 error) {
+	fake.listPortForwardingRulesMutex.Lock()
+	defer fake.listPortForwardingRulesMutex.Unlock()
+	fake.ListPortForwardingRulesStub = nil
+	fake.listPortForwardingRulesReturns = struct {
+		result1 []types.PortForwardingRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListPortForwardingRulesReturnsOnCall(i int, result1 []types.PortForwardingRule, result2 error) {
+	fake.listPortForwardingRulesMutex.Lock()
+	defer fake.listPortForwardingRulesMutex.Unlock()
+	fake.ListPortForwardingRulesStub = nil
+	if fake.listPortForwardingRulesReturnsOnCall == nil {
+		fake.listPortForwardingRulesReturnsOnCall = make(map[int]struct {
+			result1 []types.PortForwardingRule
+			result2 error
+		})
+	}
+	fake.listPortForwardingRulesReturnsOnCall[i] = struct {
+		result1 []types.PortForwardingRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListUploadTasks(arg1 context.Context) ([]types.UploadTask, error) {
+	fake.listUploadTasksMutex.Lock()
+	ret, specificReturn := fake.listUploadTasksReturnsOnCall[len(fake.listUploadTasksArgsForCall)]
+	fake.listUploadTasksArgsForCall = append(fake.listUploadTasksArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListUploadTasksStub
+	fakeReturns := fake.listUploadTasksReturns
+	fake.recordInvocation("ListUploadTasks", []interface{}{arg1})
+	fake.listUploadTasksMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ListUploadTasksCallCount() int {
+	fake.listUploadTasksMutex.RLock()
+	defer fake.listUploadTasksMutex.RUnlock()
+	return len(fake.listUploadTasksArgsForCall)
+}
+
+func (fake *Client) ListUploadTasksCalls(stub func(context.Context) ([]types.UploadTask, error)) {
+	fake.listUploadTasksMutex.Lock()
+	defer fake.listUploadTasksMutex.Unlock()
+	fake.ListUploadTasksStub = stub
+}
+
+func (fake *Client) ListUploadTasksArgsForCall(i int) context.Context {
+	fake.listUploadTasksMutex.RLock()
+	defer fake.listUploadTasksMutex.RUnlock()
+	argsForCall := fake.listUploadTasksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ListUploadTasksReturns(result1 []types.UploadTask, result2 error) {
+	fake.listUploadTasksMutex.Lock()
+	defer fake.listUploadTasksMutex.Unlock()
+	fake.ListUploadTasksStub = nil
+	fake.listUploadTasksReturns = struct {
+		result1 []types.UploadTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListUploadTasksReturnsOnCall(i int, result1 []types.UploadTask, result2 error) {
+	fake.listUploadTasksMutex.Lock()
+	defer fake.listUploadTasksMutex.Unlock()
+	fake.ListUploadTasksStub = nil
+	if fake.listUploadTasksReturnsOnCall == nil {
+		fake.listUploadTasksReturnsOnCall = make(map[int]struct {
+			result1 []types.UploadTask
+			result2 error
+		})
+	}
+	fake.listUploadTasksReturnsOnCall[i] = struct {
+		result1 []types.UploadTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListVPNUsers(arg1 context.Context) ([]types.VPNUser, error) {
+	fake.listVPNUsersMutex.Lock()
+	ret, specificReturn := fake.listVPNUsersReturnsOnCall[len(fake.listVPNUsersArgsForCall)]
+	fake.listVPNUsersArgsForCall = append(fake.listVPNUsersArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListVPNUsersStub
+	fakeReturns := fake.listVPNUsersReturns
+	fake.recordInvocation("ListVPNUsers", []interface{}{arg1})
+	fake.listVPNUsersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ListVPNUsersCallCount() int {
+	fake.listVPNUsersMutex.RLock()
+	defer fake.listVPNUsersMutex.RUnlock()
+	return len(fake.listVPNUsersArgsForCall)
+}
+
+func (fake *Client) ListVPNUsersCalls(stub func(context.Context) ([]types.VPNUser, error)) {
+	fake.listVPNUsersMutex.Lock()
+	defer fake.listVPNUsersMutex.Unlock()
+	fake.ListVPNUsersStub = stub
+}
+
+func (fake *Client) ListVPNUsersArgsForCall(i int) context.Context {
+	fake.listVPNUsersMutex.RLock()
+	defer fake.listVPNUsersMutex.RUnlock()
+	argsForCall := fake.listVPNUsersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ListVPNUsersReturns(result1 []types.VPNUser, result2 error) {
+	fake.listVPNUsersMutex.Lock()
+	defer fake.listVPNUsersMutex.Unlock()
+	fake.ListVPNUsersStub = nil
+	fake.listVPNUsersReturns = struct {
+		result1 []types.VPNUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListVPNUsersReturnsOnCall(i int, result1 []types.VPNUser, result2 error) {
+	fake.listVPNUsersMutex.Lock()
+	defer fake.listVPNUsersMutex.Unlock()
+	fake.ListVPNUsersStub = nil
+	if fake.listVPNUsersReturnsOnCall == nil {
+		fake.listVPNUsersReturnsOnCall = make(map[int]struct {
+			result1 []types.VPNUser
+			result2 error
+		})
+	}
+	fake.listVPNUsersReturnsOnCall[i] = struct {
+		result1 []types.VPNUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListVirtualMachines(arg1 context.Context) ([]types.VirtualMachine, error) {
+	fake.listVirtualMachinesMutex.Lock()
+	ret, specificReturn := fake.listVirtualMachinesReturnsOnCall[len(fake.listVirtualMachinesArgsForCall)]
+	fake.listVirtualMachinesArgsForCall = append(fake.listVirtualMachinesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.ListVirtualMachinesStub
+	fakeReturns := fake.listVirtualMachinesReturns
+	fake.recordInvocation("ListVirtualMachines", []interface{}{arg1})
+	fake.listVirtualMachinesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ListVirtualMachinesCallCount() int {
+	fake.listVirtualMachinesMutex.RLock()
+	defer fake.listVirtualMachinesMutex.RUnlock()
+	return len(fake.listVirtualMachinesArgsForCall)
+}
+
+func (fake *Client) ListVirtualMachinesCalls(stub func(context.Context) ([]types.VirtualMachine, error)) {
+	fake.listVirtualMachinesMutex.Lock()
+	defer fake.listVirtualMachinesMutex.Unlock()
+	fake.ListVirtualMachinesStub = stub
+}
+
+func (fake *Client) ListVirtualMachinesArgsForCall(i int) context.Context {
+	fake.listVirtualMachinesMutex.RLock()
+	defer fake.listVirtualMachinesMutex.RUnlock()
+	argsForCall := fake.listVirtualMachinesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) ListVirtualMachinesReturns(result1 []types.VirtualMachine, result2 error) {
+	fake.listVirtualMachinesMutex.Lock()
+	defer fake.listVirtualMachinesMutex.Unlock()
+	fake.ListVirtualMachinesStub = nil
+	fake.listVirtualMachinesReturns = struct {
+		result1 []types.VirtualMachine
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListVirtualMachinesReturnsOnCall(i int, result1 []types.VirtualMachine, result2 error) {
+	fake.listVirtualMachinesMutex.Lock()
+	defer fake.listVirtualMachinesMutex.Unlock()
+	fake.ListVirtualMachinesStub = nil
+	if fake.listVirtualMachinesReturnsOnCall == nil {
+		fake.listVirtualMachinesReturnsOnCall = make(map[int]struct {
+			result1 []types.VirtualMachine
+			result2 error
+		})
+	}
+	fake.listVirtualMachinesReturnsOnCall[i] = struct {
+		result1 []types.VirtualMachine
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListenEvents(arg1 context.Context, arg2 []types.EventDescription) (chan types.Event, error) {
+	var arg2Copy []types.EventDescription
+	if arg2 != nil {
+		arg2Copy = make([]types.EventDescription, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.listenEventsMutex.Lock()
+	ret, specificReturn := fake.listenEventsReturnsOnCall[len(fake.listenEventsArgsForCall)]
+	fake.listenEventsArgsForCall = append(fake.listenEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 []types.EventDescription
+	}{arg1, arg2Copy})
+	stub := fake.ListenEventsStub
+	fakeReturns := fake.listenEventsReturns
+	fake.recordInvocation("ListenEvents", []interface{}{arg1, arg2Copy})
+	fake.listenEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ListenEventsCallCount() int {
+	fake.listenEventsMutex.RLock()
+	defer fake.listenEventsMutex.RUnlock()
+	return len(fake.listenEventsArgsForCall)
+}
+
+func (fake *Client) ListenEventsCalls(stub func(context.Context, []types.EventDescription) (chan types.Event, error)) {
+	fake.listenEventsMutex.Lock()
+	defer fake.listenEventsMutex.Unlock()
+	fake.ListenEventsStub = stub
+}
+
+func (fake *Client) ListenEventsArgsForCall(i int) (context.Context, []types.EventDescription) {
+	fake.listenEventsMutex.RLock()
+	defer fake.listenEventsMutex.RUnlock()
+	argsForCall := fake.listenEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) ListenEventsReturns(result1 chan types.Event, result2 error) {
+	fake.listenEventsMutex.Lock()
+	defer fake.listenEventsMutex.Unlock()
+	fake.ListenEventsStub = nil
+	fake.listenEventsReturns = struct {
+		result1 chan types.Event
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ListenEventsReturnsOnCall(i int, result1 chan types.Event, result2 error) {
+	fake.listenEventsMutex.Lock()
+	defer fake.listenEventsMutex.Unlock()
+	fake.ListenEventsStub = nil
+	if fake.listenEventsReturnsOnCall == nil {
+		fake.listenEventsReturnsOnCall = make(map[int]struct {
+			result1 chan types.Event
+			result2 error
+		})
+	}
+	fake.listenEventsReturnsOnCall[i] = struct {
+		result1 chan types.Event
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) Login(arg1 context.Context) (types.Permissions, error) {
+	fake.loginMutex.Lock()
+	ret, specificReturn := fake.loginReturnsOnCall[len(fake.loginArgsForCall)]
+	fake.loginArgsForCall = append(fake.loginArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.LoginStub
+	fakeReturns := fake.loginReturns
+	fake.recordInvocation("Login", []interface{}{arg1})
+	fake.loginMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) LoginCallCount() int {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	return len(fake.loginArgsForCall)
+}
+
+func (fake *Client) LoginCalls(stub func(context.Context) (types.Permissions, error)) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = stub
+}
+
+func (fake *Client) LoginArgsForCall(i int) context.Context {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	argsForCall := fake.loginArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) LoginReturns(result1 types.Permissions, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	fake.loginReturns = struct {
+		result1 types.Permissions
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) LoginReturnsOnCall(i int, result1 types.Permissions, result2 error) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = nil
+	if fake.loginReturnsOnCall == nil {
+		fake.loginReturnsOnCall = make(map[int]struct {
+			result1 types.Permissions
+			result2 error
+		})
+	}
+	fake.loginReturnsOnCall[i] = struct {
+		result1 types.Permissions
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) Logout(arg1 context.Context) error {
+	fake.logoutMutex.Lock()
+	ret, specificReturn := fake.logoutReturnsOnCall[len(fake.logoutArgsForCall)]
+	fake.logoutArgsForCall = append(fake.logoutArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.LogoutStub
+	fakeReturns := fake.logoutReturns
+	fake.recordInvocation("Logout", []interface{}{arg1})
+	fake.logoutMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) LogoutCallCount() int {
+	fake.logoutMutex.RLock()
+	defer fake.logoutMutex.RUnlock()
+	return len(fake.logoutArgsForCall)
+}
+
+func (fake *Client) LogoutCalls(stub func(context.Context) error) {
+	fake.logoutMutex.Lock()
+	defer fake.logoutMutex.Unlock()
+	fake.LogoutStub = stub
+}
+
+func (fake *Client) LogoutArgsForCall(i int) context.Context {
+	fake.logoutMutex.RLock()
+	defer fake.logoutMutex.RUnlock()
+	argsForCall := fake.logoutArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) LogoutReturns(result1 error) {
+	fake.logoutMutex.Lock()
+	defer fake.logoutMutex.Unlock()
+	fake.LogoutStub = nil
+	fake.logoutReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) LogoutReturnsOnCall(i int, result1 error) {
+	fake.logoutMutex.Lock()
+	defer fake.logoutMutex.Unlock()
+	fake.LogoutStub = nil
+	if fake.logoutReturnsOnCall == nil {
+		fake.logoutReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.logoutReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) MoveFiles(arg1 context.Context, arg2 []string, arg3 string, arg4 types.FileMoveMode) (types.FileSystemTask, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.moveFilesMutex.Lock()
+	ret, specificReturn := fake.moveFilesReturnsOnCall[len(fake.moveFilesArgsForCall)]
+	fake.moveFilesArgsForCall = append(fake.moveFilesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+		arg3 string
+		arg4 types.FileMoveMode
+	}{arg1, arg2Copy, arg3, arg4})
+	stub := fake.MoveFilesStub
+	fakeReturns := fake.moveFilesReturns
+	fake.recordInvocation("MoveFiles", []interface{}{arg1, arg2Copy, arg3, arg4})
+	fake.moveFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) MoveFilesCallCount() int {
+	fake.moveFilesMutex.RLock()
+	defer fake.moveFilesMutex.RUnlock()
+	return len(fake.moveFilesArgsForCall)
+}
+
+func (fake *Client) MoveFilesCalls(stub func(context.Context, []string, string, types.FileMoveMode) (types.FileSystemTask, error)) {
+	fake.moveFilesMutex.Lock()
+	defer fake.moveFilesMutex.Unlock()
+	fake.MoveFilesStub = stub
+}
+
+func (fake *Client) MoveFilesArgsForCall(i int) (context.Context, []string, string, types.FileMoveMode) {
+	fake.moveFilesMutex.RLock()
+	defer fake.moveFilesMutex.RUnlock()
+	argsForCall := fake.moveFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *Client) MoveFilesReturns(result1 types.FileSystemTask, result2 error) {
+	fake.moveFilesMutex.Lock()
+	defer fake.moveFilesMutex.Unlock()
+	fake.MoveFilesStub = nil
+	fake.moveFilesReturns = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) MoveFilesReturnsOnCall(i int, result1 types.FileSystemTask, result2 error) {
+	fake.moveFilesMutex.Lock()
+	defer fake.moveFilesMutex.Unlock()
+	fake.MoveFilesStub = nil
+	if fake.moveFilesReturnsOnCall == nil {
+		fake.moveFilesReturnsOnCall = make(map[int]struct {
+			result1 types.FileSystemTask
+			result2 error
+		})
+	}
+	fake.moveFilesReturnsOnCall[i] = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) RemoveFiles(arg1 context.Context, arg2 []string) (types.FileSystemTask, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.removeFilesMutex.Lock()
+	ret, specificReturn := fake.removeFilesReturnsOnCall[len(fake.removeFilesArgsForCall)]
+	fake.removeFilesArgsForCall = append(fake.removeFilesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []string
+	}{arg1, arg2Copy})
+	stub := fake.RemoveFilesStub
+	fakeReturns := fake.removeFilesReturns
+	fake.recordInvocation("RemoveFiles", []interface{}{arg1, arg2Copy})
+	fake.removeFilesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) RemoveFilesCallCount() int {
+	fake.removeFilesMutex.RLock()
+	defer fake.removeFilesMutex.RUnlock()
+	return len(fake.removeFilesArgsForCall)
+}
+
+func (fake *Client) RemoveFilesCalls(stub func(context.Context, []string) (types.FileSystemTask, error)) {
+	fake.removeFilesMutex.Lock()
+	defer fake.removeFilesMutex.Unlock()
+	fake.RemoveFilesStub = stub
+}
+
+func (fake *Client) RemoveFilesArgsForCall(i int) (context.Context, []string) {
+	fake.removeFilesMutex.RLock()
+	defer fake.removeFilesMutex.RUnlock()
+	argsForCall := fake.removeFilesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) RemoveFilesReturns(result1 types.FileSystemTask, result2 error) {
+	fake.removeFilesMutex.Lock()
+	defer fake.removeFilesMutex.Unlock()
+	fake.RemoveFilesStub = nil
+	fake.removeFilesReturns = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) RemoveFilesReturnsOnCall(i int, result1 types.FileSystemTask, result2 error) {
+	fake.removeFilesMutex.Lock()
+	defer fake.removeFilesMutex.Unlock()
+	fake.RemoveFilesStub = nil
+	if fake.removeFilesReturnsOnCall == nil {
+		fake.removeFilesReturnsOnCall = make(map[int]struct {
+			result1 types.FileSystemTask
+			result2 error
+		})
+	}
+	fake.removeFilesReturnsOnCall[i] = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ResizeVirtualDisk(arg1 context.Context, arg2 types.VirtualDisksResizePayload) (int64, error) {
+	fake.resizeVirtualDiskMutex.Lock()
+	ret, specificReturn := fake.resizeVirtualDiskReturnsOnCall[len(fake.resizeVirtualDiskArgsForCall)]
+	fake.resizeVirtualDiskArgsForCall = append(fake.resizeVirtualDiskArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.VirtualDisksResizePayload
+	}{arg1, arg2})
+	stub := fake.ResizeVirtualDiskStub
+	fakeReturns := fake.resizeVirtualDiskReturns
+	fake.recordInvocation("ResizeVirtualDisk", []interface{}{arg1, arg2})
+	fake.resizeVirtualDiskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) ResizeVirtualDiskCallCount() int {
+	fake.resizeVirtualDiskMutex.RLock()
+	defer fake.resizeVirtualDiskMutex.RUnlock()
+	return len(fake.resizeVirtualDiskArgsForCall)
+}
+
+func (fake *Client) ResizeVirtualDiskCalls(stub func(context.Context, types.VirtualDisksResizePayload) (int64, error)) {
+	fake.resizeVirtualDiskMutex.Lock()
+	defer fake.resizeVirtualDiskMutex.Unlock()
+	fake.ResizeVirtualDiskStub = stub
+}
+
+func (fake *Client) ResizeVirtualDiskArgsForCall(i int) (context.Context, types.VirtualDisksResizePayload) {
+	fake.resizeVirtualDiskMutex.RLock()
+	defer fake.resizeVirtualDiskMutex.RUnlock()
+	argsForCall := fake.resizeVirtualDiskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) ResizeVirtualDiskReturns(result1 int64, result2 error) {
+	fake.resizeVirtualDiskMutex.Lock()
+	defer fake.resizeVirtualDiskMutex.Unlock()
+	fake.ResizeVirtualDiskStub = nil
+	fake.resizeVirtualDiskReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) ResizeVirtualDiskReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.resizeVirtualDiskMutex.Lock()
+	defer fake.resizeVirtualDiskMutex.Unlock()
+	fake.ResizeVirtualDiskStub = nil
+	if fake.resizeVirtualDiskReturnsOnCall == nil {
+		fake.resizeVirtualDiskReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.resizeVirtualDiskReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) StartVirtualMachine(arg1 context.Context, arg2 int64) error {
+	fake.startVirtualMachineMutex.Lock()
+	ret, specificReturn := fake.startVirtualMachineReturnsOnCall[len(fake.startVirtualMachineArgsForCall)]
+	fake.startVirtualMachineArgsForCall = append(fake.startVirtualMachineArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.StartVirtualMachineStub
+	fakeReturns := fake.startVirtualMachineReturns
+	fake.recordInvocation("StartVirtualMachine", []interface{}{arg1, arg2})
+	fake.startVirtualMachineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) StartVirtualMachineCallCount() int {
+	fake.startVirtualMachineMutex.RLock()
+	defer fake.startVirtualMachineMutex.RUnlock()
+	return len(fake.startVirtualMachineArgsForCall)
+}
+
+func (fake *Client) StartVirtualMachineCalls(stub func(context.Context, int64) error) {
+	fake.startVirtualMachineMutex.Lock()
+	defer fake.startVirtualMachineMutex.Unlock()
+	fake.StartVirtualMachineStub = stub
+}
+
+func (fake *Client) StartVirtualMachineArgsForCall(i int) (context.Context, int64) {
+	fake.startVirtualMachineMutex.RLock()
+	defer fake.startVirtualMachineMutex.RUnlock()
+	argsForCall := fake.startVirtualMachineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) StartVirtualMachineReturns(result1 error) {
+	fake.startVirtualMachineMutex.Lock()
+	defer fake.startVirtualMachineMutex.Unlock()
+	fake.StartVirtualMachineStub = nil
+	fake.startVirtualMachineReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) StartVirtualMachineReturnsOnCall(i int, result1 error) {
+	fake.startVirtualMachineMutex.Lock()
+	defer fake.startVirtualMachineMutex.Unlock()
+	fake.StartVirtualMachineStub = nil
+	if fake.startVirtualMachineReturnsOnCall == nil {
+		fake.startVirtualMachineReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.startVirtualMachineReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) StopVirtualMachine(arg1 context.Context, arg2 int64) error {
+	fake.stopVirtualMachineMutex.Lock()
+	ret, specificReturn := fake.stopVirtualMachineReturnsOnCall[len(fake.stopVirtualMachineArgsForCall)]
+	fake.stopVirtualMachineArgsForCall = append(fake.stopVirtualMachineArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.StopVirtualMachineStub
+	fakeReturns := fake.stopVirtualMachineReturns
+	fake.recordInvocation("StopVirtualMachine", []interface{}{arg1, arg2})
+	fake.stopVirtualMachineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) StopVirtualMachineCallCount() int {
+	fake.stopVirtualMachineMutex.RLock()
+	defer fake.stopVirtualMachineMutex.RUnlock()
+	return len(fake.stopVirtualMachineArgsForCall)
+}
+
+func (fake *Client) StopVirtualMachineCalls(stub func(context.Context, int64) error) {
+	fake.stopVirtualMachineMutex.Lock()
+	defer fake.stopVirtualMachineMutex.Unlock()
+	fake.StopVirtualMachineStub = stub
+}
+
+func (fake *Client) StopVirtualMachineArgsForCall(i int) (context.Context, int64) {
+	fake.stopVirtualMachineMutex.RLock()
+	defer fake.stopVirtualMachineMutex.RUnlock()
+	argsForCall := fake.stopVirtualMachineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) StopVirtualMachineReturns(result1 error) {
+	fake.stopVirtualMachineMutex.Lock()
+	defer fake.stopVirtualMachineMutex.Unlock()
+	fake.StopVirtualMachineStub = nil
+	fake.stopVirtualMachineReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) StopVirtualMachineReturnsOnCall(i int, result1 error) {
+	fake.stopVirtualMachineMutex.Lock()
+	defer fake.stopVirtualMachineMutex.Unlock()
+	fake.StopVirtualMachineStub = nil
+	if fake.stopVirtualMachineReturnsOnCall == nil {
+		fake.stopVirtualMachineReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.stopVirtualMachineReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) UpdateDHCPStaticLease(arg1 context.Context, arg2 string, arg3 types.DHCPStaticLeasePayload) (types.LanInterfaceHost, error) {
+	fake.updateDHCPStaticLeaseMutex.Lock()
+	ret, specificReturn := fake.updateDHCPStaticLeaseReturnsOnCall[len(fake.updateDHCPStaticLeaseArgsForCall)]
+	fake.updateDHCPStaticLeaseArgsForCall = append(fake.updateDHCPStaticLeaseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 types.DHCPStaticLeasePayload
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateDHCPStaticLeaseStub
+	fakeReturns := fake.updateDHCPStaticLeaseReturns
+	fake.recordInvocation("UpdateDHCPStaticLease", []interface{}{arg1, arg2, arg3})
+	fake.updateDHCPStaticLeaseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) UpdateDHCPStaticLeaseCallCount() int {
+	fake.updateDHCPStaticLeaseMutex.RLock()
+	defer fake.updateDHCPStaticLeaseMutex.RUnlock()
+	return len(fake.updateDHCPStaticLeaseArgsForCall)
+}
+
+func (fake *Client) UpdateDHCPStaticLeaseCalls(stub func(context.Context, string, types.DHCPStaticLeasePayload) (types.LanInterfaceHost, error)) {
+	fake.updateDHCPStaticLeaseMutex.Lock()
+	defer fake.updateDHCPStaticLeaseMutex.Unlock()
+	fake.UpdateDHCPStaticLeaseStub = stub
+}
+
+func (fake *Client) UpdateDHCPStaticLeaseArgsForCall(i int) (context.Context, string, types.DHCPStaticLeasePayload) {
+	fake.updateDHCPStaticLeaseMutex.RLock()
+	defer fake.updateDHCPStaticLeaseMutex.RUnlock()
+	argsForCall := fake.updateDHCPStaticLeaseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Client) UpdateDHCPStaticLeaseReturns(result1 types.LanInterfaceHost, result2 error) {
+	fake.updateDHCPStaticLeaseMutex.Lock()
+	defer fake.updateDHCPStaticLeaseMutex.Unlock()
+	fake.UpdateDHCPStaticLeaseStub = nil
+	fake.updateDHCPStaticLeaseReturns = struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateDHCPStaticLeaseReturnsOnCall(i int, result1 types.LanInterfaceHost, result2 error) {
+	fake.updateDHCPStaticLeaseMutex.Lock()
+	defer fake.updateDHCPStaticLeaseMutex.Unlock()
+	fake.UpdateDHCPStaticLeaseStub = nil
+	if fake.updateDHCPStaticLeaseReturnsOnCall == nil {
+		fake.updateDHCPStaticLeaseReturnsOnCall = make(map[int]struct {
+			result1 types.LanInterfaceHost
+			result2 error
+		})
+	}
+	fake.updateDHCPStaticLeaseReturnsOnCall[i] = struct {
+		result1 types.LanInterfaceHost
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateDownloadConfiguration(arg1 context.Context, arg2 types.DownloadConfiguration) (types.DownloadConfiguration, error) {
+	fake.updateDownloadConfigurationMutex.Lock()
+	ret, specificReturn := fake.updateDownloadConfigurationReturnsOnCall[len(fake.updateDownloadConfigurationArgsForCall)]
+	fake.updateDownloadConfigurationArgsForCall = append(fake.updateDownloadConfigurationArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.DownloadConfiguration
+	}{arg1, arg2})
+	stub := fake.UpdateDownloadConfigurationStub
+	fakeReturns := fake.updateDownloadConfigurationReturns
+	fake.recordInvocation("UpdateDownloadConfiguration", []interface{}{arg1, arg2})
+	fake.updateDownloadConfigurationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) UpdateDownloadConfigurationCallCount() int {
+	fake.updateDownloadConfigurationMutex.RLock()
+	defer fake.updateDownloadConfigurationMutex.RUnlock()
+	return len(fake.updateDownloadConfigurationArgsForCall)
+}
+
+func (fake *Client) UpdateDownloadConfigurationCalls(stub func(context.Context, types.DownloadConfiguration) (types.DownloadConfiguration, error)) {
+	fake.updateDownloadConfigurationMutex.Lock()
+	defer fake.updateDownloadConfigurationMutex.Unlock()
+	fake.UpdateDownloadConfigurationStub = stub
+}
+
+func (fake *Client) UpdateDownloadConfigurationArgsForCall(i int) (context.Context, types.DownloadConfiguration) {
+	fake.updateDownloadConfigurationMutex.RLock()
+	defer fake.updateDownloadConfigurationMutex.RUnlock()
+	argsForCall := fake.updateDownloadConfigurationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) UpdateDownloadConfigurationReturns(result1 types.DownloadConfiguration, result2 error) {
+	fake.updateDownloadConfigurationMutex.Lock()
+	defer fake.updateDownloadConfigurationMutex.Unlock()
+	fake.UpdateDownloadConfigurationStub = nil
+	fake.updateDownloadConfigurationReturns = struct {
+		result1 types.DownloadConfiguration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateDownloadConfigurationReturnsOnCall(i int, result1 types.DownloadConfiguration, result2 error) {
+	fake.updateDownloadConfigurationMutex.Lock()
+	defer fake.updateDownloadConfigurationMutex.Unlock()
+	fake.UpdateDownloadConfigurationStub = nil
+	if fake.updateDownloadConfigurationReturnsOnCall == nil {
+		fake.updateDownloadConfigurationReturnsOnCall = make(map[int]struct {
+			result1 types.DownloadConfiguration
+			result2 error
+		})
+	}
+	fake.updateDownloadConfigurationReturnsOnCall[i] = struct {
+		result1 types.DownloadConfiguration
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateDownloadTask(arg1 context.Context, arg2 int64, arg3 types.DownloadTaskUpdate) error {
+	fake.updateDownloadTaskMutex.Lock()
+	ret, specificReturn := fake.updateDownloadTaskReturnsOnCall[len(fake.updateDownloadTaskArgsForCall)]
+	fake.updateDownloadTaskArgsForCall = append(fake.updateDownloadTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 types.DownloadTaskUpdate
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateDownloadTaskStub
+	fakeReturns := fake.updateDownloadTaskReturns
+	fake.recordInvocation("UpdateDownloadTask", []interface{}{arg1, arg2, arg3})
+	fake.updateDownloadTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) UpdateDownloadTaskCallCount() int {
+	fake.updateDownloadTaskMutex.RLock()
+	defer fake.updateDownloadTaskMutex.RUnlock()
+	return len(fake.updateDownloadTaskArgsForCall)
+}
+
+func (fake *Client) UpdateDownloadTaskCalls(stub func(context.Context, int64, types.DownloadTaskUpdate) error) {
+	fake.updateDownloadTaskMutex.Lock()
+	defer fake.updateDownloadTaskMutex.Unlock()
+	fake.UpdateDownloadTaskStub = stub
+}
+
+func (fake *Client) UpdateDownloadTaskArgsForCall(i int) (context.Context, int64, types.DownloadTaskUpdate) {
+	fake.updateDownloadTaskMutex.RLock()
+	defer fake.updateDownloadTaskMutex.RUnlock()
+	argsForCall := fake.updateDownloadTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Client) UpdateDownloadTaskReturns(result1 error) {
+	fake.updateDownloadTaskMutex.Lock()
+	defer fake.updateDownloadTaskMutex.Unlock()
+	fake.UpdateDownloadTaskStub = nil
+	fake.updateDownloadTaskReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) UpdateDownloadTaskReturnsOnCall(i int, result1 error) {
+	fake.updateDownloadTaskMutex.Lock()
+	defer fake.updateDownloadTaskMutex.Unlock()
+	fake.UpdateDownloadTaskStub = nil
+	if fake.updateDownloadTaskReturnsOnCall == nil {
+		fake.updateDownloadTaskReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateDownloadTaskReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Client) UpdateFileSystemTask(arg1 context.Context, arg2 int64, arg3 types.FileSytemTaskUpdate) (types.FileSystemTask, error) {
+	fake.updateFileSystemTaskMutex.Lock()
+	ret, specificReturn := fake.updateFileSystemTaskReturnsOnCall[len(fake.updateFileSystemTaskArgsForCall)]
+	fake.updateFileSystemTaskArgsForCall = append(fake.updateFileSystemTaskArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 types.FileSytemTaskUpdate
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateFileSystemTaskStub
+	fakeReturns := fake.updateFileSystemTaskReturns
+	fake.recordInvocation("UpdateFileSystemTask", []interface{}{arg1, arg2, arg3})
+	fake.updateFileSystemTaskMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) UpdateFileSystemTaskCallCount() int {
+	fake.updateFileSystemTaskMutex.RLock()
+	defer fake.updateFileSystemTaskMutex.RUnlock()
+	return len(fake.updateFileSystemTaskArgsForCall)
+}
+
+func (fake *Client) UpdateFileSystemTaskCalls(stub func(context.Context, int64, types.FileSytemTaskUpdate) (types.FileSystemTask, error)) {
+	fake.updateFileSystemTaskMutex.Lock()
+	defer fake.updateFileSystemTaskMutex.Unlock()
+	fake.UpdateFileSystemTaskStub = stub
+}
+
+func (fake *Client) UpdateFileSystemTaskArgsForCall(i int) (context.Context, int64, types.FileSytemTaskUpdate) {
+	fake.updateFileSystemTaskMutex.RLock()
+	defer fake.updateFileSystemTaskMutex.RUnlock()
+	argsForCall := fake.updateFileSystemTaskArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Client) UpdateFileSystemTaskReturns(result1 types.FileSystemTask, result2 error) {
+	fake.updateFileSystemTaskMutex.Lock()
+	defer fake.updateFileSystemTaskMutex.Unlock()
+	fake.UpdateFileSystemTaskStub = nil
+	fake.updateFileSystemTaskReturns = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateFileSystemTaskReturnsOnCall(i int, result1 types.FileSystemTask, result2 error) {
+	fake.updateFileSystemTaskMutex.Lock()
+	defer fake.updateFileSystemTaskMutex.Unlock()
+	fake.UpdateFileSystemTaskStub = nil
+	if fake.updateFileSystemTaskReturnsOnCall == nil {
+		fake.updateFileSystemTaskReturnsOnCall = make(map[int]struct {
+			result1 types.FileSystemTask
+			result2 error
+		})
+	}
+	fake.updateFileSystemTaskReturnsOnCall[i] = struct {
+		result1 types.FileSystemTask
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateLanConfig(arg1 context.Context, arg2 types.LanConfig) (types.LanConfig, error) {
+	fake.updateLanConfigMutex.Lock()
+	ret, specificReturn := fake.updateLanConfigReturnsOnCall[len(fake.updateLanConfigArgsForCall)]
+	fake.updateLanConfigArgsForCall = append(fake.updateLanConfigArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.LanConfig
+	}{arg1, arg2})
+	stub := fake.UpdateLanConfigStub
+	fakeReturns := fake.updateLanConfigReturns
+	fake.recordInvocation("UpdateLanConfig", []interface{}{arg1, arg2})
+	fake.updateLanConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) UpdateLanConfigCallCount() int {
+	fake.updateLanConfigMutex.RLock()
+	defer fake.updateLanConfigMutex.RUnlock()
+	return len(fake.updateLanConfigArgsForCall)
+}
+
+func (fake *Client) UpdateLanConfigCalls(stub func(context.Context, types.LanConfig) (types.LanConfig, error)) {
+	fake.updateLanConfigMutex.Lock()
+	defer fake.updateLanConfigMutex.Unlock()
+	fake.UpdateLanConfigStub = stub
+}
+
+func (fake *Client) UpdateLanConfigArgsForCall(i int) (context.Context, types.LanConfig) {
+	fake.updateLanConfigMutex.RLock()
+	defer fake.updateLanConfigMutex.RUnlock()
+	argsForCall := fake.updateLanConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) UpdateLanConfigReturns(result1 types.LanConfig, result2 error) {
+	fake.updateLanConfigMutex.Lock()
+	defer fake.updateLanConfigMutex.Unlock()
+	fake.UpdateLanConfigStub = nil
+	fake.updateLanConfigReturns = struct {
+		result1 types.LanConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateLanConfigReturnsOnCall(i int, result1 types.LanConfig, result2 error) {
+	fake.updateLanConfigMutex.Lock()
+	defer fake.updateLanConfigMutex.Unlock()
+	fake.UpdateLanConfigStub = nil
+	if fake.updateLanConfigReturnsOnCall == nil {
+		fake.updateLanConfigReturnsOnCall = make(map[int]struct {
+			result1 types.LanConfig
+			result2 error
+		})
+	}
+	fake.updateLanConfigReturnsOnCall[i] = struct {
+		result1 types.LanConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateOpenVPNServerConfig(arg1 context.Context, arg2 types.OpenVPNServerConfig) (types.OpenVPNServerConfig, error) {
+	fake.updateOpenVPNServerConfigMutex.Lock()
+	ret, specificReturn := fake.updateOpenVPNServerConfigReturnsOnCall[len(fake.updateOpenVPNServerConfigArgsForCall)]
+	fake.updateOpenVPNServerConfigArgsForCall = append(fake.updateOpenVPNServerConfigArgsForCall, struct {
+		arg1 context.Context
+		arg2 types.OpenVPNServerConfig
+	}{arg1, arg2})
+	stub := fake.UpdateOpenVPNServerConfigStub
+	fakeReturns := fake.updateOpenVPNServerConfigReturns
+	fake.recordInvocation("UpdateOpenVPNServerConfig", []interface{}{arg1, arg2})
+	fake.updateOpenVPNServerConfigMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) UpdateOpenVPNServerConfigCallCount() int {
+	fake.updateOpenVPNServerConfigMutex.RLock()
+	defer fake.updateOpenVPNServerConfigMutex.RUnlock()
+	return len(fake.updateOpenVPNServerConfigArgsForCall)
+}
+
+func (fake *Client) UpdateOpenVPNServerConfigCalls(stub func(context.Context, types.OpenVPNServerConfig) (types.OpenVPNServerConfig, error)) {
+	fake.updateOpenVPNServerConfigMutex.Lock()
+	defer fake.updateOpenVPNServerConfigMutex.Unlock()
+	fake.UpdateOpenVPNServerConfigStub = stub
+}
+
+func (fake *Client) UpdateOpenVPNServerConfigArgsForCall(i int) (context.Context, types.OpenVPNServerConfig) {
+	fake.updateOpenVPNServerConfigMutex.RLock()
+	defer fake.updateOpenVPNServerConfigMutex.RUnlock()
+	argsForCall := fake.updateOpenVPNServerConfigArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *Client) UpdateOpenVPNServerConfigReturns(result1 types.OpenVPNServerConfig, result2 error) {
+	fake.updateOpenVPNServerConfigMutex.Lock()
+	defer fake.updateOpenVPNServerConfigMutex.Unlock()
+	fake.UpdateOpenVPNServerConfigStub = nil
+	fake.updateOpenVPNServerConfigReturns = struct {
+		result1 types.OpenVPNServerConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateOpenVPNServerConfigReturnsOnCall(i int, result1 types.OpenVPNServerConfig, result2 error) {
+	fake.updateOpenVPNServerConfigMutex.Lock()
+	defer fake.updateOpenVPNServerConfigMutex.Unlock()
+	fake.UpdateOpenVPNServerConfigStub = nil
+	if fake.updateOpenVPNServerConfigReturnsOnCall == nil {
+		fake.updateOpenVPNServerConfigReturnsOnCall = make(map[int]struct {
+			result1 types.OpenVPNServerConfig
+			result2 error
+		})
+	}
+	fake.updateOpenVPNServerConfigReturnsOnCall[i] = struct {
+		result1 types.OpenVPNServerConfig
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdatePortForwardingRule(arg1 context.Context, arg2 int64, arg3 types.PortForwardingRulePayload) (types.PortForwardingRule, error) {
+	fake.updatePortForwardingRuleMutex.Lock()
+	ret, specificReturn := fake.updatePortForwardingRuleReturnsOnCall[len(fake.updatePortForwardingRuleArgsForCall)]
+	fake.updatePortForwardingRuleArgsForCall = append(fake.updatePortForwardingRuleArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 types.PortForwardingRulePayload
+	}{arg1, arg2, arg3})
+	stub := fake.UpdatePortForwardingRuleStub
+	fakeReturns := fake.updatePortForwardingRuleReturns
+	fake.recordInvocation("UpdatePortForwardingRule", []interface{}{arg1, arg2, arg3})
+	fake.updatePortForwardingRuleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) UpdatePortForwardingRuleCallCount() int {
+	fake.updatePortForwardingRuleMutex.RLock()
+	defer fake.updatePortForwardingRuleMutex.RUnlock()
+	return len(fake.updatePortForwardingRuleArgsForCall)
+}
+
+func (fake *Client) UpdatePortForwardingRuleCalls(stub func(context.Context, int64, types.PortForwardingRulePayload) (types.PortForwardingRule, error)) {
+	fake.updatePortForwardingRuleMutex.Lock()
+	defer fake.updatePortForwardingRuleMutex.Unlock()
+	fake.UpdatePortForwardingRuleStub = stub
+}
+
+func (fake *Client) UpdatePortForwardingRuleArgsForCall(i int) (context.Context, int64, types.PortForwardingRulePayload) {
+	fake.updatePortForwardingRuleMutex.RLock()
+	defer fake.updatePortForwardingRuleMutex.RUnlock()
+	argsForCall := fake.updatePortForwardingRuleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Client) UpdatePortForwardingRuleReturns(result1 types.PortForwardingRule, result2 error) {
+	fake.updatePortForwardingRuleMutex.Lock()
+	defer fake.updatePortForwardingRuleMutex.Unlock()
+	fake.UpdatePortForwardingRuleStub = nil
+	fake.updatePortForwardingRuleReturns = struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdatePortForwardingRuleReturnsOnCall(i int, result1 types.PortForwardingRule, result2 error) {
+	fake.updatePortForwardingRuleMutex.Lock()
+	defer fake.updatePortForwardingRuleMutex.Unlock()
+	fake.UpdatePortForwardingRuleStub = nil
+	if fake.updatePortForwardingRuleReturnsOnCall == nil {
+		fake.updatePortForwardingRuleReturnsOnCall = make(map[int]struct {
+			result1 types.PortForwardingRule
+			result2 error
+		})
+	}
+	fake.updatePortForwardingRuleReturnsOnCall[i] = struct {
+		result1 types.PortForwardingRule
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateVPNUser(arg1 context.Context, arg2 string, arg3 types.VPNUserPayload) (types.VPNUser, error) {
+	fake.updateVPNUserMutex.Lock()
+	ret, specificReturn := fake.updateVPNUserReturnsOnCall[len(fake.updateVPNUserArgsForCall)]
+	fake.updateVPNUserArgsForCall = append(fake.updateVPNUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 types.VPNUserPayload
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateVPNUserStub
+	fakeReturns := fake.updateVPNUserReturns
+	fake.recordInvocation("UpdateVPNUser", []interface{}{arg1, arg2, arg3})
+	fake.updateVPNUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) UpdateVPNUserCallCount() int {
+	fake.updateVPNUserMutex.RLock()
+	defer fake.updateVPNUserMutex.RUnlock()
+	return len(fake.updateVPNUserArgsForCall)
+}
+
+func (fake *Client) UpdateVPNUserCalls(stub func(context.Context, string, types.VPNUserPayload) (types.VPNUser, error)) {
+	fake.updateVPNUserMutex.Lock()
+	defer fake.updateVPNUserMutex.Unlock()
+	fake.UpdateVPNUserStub = stub
+}
+
+func (fake *Client) UpdateVPNUserArgsForCall(i int) (context.Context, string, types.VPNUserPayload) {
+	fake.updateVPNUserMutex.RLock()
+	defer fake.updateVPNUserMutex.RUnlock()
+	argsForCall := fake.updateVPNUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Client) UpdateVPNUserReturns(result1 types.VPNUser, result2 error) {
+	fake.updateVPNUserMutex.Lock()
+	defer fake.updateVPNUserMutex.Unlock()
+	fake.UpdateVPNUserStub = nil
+	fake.updateVPNUserReturns = struct {
+		result1 types.VPNUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateVPNUserReturnsOnCall(i int, result1 types.VPNUser, result2 error) {
+	fake.updateVPNUserMutex.Lock()
+	defer fake.updateVPNUserMutex.Unlock()
+	fake.UpdateVPNUserStub = nil
+	if fake.updateVPNUserReturnsOnCall == nil {
+		fake.updateVPNUserReturnsOnCall = make(map[int]struct {
+			result1 types.VPNUser
+			result2 error
+		})
+	}
+	fake.updateVPNUserReturnsOnCall[i] = struct {
+		result1 types.VPNUser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateVirtualMachine(arg1 context.Context, arg2 int64, arg3 types.VirtualMachinePayload) (types.VirtualMachine, error) {
+	fake.updateVirtualMachineMutex.Lock()
+	ret, specificReturn := fake.updateVirtualMachineReturnsOnCall[len(fake.updateVirtualMachineArgsForCall)]
+	fake.updateVirtualMachineArgsForCall = append(fake.updateVirtualMachineArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+		arg3 types.VirtualMachinePayload
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateVirtualMachineStub
+	fakeReturns := fake.updateVirtualMachineReturns
+	fake.recordInvocation("UpdateVirtualMachine", []interface{}{arg1, arg2, arg3})
+	fake.updateVirtualMachineMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Client) UpdateVirtualMachineCallCount() int {
+	fake.updateVirtualMachineMutex.RLock()
+	defer fake.updateVirtualMachineMutex.RUnlock()
+	return len(fake.updateVirtualMachineArgsForCall)
+}
+
+func (fake *Client) UpdateVirtualMachineCalls(stub func(context.Context, int64, types.VirtualMachinePayload) (types.VirtualMachine, error)) {
+	fake.updateVirtualMachineMutex.Lock()
+	defer fake.updateVirtualMachineMutex.Unlock()
+	fake.UpdateVirtualMachineStub = stub
+}
+
+func (fake *Client) UpdateVirtualMachineArgsForCall(i int) (context.Context, int64, types.VirtualMachinePayload) {
+	fake.updateVirtualMachineMutex.RLock()
+	defer fake.updateVirtualMachineMutex.RUnlock()
+	argsForCall := fake.updateVirtualMachineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Client) UpdateVirtualMachineReturns(result1 types.VirtualMachine, result2 error) {
+	fake.updateVirtualMachineMutex.Lock()
+	defer fake.updateVirtualMachineMutex.Unlock()
+	fake.UpdateVirtualMachineStub = nil
+	fake.updateVirtualMachineReturns = struct {
+		result1 types.VirtualMachine
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) UpdateVirtualMachineReturnsOnCall(i int, result1 types.VirtualMachine, result2 error) {
+	fake.updateVirtualMachineMutex.Lock()
+	defer fake.updateVirtualMachineMutex.Unlock()
+	fake.UpdateVirtualMachineStub = nil
+	if fake.updateVirtualMachineReturnsOnCall == nil {
+		fake.updateVirtualMachineReturnsOnCall = make(map[int]struct {
+			result1 types.VirtualMachine
+			result2 error
+		})
+	}
+	fake.updateVirtualMachineReturnsOnCall[i] = struct {
+		result1 types.VirtualMachine
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Client) WithAppID(arg1 string) client.Client {
+	fake.withAppIDMutex.Lock()
+	ret, specificReturn := fake.withAppIDReturnsOnCall[len(fake.withAppIDArgsForCall)]
+	fake.withAppIDArgsForCall = append(fake.withAppIDArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.WithAppIDStub
+	fakeReturns := fake.withAppIDReturns
+	fake.recordInvocation("WithAppID", []interface{}{arg1})
+	fake.withAppIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) WithAppIDCallCount() int {
+	fake.withAppIDMutex.RLock()
+	defer fake.withAppIDMutex.RUnlock()
+	return len(fake.withAppIDArgsForCall)
+}
+
+func (fake *Client) WithAppIDCalls(stub func(string) client.Client) {
+	fake.withAppIDMutex.Lock()
+	defer fake.withAppIDMutex.Unlock()
+	fake.WithAppIDStub = stub
+}
+
+func (fake *Client) WithAppIDArgsForCall(i int) string {
+	fake.withAppIDMutex.RLock()
+	defer fake.withAppIDMutex.RUnlock()
+	argsForCall := fake.withAppIDArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) WithAppIDReturns(result1 client.Client) {
+	fake.withAppIDMutex.Lock()
+	defer fake.withAppIDMutex.Unlock()
+	fake.WithAppIDStub = nil
+	fake.withAppIDReturns = struct {
+		result1 client.Client
+	}{result1}
+}
+
+func (fake *Client) WithAppIDReturnsOnCall(i int, result1 client.Client) {
+	fake.withAppIDMutex.Lock()
+	defer fake.withAppIDMutex.Unlock()
+	fake.WithAppIDStub = nil
+	if fake.withAppIDReturnsOnCall == nil {
+		fake.withAppIDReturnsOnCall = make(map[int]struct {
+			result1 client.Client
+		})
+	}
+	fake.withAppIDReturnsOnCall[i] = struct {
+		result1 client.Client
+	}{result1}
+}
+
+func (fake *Client) WithHTTPClient(arg1 client.HTTPClient) client.Client {
+	fake.withHTTPClientMutex.Lock()
+	ret, specificReturn := fake.withHTTPClientReturnsOnCall[len(fake.withHTTPClientArgsForCall)]
+	fake.withHTTPClientArgsForCall = append(fake.withHTTPClientArgsForCall, struct {
+		arg1 client.HTTPClient
+	}{arg1})
+	stub := fake.WithHTTPClientStub
+	fakeReturns := fake.withHTTPClientReturns
+	fake.recordInvocation("WithHTTPClient", []interface{}{arg1})
+	fake.withHTTPClientMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) WithHTTPClientCallCount() int {
+	fake.withHTTPClientMutex.RLock()
+	defer fake.withHTTPClientMutex.RUnlock()
+	return len(fake.withHTTPClientArgsForCall)
+}
+
+func (fake *Client) WithHTTPClientCalls(stub func(client.HTTPClient) client.Client) {
+	fake.withHTTPClientMutex.Lock()
+	defer fake.withHTTPClientMutex.Unlock()
+	fake.WithHTTPClientStub = stub
+}
+
+func (fake *Client) WithHTTPClientArgsForCall(i int) client.HTTPClient {
+	fake.withHTTPClientMutex.RLock()
+	defer fake.withHTTPClientMutex.RUnlock()
+	argsForCall := fake.withHTTPClientArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) WithHTTPClientReturns(result1 client.Client) {
+	fake.withHTTPClientMutex.Lock()
+	defer fake.withHTTPClientMutex.Unlock()
+	fake.WithHTTPClientStub = nil
+	fake.withHTTPClientReturns = struct {
+		result1 client.Client
+	}{result1}
+}
+
+func (fake *Client) WithHTTPClientReturnsOnCall(i int, result1 client.Client) {
+	fake.withHTTPClientMutex.Lock()
+	defer fake.withHTTPClientMutex.Unlock()
+	fake.WithHTTPClientStub = nil
+	if fake.withHTTPClientReturnsOnCall == nil {
+		fake.withHTTPClientReturnsOnCall = make(map[int]struct {
+			result1 client.Client
+		})
+	}
+	fake.withHTTPClientReturnsOnCall[i] = struct {
+		result1 client.Client
+	}{result1}
+}
+
+func (fake *Client) WithPrivateToken(arg1 types.PrivateToken) client.Client {
+	fake.withPrivateTokenMutex.Lock()
+	ret, specificReturn := fake.withPrivateTokenReturnsOnCall[len(fake.withPrivateTokenArgsForCall)]
+	fake.withPrivateTokenArgsForCall = append(fake.withPrivateTokenArgsForCall, struct {
+		arg1 types.PrivateToken
+	}{arg1})
+	stub := fake.WithPrivateTokenStub
+	fakeReturns := fake.withPrivateTokenReturns
+	fake.recordInvocation("WithPrivateToken", []interface{}{arg1})
+	fake.withPrivateTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Client) WithPrivateTokenCallCount() int {
+	fake.withPrivateTokenMutex.RLock()
+	defer fake.withPrivateTokenMutex.RUnlock()
+	return len(fake.withPrivateTokenArgsForCall)
+}
+
+func (fake *Client) WithPrivateTokenCalls(stub func(types.PrivateToken) client.Client) {
+	fake.withPrivateTokenMutex.Lock()
+	defer fake.withPrivateTokenMutex.Unlock()
+	fake.WithPrivateTokenStub = stub
+}
+
+func (fake *Client) WithPrivateTokenArgsForCall(i int) types.PrivateToken {
+	fake.withPrivateTokenMutex.RLock()
+	defer fake.withPrivateTokenMutex.RUnlock()
+	argsForCall := fake.withPrivateTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *Client) WithPrivateTokenReturns(result1 client.Client) {
+	fake.withPrivateTokenMutex.Lock()
+	defer fake.withPrivateTokenMutex.Unlock()
+	fake.WithPrivateTokenStub = nil
+	fake.withPrivateTokenReturns = struct {
+		result1 client.Client
+	}{result1}
+}
+
+func (fake *Client) WithPrivateTokenReturnsOnCall(i int, result1 client.Client) {
+	fake.withPrivateTokenMutex.Lock()
+	defer fake.withPrivateTokenMutex.Unlock()
+	fake.WithPrivateTokenStub = nil
+	if fake.withPrivateTokenReturnsOnCall == nil {
+		fake.withPrivateTokenReturnsOnCall = make(map[int]struct {
+			result1 client.Client
+		})
+	}
+	fake.withPrivateTokenReturnsOnCall[i] = struct {
+		result1 client.Client
+	}{result1}
+}
+
+func (fake *Client) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.aPIVersionMutex.RLock()
+	defer fake.aPIVersionMutex.RUnlock()
+	fake.addDownloadTaskMutex.RLock()
+	defer fake.addDownloadTaskMutex.RUnlock()
+	fake.addHashFileTaskMutex.RLock()
+	defer fake.addHashFileTaskMutex.RUnlock()
+	fake.authorizeMutex.RLock()
+	defer fake.authorizeMutex.RUnlock()
+	fake.cancelUploadTaskMutex.RLock()
+	defer fake.cancelUploadTaskMutex.RUnlock()
+	fake.cleanUploadTasksMutex.RLock()
+	defer fake.cleanUploadTasksMutex.RUnlock()
+	fake.copyFilesMutex.RLock()
+	defer fake.copyFilesMutex.RUnlock()
+	fake.createDHCPStaticLeaseMutex.RLock()
+	defer fake.createDHCPStaticLeaseMutex.RUnlock()
+	fake.createDirectoryMutex.RLock()
+	defer fake.createDirectoryMutex.RUnlock()
+	fake.createPortForwardingRuleMutex.RLock()
+	defer fake.createPortForwardingRuleMutex.RUnlock()
+	fake.createVPNUserMutex.RLock()
+	defer fake.createVPNUserMutex.RUnlock()
+	fake.createVirtualDiskMutex.RLock()
+	defer fake.createVirtualDiskMutex.RUnlock()
+	fake.createVirtualMachineMutex.RLock()
+	defer fake.createVirtualMachineMutex.RUnlock()
+	fake.deleteDHCPStaticLeaseMutex.RLock()
+	defer fake.deleteDHCPStaticLeaseMutex.RUnlock()
+	fake.deleteDownloadTaskMutex.RLock()
+	defer fake.deleteDownloadTaskMutex.RUnlock()
+	fake.deleteFileSystemTaskMutex.RLock()
+	defer fake.deleteFileSystemTaskMutex.RUnlock()
+	fake.deletePortForwardingRuleMutex.RLock()
+	defer fake.deletePortForwardingRuleMutex.RUnlock()
+	fake.deleteUploadTaskMutex.RLock()
+	defer fake.deleteUploadTaskMutex.RUnlock()
+	fake.deleteVPNUserMutex.RLock()
+	defer fake.deleteVPNUserMutex.RUnlock()
+	fake.deleteVirtualDiskTaskMutex.RLock()
+	defer fake.deleteVirtualDiskTaskMutex.RUnlock()
+	fake.deleteVirtualMachineMutex.RLock()
+	defer fake.deleteVirtualMachineMutex.RUnlock()
+	fake.eraseDownloadTaskMutex.RLock()
+	defer fake.eraseDownloadTaskMutex.RUnlock()
+	fake.extractFileMutex.RLock()
+	defer fake.extractFileMutex.RUnlock()
+	fake.fileUploadStartMutex.RLock()
+	defer fake.fileUploadStartMutex.RUnlock()
+	fake.getDHCPStaticLeaseMutex.RLock()
+	defer fake.getDHCPStaticLeaseMutex.RUnlock()
+	fake.getDownloadConfigurationMutex.RLock()
+	defer fake.getDownloadConfigurationMutex.RUnlock()
+	fake.getDownloadTaskMutex.RLock()
+	defer fake.getDownloadTaskMutex.RUnlock()
+	fake.getFileMutex.RLock()
+	defer fake.getFileMutex.RUnlock()
+	fake.getFileInfoMutex.RLock()
+	defer fake.getFileInfoMutex.RUnlock()
+	fake.getFileSystemTaskMutex.RLock()
+	defer fake.getFileSystemTaskMutex.RUnlock()
+	fake.getHashResultMutex.RLock()
+	defer fake.getHashResultMutex.RUnlock()
+	fake.getLanConfigMutex.RLock()
+	defer fake.getLanConfigMutex.RUnlock()
+	fake.getLanInterfaceMutex.RLock()
+	defer fake.getLanInterfaceMutex.RUnlock()
+	fake.getLanInterfaceHostMutex.RLock()
+	defer fake.getLanInterfaceHostMutex.RUnlock()
+	fake.getOpenVPNServerConfigMutex.RLock()
+	defer fake.getOpenVPNServerConfigMutex.RUnlock()
+	fake.getPortForwardingRuleMutex.RLock()
+	defer fake.getPortForwardingRuleMutex.RUnlock()
+	fake.getSystemInfoMutex.RLock()
+	defer fake.getSystemInfoMutex.RUnlock()
+	fake.getUploadTaskMutex.RLock()
+	defer fake.getUploadTaskMutex.RUnlock()
+	fake.getVPNUserMutex.RLock()
+	defer fake.getVPNUserMutex.RUnlock()
+	fake.getVPNUserClientConfigMutex.RLock()
+	defer fake.getVPNUserClientConfigMutex.RUnlock()
+	fake.getVirtualDiskInfoMutex.RLock()
+	defer fake.getVirtualDiskInfoMutex.RUnlock()
+	fake.getVirtualDiskTaskMutex.RLock()
+	defer fake.getVirtualDiskTaskMutex.RUnlock()
+	fake.getVirtualMachineMutex.RLock()
+	defer fake.getVirtualMachineMutex.RUnlock()
+	fake.getVirtualMachineDistributionsMutex.RLock()
+	defer fake.getVirtualMachineDistributionsMutex.RUnlock()
+	fake.getVirtualMachineInfoMutex.RLock()
+	defer fake.getVirtualMachineInfoMutex.RUnlock()
+	fake.killVirtualMachineMutex.RLock()
+	defer fake.killVirtualMachineMutex.RUnlock()
+	fake.listDHCPStaticLeaseMutex.RLock()
+	defer fake.listDHCPStaticLeaseMutex.RUnlock()
+	fake.listDownloadTasksMutex.RLock()
+	defer fake.listDownloadTasksMutex.RUnlock()
+	fake.listFileSystemTasksMutex.RLock()
+	defer fake.listFileSystemTasksMutex.RUnlock()
+	fake.listLanInterfaceInfoMutex.RLock()
+	defer fake.listLanInterfaceInfoMutex.RUnlock()
+	fake.listPortForwardingRulesMutex.RLock()
+	defer fake.listPortForwardingRulesMutex.RUnlock()
+	fake.listUploadTasksMutex.RLock()
+	defer fake.listUploadTasksMutex.RUnlock()
+	fake.listVPNUsersMutex.RLock()
+	defer fake.listVPNUsersMutex.RUnlock()
+	fake.listVirtualMachinesMutex.RLock()
+	defer fake.listVirtualMachinesMutex.RUnlock()
+	fake.listenEventsMutex.RLock()
+	defer fake.listenEventsMutex.RUnlock()
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	fake.logoutMutex.RLock()
+	defer fake.logoutMutex.RUnlock()
+	fake.moveFilesMutex.RLock()
+	defer fake.moveFilesMutex.RUnlock()
+	fake.removeFilesMutex.RLock()
+	defer fake.removeFilesMutex.RUnlock()
+	fake.resizeVirtualDiskMutex.RLock()
+	defer fake.resizeVirtualDiskMutex.RUnlock()
+	fake.startVirtualMachineMutex.RLock()
+	defer fake.startVirtualMachineMutex.RUnlock()
+	fake.stopVirtualMachineMutex.RLock()
+	defer fake.stopVirtualMachineMutex.RUnlock()
+	fake.updateDHCPStaticLeaseMutex.RLock()
+	defer fake.updateDHCPStaticLeaseMutex.RUnlock()
+	fake.updateDownloadConfigurationMutex.RLock()
+	defer fake.updateDownloadConfigurationMutex.RUnlock()
+	fake.updateDownloadTaskMutex.RLock()
+	defer fake.updateDownloadTaskMutex.RUnlock()
+	fake.updateFileSystemTaskMutex.RLock()
+	defer fake.updateFileSystemTaskMutex.RUnlock()
+	fake.updateLanConfigMutex.RLock()
+	defer fake.updateLanConfigMutex.RUnlock()
+	fake.updateOpenVPNServerConfigMutex.RLock()
+	defer fake.updateOpenVPNServerConfigMutex.RUnlock()
+	fake.updatePortForwardingRuleMutex.RLock()
+	defer fake.updatePortForwardingRuleMutex.RUnlock()
+	fake.updateVPNUserMutex.RLock()
+	defer fake.updateVPNUserMutex.RUnlock()
+	fake.updateVirtualMachineMutex.RLock()
+	defer fake.updateVirtualMachineMutex.RUnlock()
+	fake.withAppIDMutex.RLock()
+	defer fake.withAppIDMutex.RUnlock()
+	fake.withHTTPClientMutex.RLock()
+	defer fake.withHTTPClientMutex.RUnlock()
+	fake.withPrivateTokenMutex.RLock()
+	defer fake.withPrivateTokenMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Client) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ client.Client = new(Client)
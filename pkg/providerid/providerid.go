@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package providerid builds and parses the provider IDs of Freebox VMs, which
+// have the format freebox://<vm-id>.
+package providerid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Prefix prefixes the ID of the VM in provider IDs.
+const Prefix = "freebox://"
+
+// New returns the provider ID of the VM vmID.
+func New(vmID int64) string {
+	return Prefix + strconv.FormatInt(vmID, 10)
+}
+
+// Parse returns the ID of the VM providerID refers to.
+func Parse(providerID string) (int64, error) {
+	id, ok := strings.CutPrefix(providerID, Prefix)
+	if !ok {
+		return 0, fmt.Errorf("provider ID %q does not start with %s", providerID, Prefix)
+	}
+	vmID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || vmID < 0 {
+		return 0, fmt.Errorf("provider ID %q does not end with a VM ID", providerID)
+	}
+	return vmID, nil
+}
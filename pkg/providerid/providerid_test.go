@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package providerid builds and parses the provider IDs of Freebox VMs, which
+package providerid
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	for providerID, want := range map[string]int64{
+		"freebox://42":  42,
+		"freebox://0":   0,
+		"freebox://":    -1,
+		"freebox://-1":  -1,
+		"freebox://abc": -1,
+		"docker:///42":  -1,
+		"42":            -1,
+	} {
+		got, err := Parse(providerID)
+		if want < 0 {
+			if err == nil {
+				t.Errorf("Parse(%q) = %d, want an error", providerID, got)
+			}
+			continue
+		}
+		if err != nil || got != want {
+			t.Errorf("Parse(%q) = %d, %v, want %d", providerID, got, err, want)
+		}
+	}
+	if got, err := Parse(New(7)); err != nil || got != 7 {
+		t.Errorf("Parse(New(7)) = %d, %v, want 7", got, err)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package templates renders the clusterctl cluster templates published with each release.
+//
+// All flavors share cluster-template.yaml.tmpl, a Go template producing a
+// clusterctl template where ${VARIABLES} are substituted by clusterctl.
+package templates
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"text/template"
+)
+
+// Flavors lists the published flavors; the empty string is the default flavor.
+var Flavors = []string{"", "kube-vip", "external-lb", "smb-csi"}
+
+//go:embed cluster-template.yaml.tmpl
+var clusterTemplate string
+
+var tmpl = template.Must(template.New("cluster-template").Parse(clusterTemplate))
+
+// FileName returns the name clusterctl expects for the template of a flavor.
+func FileName(flavor string) string {
+	if flavor == "" {
+		return "cluster-template.yaml"
+	}
+	return fmt.Sprintf("cluster-template-%s.yaml", flavor)
+}
+
+// Render returns the clusterctl template of a flavor.
+func Render(flavor string) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct{ Flavor string }{Flavor: flavor}); err != nil {
+		return nil, fmt.Errorf("rendering %s: %w", FileName(flavor), err)
+	}
+	return buf.Bytes(), nil
+}
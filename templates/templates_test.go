@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	addonsv1 "sigs.k8s.io/cluster-api/api/addons/v1beta2"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	controlplanev1 "sigs.k8s.io/cluster-api/api/controlplane/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterctlclient "sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/yaml"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+func TestTemplatesUpToDate(t *testing.T) {
+	for _, flavor := range Flavors {
+		t.Run(FileName(flavor), func(t *testing.T) {
+			want, err := Render(flavor)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(FileName(flavor))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s is out of date, run \"make generate-templates\"", FileName(flavor))
+			}
+		})
+	}
+}
+
+// TestClusterctlGenerateCluster runs the equivalent of
+// "clusterctl generate cluster --infrastructure freebox:v0.0.0 --flavor <flavor>"
+// against a local provider repository holding the rendered templates.
+func TestClusterctlGenerateCluster(t *testing.T) {
+	repository := t.TempDir()
+	providerDir := filepath.Join(repository, "infrastructure-freebox", "v0.0.0")
+	if err := os.MkdirAll(providerDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(providerDir, "infrastructure-components.yaml"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	metadata, err := os.ReadFile(filepath.Join("..", "metadata.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(providerDir, "metadata.yaml"), metadata, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, flavor := range Flavors {
+		data, err := os.ReadFile(FileName(flavor))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(providerDir, FileName(flavor)), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	configFile := filepath.Join(repository, "clusterctl.yaml")
+	config := fmt.Sprintf(`providers:
+  - name: freebox
+    type: InfrastructureProvider
+    url: %s
+CONTROL_PLANE_ENDPOINT_IP: 192.168.1.200
+CONTROL_PLANE_ENDPOINT_HOST: api.example.com
+FREEBOX_SMB_USERNAME: kube
+FREEBOX_SMB_PASSWORD: secret
+`, filepath.Join(providerDir, "infrastructure-components.yaml"))
+	if err := os.WriteFile(configFile, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	c, err := clusterctlclient.New(ctx, configFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, flavor := range Flavors {
+		t.Run(FileName(flavor), func(t *testing.T) {
+			template, err := c.GetClusterTemplate(ctx, clusterctlclient.GetClusterTemplateOptions{
+				ProviderRepositorySource: &clusterctlclient.ProviderRepositorySourceOptions{
+					InfrastructureProvider: "freebox:v0.0.0",
+					Flavor:                 flavor,
+				},
+				TargetNamespace:          "default",
+				ClusterName:              "test",
+				KubernetesVersion:        "v1.34.1",
+				ControlPlaneMachineCount: ptr.To[int64](3),
+				WorkerMachineCount:       ptr.To[int64](2),
+			})
+			if err != nil {
+				t.Fatalf("generating cluster: %v", err)
+			}
+
+			out, err := template.Yaml()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bytes.Contains(out, []byte("${")) {
+				t.Errorf("generated cluster has unsubstituted variables:\n%s", out)
+			}
+
+			var kinds []string
+			for _, obj := range template.Objs() {
+				kinds = append(kinds, obj.GetKind())
+				if obj.GetNamespace() != "default" {
+					t.Errorf("%s %s is in namespace %q, want default", obj.GetKind(), obj.GetName(), obj.GetNamespace())
+				}
+
+				// Objects must match their API types exactly.
+				var into any
+				switch obj.GetKind() {
+				case "Cluster":
+					into = &clusterv1.Cluster{}
+				case "MachineDeployment":
+					into = &clusterv1.MachineDeployment{}
+				case "KubeadmControlPlane":
+					into = &controlplanev1.KubeadmControlPlane{}
+				case "KubeadmConfigTemplate":
+					into = &bootstrapv1.KubeadmConfigTemplate{}
+				case "FreeboxCluster":
+					into = &infrastructurev1alpha1.FreeboxCluster{}
+				case "FreeboxMachineTemplate":
+					into = &infrastructurev1alpha1.FreeboxMachineTemplate{}
+				case "ClusterResourceSet":
+					into = &addonsv1.ClusterResourceSet{}
+				case "ConfigMap":
+					into = &corev1.ConfigMap{}
+				case "Secret":
+					into = &corev1.Secret{}
+				}
+				data, err := yaml.Marshal(obj.Object)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := yaml.UnmarshalStrict(data, into); err != nil {
+					t.Errorf("%s %s does not match the API: %v", obj.GetKind(), obj.GetName(), err)
+				}
+			}
+			slices.Sort(kinds)
+			wantKinds := []string{
+				"Cluster",
+				"FreeboxCluster",
+				"FreeboxMachineTemplate",
+				"FreeboxMachineTemplate",
+				"KubeadmConfigTemplate",
+				"KubeadmControlPlane",
+				"MachineDeployment",
+			}
+			if flavor == "smb-csi" {
+				wantKinds = append(wantKinds, "ClusterResourceSet", "ConfigMap", "Secret")
+				slices.Sort(wantKinds)
+			}
+			if !slices.Equal(kinds, wantKinds) {
+				t.Errorf("generated kinds = %v, want %v", kinds, wantKinds)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// kubeVIPManifest is a kube-vip static pod announcing the control plane endpoint
+// with ARP on the Freebox LAN. It is formatted with the kube-vip version and the VIP.
+const kubeVIPManifest = `apiVersion: v1
+kind: Pod
+metadata:
+  name: kube-vip
+  namespace: kube-system
+spec:
+  containers:
+  - name: kube-vip
+    image: ghcr.io/kube-vip/kube-vip:%s
+    imagePullPolicy: IfNotPresent
+    args:
+    - manager
+    env:
+    - name: vip_arp
+      value: "true"
+    - name: port
+      value: "6443"
+    - name: vip_interface
+      value: enp0s5
+    - name: vip_cidr
+      value: "32"
+    - name: cp_enable
+      value: "true"
+    - name: cp_namespace
+      value: kube-system
+    - name: vip_leaderelection
+      value: "true"
+    - name: vip_leaseduration
+      value: "15"
+    - name: vip_renewdeadline
+      value: "10"
+    - name: vip_retryperiod
+      value: "2"
+    - name: address
+      value: "%s"
+    securityContext:
+      capabilities:
+        add:
+        - NET_ADMIN
+        - NET_RAW
+    volumeMounts:
+    - mountPath: /etc/kubernetes/admin.conf
+      name: kubeconfig
+  hostAliases:
+  - hostnames:
+    - kubernetes
+    ip: 127.0.0.1
+  hostNetwork: true
+  volumes:
+  - name: kubeconfig
+    hostPath:
+      path: /etc/kubernetes/admin.conf
+      type: FileOrCreate
+`
+
+// calicoCommands install the Calico CNI from the first control plane node.
+var calicoCommands = []interface{}{
+	"if [ -f /run/kubeadm/kubeadm.yaml ]; then kubectl apply -f https://raw.githubusercontent.com/projectcalico/calico/v3.29.1/manifests/calico.yaml; fi",
+}
+
+// CreateWorkloadClusterInput is the input for CreateWorkloadCluster.
+type CreateWorkloadClusterInput struct {
+	Creator   client.Client
+	Namespace string
+	Name      string
+	// EndpointHost is the control plane endpoint, a free address of the Freebox LAN
+	// announced by kube-vip on the control plane nodes.
+	EndpointHost string
+	// Replicas is the number of control plane nodes, 1 when zero.
+	Replicas int64
+	// KubernetesVersion defaults to v1.34.0.
+	KubernetesVersion string
+	// VCPUs, MemoryMB and DiskSizeBytes size the control plane VMs, 2 vCPUs, 4096 MB
+	// and 10GB by default.
+	VCPUs         int64
+	MemoryMB      int64
+	DiskSizeBytes int64
+	// Untainted lets workloads run on the control plane nodes.
+	Untainted bool
+	// PodCIDRs and ServiceCIDRs are the networks of the cluster, those of kubeadm
+	// when empty. Two of each make a dual-stack cluster.
+	PodCIDRs     []string
+	ServiceCIDRs []string
+	// PreKubeadmCommands run on the nodes before they are set up for kubeadm.
+	PreKubeadmCommands []interface{}
+	// CNICommands install the CNI with the admin kubeconfig, Calico when empty.
+	CNICommands []interface{}
+}
+
+// WorkloadCluster holds the objects of a workload cluster created by CreateWorkloadCluster.
+type WorkloadCluster struct {
+	Cluster              *unstructured.Unstructured
+	FreeboxCluster       *infrastructurev1alpha1.FreeboxCluster
+	ControlPlaneTemplate *infrastructurev1alpha1.FreeboxMachineTemplate
+	KubeadmControlPlane  *unstructured.Unstructured
+}
+
+// CreateWorkloadCluster creates a Cluster with a KubeadmControlPlane of Freebox VMs,
+// whose endpoint is announced by kube-vip. The image and kube-vip version are
+// TEST_IMAGE_URL and KUBE_VIP_VERSION of the e2e config, when set.
+func CreateWorkloadCluster(ctx context.Context, input CreateWorkloadClusterInput) *WorkloadCluster {
+	imageURL := "https://cloud.debian.org/images/cloud/trixie/daily/latest/debian-13-generic-arm64-daily.qcow2"
+	if testImageURL, ok := e2eConfig.Variables["TEST_IMAGE_URL"]; ok {
+		imageURL = testImageURL
+	}
+	kubeVIPVersion := "v0.8.9"
+	if version, ok := e2eConfig.Variables["KUBE_VIP_VERSION"]; ok {
+		kubeVIPVersion = version
+	}
+	replicas := input.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+	kubernetesVersion := input.KubernetesVersion
+	if kubernetesVersion == "" {
+		kubernetesVersion = "v1.34.0"
+	}
+	vcpus, memoryMB, diskSizeBytes := input.VCPUs, input.MemoryMB, input.DiskSizeBytes
+	if vcpus == 0 {
+		vcpus = 2
+	}
+	if memoryMB == 0 {
+		memoryMB = 4096
+	}
+	if diskSizeBytes == 0 {
+		diskSizeBytes = 10737418240 // 10GB
+	}
+	cniCommands := input.CNICommands
+	if len(cniCommands) == 0 {
+		cniCommands = calicoCommands
+	}
+	kcpName := input.Name + "-cp"
+
+	freeboxCluster := &infrastructurev1alpha1.FreeboxCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      input.Name,
+			Namespace: input.Namespace,
+		},
+		Spec: infrastructurev1alpha1.FreeboxClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{
+				Host: input.EndpointHost,
+				Port: 6443,
+			},
+		},
+	}
+	Expect(input.Creator.Create(ctx, freeboxCluster)).To(Succeed())
+
+	capiCluster := &unstructured.Unstructured{}
+	capiCluster.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "cluster.x-k8s.io",
+		Version: "v1beta2",
+		Kind:    "Cluster",
+	})
+	capiCluster.SetName(input.Name)
+	capiCluster.SetNamespace(input.Namespace)
+	if len(input.PodCIDRs) > 0 {
+		Expect(unstructured.SetNestedField(capiCluster.Object, map[string]interface{}{
+			"pods": map[string]interface{}{
+				"cidrBlocks": toInterfaces(input.PodCIDRs),
+			},
+			"services": map[string]interface{}{
+				"cidrBlocks": toInterfaces(input.ServiceCIDRs),
+			},
+		}, "spec", "clusterNetwork")).To(Succeed())
+	}
+	Expect(unstructured.SetNestedField(capiCluster.Object, map[string]interface{}{
+		"apiGroup": "infrastructure.cluster.x-k8s.io",
+		"kind":     "FreeboxCluster",
+		"name":     freeboxCluster.Name,
+	}, "spec", "infrastructureRef")).To(Succeed())
+	Expect(unstructured.SetNestedField(capiCluster.Object, map[string]interface{}{
+		"apiGroup": "controlplane.cluster.x-k8s.io",
+		"kind":     "KubeadmControlPlane",
+		"name":     kcpName,
+	}, "spec", "controlPlaneRef")).To(Succeed())
+	Expect(input.Creator.Create(ctx, capiCluster)).To(Succeed())
+
+	controlPlaneTemplate := &infrastructurev1alpha1.FreeboxMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kcpName + "-template",
+			Namespace: input.Namespace,
+		},
+		Spec: infrastructurev1alpha1.FreeboxMachineTemplateSpec{
+			Template: infrastructurev1alpha1.FreeboxMachineTemplateResource{
+				Spec: infrastructurev1alpha1.FreeboxMachineSpec{
+					Name:          kcpName,
+					VCPUs:         vcpus,
+					MemoryMB:      memoryMB,
+					ImageURL:      imageURL,
+					DiskSizeBytes: diskSizeBytes,
+				},
+			},
+		},
+	}
+	Expect(input.Creator.Create(ctx, controlPlaneTemplate)).To(Succeed())
+
+	clusterConfiguration := map[string]interface{}{
+		"controlPlaneEndpoint": fmt.Sprintf("%s:6443", input.EndpointHost),
+		"apiServer": map[string]interface{}{
+			"certSANs": []interface{}{
+				input.EndpointHost,
+			},
+		},
+	}
+	if len(input.PodCIDRs) > 0 {
+		clusterConfiguration["networking"] = map[string]interface{}{
+			"podSubnet":     strings.Join(input.PodCIDRs, ","),
+			"serviceSubnet": strings.Join(input.ServiceCIDRs, ","),
+		}
+	}
+	preKubeadmCommands := []interface{}{
+		// admin.conf has no permissions until kubeadm init has bootstrapped RBAC,
+		// so kube-vip uses super-admin.conf on the first control plane node.
+		"if [ -f /run/kubeadm/kubeadm.yaml ]; then sed -i 's#path: /etc/kubernetes/admin.conf#path: /etc/kubernetes/super-admin.conf#' /etc/kubernetes/manifests/kube-vip.yaml; fi",
+	}
+	preKubeadmCommands = append(preKubeadmCommands, input.PreKubeadmCommands...)
+	preKubeadmCommands = append(preKubeadmCommands, kubeadmNodeSetupCommands()...)
+	postKubeadmCommands := []interface{}{
+		"if [ -f /run/kubeadm/kubeadm.yaml ]; then sed -i 's#path: /etc/kubernetes/super-admin.conf#path: /etc/kubernetes/admin.conf#' /etc/kubernetes/manifests/kube-vip.yaml; fi",
+		"export KUBECONFIG=/etc/kubernetes/admin.conf",
+	}
+	postKubeadmCommands = append(postKubeadmCommands, cniCommands...)
+	kubeadmConfigSpec := map[string]interface{}{
+		"clusterConfiguration": clusterConfiguration,
+		"files": []interface{}{
+			map[string]interface{}{
+				"path":        "/etc/kubernetes/manifests/kube-vip.yaml",
+				"owner":       "root:root",
+				"permissions": "0644",
+				"content":     fmt.Sprintf(kubeVIPManifest, kubeVIPVersion, input.EndpointHost),
+			},
+		},
+		"preKubeadmCommands":  preKubeadmCommands,
+		"postKubeadmCommands": postKubeadmCommands,
+	}
+	if input.Untainted {
+		kubeadmConfigSpec["initConfiguration"] = map[string]interface{}{
+			"nodeRegistration": map[string]interface{}{
+				"taints": []interface{}{},
+			},
+		}
+	}
+
+	kubeadmControlPlane := &unstructured.Unstructured{}
+	kubeadmControlPlane.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   "controlplane.cluster.x-k8s.io",
+		Version: "v1beta2",
+		Kind:    "KubeadmControlPlane",
+	})
+	kubeadmControlPlane.SetName(kcpName)
+	kubeadmControlPlane.SetNamespace(input.Namespace)
+	Expect(unstructured.SetNestedField(kubeadmControlPlane.Object, replicas, "spec", "replicas")).To(Succeed())
+	Expect(unstructured.SetNestedField(kubeadmControlPlane.Object, kubernetesVersion, "spec", "version")).To(Succeed())
+	Expect(unstructured.SetNestedField(kubeadmControlPlane.Object, map[string]interface{}{
+		"spec": map[string]interface{}{
+			"infrastructureRef": map[string]interface{}{
+				"apiGroup": "infrastructure.cluster.x-k8s.io",
+				"kind":     "FreeboxMachineTemplate",
+				"name":     controlPlaneTemplate.Name,
+			},
+		},
+	}, "spec", "machineTemplate")).To(Succeed())
+	Expect(unstructured.SetNestedField(kubeadmControlPlane.Object, kubeadmConfigSpec, "spec", "kubeadmConfigSpec")).To(Succeed())
+	Expect(input.Creator.Create(ctx, kubeadmControlPlane)).To(Succeed())
+
+	return &WorkloadCluster{
+		Cluster:              capiCluster,
+		FreeboxCluster:       freeboxCluster,
+		ControlPlaneTemplate: controlPlaneTemplate,
+		KubeadmControlPlane:  kubeadmControlPlane,
+	}
+}
+
+// toInterfaces returns values as the []interface{} of unstructured objects.
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		result = append(result, value)
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/test/framework/kubetest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// The conformance scenario only runs with -e2e.run-conformance, as the suite takes hours.
+// Results are written to the kubetest folder of the artifacts directory.
+var _ = Describe("Freebox Provider Conformance Tests", func() {
+	var (
+		namespace *corev1.Namespace
+	)
+
+	BeforeEach(func() {
+		if !runConformance {
+			Skip("conformance tests are only run with -e2e.run-conformance")
+		}
+		Expect(e2eConfig).ToNot(BeNil(), "E2E config is required")
+		Expect(clusterProxy).ToNot(BeNil(), "Cluster proxy is required")
+
+		By("Creating a namespace for the test")
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "freebox-e2e-conformance-",
+			},
+		}
+		Expect(clusterProxy.GetClient().Create(ctx, namespace)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		if !skipCleanup && namespace != nil {
+			By(fmt.Sprintf("Deleting namespace %s", namespace.Name))
+			Expect(clusterProxy.GetClient().Delete(ctx, namespace)).To(Succeed())
+		}
+	})
+
+	Context("Kubernetes conformance", Label("conformance"), func() {
+		It("Should pass the Kubernetes conformance suite", func() {
+			const clusterName = "test-conformance"
+
+			var freeboxMachine *infrastructurev1alpha1.FreeboxMachine
+			DeferCleanup(func() {
+				if freeboxClient != nil && freeboxMachine != nil && freeboxMachine.Status.VMID != nil {
+					CleanupFreeboxVM(freeboxClient, *freeboxMachine.Status.VMID)
+				}
+			})
+
+			endpointHost := "192.168.1.206"
+			if host, ok := e2eConfig.Variables["CONFORMANCE_CONTROL_PLANE_ENDPOINT_IP"]; ok {
+				endpointHost = host
+			}
+			kubernetesVersion := e2eConfig.Variables["KUBERNETES_VERSION"]
+
+			By("Creating a single node workload cluster")
+			workloadCluster := CreateWorkloadCluster(ctx, CreateWorkloadClusterInput{
+				Creator:           clusterProxy.GetClient(),
+				Namespace:         namespace.Name,
+				Name:              clusterName,
+				EndpointHost:      endpointHost,
+				KubernetesVersion: kubernetesVersion,
+				VCPUs:             4,
+				MemoryMB:          8192,
+				DiskSizeBytes:     21474836480, // 20GB
+				// Conformance workloads run on the single control plane node.
+				Untainted: true,
+			})
+			capiCluster := workloadCluster.Cluster
+
+			By("Waiting for the Cluster to be available")
+			Eventually(func() error {
+				freeboxMachineList := &infrastructurev1alpha1.FreeboxMachineList{}
+				if err := clusterProxy.GetClient().List(ctx, freeboxMachineList,
+					client.InNamespace(namespace.Name),
+					client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName}); err != nil {
+					return fmt.Errorf("failed to list FreeboxMachines: %w", err)
+				}
+				if len(freeboxMachineList.Items) > 0 {
+					freeboxMachine = &freeboxMachineList.Items[0]
+				}
+
+				cluster := &unstructured.Unstructured{}
+				cluster.SetGroupVersionKind(capiCluster.GroupVersionKind())
+				if err := clusterProxy.GetClient().Get(ctx, GetObjectKey(capiCluster), cluster); err != nil {
+					return fmt.Errorf("failed to get Cluster: %w", err)
+				}
+				return checkUnstructuredCondition(cluster, "Available")
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-machine")...).Should(Succeed(),
+				"Cluster should be available")
+
+			By("Running the Kubernetes conformance suite")
+			workloadProxy := clusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName)
+			DeferCleanup(func() {
+				workloadProxy.Dispose(ctx)
+			})
+			Expect(kubetest.Run(ctx, kubetest.RunInput{
+				ClusterProxy:       workloadProxy,
+				NumberOfNodes:      1,
+				ArtifactsDirectory: artifactFolder,
+				ConfigFilePath:     e2eConfig.Variables["KUBETEST_CONFIGURATION"],
+				KubernetesVersion:  kubernetesVersion,
+				ClusterName:        clusterName,
+			})).To(Succeed(), "Conformance suite should pass")
+
+			By("Deleting the Cluster")
+			Expect(clusterProxy.GetClient().Delete(ctx, capiCluster)).To(Succeed())
+			WaitForFreeboxMachineDeleted(ctx, WaitForFreeboxMachineDeletedInput{
+				Getter:  clusterProxy.GetClient(),
+				Machine: freeboxMachine,
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete-cluster")...)
+			Expect(clusterProxy.GetClient().Delete(ctx, workloadCluster.ControlPlaneTemplate)).To(Succeed())
+		})
+	})
+})
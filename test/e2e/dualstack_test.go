@@ -0,0 +1,226 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+const (
+	dualStackPodSubnetIPv4     = "192.168.0.0/16"
+	dualStackPodSubnetIPv6     = "fd00:10:244::/56"
+	dualStackServiceSubnetIPv4 = "10.96.0.0/12"
+	dualStackServiceSubnetIPv6 = "fd00:10:96::/112"
+)
+
+// ipv6Addresses returns the IPv6 InternalIP addresses among the given machine addresses.
+func ipv6Addresses(addresses []clusterv1.MachineAddress) []string {
+	var result []string
+	for _, addr := range addresses {
+		ip := net.ParseIP(addr.Address)
+		if addr.Type == clusterv1.MachineInternalIP && ip != nil && ip.To4() == nil {
+			result = append(result, addr.Address)
+		}
+	}
+	return result
+}
+
+// The dual-stack scenario requires the Freebox LAN to hand out global IPv6 addresses (SLAAC).
+var _ = Describe("Freebox Provider Dual-Stack E2E Tests", func() {
+	var (
+		namespace *corev1.Namespace
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "E2E config is required")
+		Expect(clusterProxy).ToNot(BeNil(), "Cluster proxy is required")
+
+		By("Creating a namespace for the test")
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "freebox-e2e-dualstack-",
+			},
+		}
+		Expect(clusterProxy.GetClient().Create(ctx, namespace)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		if !skipCleanup && namespace != nil {
+			By(fmt.Sprintf("Deleting namespace %s", namespace.Name))
+			Expect(clusterProxy.GetClient().Delete(ctx, namespace)).To(Succeed())
+		}
+	})
+
+	Context("Dual-stack workload cluster", Label("IPv6"), func() {
+		It("Should discover IPv6 machine addresses and use them for the workload nodes", func() {
+			const clusterName = "test-dualstack"
+
+			var freeboxMachine *infrastructurev1alpha1.FreeboxMachine
+			DeferCleanup(func() {
+				if freeboxClient != nil && freeboxMachine != nil && freeboxMachine.Status.VMID != nil {
+					CleanupFreeboxVM(freeboxClient, *freeboxMachine.Status.VMID)
+				}
+			})
+
+			endpointHost := "192.168.1.205"
+			if host, ok := e2eConfig.Variables["DUALSTACK_CONTROL_PLANE_ENDPOINT_IP"]; ok {
+				endpointHost = host
+			}
+
+			By("Creating a dual-stack workload cluster")
+			workloadCluster := CreateWorkloadCluster(ctx, CreateWorkloadClusterInput{
+				Creator:      clusterProxy.GetClient(),
+				Namespace:    namespace.Name,
+				Name:         clusterName,
+				EndpointHost: endpointHost,
+				PodCIDRs:     []string{dualStackPodSubnetIPv4, dualStackPodSubnetIPv6},
+				ServiceCIDRs: []string{dualStackServiceSubnetIPv4, dualStackServiceSubnetIPv6},
+				PreKubeadmCommands: []interface{}{
+					// Wait for SLAAC, then make kubelet report both address families
+					"for i in $(seq 1 60); do ip -6 -o addr show dev enp0s5 scope global | grep -q inet6 && break; sleep 2; done",
+					"echo \"KUBELET_EXTRA_ARGS=--node-ip=$(ip -4 -o addr show dev enp0s5 scope global | awk '{print $4}' | cut -d/ -f1 | grep -v " + endpointHost + " | head -1),$(ip -6 -o addr show dev enp0s5 scope global | awk '{print $4}' | cut -d/ -f1 | head -1)\" > /etc/default/kubelet",
+				},
+				CNICommands: []interface{}{
+					// Install Calico CNI with IPv6 address assignment enabled
+					"curl -fsSL https://raw.githubusercontent.com/projectcalico/calico/v3.29.1/manifests/calico.yaml | sed 's/\"type\": \"calico-ipam\"/\"type\": \"calico-ipam\", \"assign_ipv4\": \"true\", \"assign_ipv6\": \"true\"/' | kubectl apply -f -",
+					"kubectl -n kube-system set env daemonset/calico-node IP6=autodetect FELIX_IPV6SUPPORT=true CALICO_IPV6POOL_CIDR=" + dualStackPodSubnetIPv6,
+				},
+			})
+			capiCluster := workloadCluster.Cluster
+			freeboxCluster := workloadCluster.FreeboxCluster
+
+			By("Waiting for the FreeboxMachine to report an IPv6 address")
+			Eventually(func() error {
+				freeboxMachineList := &infrastructurev1alpha1.FreeboxMachineList{}
+				if err := clusterProxy.GetClient().List(ctx, freeboxMachineList,
+					client.InNamespace(namespace.Name),
+					client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName}); err != nil {
+					return fmt.Errorf("failed to list FreeboxMachines: %w", err)
+				}
+				if len(freeboxMachineList.Items) != 1 {
+					return fmt.Errorf("expected 1 FreeboxMachine, got %d", len(freeboxMachineList.Items))
+				}
+				freeboxMachine = &freeboxMachineList.Items[0]
+				if len(ipv6Addresses(freeboxMachine.Status.Addresses)) == 0 {
+					return fmt.Errorf("FreeboxMachine has no IPv6 address yet: %v", freeboxMachine.Status.Addresses)
+				}
+				return nil
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-machine")...).Should(Succeed(),
+				"FreeboxMachine should report an IPv6 address")
+			machineIPv6Addresses := ipv6Addresses(freeboxMachine.Status.Addresses)
+
+			By("Verifying the IPv6 address is propagated to the CAPI Machine")
+			Eventually(func() error {
+				machineList := &clusterv1.MachineList{}
+				if err := clusterProxy.GetClient().List(ctx, machineList,
+					client.InNamespace(namespace.Name),
+					client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName}); err != nil {
+					return fmt.Errorf("failed to list Machines: %w", err)
+				}
+				if len(machineList.Items) != 1 {
+					return fmt.Errorf("expected 1 Machine, got %d", len(machineList.Items))
+				}
+				addresses := ipv6Addresses(machineList.Items[0].Status.Addresses)
+				if len(addresses) == 0 {
+					return fmt.Errorf("CAPI Machine has no IPv6 address yet")
+				}
+				return nil
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-machine")...).Should(Succeed(),
+				"CAPI Machine should have the IPv6 address of the FreeboxMachine")
+
+			By("Waiting for the Cluster to be available")
+			Eventually(func() error {
+				cluster := &unstructured.Unstructured{}
+				cluster.SetGroupVersionKind(capiCluster.GroupVersionKind())
+				if err := clusterProxy.GetClient().Get(ctx, GetObjectKey(capiCluster), cluster); err != nil {
+					return fmt.Errorf("failed to get Cluster: %w", err)
+				}
+				return checkUnstructuredCondition(cluster, "Available")
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-control-plane")...).Should(Succeed(),
+				"Cluster should be available")
+
+			By("Verifying the workload node uses the discovered IPv6 address")
+			Eventually(func() error {
+				clientset, err := GetWorkloadClientset(ctx, GetWorkloadClientsetInput{
+					Getter:      clusterProxy.GetClient(),
+					ClusterName: clusterName,
+					Namespace:   namespace.Name,
+				})
+				if err != nil {
+					return err
+				}
+				nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+				if err != nil {
+					return fmt.Errorf("failed to list nodes: %w", err)
+				}
+				if len(nodes.Items) != 1 {
+					return fmt.Errorf("expected 1 node, got %d", len(nodes.Items))
+				}
+				node := nodes.Items[0]
+				if node.Spec.ProviderID != fmt.Sprintf("freebox://%d", *freeboxMachine.Status.VMID) {
+					return fmt.Errorf("node providerID is %q, expected freebox://%d", node.Spec.ProviderID, *freeboxMachine.Status.VMID)
+				}
+				if len(node.Spec.PodCIDRs) != 2 {
+					return fmt.Errorf("node should have an IPv4 and an IPv6 pod CIDR, got %v", node.Spec.PodCIDRs)
+				}
+				for _, addr := range node.Status.Addresses {
+					if addr.Type != corev1.NodeInternalIP {
+						continue
+					}
+					for _, machineAddr := range machineIPv6Addresses {
+						if addr.Address == machineAddr {
+							return nil
+						}
+					}
+				}
+				return fmt.Errorf("node addresses %v do not include the FreeboxMachine IPv6 addresses %v",
+					node.Status.Addresses, machineIPv6Addresses)
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-control-plane")...).Should(Succeed(),
+				"Workload node should use the IPv6 address discovered by the provider")
+
+			By("Deleting the Cluster")
+			Expect(clusterProxy.GetClient().Delete(ctx, capiCluster)).To(Succeed())
+			WaitForFreeboxMachineDeleted(ctx, WaitForFreeboxMachineDeletedInput{
+				Getter:  clusterProxy.GetClient(),
+				Machine: freeboxMachine,
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete-cluster")...)
+			Eventually(func() error {
+				return clusterProxy.GetClient().Get(ctx, types.NamespacedName{
+					Name:      freeboxCluster.Name,
+					Namespace: namespace.Name,
+				}, &infrastructurev1alpha1.FreeboxCluster{})
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete-cluster")...).ShouldNot(Succeed(),
+				"FreeboxCluster should be deleted with the Cluster")
+			Expect(clusterProxy.GetClient().Delete(ctx, workloadCluster.ControlPlaneTemplate)).To(Succeed())
+		})
+	})
+})
@@ -21,6 +21,7 @@ package e2e
 
 import (
 	"context"
+	"flag"
 	"os"
 	"path/filepath"
 	"testing"
@@ -60,8 +61,15 @@ var (
 
 	// freeboxClient is the Freebox API client for E2E tests
 	freeboxClient freeboxclient.Client
+
+	// runConformance enables running the Kubernetes conformance suite against a workload cluster
+	runConformance bool
 )
 
+func init() {
+	flag.BoolVar(&runConformance, "e2e.run-conformance", false, "run the Kubernetes conformance suite against a Freebox workload cluster")
+}
+
 // TestE2E runs the end-to-end (e2e) test suite for the Freebox provider.
 func TestE2E(t *testing.T) {
 	RegisterFailHandler(Fail)
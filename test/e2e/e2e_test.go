@@ -20,10 +20,9 @@ limitations under the License.
 package e2e
 
 import (
-	"context"
 	"fmt"
+	"path"
 	"strings"
-	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -36,7 +35,6 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
-	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
 )
@@ -82,32 +80,7 @@ var _ = Describe("Freebox Provider E2E Tests", func() {
 			// Cleanup function to delete VM on failure
 			cleanupVM := func() {
 				if vmID != nil && freeboxClient != nil {
-					cleanupCtx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
-					defer cancel()
-					log.FromContext(context.Background()).Info("Cleaning up VM", "vmID", *vmID)
-					// Stop the VM first before deleting (required by Freebox API)
-					// Ignore "not running" errors — the VM may already be stopped.
-					if stopErr := freeboxClient.StopVirtualMachine(cleanupCtx, *vmID); stopErr != nil {
-						log.FromContext(context.Background()).Info("VM stop returned error (may already be stopped)", "vmID", *vmID, "error", stopErr)
-					}
-					// Wait for VM to reach stopped state before deleting
-					for i := 0; i < 24; i++ {
-						vm, getErr := freeboxClient.GetVirtualMachine(cleanupCtx, *vmID)
-						if getErr != nil {
-							log.FromContext(context.Background()).Info("Could not get VM status during cleanup, proceeding with delete", "vmID", *vmID, "error", getErr)
-							break
-						}
-						if vm.Status == "stopped" {
-							break
-						}
-						log.FromContext(context.Background()).Info("Waiting for VM to stop", "vmID", *vmID, "status", vm.Status)
-						time.Sleep(5 * time.Second)
-					}
-					if err := freeboxClient.DeleteVirtualMachine(cleanupCtx, *vmID); err != nil {
-						log.FromContext(context.Background()).Error(err, "Failed to cleanup VM", "vmID", *vmID)
-					} else {
-						log.FromContext(context.Background()).Info("VM cleanup successful", "vmID", *vmID)
-					}
+					CleanupFreeboxVM(freeboxClient, *vmID)
 				}
 			}
 			DeferCleanup(cleanupVM)
@@ -250,36 +223,11 @@ var _ = Describe("Freebox Provider E2E Tests", func() {
 						"content":     "Bootstrap data was successfully passed to the VM!",
 					},
 				},
-				"preKubeadmCommands": []interface{}{
+				"preKubeadmCommands": append([]interface{}{
 					"echo 'Bootstrap test completed' > /var/log/bootstrap-test.log",
 					// Add control plane endpoint IP as secondary IP so kubeadm and kubelet can bind to it
 					"ip addr add 192.168.1.202/24 dev enp0s5 || true",
-					// Enable IP forwarding and bridge netfilter
-					"modprobe br_netfilter",
-					"echo 1 > /proc/sys/net/ipv4/ip_forward",
-					"echo 1 > /proc/sys/net/bridge/bridge-nf-call-iptables",
-					"cat <<EOF > /etc/sysctl.d/k8s.conf\nnet.bridge.bridge-nf-call-iptables = 1\nnet.bridge.bridge-nf-call-ip6tables = 1\nnet.ipv4.ip_forward = 1\nEOF",
-					"sysctl --system",
-					// Install dependencies
-					"apt-get update",
-					"apt-get install -y apt-transport-https ca-certificates curl gpg",
-					// Add Kubernetes apt repository
-					"mkdir -p /etc/apt/keyrings",
-					"curl -fsSL https://pkgs.k8s.io/core:/stable:/v1.34/deb/Release.key | gpg --dearmor -o /etc/apt/keyrings/kubernetes-apt-keyring.gpg",
-					"echo 'deb [signed-by=/etc/apt/keyrings/kubernetes-apt-keyring.gpg] https://pkgs.k8s.io/core:/stable:/v1.34/deb/ /' > /etc/apt/sources.list.d/kubernetes.list",
-					// Install Kubernetes components
-					"apt-get update",
-					"apt-get install -y kubelet kubeadm kubectl containerd",
-					"apt-mark hold kubelet kubeadm kubectl",
-					// Configure containerd
-					"mkdir -p /etc/containerd",
-					"containerd config default > /etc/containerd/config.toml",
-					"sed -i 's/SystemdCgroup = false/SystemdCgroup = true/' /etc/containerd/config.toml",
-					"systemctl restart containerd",
-					"systemctl enable containerd",
-					// Enable kubelet
-					"systemctl enable kubelet",
-				},
+				}, kubeadmNodeSetupCommands()...),
 				"postKubeadmCommands": []interface{}{
 					// Install Calico CNI
 					"export KUBECONFIG=/etc/kubernetes/admin.conf",
@@ -737,23 +685,27 @@ var _ = Describe("Freebox Provider E2E Tests", func() {
 			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete")...).Should(Succeed(),
 				"VM should be deleted from Freebox")
 
-			By("Verifying disk file is deleted from Freebox")
-			// Store the disk path for verification
+			By("Verifying disk, EFI variables and metadata files are deleted from Freebox")
 			diskPath := freeboxMachine.Status.DiskPath
-			if diskPath != "" {
-				Eventually(func() error {
-					// Try to list VMs to ensure API is responsive.
-					// After deletion, there may be zero VMs - this is expected.
-					_, err := freeboxClient.ListVirtualMachines(ctx)
-					if err != nil {
-						return fmt.Errorf("Freebox API not responsive: %w", err)
-					}
-					// If we get here, disk cleanup should have happened
-					// We can't directly verify file deletion via API, but we verified VM is gone
-					return nil
-				}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete")...).Should(Succeed(),
-					"Disk cleanup should complete")
-			}
+			Expect(diskPath).ToNot(BeEmpty(), "FreeboxMachine should have recorded its disk path")
+			WaitForFreeboxFilesDeleted(ctx, WaitForFreeboxFilesDeletedInput{
+				FreeboxClient: freeboxClient,
+				Paths:         []string{diskPath, diskPath + ".efivars", diskPath + ".meta.json"},
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete")...)
+
+			By("Verifying no download task or downloaded image remains on the Freebox")
+			imageName := path.Base(imageURL)
+			WaitForNoFreeboxDownloadTask(ctx, WaitForNoFreeboxDownloadTaskInput{
+				FreeboxClient: freeboxClient,
+				Name:          imageName,
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete")...)
+			WaitForFreeboxFilesDeleted(ctx, WaitForFreeboxFilesDeletedInput{
+				FreeboxClient: freeboxClient,
+				Paths: []string{
+					path.Join(e2eConfig.Variables["FREEBOX_DOWNLOAD_DIR"], imageName),
+					path.Join(e2eConfig.Variables["VM_STORAGE_PATH"], imageName),
+				},
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete")...)
 
 			By("Cleaning up remaining test resources in correct order")
 			// Delete remaining resources in reverse order of dependencies
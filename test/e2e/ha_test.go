@@ -0,0 +1,319 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+var _ = Describe("Freebox Provider HA E2E Tests", func() {
+	var (
+		namespace *corev1.Namespace
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "E2E config is required")
+		Expect(clusterProxy).ToNot(BeNil(), "Cluster proxy is required")
+
+		By("Creating a namespace for the test")
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "freebox-e2e-ha-",
+			},
+		}
+		Expect(clusterProxy.GetClient().Create(ctx, namespace)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		if !skipCleanup && namespace != nil {
+			By(fmt.Sprintf("Deleting namespace %s", namespace.Name))
+			Expect(clusterProxy.GetClient().Delete(ctx, namespace)).To(Succeed())
+		}
+	})
+
+	Context("HA control plane with a kube-vip endpoint", Label("HA"), func() {
+		It("Should keep the API available while a control plane machine is deleted", func() {
+			const (
+				clusterName = "test-ha-cluster"
+				replicas    = 3
+			)
+
+			// Every VM seen during the test is cleaned up on failure.
+			var vmIDsMu sync.Mutex
+			vmIDs := map[int64]struct{}{}
+			DeferCleanup(func() {
+				if freeboxClient == nil {
+					return
+				}
+				vmIDsMu.Lock()
+				defer vmIDsMu.Unlock()
+				for id := range vmIDs {
+					CleanupFreeboxVM(freeboxClient, id)
+				}
+			})
+
+			endpointHost := "192.168.1.203"
+			if host, ok := e2eConfig.Variables["HA_CONTROL_PLANE_ENDPOINT_IP"]; ok {
+				endpointHost = host
+			}
+
+			By(fmt.Sprintf("Creating a workload cluster with %d control plane nodes behind a kube-vip endpoint", replicas))
+			workloadCluster := CreateWorkloadCluster(ctx, CreateWorkloadClusterInput{
+				Creator:      clusterProxy.GetClient(),
+				Namespace:    namespace.Name,
+				Name:         clusterName,
+				EndpointHost: endpointHost,
+				Replicas:     replicas,
+				MemoryMB:     2048,
+			})
+			capiCluster := workloadCluster.Cluster
+			freeboxCluster := workloadCluster.FreeboxCluster
+			freeboxMachineTemplate := workloadCluster.ControlPlaneTemplate
+			kubeadmControlPlane := workloadCluster.KubeadmControlPlane
+
+			listFreeboxMachines := func() ([]infrastructurev1alpha1.FreeboxMachine, error) {
+				freeboxMachineList := &infrastructurev1alpha1.FreeboxMachineList{}
+				if err := clusterProxy.GetClient().List(ctx, freeboxMachineList,
+					client.InNamespace(namespace.Name),
+					client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName}); err != nil {
+					return nil, fmt.Errorf("failed to list FreeboxMachines: %w", err)
+				}
+				vmIDsMu.Lock()
+				defer vmIDsMu.Unlock()
+				for _, machine := range freeboxMachineList.Items {
+					if machine.Status.VMID != nil {
+						vmIDs[*machine.Status.VMID] = struct{}{}
+					}
+				}
+				return freeboxMachineList.Items, nil
+			}
+
+			By(fmt.Sprintf("Waiting for %d FreeboxMachines to get their own VM and disk", replicas))
+			Eventually(func() error {
+				machines, err := listFreeboxMachines()
+				if err != nil {
+					return err
+				}
+				if len(machines) < replicas {
+					return fmt.Errorf("expected %d FreeboxMachines, got %d", replicas, len(machines))
+				}
+				seenVMs := map[int64]string{}
+				seenDisks := map[string]string{}
+				for _, machine := range machines {
+					if machine.Status.VMID == nil || machine.Status.DiskPath == "" {
+						return fmt.Errorf("FreeboxMachine %s has no VM or disk yet", machine.Name)
+					}
+					if other, ok := seenVMs[*machine.Status.VMID]; ok {
+						return StopTrying(fmt.Sprintf("FreeboxMachines %s and %s share VM %d", other, machine.Name, *machine.Status.VMID))
+					}
+					if other, ok := seenDisks[machine.Status.DiskPath]; ok {
+						return StopTrying(fmt.Sprintf("FreeboxMachines %s and %s share disk %s", other, machine.Name, machine.Status.DiskPath))
+					}
+					seenVMs[*machine.Status.VMID] = machine.Name
+					seenDisks[machine.Status.DiskPath] = machine.Name
+				}
+				return nil
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-machine")...).Should(Succeed(),
+				"Each control plane machine should have its own VM and disk")
+
+			By(fmt.Sprintf("Waiting for the KubeadmControlPlane to have %d ready replicas", replicas))
+			waitForReadyReplicas := func() {
+				Eventually(func() error {
+					kcp := &unstructured.Unstructured{}
+					kcp.SetGroupVersionKind(kubeadmControlPlane.GroupVersionKind())
+					if err := clusterProxy.GetClient().Get(ctx, GetObjectKey(kubeadmControlPlane), kcp); err != nil {
+						return fmt.Errorf("failed to get KubeadmControlPlane: %w", err)
+					}
+					readyReplicas, _, err := unstructured.NestedInt64(kcp.Object, "status", "readyReplicas")
+					if err != nil {
+						return fmt.Errorf("failed to get readyReplicas: %w", err)
+					}
+					if readyReplicas != replicas {
+						return fmt.Errorf("expected %d ready replicas, got %d", replicas, readyReplicas)
+					}
+					return checkUnstructuredCondition(kcp, "Available")
+				}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-machine")...).Should(Succeed(),
+					"KubeadmControlPlane should have %d ready replicas", replicas)
+			}
+			waitForReadyReplicas()
+
+			By("Verifying the workload cluster has all control plane nodes")
+			Eventually(func() error {
+				clientset, err := GetWorkloadClientset(ctx, GetWorkloadClientsetInput{
+					Getter:      clusterProxy.GetClient(),
+					ClusterName: clusterName,
+					Namespace:   namespace.Name,
+				})
+				if err != nil {
+					return err
+				}
+				nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{
+					LabelSelector: "node-role.kubernetes.io/control-plane",
+				})
+				if err != nil {
+					return fmt.Errorf("failed to list nodes: %w", err)
+				}
+				if len(nodes.Items) != replicas {
+					return fmt.Errorf("expected %d control plane nodes, got %d", replicas, len(nodes.Items))
+				}
+				return nil
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-control-plane")...).Should(Succeed(),
+				"Workload cluster should have %d control plane nodes", replicas)
+
+			By("Watching API availability through the control plane endpoint")
+			clientset, err := GetWorkloadClientset(ctx, GetWorkloadClientsetInput{
+				Getter:      clusterProxy.GetClient(),
+				ClusterName: clusterName,
+				Namespace:   namespace.Name,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			stopProbe := make(chan struct{})
+			probeDone := make(chan time.Duration)
+			go func() {
+				defer GinkgoRecover()
+				var longestOutage time.Duration
+				var outageStart time.Time
+				ticker := time.NewTicker(2 * time.Second)
+				defer ticker.Stop()
+				for {
+					select {
+					case <-stopProbe:
+						probeDone <- longestOutage
+						return
+					case <-ticker.C:
+						_, err := clientset.Discovery().ServerVersion()
+						switch {
+						case err != nil && outageStart.IsZero():
+							outageStart = time.Now()
+						case err == nil && !outageStart.IsZero():
+							longestOutage = max(longestOutage, time.Since(outageStart))
+							outageStart = time.Time{}
+						}
+					}
+				}
+			}()
+
+			By("Deleting one control plane Machine")
+			machineList := &clusterv1.MachineList{}
+			Expect(clusterProxy.GetClient().List(ctx, machineList,
+				client.InNamespace(namespace.Name),
+				client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName})).To(Succeed())
+			Expect(machineList.Items).To(HaveLen(replicas))
+			deletedMachine := &machineList.Items[0]
+
+			var deletedFreeboxMachine *infrastructurev1alpha1.FreeboxMachine
+			machines, err := listFreeboxMachines()
+			Expect(err).ToNot(HaveOccurred())
+			for i := range machines {
+				for _, owner := range machines[i].GetOwnerReferences() {
+					if owner.Kind == "Machine" && owner.Name == deletedMachine.Name {
+						deletedFreeboxMachine = &machines[i]
+					}
+				}
+			}
+			Expect(deletedFreeboxMachine).ToNot(BeNil(), "FreeboxMachine of Machine %s should exist", deletedMachine.Name)
+			Expect(clusterProxy.GetClient().Delete(ctx, deletedMachine)).To(Succeed())
+
+			By("Waiting for the FreeboxMachine of the deleted Machine to be gone")
+			WaitForFreeboxMachineDeleted(ctx, WaitForFreeboxMachineDeletedInput{
+				Getter:  clusterProxy.GetClient(),
+				Machine: deletedFreeboxMachine,
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete")...)
+
+			By("Verifying the deleted machine's VM and disk are removed from the Freebox")
+			Eventually(func() error {
+				vms, err := freeboxClient.ListVirtualMachines(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to list VMs: %w", err)
+				}
+				for _, vm := range vms {
+					if vm.ID == *deletedFreeboxMachine.Status.VMID {
+						return fmt.Errorf("VM %d still exists in Freebox", vm.ID)
+					}
+				}
+				return nil
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete")...).Should(Succeed(),
+				"VM should be deleted from Freebox")
+			WaitForFreeboxFilesDeleted(ctx, WaitForFreeboxFilesDeletedInput{
+				FreeboxClient: freeboxClient,
+				Paths: []string{
+					deletedFreeboxMachine.Status.DiskPath,
+					deletedFreeboxMachine.Status.DiskPath + ".efivars",
+					deletedFreeboxMachine.Status.DiskPath + ".meta.json",
+				},
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete")...)
+
+			By("Waiting for the KubeadmControlPlane to replace the deleted machine")
+			waitForReadyReplicas()
+
+			By("Verifying the API stayed available during the control plane change")
+			close(stopProbe)
+			longestOutage := <-probeDone
+			// A short outage is expected while kube-vip moves the VIP to another node.
+			Expect(longestOutage).To(BeNumerically("<", 30*time.Second),
+				"API should not be unavailable for more than the kube-vip failover time")
+
+			By("Deleting the Cluster")
+			Expect(clusterProxy.GetClient().Delete(ctx, capiCluster)).To(Succeed())
+			Eventually(func() error {
+				machines, err := listFreeboxMachines()
+				if err != nil {
+					return err
+				}
+				if len(machines) > 0 {
+					return fmt.Errorf("%d FreeboxMachines still exist", len(machines))
+				}
+				return nil
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete-cluster")...).Should(Succeed(),
+				"All FreeboxMachines should be deleted with the Cluster")
+			Eventually(func() error {
+				return clusterProxy.GetClient().Get(ctx, types.NamespacedName{
+					Name:      freeboxCluster.Name,
+					Namespace: namespace.Name,
+				}, &infrastructurev1alpha1.FreeboxCluster{})
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete-cluster")...).ShouldNot(Succeed(),
+				"FreeboxCluster should be deleted with the Cluster")
+
+			By("Verifying no image is left in the VM storage")
+			WaitForFreeboxFilesDeleted(ctx, WaitForFreeboxFilesDeletedInput{
+				FreeboxClient: freeboxClient,
+				Paths: []string{
+					path.Join(e2eConfig.Variables["VM_STORAGE_PATH"], path.Base(freeboxMachineTemplate.Spec.Template.Spec.ImageURL)),
+				},
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete")...)
+
+			Expect(clusterProxy.GetClient().Delete(ctx, freeboxMachineTemplate)).To(Succeed())
+		})
+	})
+})
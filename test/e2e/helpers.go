@@ -21,12 +21,19 @@ package e2e
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	freeboxclient "github.com/nikolalohinski/free-go/client"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
 )
@@ -70,6 +77,145 @@ func WaitForFreeboxMachineDeleted(ctx context.Context, input WaitForFreeboxMachi
 	}, intervals...).Should(BeTrue(), "FreeboxMachine %s/%s was not deleted", input.Machine.Namespace, input.Machine.Name)
 }
 
+// WaitForFreeboxFilesDeletedInput is the input for WaitForFreeboxFilesDeleted.
+type WaitForFreeboxFilesDeletedInput struct {
+	FreeboxClient freeboxclient.Client
+	Paths         []string
+}
+
+// WaitForFreeboxFilesDeleted waits for the given paths to be removed from the Freebox storage.
+func WaitForFreeboxFilesDeleted(ctx context.Context, input WaitForFreeboxFilesDeletedInput, intervals ...interface{}) {
+	for _, path := range input.Paths {
+		Eventually(func() error {
+			_, err := input.FreeboxClient.GetFileInfo(ctx, path)
+			if errors.Is(err, freeboxclient.ErrPathNotFound) {
+				return nil
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get file info for %s: %w", path, err)
+			}
+			return fmt.Errorf("file %s still exists on the Freebox", path)
+		}, intervals...).Should(Succeed(), "File %s was not deleted from the Freebox", path)
+	}
+}
+
+// WaitForNoFreeboxDownloadTaskInput is the input for WaitForNoFreeboxDownloadTask.
+type WaitForNoFreeboxDownloadTaskInput struct {
+	FreeboxClient freeboxclient.Client
+	Name          string
+}
+
+// WaitForNoFreeboxDownloadTask waits until no Freebox download task with the given name remains.
+func WaitForNoFreeboxDownloadTask(ctx context.Context, input WaitForNoFreeboxDownloadTaskInput, intervals ...interface{}) {
+	Eventually(func() error {
+		tasks, err := input.FreeboxClient.ListDownloadTasks(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list download tasks: %w", err)
+		}
+		for _, task := range tasks {
+			if task.Name == input.Name {
+				return fmt.Errorf("download task %d for %s still exists with status %s", task.ID, task.Name, task.Status)
+			}
+		}
+		return nil
+	}, intervals...).Should(Succeed(), "Download task %s was not removed from the Freebox", input.Name)
+}
+
+// CleanupFreeboxVM stops and deletes a VM directly through the Freebox API.
+// It is meant to be used from DeferCleanup so that a failed test does not leak VMs.
+func CleanupFreeboxVM(freeboxClient freeboxclient.Client, vmID int64) {
+	cleanupCtx, cancel := context.WithTimeout(context.Background(), 3*time.Minute)
+	defer cancel()
+	logger := log.FromContext(cleanupCtx)
+	logger.Info("Cleaning up VM", "vmID", vmID)
+	// Stop the VM first before deleting (required by Freebox API)
+	// Ignore "not running" errors — the VM may already be stopped.
+	if stopErr := freeboxClient.StopVirtualMachine(cleanupCtx, vmID); stopErr != nil {
+		logger.Info("VM stop returned error (may already be stopped)", "vmID", vmID, "error", stopErr)
+	}
+	// Wait for VM to reach stopped state before deleting
+	for i := 0; i < 24; i++ {
+		vm, getErr := freeboxClient.GetVirtualMachine(cleanupCtx, vmID)
+		if getErr != nil {
+			logger.Info("Could not get VM status during cleanup, proceeding with delete", "vmID", vmID, "error", getErr)
+			break
+		}
+		if vm.Status == "stopped" {
+			break
+		}
+		logger.Info("Waiting for VM to stop", "vmID", vmID, "status", vm.Status)
+		time.Sleep(5 * time.Second)
+	}
+	if err := freeboxClient.DeleteVirtualMachine(cleanupCtx, vmID); err != nil {
+		logger.Error(err, "Failed to cleanup VM", "vmID", vmID)
+	} else {
+		logger.Info("VM cleanup successful", "vmID", vmID)
+	}
+}
+
+// GetWorkloadClientsetInput is the input for GetWorkloadClientset.
+type GetWorkloadClientsetInput struct {
+	Getter      client.Client
+	ClusterName string
+	Namespace   string
+}
+
+// GetWorkloadClientset builds a clientset for a workload cluster from its kubeconfig secret.
+func GetWorkloadClientset(ctx context.Context, input GetWorkloadClientsetInput) (kubernetes.Interface, error) {
+	kubeconfigSecret := &corev1.Secret{}
+	if err := input.Getter.Get(ctx, types.NamespacedName{
+		Name:      input.ClusterName + "-kubeconfig",
+		Namespace: input.Namespace,
+	}, kubeconfigSecret); err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig secret: %w", err)
+	}
+
+	kubeconfigData, ok := kubeconfigSecret.Data["value"]
+	if !ok {
+		return nil, fmt.Errorf("kubeconfig secret does not contain 'value' key")
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	restConfig.Timeout = 10 * time.Second
+
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// kubeadmNodeSetupCommands returns the preKubeadmCommands installing the
+// container runtime and Kubernetes packages on the Debian cloud image used by the tests.
+func kubeadmNodeSetupCommands() []interface{} {
+	return []interface{}{
+		// Enable IP forwarding and bridge netfilter
+		"modprobe br_netfilter",
+		"echo 1 > /proc/sys/net/ipv4/ip_forward",
+		"echo 1 > /proc/sys/net/bridge/bridge-nf-call-iptables",
+		"cat <<EOF > /etc/sysctl.d/k8s.conf\nnet.bridge.bridge-nf-call-iptables = 1\nnet.bridge.bridge-nf-call-ip6tables = 1\nnet.ipv4.ip_forward = 1\nEOF",
+		"sysctl --system",
+		// Install dependencies
+		"apt-get update",
+		"apt-get install -y apt-transport-https ca-certificates curl gpg",
+		// Add Kubernetes apt repository
+		"mkdir -p /etc/apt/keyrings",
+		"curl -fsSL https://pkgs.k8s.io/core:/stable:/v1.34/deb/Release.key | gpg --dearmor -o /etc/apt/keyrings/kubernetes-apt-keyring.gpg",
+		"echo 'deb [signed-by=/etc/apt/keyrings/kubernetes-apt-keyring.gpg] https://pkgs.k8s.io/core:/stable:/v1.34/deb/ /' > /etc/apt/sources.list.d/kubernetes.list",
+		// Install Kubernetes components
+		"apt-get update",
+		"apt-get install -y kubelet kubeadm kubectl containerd",
+		"apt-mark hold kubelet kubeadm kubectl",
+		// Configure containerd
+		"mkdir -p /etc/containerd",
+		"containerd config default > /etc/containerd/config.toml",
+		"sed -i 's/SystemdCgroup = false/SystemdCgroup = true/' /etc/containerd/config.toml",
+		"systemctl restart containerd",
+		"systemctl enable containerd",
+		// Enable kubelet
+		"systemctl enable kubelet",
+	}
+}
+
 // GetObjectKey returns the ObjectKey for a client.Object.
 func GetObjectKey(obj client.Object) types.NamespacedName {
 	return types.NamespacedName{
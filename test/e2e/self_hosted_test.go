@@ -0,0 +1,334 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+)
+
+// The self-hosted scenario runs the provider inside the workload cluster, so the
+// provider image referenced by the e2e config must be pullable from the Freebox VMs,
+// not only loaded into the kind management cluster.
+var _ = Describe("Freebox Provider Self-Hosted E2E Tests", func() {
+	var (
+		namespace *corev1.Namespace
+	)
+
+	BeforeEach(func() {
+		Expect(e2eConfig).ToNot(BeNil(), "E2E config is required")
+		Expect(clusterProxy).ToNot(BeNil(), "Cluster proxy is required")
+
+		By("Creating a namespace for the test")
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "freebox-e2e-self-hosted-",
+			},
+		}
+		Expect(clusterProxy.GetClient().Create(ctx, namespace)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		if !skipCleanup && namespace != nil {
+			By(fmt.Sprintf("Deleting namespace %s", namespace.Name))
+			Expect(clusterProxy.GetClient().Delete(ctx, namespace)).To(Succeed())
+		}
+	})
+
+	Context("Pivoting the management plane into a Freebox cluster", Label("self-hosted"), func() {
+		It("Should keep managing its own machines after clusterctl move", func() {
+			const (
+				clusterName = "test-self-hosted"
+				mdName      = "test-self-hosted-md"
+			)
+
+			// Every VM seen during the test is cleaned up on failure.
+			var vmIDsMu sync.Mutex
+			vmIDs := map[int64]struct{}{}
+			DeferCleanup(func() {
+				if freeboxClient == nil {
+					return
+				}
+				vmIDsMu.Lock()
+				defer vmIDsMu.Unlock()
+				for id := range vmIDs {
+					CleanupFreeboxVM(freeboxClient, id)
+				}
+			})
+
+			endpointHost := "192.168.1.204"
+			if host, ok := e2eConfig.Variables["SELF_HOSTED_CONTROL_PLANE_ENDPOINT_IP"]; ok {
+				endpointHost = host
+			}
+
+			By("Creating a single control plane workload cluster")
+			workloadCluster := CreateWorkloadCluster(ctx, CreateWorkloadClusterInput{
+				Creator:      clusterProxy.GetClient(),
+				Namespace:    namespace.Name,
+				Name:         clusterName,
+				EndpointHost: endpointHost,
+				// The providers run on the control plane node once the cluster is self-hosted.
+				Untainted: true,
+			})
+			capiCluster := workloadCluster.Cluster
+			freeboxCluster := workloadCluster.FreeboxCluster
+			imageURL := workloadCluster.ControlPlaneTemplate.Spec.Template.Spec.ImageURL
+
+			trackVMs := func(proxy framework.ClusterProxy) ([]infrastructurev1alpha1.FreeboxMachine, error) {
+				freeboxMachineList := &infrastructurev1alpha1.FreeboxMachineList{}
+				if err := proxy.GetClient().List(ctx, freeboxMachineList,
+					client.InNamespace(namespace.Name),
+					client.MatchingLabels{clusterv1.ClusterNameLabel: clusterName}); err != nil {
+					return nil, fmt.Errorf("failed to list FreeboxMachines: %w", err)
+				}
+				vmIDsMu.Lock()
+				defer vmIDsMu.Unlock()
+				for _, machine := range freeboxMachineList.Items {
+					if machine.Status.VMID != nil {
+						vmIDs[*machine.Status.VMID] = struct{}{}
+					}
+				}
+				return freeboxMachineList.Items, nil
+			}
+
+			waitForClusterAvailable := func(proxy framework.ClusterProxy) {
+				Eventually(func() error {
+					if _, err := trackVMs(proxy); err != nil {
+						return err
+					}
+					cluster := &unstructured.Unstructured{}
+					cluster.SetGroupVersionKind(capiCluster.GroupVersionKind())
+					if err := proxy.GetClient().Get(ctx, GetObjectKey(capiCluster), cluster); err != nil {
+						return fmt.Errorf("failed to get Cluster: %w", err)
+					}
+					return checkUnstructuredCondition(cluster, "Available")
+				}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-machine")...).Should(Succeed(),
+					"Cluster should be available")
+			}
+
+			By("Waiting for the workload cluster to be available")
+			waitForClusterAvailable(clusterProxy)
+
+			controlPlaneMachines, err := trackVMs(clusterProxy)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(controlPlaneMachines).To(HaveLen(1))
+			controlPlaneMachine := controlPlaneMachines[0]
+			Expect(controlPlaneMachine.Status.VMID).ToNot(BeNil())
+
+			By("Initializing the workload cluster as a management cluster")
+			selfHostedProxy := clusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterName)
+			DeferCleanup(func() {
+				selfHostedProxy.Dispose(ctx)
+			})
+			clusterctl.InitManagementClusterAndWatchControllerLogs(ctx, clusterctl.InitManagementClusterAndWatchControllerLogsInput{
+				ClusterProxy:            selfHostedProxy,
+				ClusterctlConfigPath:    clusterctlConfigPath,
+				CoreProvider:            e2eConfig.GetProviderLatestVersionsByContract("v1beta1", "cluster-api")[0],
+				BootstrapProviders:      e2eConfig.GetProviderLatestVersionsByContract("v1beta1", "kubeadm"),
+				ControlPlaneProviders:   e2eConfig.GetProviderLatestVersionsByContract("v1beta1", "kubeadm"),
+				InfrastructureProviders: e2eConfig.InfrastructureProviders(),
+				LogFolder:               filepath.Join(artifactFolder, "clusters", clusterName),
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-controllers")...)
+
+			By("Moving the cluster into itself")
+			Expect(selfHostedProxy.GetClient().Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: namespace.Name},
+			})).To(Succeed())
+			clusterctl.Move(ctx, clusterctl.MoveInput{
+				LogFolder:            filepath.Join(artifactFolder, "clusters", "move-to-self-hosted"),
+				ClusterctlConfigPath: clusterctlConfigPath,
+				FromKubeconfigPath:   clusterProxy.GetKubeconfigPath(),
+				ToKubeconfigPath:     selfHostedProxy.GetKubeconfigPath(),
+				Namespace:            namespace.Name,
+			})
+
+			By("Verifying the moved FreeboxMachine still points at the same VM")
+			Eventually(func() error {
+				machine := &infrastructurev1alpha1.FreeboxMachine{}
+				if err := selfHostedProxy.GetClient().Get(ctx, GetObjectKey(&controlPlaneMachine), machine); err != nil {
+					return fmt.Errorf("failed to get FreeboxMachine: %w", err)
+				}
+				if machine.Status.VMID == nil || *machine.Status.VMID != *controlPlaneMachine.Status.VMID {
+					return fmt.Errorf("FreeboxMachine VMID changed after move: expected %d, got %v",
+						*controlPlaneMachine.Status.VMID, machine.Status.VMID)
+				}
+				return nil
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-crd")...).Should(Succeed(),
+				"Moved FreeboxMachine should keep its VM")
+			waitForClusterAvailable(selfHostedProxy)
+
+			By("Adding a worker MachineDeployment from the self-hosted cluster")
+			workerTemplate := &infrastructurev1alpha1.FreeboxMachineTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-self-hosted-md-template",
+					Namespace: namespace.Name,
+				},
+				Spec: infrastructurev1alpha1.FreeboxMachineTemplateSpec{
+					Template: infrastructurev1alpha1.FreeboxMachineTemplateResource{
+						Spec: infrastructurev1alpha1.FreeboxMachineSpec{
+							Name:          "test-vm-self-hosted-md",
+							VCPUs:         1,
+							MemoryMB:      2048,
+							ImageURL:      imageURL,
+							DiskSizeBytes: 10737418240, // 10GB
+						},
+					},
+				},
+			}
+			Expect(selfHostedProxy.GetClient().Create(ctx, workerTemplate)).To(Succeed())
+
+			kubeadmConfigTemplate := &unstructured.Unstructured{}
+			kubeadmConfigTemplate.SetGroupVersionKind(schema.GroupVersionKind{
+				Group:   "bootstrap.cluster.x-k8s.io",
+				Version: "v1beta2",
+				Kind:    "KubeadmConfigTemplate",
+			})
+			kubeadmConfigTemplate.SetName(mdName)
+			kubeadmConfigTemplate.SetNamespace(namespace.Name)
+			Expect(unstructured.SetNestedField(kubeadmConfigTemplate.Object, map[string]interface{}{
+				"preKubeadmCommands": kubeadmNodeSetupCommands(),
+			}, "spec", "template", "spec")).To(Succeed())
+			Expect(selfHostedProxy.GetClient().Create(ctx, kubeadmConfigTemplate)).To(Succeed())
+
+			machineDeployment := &unstructured.Unstructured{}
+			machineDeployment.SetGroupVersionKind(schema.GroupVersionKind{
+				Group:   "cluster.x-k8s.io",
+				Version: "v1beta2",
+				Kind:    "MachineDeployment",
+			})
+			machineDeployment.SetName(mdName)
+			machineDeployment.SetNamespace(namespace.Name)
+			Expect(unstructured.SetNestedField(machineDeployment.Object, map[string]interface{}{
+				"clusterName": clusterName,
+				"replicas":    int64(1),
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{
+						clusterv1.ClusterNameLabel: clusterName,
+					},
+				},
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{
+							clusterv1.ClusterNameLabel: clusterName,
+						},
+					},
+					"spec": map[string]interface{}{
+						"clusterName": clusterName,
+						"version":     "v1.34.0",
+						"bootstrap": map[string]interface{}{
+							"configRef": map[string]interface{}{
+								"apiGroup": "bootstrap.cluster.x-k8s.io",
+								"kind":     "KubeadmConfigTemplate",
+								"name":     kubeadmConfigTemplate.GetName(),
+							},
+						},
+						"infrastructureRef": map[string]interface{}{
+							"apiGroup": "infrastructure.cluster.x-k8s.io",
+							"kind":     "FreeboxMachineTemplate",
+							"name":     workerTemplate.Name,
+						},
+					},
+				},
+			}, "spec")).To(Succeed())
+			Expect(selfHostedProxy.GetClient().Create(ctx, machineDeployment)).To(Succeed())
+
+			By("Waiting for the self-hosted provider to provision the worker VM")
+			var workerMachine *infrastructurev1alpha1.FreeboxMachine
+			Eventually(func() error {
+				machines, err := trackVMs(selfHostedProxy)
+				if err != nil {
+					return err
+				}
+				for i := range machines {
+					if machines[i].Name == controlPlaneMachine.Name {
+						continue
+					}
+					if !ptr.Deref(machines[i].Status.Initialization.Provisioned, false) {
+						return fmt.Errorf("worker FreeboxMachine %s is not provisioned yet", machines[i].Name)
+					}
+					workerMachine = &machines[i]
+					return nil
+				}
+				return fmt.Errorf("no worker FreeboxMachine created yet")
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-machine")...).Should(Succeed(),
+				"Self-hosted provider should provision the worker FreeboxMachine")
+			Expect(*workerMachine.Status.VMID).ToNot(Equal(*controlPlaneMachine.Status.VMID))
+
+			By("Verifying the worker joined the self-hosted cluster")
+			Eventually(func() error {
+				nodes := &corev1.NodeList{}
+				if err := selfHostedProxy.GetClient().List(ctx, nodes); err != nil {
+					return fmt.Errorf("failed to list nodes: %w", err)
+				}
+				if len(nodes.Items) != 2 {
+					return fmt.Errorf("expected 2 nodes, got %d", len(nodes.Items))
+				}
+				return nil
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-worker-nodes")...).Should(Succeed(),
+				"Worker node should join the self-hosted cluster")
+
+			By("Moving the cluster back to the bootstrap cluster")
+			clusterctl.Move(ctx, clusterctl.MoveInput{
+				LogFolder:            filepath.Join(artifactFolder, "clusters", "move-to-bootstrap"),
+				ClusterctlConfigPath: clusterctlConfigPath,
+				FromKubeconfigPath:   selfHostedProxy.GetKubeconfigPath(),
+				ToKubeconfigPath:     clusterProxy.GetKubeconfigPath(),
+				Namespace:            namespace.Name,
+			})
+
+			By("Deleting the Cluster from the bootstrap cluster")
+			Expect(clusterProxy.GetClient().Delete(ctx, capiCluster)).To(Succeed())
+			Eventually(func() error {
+				machines, err := trackVMs(clusterProxy)
+				if err != nil {
+					return err
+				}
+				if len(machines) > 0 {
+					return fmt.Errorf("%d FreeboxMachines still exist", len(machines))
+				}
+				return nil
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete-cluster")...).Should(Succeed(),
+				"All FreeboxMachines should be deleted with the Cluster")
+			Eventually(func() error {
+				return clusterProxy.GetClient().Get(ctx, types.NamespacedName{
+					Name:      freeboxCluster.Name,
+					Namespace: namespace.Name,
+				}, &infrastructurev1alpha1.FreeboxCluster{})
+			}, e2eConfig.GetIntervals(clusterProxy.GetName(), "wait-delete-cluster")...).ShouldNot(Succeed(),
+				"FreeboxCluster should be deleted with the Cluster")
+		})
+	})
+})
@@ -0,0 +1,384 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	freeboxclient "github.com/nikolalohinski/free-go/client"
+	freeboxTypes "github.com/nikolalohinski/free-go/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrastructurev1alpha1 "github.com/mcanevet/cluster-api-provider-freebox/api/v1alpha1"
+	"github.com/mcanevet/cluster-api-provider-freebox/internal/controller"
+	"github.com/mcanevet/cluster-api-provider-freebox/pkg/freebox/mock"
+)
+
+const (
+	downloadDir   = "/mnt/downloads"
+	vmStoragePath = "/mnt/VMs"
+	imageURL      = "https://example.com/images/nocloud.raw.xz"
+	imageName     = "nocloud.raw.xz"
+)
+
+var errFreebox = errors.New("freebox unavailable")
+
+func newReconciler(fc *mock.Client) *controller.FreeboxMachineReconciler {
+	return &controller.FreeboxMachineReconciler{
+		Client:             k8sClient,
+		Scheme:             k8sClient.Scheme(),
+		FreeboxClient:      fc,
+		FreeboxDownloadDir: downloadDir,
+		VMStoragePath:      vmStoragePath,
+	}
+}
+
+// createMachine creates a FreeboxMachine carrying the controller finalizer and
+// applies the given status, returning its key.
+func createMachine(ctx context.Context, status infrastructurev1alpha1.FreeboxMachineStatus) types.NamespacedName {
+	machine := &infrastructurev1alpha1.FreeboxMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "integration-",
+			Namespace:    "default",
+			Finalizers:   []string{controller.FreeboxMachineFinalizer},
+		},
+		Spec: infrastructurev1alpha1.FreeboxMachineSpec{
+			Name:          "integration-vm",
+			VCPUs:         1,
+			MemoryMB:      512,
+			DiskSizeBytes: 10 * 1024 * 1024 * 1024,
+			ImageURL:      imageURL,
+		},
+	}
+	Expect(k8sClient.Create(ctx, machine)).To(Succeed())
+	machine.Status = status
+	Expect(k8sClient.Status().Update(ctx, machine)).To(Succeed())
+	return types.NamespacedName{Name: machine.Name, Namespace: machine.Namespace}
+}
+
+// deleteMachine removes the finalizer and deletes the FreeboxMachine, ignoring
+// objects already removed by the reconciler.
+func deleteMachine(ctx context.Context, nn types.NamespacedName) {
+	machine := &infrastructurev1alpha1.FreeboxMachine{}
+	if err := k8sClient.Get(ctx, nn, machine); err != nil {
+		return
+	}
+	machine.Finalizers = nil
+	_ = k8sClient.Update(ctx, machine)
+	_ = k8sClient.Delete(ctx, machine)
+}
+
+var _ = Describe("FreeboxMachine reconciler", func() {
+	testCtx := context.Background()
+
+	Describe("create", func() {
+		type createCase struct {
+			existingTasks []freeboxTypes.DownloadTask
+			listErr       error
+			addErr        error
+			wantErr       bool
+			wantAdded     bool
+			wantTaskID    int64
+		}
+
+		DescribeTable("starting the image download",
+			func(tc createCase) {
+				fc := &mock.Client{}
+				fc.ListDownloadTasksReturns(tc.existingTasks, tc.listErr)
+				fc.AddDownloadTaskReturns(42, tc.addErr)
+				fc.GetFileInfoReturns(freeboxTypes.FileInfo{}, freeboxclient.ErrPathNotFound)
+
+				nn := createMachine(testCtx, infrastructurev1alpha1.FreeboxMachineStatus{})
+				DeferCleanup(deleteMachine, testCtx, nn)
+
+				_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+				if tc.wantErr {
+					Expect(err).To(MatchError(errFreebox))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				Expect(fc.AddDownloadTaskCallCount() == 1).To(Equal(tc.wantAdded))
+				if tc.wantAdded {
+					_, req := fc.AddDownloadTaskArgsForCall(0)
+					Expect(req.DownloadURLs).To(ConsistOf(imageURL))
+					Expect(req.DownloadDirectory).To(Equal(downloadDir))
+					Expect(req.Filename).To(Equal(imageName))
+				}
+
+				updated := &infrastructurev1alpha1.FreeboxMachine{}
+				Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+				Expect(updated.Status.TaskID).To(Equal(tc.wantTaskID))
+				if tc.wantErr {
+					Expect(updated.Status.Phase).To(BeEmpty())
+				} else {
+					Expect(updated.Status.Phase).To(Equal("download"))
+				}
+			},
+			Entry("creates a download task when none exists", createCase{
+				wantAdded:  true,
+				wantTaskID: 42,
+			}),
+			Entry("reuses a running download task for the same image", createCase{
+				existingTasks: []freeboxTypes.DownloadTask{
+					{ID: 7, Name: imageName, DownloadDirectory: downloadDir, Status: freeboxTypes.DownloadTaskStatusDownloading},
+				},
+				wantTaskID: 7,
+			}),
+			Entry("ignores a download task for the same image in another directory", createCase{
+				existingTasks: []freeboxTypes.DownloadTask{
+					{ID: 7, Name: imageName, DownloadDirectory: downloadDir + "/other-instance", Status: freeboxTypes.DownloadTaskStatusDownloading},
+				},
+				wantAdded:  true,
+				wantTaskID: 42,
+			}),
+			Entry("ignores a failed download task for the same image", createCase{
+				existingTasks: []freeboxTypes.DownloadTask{
+					{ID: 7, Name: imageName, DownloadDirectory: downloadDir, Status: freeboxTypes.DownloadTaskStatusError},
+				},
+				wantAdded:  true,
+				wantTaskID: 42,
+			}),
+			Entry("returns the error when download tasks cannot be listed", createCase{
+				listErr: errFreebox,
+				wantErr: true,
+			}),
+			Entry("returns the error when the download task cannot be created", createCase{
+				addErr:    errFreebox,
+				wantErr:   true,
+				wantAdded: true,
+			}),
+		)
+	})
+
+	Describe("status updates", func() {
+		type statusCase struct {
+			task           freeboxTypes.DownloadTask
+			getErr         error
+			wantErr        bool
+			wantPhase      string
+			wantReason     string
+			wantTaskPruned bool
+		}
+
+		DescribeTable("polling the download task",
+			func(tc statusCase) {
+				fc := &mock.Client{}
+				fc.GetDownloadTaskReturns(tc.task, tc.getErr)
+
+				nn := createMachine(testCtx, infrastructurev1alpha1.FreeboxMachineStatus{
+					Phase:  "download",
+					TaskID: 42,
+					Conditions: []metav1.Condition{{
+						Type:               controller.ReadyCondition,
+						Status:             metav1.ConditionFalse,
+						Reason:             "Provisioning",
+						LastTransitionTime: metav1.Now(),
+					}},
+				})
+				DeferCleanup(deleteMachine, testCtx, nn)
+
+				_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+				if tc.wantErr {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+				Expect(fc.DeleteDownloadTaskCallCount() == 1).To(Equal(tc.wantTaskPruned))
+
+				updated := &infrastructurev1alpha1.FreeboxMachine{}
+				Expect(k8sClient.Get(testCtx, nn, updated)).To(Succeed())
+				Expect(updated.Status.Phase).To(Equal(tc.wantPhase))
+				ready := meta.FindStatusCondition(updated.Status.Conditions, controller.ReadyCondition)
+				Expect(ready).NotTo(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(tc.wantReason))
+			},
+			Entry("keeps waiting while the download is in progress", statusCase{
+				task:       freeboxTypes.DownloadTask{ID: 42, Status: freeboxTypes.DownloadTaskStatusDownloading},
+				wantPhase:  "download",
+				wantReason: "Provisioning",
+			}),
+			Entry("moves to extraction once a compressed image is downloaded", statusCase{
+				task:           freeboxTypes.DownloadTask{ID: 42, Status: freeboxTypes.DownloadTaskStatusDone},
+				wantPhase:      "extract",
+				wantReason:     "Provisioning",
+				wantTaskPruned: true,
+			}),
+			Entry("resumes a download that failed with a transient error", statusCase{
+				task:       freeboxTypes.DownloadTask{ID: 42, Status: freeboxTypes.DownloadTaskStatusError},
+				wantPhase:  "download",
+				wantReason: "Provisioning",
+			}),
+			Entry("reports a download that failed because of its URL", statusCase{
+				task:       freeboxTypes.DownloadTask{ID: 42, Status: freeboxTypes.DownloadTaskStatusError, Error: freeboxTypes.DownloadTaskErrorInvalidURL},
+				wantErr:    true,
+				wantPhase:  "download",
+				wantReason: "ProvisioningFailed",
+			}),
+			Entry("returns the error when the download task cannot be fetched", statusCase{
+				getErr:     errFreebox,
+				wantErr:    true,
+				wantPhase:  "download",
+				wantReason: "Provisioning",
+			}),
+		)
+	})
+
+	Describe("delete", func() {
+		type deleteCase struct {
+			status          infrastructurev1alpha1.FreeboxMachineStatus
+			deleteForMove   bool
+			deleteVMErr     error
+			removeFilesErr  error
+			fsTask          freeboxTypes.FileSystemTask
+			wantErr         bool
+			wantVMDeleted   bool
+			wantTaskErased  bool
+			wantDiskRemoved []string
+		}
+
+		DescribeTable("releasing Freebox resources",
+			func(tc deleteCase) {
+				fc := &mock.Client{}
+				fc.GetVirtualMachineReturns(freeboxTypes.VirtualMachine{Status: "stopped"}, nil)
+				fc.DeleteVirtualMachineReturns(tc.deleteVMErr)
+				fc.RemoveFilesReturns(freeboxTypes.FileSystemTask{ID: 1}, tc.removeFilesErr)
+				fc.GetFileSystemTaskReturns(tc.fsTask, nil)
+
+				nn := createMachine(testCtx, tc.status)
+				DeferCleanup(deleteMachine, testCtx, nn)
+
+				machine := &infrastructurev1alpha1.FreeboxMachine{}
+				Expect(k8sClient.Get(testCtx, nn, machine)).To(Succeed())
+				if tc.deleteForMove {
+					machine.Annotations = map[string]string{controller.DeleteForMoveAnnotation: ""}
+					Expect(k8sClient.Update(testCtx, machine)).To(Succeed())
+				}
+				Expect(k8sClient.Delete(testCtx, machine)).To(Succeed())
+
+				_, err := newReconciler(fc).Reconcile(testCtx, reconcile.Request{NamespacedName: nn})
+				if tc.wantErr {
+					Expect(err).To(MatchError(errFreebox))
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+
+				if tc.wantVMDeleted {
+					// The VM is already stopped, so it is neither shut down nor killed.
+					Expect(fc.StopVirtualMachineCallCount()).To(BeZero())
+					Expect(fc.KillVirtualMachineCallCount()).To(BeZero())
+					Expect(fc.DeleteVirtualMachineCallCount()).To(Equal(1))
+					_, vmID := fc.DeleteVirtualMachineArgsForCall(0)
+					Expect(vmID).To(Equal(*tc.status.VMID))
+				} else {
+					Expect(fc.DeleteVirtualMachineCallCount()).To(BeZero())
+				}
+
+				switch tc.status.Phase {
+				case "download":
+					Expect(fc.EraseDownloadTaskCallCount() == 1).To(Equal(tc.wantTaskErased))
+				case "extract", "copy", "rename":
+					Expect(fc.DeleteFileSystemTaskCallCount() == 1).To(Equal(tc.wantTaskErased))
+				}
+
+				if tc.wantDiskRemoved != nil {
+					Expect(fc.RemoveFilesCallCount()).To(Equal(1))
+					_, paths := fc.RemoveFilesArgsForCall(0)
+					Expect(paths).To(Equal(tc.wantDiskRemoved))
+				} else {
+					Expect(fc.RemoveFilesCallCount()).To(BeZero())
+				}
+
+				err = k8sClient.Get(testCtx, nn, &infrastructurev1alpha1.FreeboxMachine{})
+				if tc.wantErr {
+					Expect(err).NotTo(HaveOccurred(), "finalizer should be kept so deletion is retried")
+				} else {
+					Expect(apierrors.IsNotFound(err)).To(BeTrue(), "finalizer should be removed")
+				}
+			},
+			Entry("deletes the VM and its disk files", deleteCase{
+				status: infrastructurev1alpha1.FreeboxMachineStatus{
+					VMID:     ptr.To[int64](12),
+					DiskPath: vmStoragePath + "/integration-vm.raw",
+				},
+				wantVMDeleted:   true,
+				wantDiskRemoved: []string{vmStoragePath + "/integration-vm.raw", vmStoragePath + "/integration-vm.raw.efivars", vmStoragePath + "/integration-vm.raw.meta.json"},
+			}),
+			Entry("only removes the finalizer when nothing was provisioned", deleteCase{}),
+			Entry("leaves Freebox resources alone during clusterctl move", deleteCase{
+				status: infrastructurev1alpha1.FreeboxMachineStatus{
+					VMID:     ptr.To[int64](12),
+					DiskPath: vmStoragePath + "/integration-vm.raw",
+				},
+				deleteForMove: true,
+			}),
+			Entry("keeps the finalizer when the VM cannot be deleted", deleteCase{
+				status: infrastructurev1alpha1.FreeboxMachineStatus{
+					VMID:     ptr.To[int64](12),
+					DiskPath: vmStoragePath + "/integration-vm.raw",
+				},
+				deleteVMErr:   errFreebox,
+				wantErr:       true,
+				wantVMDeleted: true,
+			}),
+			Entry("keeps the finalizer when the disk files cannot be removed", deleteCase{
+				status: infrastructurev1alpha1.FreeboxMachineStatus{
+					DiskPath: vmStoragePath + "/integration-vm.raw",
+				},
+				removeFilesErr:  errFreebox,
+				wantErr:         true,
+				wantDiskRemoved: []string{vmStoragePath + "/integration-vm.raw", vmStoragePath + "/integration-vm.raw.efivars", vmStoragePath + "/integration-vm.raw.meta.json"},
+			}),
+			Entry("cancels an image download in progress", deleteCase{
+				status:         infrastructurev1alpha1.FreeboxMachineStatus{Phase: "download", TaskID: 42},
+				wantTaskErased: true,
+			}),
+			Entry("cancels an image extraction in progress and removes its files", deleteCase{
+				status: infrastructurev1alpha1.FreeboxMachineStatus{Phase: "extract", TaskID: 43},
+				fsTask: freeboxTypes.FileSystemTask{
+					ID:          43,
+					Type:        freeboxTypes.FileTaskTypeExtract,
+					Sources:     []string{base64.StdEncoding.EncodeToString([]byte(downloadDir + "/nocloud.raw.xz"))},
+					Destination: base64.StdEncoding.EncodeToString([]byte(vmStoragePath)),
+				},
+				wantTaskErased:  true,
+				wantDiskRemoved: []string{downloadDir + "/nocloud.raw.xz", vmStoragePath + "/nocloud.raw"},
+			}),
+			Entry("cancels an image rename in progress and removes both files", deleteCase{
+				status: infrastructurev1alpha1.FreeboxMachineStatus{
+					Phase:     "rename",
+					TaskID:    44,
+					RenameSrc: vmStoragePath + "/nocloud.raw",
+					RenameDst: vmStoragePath + "/integration-vm.raw",
+				},
+				fsTask:          freeboxTypes.FileSystemTask{ID: 44, Type: freeboxTypes.FileTaskTypeMove},
+				wantTaskErased:  true,
+				wantDiskRemoved: []string{vmStoragePath + "/nocloud.raw", vmStoragePath + "/integration-vm.raw"},
+			}),
+		)
+	})
+})